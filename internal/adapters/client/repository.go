@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 	"github.com/samber/do"
 	"os"
@@ -16,6 +17,23 @@ import (
 
 const seedDirectory = "internal/database/seeders/client"
 
+// bookkeepingStatements create the private schema fluxend keeps its own records in, it is never exposed
+// through PostgREST. Migrations recorded against the database land in fluxend_migrations
+var bookkeepingStatements = []string{
+	"CREATE SCHEMA IF NOT EXISTS fluxend",
+	`CREATE TABLE IF NOT EXISTS fluxend.fluxend_migrations (
+		id SERIAL PRIMARY KEY,
+		version INTEGER NOT NULL UNIQUE,
+		name VARCHAR(255) NOT NULL,
+		up_sql TEXT NOT NULL,
+		down_sql TEXT NOT NULL DEFAULT '',
+		source VARCHAR(20) NOT NULL,
+		status VARCHAR(20) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		applied_at TIMESTAMP NULL
+	)`,
+}
+
 type Repository struct {
 	db shared.DB
 }
@@ -37,6 +55,10 @@ func (r *Repository) Create(name string, userUUID uuid.NullUUID) error {
 		return err
 	}
 
+	if err = r.Prepare(name); err != nil {
+		return err
+	}
+
 	if userUUID.Valid {
 		return r.importSeedFiles(name, userUUID.UUID)
 	}
@@ -44,6 +66,30 @@ func (r *Repository) Create(name string, userUUID uuid.NullUUID) error {
 	return nil
 }
 
+// Prepare creates what fluxend needs inside a project database. It runs when the database is created
+// and can run again on existing databases, every statement is idempotent
+func (r *Repository) Prepare(name string) error {
+	connection, err := r.Connect(name)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	for _, statement := range bookkeepingStatements {
+		if _, err = connection.Exec(statement); err != nil {
+			log.Error().
+				Str("action", constants.ActionClientDatabasePrepare).
+				Str("db", name).
+				Str("error", err.Error()).
+				Msg("failed to prepare database")
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Repository) DropIfExists(name string) error {
 	_, err := r.db.ExecWithRowsAffected(fmt.Sprintf(`DROP DATABASE IF EXISTS "%s"`, name))
 	return err
@@ -68,16 +114,24 @@ func (r *Repository) Exists(name string) (bool, error) {
 
 // Connect TODO: create actual user for using here
 func (r *Repository) Connect(name string) (*sqlx.DB, error) {
-	connStr := fmt.Sprintf(
-		"user=%s dbname=%s password=%s host=%s sslmode=%s port=5432",
-		os.Getenv("DATABASE_USER"),
-		name,
-		os.Getenv("DATABASE_PASSWORD"),
-		os.Getenv("DATABASE_HOST"),
-		os.Getenv("DATABASE_SSL_MODE"),
-	)
+	return r.connect(name, r.connectionString(name))
+}
+
+// ConnectReadOnly logs in as the authenticator and starts every session as the reader role in read-only
+// mode. Read-only is not enough on its own, a superuser session could still read server files or run
+// programs, the reader can only read tables
+func (r *Repository) ConnectReadOnly(name string) (*sqlx.DB, error) {
+	return r.connect(name, r.authenticatorConnectionString(name)+fmt.Sprintf(" options='-c default_transaction_read_only=on -c role=%s'", constants.RoleReader))
+}
+
+// ConnectAuthenticator logs in as the role PostgREST authenticates with. It is not a superuser and can only
+// switch to the roles granted to it, so SQL written by users can run on it without reaching past those roles
+func (r *Repository) ConnectAuthenticator(name string) (*sqlx.DB, error) {
+	return r.connect(name, r.authenticatorConnectionString(name))
+}
 
-	connection, err := sqlx.Connect("postgres", connStr)
+func (r *Repository) connect(name, connectionString string) (*sqlx.DB, error) {
+	connection, err := sqlx.Connect("postgres", connectionString)
 	if err != nil {
 		log.Error().
 			Str("action", constants.ActionClientDatabaseConnect).
@@ -95,6 +149,63 @@ func (r *Repository) Connect(name string) (*sqlx.DB, error) {
 	return connection, nil
 }
 
+// Listen opens a dedicated connection that receives notifications of the given channel. The listener
+// reconnects on its own, onEvent is told about lost and restored connections
+func (r *Repository) Listen(name, channel string, onEvent pq.EventCallbackType) (*pq.Listener, error) {
+	listener := pq.NewListener(
+		r.connectionString(name),
+		constants.RealtimeMinReconnectInterval,
+		constants.RealtimeMaxReconnectInterval,
+		onEvent,
+	)
+
+	// Listen waits for the connection and pq keeps retrying unreachable databases, so it is given up on after a while
+	listened := make(chan error, 1)
+	go func() {
+		listened <- listener.Listen(channel)
+	}()
+
+	var err error
+	select {
+	case err = <-listened:
+	case <-time.After(constants.RealtimeListenTimeout):
+		err = fmt.Errorf("no connection after %s", constants.RealtimeListenTimeout)
+	}
+
+	if err != nil {
+		listener.Close()
+
+		log.Error().
+			Str("action", constants.ActionClientDatabaseConnect).
+			Str("db", name).
+			Str("error", err.Error()).
+			Msg("failed to listen on database")
+
+		return nil, fmt.Errorf("could not listen on database: %v", err)
+	}
+
+	return listener, nil
+}
+
+func (r *Repository) connectionString(name string) string {
+	return r.buildConnectionString(os.Getenv("DATABASE_USER"), os.Getenv("DATABASE_PASSWORD"), name)
+}
+
+func (r *Repository) authenticatorConnectionString(name string) string {
+	return r.buildConnectionString(constants.RoleAuthenticator, os.Getenv("DATABASE_AUTHENTICATOR_PASSWORD"), name)
+}
+
+func (r *Repository) buildConnectionString(user, password, name string) string {
+	return fmt.Sprintf(
+		"user=%s dbname=%s password=%s host=%s sslmode=%s port=5432",
+		user,
+		name,
+		password,
+		os.Getenv("DATABASE_HOST"),
+		os.Getenv("DATABASE_SSL_MODE"),
+	)
+}
+
 func (r *Repository) importSeedFiles(databaseName string, userUUID uuid.UUID) error {
 	connection, err := r.Connect(databaseName)
 	if err != nil {
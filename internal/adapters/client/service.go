@@ -25,6 +25,14 @@ func (s *ServiceImpl) ConnectByDatabaseName(name string) (*sqlx.DB, error) {
 	return s.databaseRepo.Connect(name)
 }
 
+func (s *ServiceImpl) ConnectReadOnlyByDatabaseName(name string) (*sqlx.DB, error) {
+	return s.databaseRepo.ConnectReadOnly(name)
+}
+
+func (s *ServiceImpl) ConnectAuthenticatorByDatabaseName(name string) (*sqlx.DB, error) {
+	return s.databaseRepo.ConnectAuthenticator(name)
+}
+
 func (s *ServiceImpl) GetDatabaseStatsRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
 	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
 	if err != nil {
@@ -115,6 +123,134 @@ func (s *ServiceImpl) GetIndexRepo(databaseName string, connection *sqlx.DB) (in
 	return clientIndexRepo, clientDatabaseConnection, nil
 }
 
+func (s *ServiceImpl) GetConstraintRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientConstraintRepo, err := repositories.NewConstraintRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientConstraintRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetTriggerRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientTriggerRepo, err := repositories.NewTriggerRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientTriggerRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetPolicyRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientPolicyRepo, err := repositories.NewPolicyRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientPolicyRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetTypeRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientTypeRepo, err := repositories.NewTypeRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientTypeRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetViewRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientViewRepo, err := repositories.NewViewRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientViewRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetRoleRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientRoleRepo, err := repositories.NewRoleRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientRoleRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetExtensionRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientExtensionRepo, err := repositories.NewExtensionRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientExtensionRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetSchemaRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientSchemaRepo, err := repositories.NewSchemaRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientSchemaRepo, clientDatabaseConnection, nil
+}
+
 func (s *ServiceImpl) GetRowRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
 	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
 	if err != nil {
@@ -131,6 +267,134 @@ func (s *ServiceImpl) GetRowRepo(databaseName string, connection *sqlx.DB) (inte
 	return clientRowRepo, clientDatabaseConnection, nil
 }
 
+func (s *ServiceImpl) GetQueryRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientQueryRepo, err := repositories.NewQueryRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientQueryRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetCronStatementRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientCronStatementRepo, err := repositories.NewCronStatementRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientCronStatementRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetRealtimeRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientRealtimeRepo, err := repositories.NewRealtimeRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientRealtimeRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetMigrationRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientMigrationRepo, err := repositories.NewMigrationRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientMigrationRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetPartitionRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientPartitionRepo, err := repositories.NewPartitionRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientPartitionRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetDDLRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientDDLRepo, err := repositories.NewDDLRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientDDLRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetDropRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientDropRepo, err := repositories.NewDropRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientDropRepo, clientDatabaseConnection, nil
+}
+
+func (s *ServiceImpl) GetMaintenanceRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error) {
+	clientDatabaseConnection, err := s.getOrCreateConnection(databaseName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientInjector := s.createClientInjector(clientDatabaseConnection)
+
+	clientMaintenanceRepo, err := repositories.NewMaintenanceRepository(clientInjector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientMaintenanceRepo, clientDatabaseConnection, nil
+}
+
 func (s *ServiceImpl) getOrCreateConnection(databaseName string, connection *sqlx.DB) (*sqlx.DB, error) {
 	if connection != nil {
 		return connection, nil
@@ -129,6 +129,12 @@ func (s *ServiceImpl) RemoveContainer(dbName string) {
 	}
 }
 
+// RestartContainer recreates the container, PostgREST only reads PGRST_DB_SCHEMA when it starts
+func (s *ServiceImpl) RestartContainer(dbName string) {
+	s.RemoveContainer(dbName)
+	s.StartContainer(dbName)
+}
+
 func (s *ServiceImpl) HasContainer(dbName string) bool {
 	cmd := []string{"docker", "inspect", "--format='{{.State.Running}}'", s.getContainerName(dbName)}
 	output, err := pkg.ExecuteCommandWithOutput(cmd)
@@ -151,7 +157,7 @@ func (s *ServiceImpl) buildStartCommand(dbName string) []string {
 		"--network", "fluxend_network",
 		"-e", fmt.Sprintf("PGRST_DB_URI=postgres://%s:%s@%s/%s", s.config.DBUser, s.config.DBPassword, s.config.DBHost, dbName),
 		"-e", "PGRST_DB_ANON_ROLE=" + s.config.DBRole,
-		"-e", "PGRST_DB_SCHEMA=" + s.config.DBSchema,
+		"-e", "PGRST_DB_SCHEMA=" + s.getDBSchemas(dbName),
 		"-e", "PGRST_JWT_SECRET=" + s.config.JWTSecret,
 		"-e", "PGRST_SERVER_CORS_ALLOWED_ORIGINS=" + s.config.CustomOrigins,
 		"-e", "PGRST_SERVER_CORS_ALLOWED_HEADERS=*",
@@ -197,6 +203,21 @@ func (s *ServiceImpl) RefreshSchemaCache(dbName string) {
 	}
 }
 
+// getDBSchemas lists the schemas the project exposes, falling back to the configured default schema
+func (s *ServiceImpl) getDBSchemas(dbName string) string {
+	projectUUID, err := s.projectRepo.GetUUIDByDatabaseName(dbName)
+	if err != nil {
+		return s.config.DBSchema
+	}
+
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil || len(fetchedProject.ExposedSchemas) == 0 {
+		return s.config.DBSchema
+	}
+
+	return strings.Join(fetchedProject.ExposedSchemas, ",")
+}
+
 func (s *ServiceImpl) getContainerName(dbName string) string {
 	return fmt.Sprintf("postgrest_%s", dbName)
 }
@@ -72,6 +72,10 @@ func (t *TxAdapter) SelectContext(ctx context.Context, dest interface{}, query s
 	return t.tx.SelectContext(ctx, dest, query, args...)
 }
 
+func (t *TxAdapter) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
 func (t *TxAdapter) Commit() error {
 	return t.tx.Commit()
 }
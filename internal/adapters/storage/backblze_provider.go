@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fluxend/pkg/errors"
@@ -263,6 +264,15 @@ func (b *BackblazeServiceImpl) getUploadURL(bucketID string) (*B2GetUploadURLRes
 }
 
 func (b *BackblazeServiceImpl) UploadFile(input UploadFileInput) error {
+	return b.UploadStream(UploadStreamInput{
+		ContainerName: input.ContainerName,
+		FileName:      input.FileName,
+		Reader:        bytes.NewReader(input.FileBytes),
+		Size:          int64(len(input.FileBytes)),
+	})
+}
+
+func (b *BackblazeServiceImpl) UploadStream(input UploadStreamInput) error {
 	// First, get the container metadata to get the bucket ID
 	containerMetadata, err := b.ShowContainer(input.ContainerName)
 	if err != nil {
@@ -278,11 +288,14 @@ func (b *BackblazeServiceImpl) UploadFile(input UploadFileInput) error {
 	}
 
 	// Create a request to upload the file
-	req, err := http.NewRequest("POST", uploadURLResponse.UploadURL, strings.NewReader(string(input.FileBytes)))
+	req, err := http.NewRequest("POST", uploadURLResponse.UploadURL, input.Reader)
 	if err != nil {
 		return fmt.Errorf("error creating upload request: %w", err)
 	}
 
+	// B2 requires the length up front, the body is streamed as is
+	req.ContentLength = input.Size
+
 	// Add required headers
 	req.Header.Add("Authorization", uploadURLResponse.AuthorizationToken)
 	req.Header.Add("X-Bz-File-Name", url.QueryEscape(input.FileName))
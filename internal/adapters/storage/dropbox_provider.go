@@ -1,11 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
 	"fluxend/pkg"
 	"fluxend/pkg/errors"
 	"fmt"
 	"github.com/samber/do"
+	"io"
 	"os"
 	"resty.dev/v3"
 	"strings"
@@ -207,6 +209,15 @@ func (d *DropboxServiceImpl) DeleteContainer(path string) error {
 }
 
 func (d *DropboxServiceImpl) UploadFile(input UploadFileInput) error {
+	return d.UploadStream(UploadStreamInput{
+		ContainerName: input.ContainerName,
+		FileName:      input.FileName,
+		Reader:        bytes.NewReader(input.FileBytes),
+		Size:          int64(len(input.FileBytes)),
+	})
+}
+
+func (d *DropboxServiceImpl) UploadStream(input UploadStreamInput) error {
 	path := normalizePath(fmt.Sprintf("%s/%s", input.ContainerName, input.FileName))
 
 	apiArg := map[string]interface{}{
@@ -216,7 +227,7 @@ func (d *DropboxServiceImpl) UploadFile(input UploadFileInput) error {
 		"mute":       false,
 	}
 
-	resp, err := d.executeContentRequest("POST", "/files/upload", apiArg, input.FileBytes)
+	resp, err := d.executeContentRequest("POST", "/files/upload", apiArg, input.Reader)
 	if err != nil {
 		return err
 	}
@@ -305,7 +316,7 @@ func (d *DropboxServiceImpl) executeAPIRequest(method, endpoint string, payload
 	return resp, nil
 }
 
-func (d *DropboxServiceImpl) executeContentRequest(method, endpoint string, apiArg interface{}, body []byte) (*resty.Response, error) {
+func (d *DropboxServiceImpl) executeContentRequest(method, endpoint string, apiArg interface{}, body io.Reader) (*resty.Response, error) {
 	apiArgJson, err := json.Marshal(apiArg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal JSON: %v", err)
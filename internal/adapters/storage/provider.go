@@ -13,6 +13,7 @@ type Provider interface {
 	ShowContainer(name string) (*ContainerMetadata, error)
 	DeleteContainer(name string) error
 	UploadFile(input UploadFileInput) error
+	UploadStream(input UploadStreamInput) error
 	RenameFile(input RenameFileInput) error
 	DownloadFile(input FileInput) ([]byte, error)
 	DeleteFile(input FileInput) error
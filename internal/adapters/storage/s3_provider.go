@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"fluxend/pkg"
 	"fluxend/pkg/errors"
@@ -137,10 +138,20 @@ func (s *S3ServiceImpl) DeleteContainer(bucketName string) error {
 }
 
 func (s *S3ServiceImpl) UploadFile(input UploadFileInput) error {
+	return s.UploadStream(UploadStreamInput{
+		ContainerName: input.ContainerName,
+		FileName:      input.FileName,
+		Reader:        bytes.NewReader(input.FileBytes),
+		Size:          int64(len(input.FileBytes)),
+	})
+}
+
+func (s *S3ServiceImpl) UploadStream(input UploadStreamInput) error {
 	_, err := s.client.PutObject(context.Background(), &s3.PutObjectInput{
-		Bucket: aws.String(input.ContainerName),
-		Key:    aws.String(input.FileName),
-		Body:   strings.NewReader(string(input.FileBytes)),
+		Bucket:        aws.String(input.ContainerName),
+		Key:           aws.String(input.FileName),
+		Body:          input.Reader,
+		ContentLength: aws.Int64(input.Size),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to upload file %q, %v", input.FileName, err)
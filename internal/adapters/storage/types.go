@@ -2,6 +2,7 @@ package storage
 
 import (
 	"github.com/guregu/null/v6"
+	"io"
 	"net/http"
 )
 
@@ -29,6 +30,14 @@ type UploadFileInput struct {
 	FileBytes     []byte
 }
 
+// UploadStreamInput uploads from a reader without holding the file in memory, Size must be the exact length
+type UploadStreamInput struct {
+	ContainerName string
+	FileName      string
+	Reader        io.Reader
+	Size          int64
+}
+
 type RenameFileInput struct {
 	ContainerName string
 	FileName      string
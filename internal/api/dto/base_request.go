@@ -27,16 +27,34 @@ var (
 	}
 
 	allowedColumnTypes = map[string]bool{
-		constants.ColumnTypeInteger:   true,
-		constants.ColumnTypeSerial:    true,
-		constants.ColumnTypeVarchar:   true,
-		constants.ColumnTypeText:      true,
-		constants.ColumnTypeBoolean:   true,
-		constants.ColumnTypeDate:      true,
-		constants.ColumnTypeTimestamp: true,
-		constants.ColumnTypeFloat:     true,
-		constants.ColumnTypeUUID:      true,
-		constants.ColumnTypeJSON:      true,
+		constants.ColumnTypeInteger:     true,
+		constants.ColumnTypeSmallint:    true,
+		constants.ColumnTypeBigint:      true,
+		constants.ColumnTypeSerial:      true,
+		constants.ColumnTypeSmallSerial: true,
+		constants.ColumnTypeBigSerial:   true,
+		constants.ColumnTypeNumeric:     true,
+		constants.ColumnTypeDecimal:     true,
+		constants.ColumnTypeReal:        true,
+		constants.ColumnTypeDouble:      true,
+		constants.ColumnTypeFloat:       true,
+		constants.ColumnTypeVarchar:     true,
+		constants.ColumnTypeChar:        true,
+		constants.ColumnTypeText:        true,
+		constants.ColumnTypeBoolean:     true,
+		constants.ColumnTypeDate:        true,
+		constants.ColumnTypeTime:        true,
+		constants.ColumnTypeTimeTZ:      true,
+		constants.ColumnTypeTimestamp:   true,
+		constants.ColumnTypeTimestampTZ: true,
+		constants.ColumnTypeInterval:    true,
+		constants.ColumnTypeUUID:        true,
+		constants.ColumnTypeJSON:        true,
+		constants.ColumnTypeJSONB:       true,
+		constants.ColumnTypeBytea:       true,
+		constants.ColumnTypeInet:        true,
+		constants.ColumnTypeCidr:        true,
+		constants.ColumnTypeMacaddr:     true,
 	}
 
 	reservedIndexNames = map[string]bool{
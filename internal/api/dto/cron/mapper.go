@@ -0,0 +1,21 @@
+package cron
+
+import (
+	"fluxend/internal/domain/cron"
+)
+
+func ToCreateJobInput(request *CreateRequest) cron.CreateJobInput {
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+
+	return cron.CreateJobInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+		Schedule:    request.Schedule,
+		Command:     request.Command,
+		TimeoutInMs: request.TimeoutInMs,
+		Enabled:     enabled,
+	}
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/cron"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type CreateRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"name"`
+	Schedule    string    `json:"schedule"`
+	Command     string    `json:"command"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+
+	// jobs are enabled unless explicitly created paused
+	Enabled *bool `json:"enabled"`
+}
+
+// JobRequest addresses an existing job of a project
+type JobRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	JobUUID     uuid.UUID `json:"-"`
+}
+
+func (r *CreateRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = strings.TrimSpace(r.Name)
+	r.Schedule = strings.TrimSpace(r.Schedule)
+	r.Command = strings.TrimSpace(r.Command)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Name is required"),
+			validation.Length(
+				constants.MinCronJobNameLength, constants.MaxCronJobNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Name must be between %d and %d characters",
+					constants.MinCronJobNameLength,
+					constants.MaxCronJobNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Schedule,
+			validation.Required.Error("Schedule is required"),
+			validation.By(validateSchedule),
+		),
+		validation.Field(&r.Command, validation.Required.Error("Command is required")),
+		validation.Field(
+			&r.TimeoutInMs,
+			validation.Min(0).Error("Timeout cannot be negative"),
+			validation.Max(constants.MaxCronTimeoutInMs).Error(
+				fmt.Sprintf("Timeout cannot exceed %d milliseconds", constants.MaxCronTimeoutInMs),
+			),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *JobRequest) BindAndValidate(c echo.Context) []string {
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	jobUUID, err := r.GetUUIDPathParam(c, "jobUUID", true)
+	if err != nil {
+		return []string{"Invalid job UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.JobUUID = jobUUID
+
+	return nil
+}
+
+func validateSchedule(value interface{}) error {
+	expression, _ := value.(string)
+	if expression == "" {
+		return nil
+	}
+
+	if _, err := cron.ParseSchedule(expression); err != nil {
+		return fmt.Errorf("Schedule is invalid: %s", err.Error())
+	}
+
+	return nil
+}
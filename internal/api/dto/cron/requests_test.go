@@ -0,0 +1,146 @@
+package cron
+
+import (
+	"fluxend/pkg"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateRequest: valid", func(t *testing.T) {
+		projectUUID := uuid.New()
+		payload := map[string]interface{}{
+			"name":        "purge_sessions",
+			"schedule":    "*/15 * * * *",
+			"command":     "DELETE FROM sessions WHERE expires_at < now()",
+			"timeoutInMs": 5000,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(projectUUID.String())
+
+		var r CreateRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, projectUUID, r.ProjectUUID)
+		assert.Equal(t, payload["schedule"], r.Schedule)
+
+		input := ToCreateJobInput(&r)
+		assert.True(t, input.Enabled)
+		assert.Equal(t, 5000, input.TimeoutInMs)
+	})
+
+	t.Run("CreateRequest: created paused", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":     "nightly_rollup",
+			"schedule": "@daily",
+			"command":  "CALL rollup()",
+			"enabled":  false,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(uuid.New().String())
+
+		var r CreateRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.False(t, ToCreateJobInput(&r).Enabled)
+	})
+
+	t.Run("CreateRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "not-a-uuid",
+				payload:     map[string]interface{}{"name": "job", "schedule": "* * * * *", "command": "SELECT 1"},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing name",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"schedule": "* * * * *", "command": "SELECT 1"},
+				expected:    "Name is required",
+			},
+			{
+				name:        "Invalid name",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"name": "my job", "schedule": "* * * * *", "command": "SELECT 1"},
+				expected:    "Name must be alphanumeric with underscores",
+			},
+			{
+				name:        "Missing schedule",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"name": "job", "command": "SELECT 1"},
+				expected:    "Schedule is required",
+			},
+			{
+				name:        "Invalid schedule",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"name": "job", "schedule": "61 * * * *", "command": "SELECT 1"},
+				expected:    "Schedule is invalid",
+			},
+			{
+				name:        "Missing command",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"name": "job", "schedule": "* * * * *", "command": "  "},
+				expected:    "Command is required",
+			},
+			{
+				name:        "Timeout too large",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"name": "job", "schedule": "* * * * *", "command": "SELECT 1", "timeoutInMs": 3600000},
+				expected:    "Timeout cannot exceed",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r CreateRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestJobRequest_BindAndValidate(t *testing.T) {
+	e := echo.New()
+
+	projectUUID := uuid.New()
+	jobUUID := uuid.New()
+
+	ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, nil)
+	ctx.SetParamNames("projectUUID", "jobUUID")
+	ctx.SetParamValues(projectUUID.String(), jobUUID.String())
+
+	var r JobRequest
+	assert.Len(t, r.BindAndValidate(ctx), 0)
+	assert.Equal(t, projectUUID, r.ProjectUUID)
+	assert.Equal(t, jobUUID, r.JobUUID)
+
+	ctx = pkg.CreateFakeRequestContext(t, e, http.MethodPost, nil)
+	ctx.SetParamNames("projectUUID", "jobUUID")
+	ctx.SetParamValues(projectUUID.String(), "not-a-uuid")
+
+	var invalid JobRequest
+	pkg.AssertErrorContains(t, invalid.BindAndValidate(ctx), "Invalid job UUID")
+}
@@ -0,0 +1,33 @@
+package cron
+
+import (
+	"github.com/google/uuid"
+)
+
+type JobResponse struct {
+	Uuid        uuid.UUID `json:"uuid"`
+	ProjectUuid uuid.UUID `json:"projectUuid"`
+	Name        string    `json:"name"`
+	Schedule    string    `json:"schedule"`
+	Command     string    `json:"command"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+	Enabled     bool      `json:"enabled"`
+	NextRunAt   string    `json:"nextRunAt"`
+	LastRunAt   string    `json:"lastRunAt"`
+	CreatedBy   uuid.UUID `json:"createdBy"`
+	UpdatedBy   uuid.UUID `json:"updatedBy"`
+	CreatedAt   string    `json:"createdAt"`
+	UpdatedAt   string    `json:"updatedAt"`
+}
+
+type RunResponse struct {
+	Uuid         uuid.UUID `json:"uuid"`
+	JobUuid      uuid.UUID `json:"jobUuid"`
+	Trigger      string    `json:"trigger"`
+	Status       string    `json:"status"`
+	RowsAffected int64     `json:"rowsAffected"`
+	DurationMs   int64     `json:"durationMs"`
+	Error        string    `json:"error"`
+	StartedAt    string    `json:"startedAt"`
+	FinishedAt   string    `json:"finishedAt"`
+}
@@ -101,6 +101,12 @@ func Validate(column columnDomain.Column) error {
 			&column.Foreign,
 			validation.By(validateForeignKeyConstraints(column)),
 		),
+		validation.Field(
+			&column.Description,
+			validation.Length(0, constants.MaxColumnDescriptionLength).Error(
+				fmt.Sprintf("Column description must be at most %d characters", constants.MaxColumnDescriptionLength),
+			),
+		),
 	)
 }
 
@@ -115,13 +121,18 @@ func validateColumnName(value interface{}) error {
 }
 
 func validateType(value interface{}) error {
-	columnType := value.(string)
+	rawType := value.(string)
 
-	if !dto.IsAllowedColumnType(strings.ToLower(columnType)) {
-		return fmt.Errorf("column type '%s' is not allowed", columnType)
+	columnType, err := columnDomain.ParseColumnType(rawType)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if !columnType.UserDefined && !dto.IsAllowedColumnType(columnType.Name) {
+		return fmt.Errorf("column type '%s' is not allowed", rawType)
+	}
+
+	return columnType.Validate()
 }
 
 func validateForeignKeyConstraints(column columnDomain.Column) validation.RuleFunc {
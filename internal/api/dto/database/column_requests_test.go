@@ -46,6 +46,26 @@ func TestCreateColumnRequest_BindAndValidate_Suite(t *testing.T) {
 		assert.Equal(t, constants.ColumnTypeVarchar, r.Columns[0].Type)
 	})
 
+	t.Run("CreateColumnRequest: valid with parameterized, array and enum types", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"columns": []database.Column{
+				{Name: "price", Type: "numeric(10,2)"},
+				{Name: "labels", Type: "varchar(255)[]"},
+				{Name: "created_at", Type: "timestamp with time zone"},
+				{Name: "status", Type: "public.order_status"},
+			},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateColumnRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Len(t, r.Columns, 4)
+	})
+
 	t.Run("CreateColumnRequest: valid with foreign key", func(t *testing.T) {
 		columns := []database.Column{
 			{
@@ -163,6 +183,42 @@ func TestCreateColumnRequest_BindAndValidate_Suite(t *testing.T) {
 				},
 				expected: []string{"column type 'invalid_type' is not allowed"},
 			},
+			{
+				name: "Invalid numeric scale",
+				payload: map[string]interface{}{
+					"columns": []database.Column{
+						{Name: "test_column", Type: "numeric(4,6)"},
+					},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"column type 'numeric(4,6)' cannot have a scale larger than its precision"},
+			},
+			{
+				name: "Unexpected column type parameters",
+				payload: map[string]interface{}{
+					"columns": []database.Column{
+						{Name: "test_column", Type: "boolean(1)"},
+					},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"column type 'boolean' does not take parameters"},
+			},
+			{
+				name: "Serial array column type",
+				payload: map[string]interface{}{
+					"columns": []database.Column{
+						{Name: "test_column", Type: "serial[]"},
+					},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"column type 'serial' cannot be an array"},
+			},
 			{
 				name: "Foreign key without reference table",
 				payload: map[string]interface{}{
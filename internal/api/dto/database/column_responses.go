@@ -13,6 +13,7 @@ type ColumnResponse struct {
 	Primary         bool        `json:"primary"`
 	Unique          bool        `json:"unique"`
 	Foreign         bool        `json:"foreign"`
+	Description     string      `json:"description"`
 	ReferenceTable  null.String `json:"referenceTable" swaggertype:"string"`
 	ReferenceColumn null.String `json:"referenceColumn" swaggertype:"string"`
 }
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type CreateConstraintRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Columns           []string `json:"columns"`
+	ReferenceTable    string   `json:"referenceTable"`
+	ReferenceColumns  []string `json:"referenceColumns"`
+	OnDelete          string   `json:"onDelete"`
+	OnUpdate          string   `json:"onUpdate"`
+	Expression        string   `json:"expression"`
+	Deferrable        bool     `json:"deferrable"`
+	InitiallyDeferred bool     `json:"initiallyDeferred"`
+	NotValid          bool     `json:"notValid"`
+}
+
+func (r *CreateConstraintRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Type = strings.ToLower(strings.TrimSpace(r.Type))
+	r.OnDelete = strings.ToUpper(strings.TrimSpace(r.OnDelete))
+	r.OnUpdate = strings.ToUpper(strings.TrimSpace(r.OnUpdate))
+
+	isForeignKey := r.Type == constants.ConstraintTypeForeignKey
+	isCheck := r.Type == constants.ConstraintTypeCheck
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Constraint name is required"),
+			validation.Length(
+				constants.MinConstraintNameLength, constants.MaxConstraintNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Constraint name must be between %d and %d characters",
+					constants.MinConstraintNameLength,
+					constants.MaxConstraintNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Constraint name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Type,
+			validation.Required.Error("Constraint type is required"),
+			validation.In(constants.ConstraintTypes...).Error(
+				"Constraint type must be one of primary_key, unique, foreign_key or check",
+			),
+		),
+		validation.Field(
+			&r.Columns,
+			validation.When(!isCheck, validation.Required.Error("At least one column is required")),
+		),
+		validation.Field(
+			&r.ReferenceTable,
+			validation.When(isForeignKey, validation.Required.Error("Reference table is required for foreign keys")),
+		),
+		validation.Field(
+			&r.ReferenceColumns,
+			validation.When(isForeignKey, validation.Required.Error("Reference columns are required for foreign keys")),
+		),
+		validation.Field(
+			&r.OnDelete,
+			validation.In(constants.ConstraintReferentialActions...).Error(
+				"On delete must be one of NO ACTION, RESTRICT, CASCADE, SET NULL or SET DEFAULT",
+			),
+		),
+		validation.Field(
+			&r.OnUpdate,
+			validation.In(constants.ConstraintReferentialActions...).Error(
+				"On update must be one of NO ACTION, RESTRICT, CASCADE, SET NULL or SET DEFAULT",
+			),
+		),
+		validation.Field(
+			&r.Expression,
+			validation.When(isCheck, validation.Required.Error("Expression is required for check constraints")),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return r.validateCombinations()
+}
+
+// validateCombinations rejects options postgres does not support for the chosen constraint type
+func (r *CreateConstraintRequest) validateCombinations() []string {
+	var errors []string
+
+	isForeignKey := r.Type == constants.ConstraintTypeForeignKey
+	isCheck := r.Type == constants.ConstraintTypeCheck
+
+	if isCheck && len(r.Columns) > 0 {
+		errors = append(errors, "Check constraints take an expression instead of columns")
+	}
+
+	if !isForeignKey && (r.ReferenceTable != "" || len(r.ReferenceColumns) > 0 || r.OnDelete != "" || r.OnUpdate != "") {
+		errors = append(errors, "References and referential actions are only allowed for foreign keys")
+	}
+
+	if isForeignKey && len(r.ReferenceColumns) != len(r.Columns) {
+		errors = append(errors, "Foreign key must reference as many columns as it has")
+	}
+
+	if !isCheck && r.Expression != "" {
+		errors = append(errors, "Expression is only allowed for check constraints")
+	}
+
+	if isCheck && r.Deferrable {
+		errors = append(errors, "Check constraints cannot be deferrable")
+	}
+
+	if r.InitiallyDeferred && !r.Deferrable {
+		errors = append(errors, "Only deferrable constraints can be initially deferred")
+	}
+
+	if r.NotValid && !isForeignKey && !isCheck {
+		errors = append(errors, "Only foreign key and check constraints can skip validation")
+	}
+
+	seen := make(map[string]bool)
+	for _, column := range r.Columns {
+		if strings.TrimSpace(column) == "" {
+			errors = append(errors, "Column name in constraint cannot be empty")
+
+			continue
+		}
+
+		if seen[strings.ToLower(column)] {
+			errors = append(errors, fmt.Sprintf("Duplicate column '%s' in constraint definition", column))
+		}
+
+		seen[strings.ToLower(column)] = true
+	}
+
+	return errors
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateConstraintRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateConstraintRequest: valid foreign key", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":              "orders_customer_fk",
+			"type":              "foreign_key",
+			"columns":           []string{"tenant_id", "customer_id"},
+			"referenceTable":    "public.customers",
+			"referenceColumns":  []string{"tenant_id", "id"},
+			"onDelete":          "cascade",
+			"onUpdate":          "NO ACTION",
+			"deferrable":        true,
+			"initiallyDeferred": true,
+			"notValid":          true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateConstraintRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.ConstraintTypeForeignKey, r.Type)
+		assert.Equal(t, []string{"tenant_id", "customer_id"}, r.Columns)
+		assert.Equal(t, "CASCADE", r.OnDelete)
+		assert.True(t, r.NotValid)
+	})
+
+	t.Run("CreateConstraintRequest: valid check", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":       "orders_total_check",
+			"type":       "check",
+			"expression": "total >= 0",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateConstraintRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "total >= 0", r.Expression)
+	})
+
+	t.Run("CreateConstraintRequest: valid composite primary key", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":    "order_items_pkey",
+			"type":    "primary_key",
+			"columns": []string{"order_id", "product_id"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateConstraintRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+	})
+
+	t.Run("CreateConstraintRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Missing name",
+				payload:  map[string]interface{}{"type": "unique", "columns": []string{"email"}},
+				expected: "Constraint name is required",
+			},
+			{
+				name:     "Invalid name",
+				payload:  map[string]interface{}{"name": "users-email", "type": "unique", "columns": []string{"email"}},
+				expected: "Constraint name must be alphanumeric with underscores",
+			},
+			{
+				name:     "Unknown type",
+				payload:  map[string]interface{}{"name": "users_email", "type": "exclusion", "columns": []string{"email"}},
+				expected: "Constraint type must be one of",
+			},
+			{
+				name:     "Missing columns",
+				payload:  map[string]interface{}{"name": "users_email", "type": "unique"},
+				expected: "At least one column is required",
+			},
+			{
+				name:     "Foreign key without reference",
+				payload:  map[string]interface{}{"name": "orders_fk", "type": "foreign_key", "columns": []string{"user_id"}},
+				expected: "Reference table is required for foreign keys",
+			},
+			{
+				name: "Foreign key column count mismatch",
+				payload: map[string]interface{}{
+					"name":             "orders_fk",
+					"type":             "foreign_key",
+					"columns":          []string{"tenant_id", "user_id"},
+					"referenceTable":   "users",
+					"referenceColumns": []string{"id"},
+				},
+				expected: "Foreign key must reference as many columns as it has",
+			},
+			{
+				name: "Unknown referential action",
+				payload: map[string]interface{}{
+					"name":             "orders_fk",
+					"type":             "foreign_key",
+					"columns":          []string{"user_id"},
+					"referenceTable":   "users",
+					"referenceColumns": []string{"id"},
+					"onDelete":         "DROP",
+				},
+				expected: "On delete must be one of",
+			},
+			{
+				name:     "Check without expression",
+				payload:  map[string]interface{}{"name": "orders_check", "type": "check"},
+				expected: "Expression is required for check constraints",
+			},
+			{
+				name:     "Deferrable check",
+				payload:  map[string]interface{}{"name": "orders_check", "type": "check", "expression": "total > 0", "deferrable": true},
+				expected: "Check constraints cannot be deferrable",
+			},
+			{
+				name:     "Initially deferred without deferrable",
+				payload:  map[string]interface{}{"name": "users_email", "type": "unique", "columns": []string{"email"}, "initiallyDeferred": true},
+				expected: "Only deferrable constraints can be initially deferred",
+			},
+			{
+				name:     "Not valid unique",
+				payload:  map[string]interface{}{"name": "users_email", "type": "unique", "columns": []string{"email"}, "notValid": true},
+				expected: "Only foreign key and check constraints can skip validation",
+			},
+			{
+				name:     "References on unique",
+				payload:  map[string]interface{}{"name": "users_email", "type": "unique", "columns": []string{"email"}, "onDelete": "CASCADE"},
+				expected: "References and referential actions are only allowed for foreign keys",
+			},
+			{
+				name:     "Duplicate columns",
+				payload:  map[string]interface{}{"name": "users_email", "type": "unique", "columns": []string{"email", "Email"}},
+				expected: "Duplicate column 'Email' in constraint definition",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r CreateConstraintRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package database
+
+type ConstraintResponse struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Definition        string   `json:"definition"`
+	Columns           []string `json:"columns"`
+	ReferenceTable    string   `json:"referenceTable,omitempty"`
+	ReferenceColumns  []string `json:"referenceColumns,omitempty"`
+	OnDelete          string   `json:"onDelete,omitempty"`
+	OnUpdate          string   `json:"onUpdate,omitempty"`
+	Deferrable        bool     `json:"deferrable"`
+	InitiallyDeferred bool     `json:"initiallyDeferred"`
+	Validated         bool     `json:"validated"`
+}
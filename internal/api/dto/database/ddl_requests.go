@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"slices"
+	"strings"
+)
+
+type TableDDLRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Format string `json:"-"`
+}
+
+type ProjectDDLRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Format      string    `json:"-"`
+	Schemas     []string  `json:"-"`
+}
+
+// BindAndValidate reads the format from the query string, e.g. ?format=sql
+func (r *TableDDLRequest) BindAndValidate(c echo.Context) []string {
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	var errors []string
+	r.Format, errors = bindDDLFormat(c)
+
+	return errors
+}
+
+// BindAndValidate reads the options from the query string, e.g. ?format=sql&schema=public,billing
+func (r *ProjectDDLRequest) BindAndValidate(c echo.Context) []string {
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	var errors []string
+	r.Format, errors = bindDDLFormat(c)
+
+	schemas, schemaErrors := bindSchemaFilter(c)
+	r.Schemas = schemas
+	errors = append(errors, schemaErrors...)
+
+	return errors
+}
+
+func bindDDLFormat(c echo.Context) (string, []string) {
+	format := strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if format == "" {
+		return constants.DDLFormatJSON, nil
+	}
+
+	if !slices.Contains(constants.DDLFormats, format) {
+		return format, []string{fmt.Sprintf(
+			"Format '%s' is not supported, allowed formats are: %s",
+			format,
+			strings.Join(constants.DDLFormats, ", "),
+		)}
+	}
+
+	return format, nil
+}
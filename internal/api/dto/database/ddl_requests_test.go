@@ -0,0 +1,79 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTableDDLRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("TableDDLRequest: defaults to json", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableDDLRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.DDLFormatJSON, r.Format)
+	})
+
+	t.Run("TableDDLRequest: sql", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=SQL"
+
+		var r TableDDLRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.DDLFormatSQL, r.Format)
+	})
+
+	t.Run("TableDDLRequest: unknown format", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=yaml"
+
+		var r TableDDLRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Format 'yaml' is not supported")
+	})
+}
+
+func TestProjectDDLRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ProjectDDLRequest: valid with options", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=sql&schema=public, billing"
+
+		var r ProjectDDLRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+		assert.Equal(t, constants.DDLFormatSQL, r.Format)
+		assert.Equal(t, []string{"public", "billing"}, r.Schemas)
+	})
+
+	t.Run("ProjectDDLRequest: invalid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "schema=bad-name"
+
+		var r ProjectDDLRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Schema name must be alphanumeric with underscores")
+	})
+}
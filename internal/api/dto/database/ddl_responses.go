@@ -0,0 +1,6 @@
+package database
+
+type DDLResponse struct {
+	Statements []string `json:"statements"`
+	Script     string   `json:"script"`
+}
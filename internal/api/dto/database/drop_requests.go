@@ -0,0 +1,67 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DropRequest is shared by table and column deletes, the options come from the query string,
+// e.g. ?dryRun=true&cascade=true&snapshot=true&retentionDays=14
+type DropRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	DryRun        bool `query:"dryRun"`
+	Cascade       bool `query:"cascade"`
+	Snapshot      bool `query:"snapshot"`
+	RetentionDays int  `query:"retentionDays"`
+}
+
+type TrashSnapshotRequest struct {
+	dto.BaseRequest
+	ProjectUUID  uuid.UUID `json:"-"`
+	SnapshotUUID uuid.UUID `json:"-"`
+}
+
+func (r *DropRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	if r.RetentionDays == 0 {
+		r.RetentionDays = constants.DefaultTrashRetentionDays
+	}
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.RetentionDays,
+			validation.Min(1).Error(fmt.Sprintf("Retention must be between 1 and %d days", constants.MaxTrashRetentionDays)),
+			validation.Max(constants.MaxTrashRetentionDays).Error(fmt.Sprintf("Retention must be between 1 and %d days", constants.MaxTrashRetentionDays)),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *TrashSnapshotRequest) BindAndValidate(c echo.Context) []string {
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	snapshotUUID, err := r.GetUUIDPathParam(c, "snapshotUUID", true)
+	if err != nil {
+		return []string{"Invalid snapshot UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.SnapshotUUID = snapshotUUID
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestDropRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("DropRequest: defaults", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r DropRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.False(t, r.DryRun)
+		assert.False(t, r.Cascade)
+		assert.False(t, r.Snapshot)
+		assert.Equal(t, constants.DefaultTrashRetentionDays, r.RetentionDays)
+	})
+
+	t.Run("DropRequest: options from query", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "dryRun=true&cascade=true&snapshot=true&retentionDays=14"
+
+		var r DropRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.True(t, r.DryRun)
+		assert.True(t, r.Cascade)
+		assert.True(t, r.Snapshot)
+		assert.Equal(t, 14, r.RetentionDays)
+	})
+
+	t.Run("DropRequest: retention out of range", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "snapshot=true&retentionDays=365"
+
+		var r DropRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Retention must be between 1 and 90 days")
+	})
+
+	t.Run("DropRequest: missing project header", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+
+		var r DropRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "invalid project UUID")
+	})
+}
+
+func TestTrashSnapshotRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("TrashSnapshotRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, nil)
+		ctx.SetParamNames("projectUUID", "snapshotUUID")
+		ctx.SetParamValues(dummyProjectUUID, dummyProjectUUID)
+
+		var r TrashSnapshotRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.SnapshotUUID.String())
+	})
+
+	t.Run("TrashSnapshotRequest: invalid snapshot", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, nil)
+		ctx.SetParamNames("projectUUID", "snapshotUUID")
+		ctx.SetParamValues(dummyProjectUUID, "latest")
+
+		var r TrashSnapshotRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Invalid snapshot UUID")
+	})
+}
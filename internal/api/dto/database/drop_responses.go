@@ -0,0 +1,39 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type DependencyResponse struct {
+	Type            string `json:"type"`
+	Schema          string `json:"schema"`
+	Identity        string `json:"identity"`
+	DependsOn       string `json:"dependsOn"`
+	Depth           int    `json:"depth"`
+	RequiresCascade bool   `json:"requiresCascade"`
+}
+
+type DropPlanResponse struct {
+	Object               string                 `json:"object"`
+	DryRun               bool                   `json:"dryRun"`
+	Cascade              bool                   `json:"cascade"`
+	RequiresCascade      bool                   `json:"requiresCascade"`
+	Dependencies         []DependencyResponse   `json:"dependencies"`
+	ReferencingFunctions []DependencyResponse   `json:"referencingFunctions"`
+	Statements           []string               `json:"statements"`
+	Snapshot             *TrashSnapshotResponse `json:"snapshot"`
+	Warning              string                 `json:"warning,omitempty"`
+}
+
+type TrashSnapshotResponse struct {
+	Uuid       uuid.UUID `json:"uuid"`
+	Kind       string    `json:"kind"`
+	Schema     string    `json:"schema"`
+	TableName  string    `json:"tableName"`
+	ColumnName string    `json:"columnName,omitempty"`
+	TrashTable string    `json:"trashTable"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	CreatedBy  uuid.UUID `json:"createdBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ExportTableRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Format        string
+	Columns       []string
+	Filters       []database.RowFilter
+	Order         []database.RowOrder
+	Async         bool
+	ContainerUUID uuid.UUID
+}
+
+// BindAndValidate reads export options from the query string, e.g.
+// ?format=csv&columns=id,name&filter=age.gte.18&filter=name.ilike.jo%&order=id.desc&async=true&containerUUID=...
+func (r *ExportTableRequest) BindAndValidate(c echo.Context) []string {
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	var errors []string
+	columnPattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+
+	r.Format = strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if r.Format == "" {
+		r.Format = constants.ExportFormatCSV
+	}
+
+	if !slices.Contains(constants.ExportFormats, r.Format) {
+		errors = append(errors, fmt.Sprintf(
+			"Format '%s' is not supported, allowed formats are: %s",
+			r.Format,
+			strings.Join(constants.ExportFormats, ", "),
+		))
+	}
+
+	if columns := strings.TrimSpace(c.QueryParam("columns")); columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			column = strings.TrimSpace(column)
+			if !columnPattern.MatchString(column) {
+				errors = append(errors, fmt.Sprintf("Invalid column name '%s'", column))
+
+				continue
+			}
+
+			r.Columns = append(r.Columns, column)
+		}
+	}
+
+	for _, rawFilter := range c.QueryParams()["filter"] {
+		parts := strings.SplitN(rawFilter, ".", 3)
+		if len(parts) != 3 {
+			errors = append(errors, fmt.Sprintf("Invalid filter '%s', expected column.operator.value", rawFilter))
+
+			continue
+		}
+
+		if !columnPattern.MatchString(parts[0]) {
+			errors = append(errors, fmt.Sprintf("Invalid column name '%s' in filter", parts[0]))
+
+			continue
+		}
+
+		if _, ok := database.RowFilterOperators[parts[1]]; !ok {
+			errors = append(errors, fmt.Sprintf("Unsupported filter operator '%s'", parts[1]))
+
+			continue
+		}
+
+		if _, ok := database.RowFilterIsValues[strings.ToLower(parts[2])]; parts[1] == "is" && !ok {
+			errors = append(errors, fmt.Sprintf("Invalid value '%s' for the is operator, expected null, notnull, true or false", parts[2]))
+
+			continue
+		}
+
+		r.Filters = append(r.Filters, database.RowFilter{
+			Column:   parts[0],
+			Operator: parts[1],
+			Value:    parts[2],
+		})
+	}
+
+	if order := strings.TrimSpace(c.QueryParam("order")); order != "" {
+		for _, rawOrder := range strings.Split(order, ",") {
+			column, direction, _ := strings.Cut(strings.TrimSpace(rawOrder), ".")
+			if !columnPattern.MatchString(column) {
+				errors = append(errors, fmt.Sprintf("Invalid column name '%s' in order", column))
+
+				continue
+			}
+
+			if direction != "" && direction != "asc" && direction != "desc" {
+				errors = append(errors, fmt.Sprintf("Invalid order direction '%s'", direction))
+
+				continue
+			}
+
+			r.Order = append(r.Order, database.RowOrder{
+				Column:     column,
+				Descending: direction == "desc",
+			})
+		}
+	}
+
+	if async := c.QueryParam("async"); async != "" {
+		parsedAsync, err := strconv.ParseBool(async)
+		if err != nil {
+			errors = append(errors, "Async must be a boolean")
+		}
+
+		r.Async = parsedAsync
+	}
+
+	if r.Async {
+		containerUUID, err := r.GetUUIDQueryParam(c, "containerUUID", true)
+		if err != nil {
+			errors = append(errors, "A valid containerUUID is required for async exports")
+		}
+
+		r.ContainerUUID = containerUUID
+	}
+
+	return errors
+}
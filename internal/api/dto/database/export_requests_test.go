@@ -0,0 +1,135 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestExportTableRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ExportTableRequest: defaults to csv", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r ExportTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.ExportFormatCSV, r.Format)
+		assert.False(t, r.Async)
+	})
+
+	t.Run("ExportTableRequest: valid with options", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=ndjson&columns=id,name&filter=age.gte.18&filter=name.ilike.jo.n%25&filter=deleted_at.is.NULL&order=id.desc,name"
+
+		var r ExportTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.ExportFormatNDJSON, r.Format)
+		assert.Equal(t, []string{"id", "name"}, r.Columns)
+		assert.Equal(t, []database.RowFilter{
+			{Column: "age", Operator: "gte", Value: "18"},
+			{Column: "name", Operator: "ilike", Value: "jo.n%"},
+			{Column: "deleted_at", Operator: "is", Value: "NULL"},
+		}, r.Filters)
+		assert.Equal(t, []database.RowOrder{
+			{Column: "id", Descending: true},
+			{Column: "name", Descending: false},
+		}, r.Order)
+	})
+
+	t.Run("ExportTableRequest: valid async", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=sql&async=true&containerUUID=" + dummyProjectUUID
+
+		var r ExportTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.True(t, r.Async)
+		assert.Equal(t, dummyProjectUUID, r.ContainerUUID.String())
+	})
+
+	t.Run("ExportTableRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    string
+			headers  map[string]string
+			expected string
+		}{
+			{
+				name:     "Missing project header",
+				query:    "format=csv",
+				headers:  map[string]string{},
+				expected: "invalid project UUID",
+			},
+			{
+				name:     "Unsupported format",
+				query:    "format=xml",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Format 'xml' is not supported",
+			},
+			{
+				name:     "Invalid column name",
+				query:    "columns=id,na-me",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Invalid column name 'na-me'",
+			},
+			{
+				name:     "Malformed filter",
+				query:    "filter=age.18",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Invalid filter 'age.18'",
+			},
+			{
+				name:     "Unsupported filter operator",
+				query:    "filter=age.between.18",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Unsupported filter operator 'between'",
+			},
+			{
+				name:     "Unsupported is value",
+				query:    "filter=deleted_at.is.empty",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Invalid value 'empty' for the is operator",
+			},
+			{
+				name:     "Invalid order direction",
+				query:    "order=id.up",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Invalid order direction 'up'",
+			},
+			{
+				name:     "Async without container",
+				query:    "async=true",
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "A valid containerUUID is required for async exports",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, map[string]interface{}{})
+				ctx.Request().URL.RawQuery = tt.query
+				for key, value := range tt.headers {
+					ctx.Request().Header.Set(key, value)
+				}
+
+				var r ExportTableRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type EnableExtensionRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"-"`
+	Schema      string    `json:"schema"`
+}
+
+type DisableExtensionRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"-"`
+	Cascade     bool      `query:"cascade"`
+}
+
+func (r *EnableExtensionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = c.Param("extensionName")
+	r.Schema = strings.TrimSpace(r.Schema)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Name, extensionNameRules()...),
+		validation.Field(
+			&r.Schema,
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Schema name must be alphanumeric with underscores"),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *DisableExtensionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = c.Param("extensionName")
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Name, extensionNameRules()...),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+// extensionNameRules allows dashes, uuid-ossp needs them
+func extensionNameRules() []validation.Rule {
+	return []validation.Rule{
+		validation.Required.Error("Extension name is required"),
+		validation.Match(
+			regexp.MustCompile(constants.AlphanumericWithUnderscoreAndDashPattern),
+		).Error("Extension name must be alphanumeric with underscores or dashes"),
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestEnableExtensionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("EnableExtensionRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"schema": " extensions "})
+		ctx.SetParamNames("projectUUID", "extensionName")
+		ctx.SetParamValues(dummyProjectUUID, "uuid-ossp")
+
+		var r EnableExtensionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "uuid-ossp", r.Name)
+		assert.Equal(t, "extensions", r.Schema)
+	})
+
+	t.Run("EnableExtensionRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			extensionName string
+			payload       map[string]interface{}
+			expected      string
+		}{
+			{
+				name:          "Invalid extension name",
+				extensionName: "pg_trgm;drop",
+				payload:       map[string]interface{}{},
+				expected:      "Extension name must be alphanumeric with underscores or dashes",
+			},
+			{
+				name:          "Invalid schema",
+				extensionName: "pg_trgm",
+				payload:       map[string]interface{}{"schema": "my schema"},
+				expected:      "Schema name must be alphanumeric with underscores",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID", "extensionName")
+				ctx.SetParamValues(dummyProjectUUID, tt.extensionName)
+
+				var r EnableExtensionRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
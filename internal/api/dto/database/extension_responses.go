@@ -0,0 +1,11 @@
+package database
+
+type ExtensionResponse struct {
+	Name             string `json:"name"`
+	DefaultVersion   string `json:"defaultVersion"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	Installed        bool   `json:"installed"`
+	Schema           string `json:"schema,omitempty"`
+	Comment          string `json:"comment"`
+	Allowed          bool   `json:"allowed"`
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"fluxend/internal/api/dto"
 	"fluxend/internal/config/constants"
 	"fmt"
@@ -15,13 +16,55 @@ type functionParameter struct {
 	Type string `json:"type"`
 }
 
+// functionTypes lists the types allowed for parameters and return values, they end up unquoted in statements
+var functionTypes = map[string]bool{
+	"integer": true, "bigint": true, "smallint": true, "serial": true, "bigserial": true,
+	"text": true, "varchar": true, "char": true, "boolean": true,
+	"real": true, "double precision": true, "numeric": true,
+	"json": true, "jsonb": true, "uuid": true,
+	"timestamp": true, "timestamptz": true, "date": true, "time": true,
+	"bytea": true, "void": true, "record": true, "table": true,
+}
+
+var functionLanguages = map[string]bool{
+	"plpgsql": true,
+	"sql":     true,
+}
+
 type CreateFunctionRequest struct {
 	dto.DefaultRequestWithProjectHeader
-	Name       string              `json:"name"`
-	Parameters []functionParameter `json:"parameters"`
-	Definition string              `json:"definition"`
-	Language   string              `json:"language"`
-	ReturnType string              `json:"return_type"`
+	Name            string              `json:"name"`
+	Parameters      []functionParameter `json:"parameters"`
+	Definition      string              `json:"definition"`
+	Language        string              `json:"language"`
+	ReturnType      string              `json:"return_type"`
+	Volatility      string              `json:"volatility"`
+	SecurityDefiner bool                `json:"securityDefiner"`
+	SearchPath      []string            `json:"searchPath"`
+}
+
+// UpdateFunctionRequest replaces the overload matching the parameter types, the name comes from the path
+type UpdateFunctionRequest struct {
+	CreateFunctionRequest
+}
+
+// FunctionSignatureRequest picks one overload through ?args=integer,text, an empty value
+// means the overload without arguments and a missing one means the name is not overloaded
+type FunctionSignatureRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	ArgumentTypes []string `json:"-"`
+}
+
+type functionArgument struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value" swaggertype:"object"`
+}
+
+type ExecuteFunctionRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Arguments []functionArgument `json:"arguments"`
+	ReadOnly  bool               `json:"readOnly"`
+	MaxRows   int                `json:"maxRows"`
 }
 
 func (r *CreateFunctionRequest) BindAndValidate(c echo.Context) []string {
@@ -46,21 +89,92 @@ func (r *CreateFunctionRequest) BindAndValidate(c echo.Context) []string {
 	return errors
 }
 
-func (r *CreateFunctionRequest) validate() error {
-	validTypes := map[string]bool{
-		"integer": true, "bigint": true, "smallint": true, "serial": true, "bigserial": true,
-		"text": true, "varchar": true, "char": true, "boolean": true,
-		"real": true, "double precision": true, "numeric": true,
-		"json": true, "jsonb": true, "uuid": true,
-		"timestamp": true, "timestamptz": true, "date": true, "time": true,
-		"bytea": true, "void": true, "record": true, "table": true,
+func (r *UpdateFunctionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Name = c.Param("functionName")
+
+	return r.ExtractValidationErrors(r.validate())
+}
+
+func (r *FunctionSignatureRequest) BindAndValidate(c echo.Context) []string {
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	values, ok := c.QueryParams()["args"]
+	if !ok {
+		return nil
+	}
+
+	r.ArgumentTypes = []string{}
+	for _, value := range strings.Split(strings.Join(values, ","), ",") {
+		argumentType := strings.TrimSpace(value)
+		if argumentType == "" {
+			continue
+		}
+
+		if _, exists := functionTypes[argumentType]; !exists {
+			return []string{fmt.Sprintf("invalid argument type: %s", argumentType)}
+		}
+
+		r.ArgumentTypes = append(r.ArgumentTypes, argumentType)
+	}
+
+	return nil
+}
+
+func (r *ExecuteFunctionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	for i := range r.Arguments {
+		r.Arguments[i].Type = strings.TrimSpace(r.Arguments[i].Type)
 	}
 
-	validLanguages := map[string]bool{
-		"plpgsql": true,
-		"sql":     true,
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Arguments,
+			validation.By(func(value interface{}) error {
+				for i, argument := range value.([]functionArgument) {
+					if _, exists := functionTypes[argument.Type]; !exists || argument.Type == "void" {
+						return fmt.Errorf("invalid type for argument %d: %s", i+1, argument.Type)
+					}
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(
+			&r.MaxRows,
+			validation.Min(0).Error("Max rows cannot be negative"),
+			validation.Max(constants.MaxQueryRows).Error(
+				fmt.Sprintf("Max rows cannot be more than %d", constants.MaxQueryRows),
+			),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *CreateFunctionRequest) validate() error {
+	if r.Volatility == "" {
+		r.Volatility = constants.FunctionVolatile
 	}
 
+	r.Volatility = strings.ToUpper(r.Volatility)
+
 	return validation.ValidateStruct(r,
 		validation.Field(
 			&r.Name,
@@ -84,7 +198,12 @@ func (r *CreateFunctionRequest) validate() error {
 			&r.ReturnType,
 			validation.Required.Error("return_type is required"),
 			validation.By(func(value interface{}) error {
-				if _, exists := validTypes[value.(string)]; !exists {
+				// trigger is only valid as a return type, such functions are attached to tables through triggers
+				if value.(string) == constants.TriggerFunctionReturnType {
+					return nil
+				}
+
+				if _, exists := functionTypes[value.(string)]; !exists {
 					return fmt.Errorf("invalid return type: %s", value.(string))
 				}
 				return nil
@@ -96,7 +215,7 @@ func (r *CreateFunctionRequest) validate() error {
 			&r.Language,
 			validation.Required.Error("language is required"),
 			validation.By(func(value interface{}) error {
-				if _, exists := validLanguages[value.(string)]; !exists {
+				if _, exists := functionLanguages[value.(string)]; !exists {
 					return fmt.Errorf("invalid language: %s", value.(string))
 				}
 				return nil
@@ -125,12 +244,30 @@ func (r *CreateFunctionRequest) validate() error {
 					return fmt.Errorf("invalid parameters format")
 				}
 				for _, param := range params {
-					if _, exists := validTypes[param.Type]; !exists {
+					if _, exists := functionTypes[param.Type]; !exists {
 						return fmt.Errorf("invalid parameter type: %s", param.Type)
 					}
 				}
 				return nil
 			}),
 		),
+
+		// Validate options
+		validation.Field(
+			&r.Volatility,
+			validation.In(constants.FunctionVolatilities...).Error("volatility must be one of VOLATILE, STABLE or IMMUTABLE"),
+		),
+		validation.Field(
+			&r.SearchPath,
+			validation.When(
+				r.SecurityDefiner,
+				validation.Required.Error("search_path is required for security definer functions"),
+			),
+			validation.Each(
+				validation.Match(
+					regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+				).Error("search_path must only contain schema names"),
+			),
+		),
 	)
 }
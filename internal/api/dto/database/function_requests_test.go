@@ -61,6 +61,24 @@ func TestCreateFunctionRequest_BindAndValidate_Suite(t *testing.T) {
 		assert.Equal(t, "integer", r.Parameters[0].Type)
 	})
 
+	t.Run("CreateFunctionRequest: valid trigger function", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":        "touch_updated_at",
+			"definition":  "BEGIN NEW.updated_at = now(); RETURN NEW; END",
+			"language":    "plpgsql",
+			"return_type": "trigger",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateFunctionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.TriggerFunctionReturnType, r.ReturnType)
+	})
+
 	t.Run("CreateFunctionRequest: invalid", func(t *testing.T) {
 		tests := []struct {
 			name     string
@@ -234,6 +252,22 @@ func TestCreateFunctionRequest_BindAndValidate_Suite(t *testing.T) {
 				},
 				expected: []string{"invalid parameter type: invalid_type"},
 			},
+			{
+				name: "Trigger parameter type",
+				payload: map[string]interface{}{
+					"name": "test_function",
+					"parameters": []functionParameter{
+						{Name: "param1", Type: "trigger"},
+					},
+					"definition":  "BEGIN RETURN 1; END",
+					"language":    "plpgsql",
+					"return_type": "integer",
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"invalid parameter type: trigger"},
+			},
 		}
 
 		for _, tc := range tests {
@@ -254,3 +288,191 @@ func TestCreateFunctionRequest_BindAndValidate_Suite(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateFunctionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("UpdateFunctionRequest: name from path and default volatility", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":        "ignored",
+			"definition":  "BEGIN RETURN 1; END",
+			"language":    "plpgsql",
+			"return_type": "integer",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.SetParamNames("schema", "functionName")
+		ctx.SetParamValues("public", "test_function")
+
+		var r UpdateFunctionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "test_function", r.Name)
+		assert.Equal(t, constants.FunctionVolatile, r.Volatility)
+	})
+
+	t.Run("UpdateFunctionRequest: invalid options", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			options  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Unknown volatility",
+				options:  map[string]interface{}{"volatility": "sometimes"},
+				expected: "volatility must be one of VOLATILE, STABLE or IMMUTABLE",
+			},
+			{
+				name:     "Security definer without search path",
+				options:  map[string]interface{}{"securityDefiner": true},
+				expected: "search_path is required for security definer functions",
+			},
+			{
+				name:     "Invalid search path",
+				options:  map[string]interface{}{"searchPath": []string{"public; DROP TABLE users"}},
+				expected: "search_path must only contain schema names",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				payload := map[string]interface{}{
+					"definition":  "BEGIN RETURN 1; END",
+					"language":    "plpgsql",
+					"return_type": "integer",
+				}
+				for key, value := range tt.options {
+					payload[key] = value
+				}
+
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+				ctx.SetParamNames("schema", "functionName")
+				ctx.SetParamValues("public", "test_function")
+
+				var r UpdateFunctionRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestFunctionSignatureRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("FunctionSignatureRequest: argument types from query", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.QueryParams().Set("args", "integer, double precision")
+
+		var r FunctionSignatureRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, []string{"integer", "double precision"}, r.ArgumentTypes)
+	})
+
+	t.Run("FunctionSignatureRequest: empty args picks the overload without arguments", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.QueryParams().Set("args", "")
+
+		var r FunctionSignatureRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.NotNil(t, r.ArgumentTypes)
+		assert.Len(t, r.ArgumentTypes, 0)
+	})
+
+	t.Run("FunctionSignatureRequest: missing args resolves by name", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r FunctionSignatureRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Nil(t, r.ArgumentTypes)
+	})
+
+	t.Run("FunctionSignatureRequest: invalid type", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+		ctx.QueryParams().Set("args", "integer,text);DROP")
+
+		var r FunctionSignatureRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "invalid argument type: text);DROP")
+	})
+}
+
+func TestExecuteFunctionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ExecuteFunctionRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"arguments": []map[string]interface{}{
+				{"type": "integer", "value": 10},
+				{"type": "jsonb", "value": map[string]interface{}{"tier": "gold"}},
+			},
+			"readOnly": true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r ExecuteFunctionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Len(t, r.Arguments, 2)
+		assert.JSONEq(t, `{"tier":"gold"}`, string(r.Arguments[1].Value))
+		assert.True(t, r.ReadOnly)
+	})
+
+	t.Run("ExecuteFunctionRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name: "Unknown argument type",
+				payload: map[string]interface{}{
+					"arguments": []map[string]interface{}{{"type": "integer) OR (1", "value": 1}},
+				},
+				expected: "invalid type for argument 1: integer) OR (1",
+			},
+			{
+				name: "Void argument",
+				payload: map[string]interface{}{
+					"arguments": []map[string]interface{}{{"type": "text", "value": "a"}, {"type": "void"}},
+				},
+				expected: "invalid type for argument 2: void",
+			},
+			{
+				name:     "Too many rows",
+				payload:  map[string]interface{}{"maxRows": constants.MaxQueryRows + 1},
+				expected: "Max rows cannot be more than",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r ExecuteFunctionRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -1,9 +1,26 @@
 package database
 
+import (
+	"fluxend/internal/domain/database"
+)
+
 type FunctionResponse struct {
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	DataType   string `json:"dataType"`
-	Definition string `json:"definition"`
-	Language   string `json:"language"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	DataType        string `json:"dataType"`
+	Arguments       string `json:"arguments"`
+	Definition      string `json:"definition"`
+	Language        string `json:"language"`
+	Volatility      string `json:"volatility"`
+	SecurityDefiner bool   `json:"securityDefiner"`
+	SearchPath      string `json:"searchPath"`
+}
+
+type FunctionResultResponse struct {
+	Columns    []database.ResultColumn `json:"columns"`
+	Rows       [][]interface{}         `json:"rows"`
+	RowCount   int                     `json:"rowCount"`
+	Truncated  bool                    `json:"truncated"`
+	ReadOnly   bool                    `json:"readOnly"`
+	DurationMs int64                   `json:"durationMs"`
 }
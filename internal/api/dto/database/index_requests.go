@@ -12,9 +12,22 @@ import (
 
 type CreateIndexRequest struct {
 	dto.DefaultRequestWithProjectHeader
-	Name     string   `json:"name"`
-	Columns  []string `json:"columns"`
-	IsUnique bool     `json:"is_unique"`
+	Name         string            `json:"name"`
+	Method       string            `json:"method"`
+	Columns      []string          `json:"columns"`
+	Keys         []IndexKeyRequest `json:"keys"`
+	Include      []string          `json:"include"`
+	Where        string            `json:"where"`
+	IsUnique     bool              `json:"is_unique"`
+	Concurrently bool              `json:"concurrently"`
+}
+
+// IndexKeyRequest is a single key of the index, either a column or an expression such as lower(email)
+type IndexKeyRequest struct {
+	Column     string `json:"column"`
+	Expression string `json:"expression"`
+	Order      string `json:"order"`
+	Nulls      string `json:"nulls"`
 }
 
 func (r *CreateIndexRequest) BindAndValidate(c echo.Context) []string {
@@ -26,6 +39,13 @@ func (r *CreateIndexRequest) BindAndValidate(c echo.Context) []string {
 		return []string{err.Error()}
 	}
 
+	r.Method = strings.ToLower(strings.TrimSpace(r.Method))
+	if r.Method == "" {
+		r.Method = constants.IndexMethodBtree
+	}
+
+	r.Where = strings.TrimSpace(r.Where)
+
 	var errors []string
 
 	err := validation.ValidateStruct(r,
@@ -45,7 +65,10 @@ func (r *CreateIndexRequest) BindAndValidate(c echo.Context) []string {
 				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
 			).Error("Index name must be alphanumeric with underscores"),
 		),
-		validation.Field(&r.Columns, validation.Required.Error("At least one column is required")),
+		validation.Field(
+			&r.Method,
+			validation.In(constants.IndexMethods...).Error("Index method must be one of btree, hash, gin, gist or brin"),
+		),
 	)
 
 	errors = append(errors, r.ExtractValidationErrors(err)...)
@@ -57,20 +80,95 @@ func (r *CreateIndexRequest) BindAndValidate(c echo.Context) []string {
 		return append(errors, fmt.Sprintf("Index name '%s' is reserved and cannot be used", r.Name))
 	}
 
+	if len(r.Columns) > 0 && len(r.Keys) > 0 {
+		return append(errors, "Use either columns or keys, not both")
+	}
+
+	// plain columns are a shorthand for keys without expression or sort options
+	for _, column := range r.Columns {
+		r.Keys = append(r.Keys, IndexKeyRequest{Column: column})
+	}
+
+	if len(r.Keys) == 0 {
+		return append(errors, "At least one column is required")
+	}
+
+	return r.validateKeys()
+}
+
+// validateKeys checks every key and the options postgres only supports for some index methods
+func (r *CreateIndexRequest) validateKeys() []string {
+	var errors []string
+
+	isBtree := r.Method == constants.IndexMethodBtree
+
+	if r.IsUnique && !isBtree {
+		errors = append(errors, "Only btree indexes can be unique")
+	}
+
+	if r.Method == constants.IndexMethodHash && len(r.Keys) > 1 {
+		errors = append(errors, "Hash indexes support a single key")
+	}
+
+	if len(r.Include) > 0 && !isBtree && r.Method != constants.IndexMethodGist {
+		errors = append(errors, "Include columns are only supported by btree and gist indexes")
+	}
+
 	// Ensure unique column names for the index
 	seen := make(map[string]bool)
-	for _, column := range r.Columns {
-		if strings.TrimSpace(column) == "" {
+	for i := range r.Keys {
+		key := &r.Keys[i]
+		key.Column = strings.TrimSpace(key.Column)
+		key.Expression = strings.TrimSpace(key.Expression)
+		key.Order = strings.ToUpper(strings.TrimSpace(key.Order))
+		key.Nulls = strings.ToUpper(strings.TrimSpace(key.Nulls))
+
+		if key.Order != "" && key.Order != constants.IndexOrderAsc && key.Order != constants.IndexOrderDesc {
+			errors = append(errors, "Index key order must be ASC or DESC")
+		}
+
+		if key.Nulls != "" && key.Nulls != constants.IndexNullsFirst && key.Nulls != constants.IndexNullsLast {
+			errors = append(errors, "Index key nulls must be FIRST or LAST")
+		}
+
+		if (key.Order != "" || key.Nulls != "") && !isBtree {
+			errors = append(errors, "Sort order and nulls placement are only supported by btree indexes")
+		}
+
+		if key.Expression != "" {
+			if key.Column != "" {
+				errors = append(errors, "Index key takes either a column or an expression, not both")
+			}
+
+			continue
+		}
+
+		if key.Column == "" {
 			errors = append(errors, "Column name in index cannot be empty")
 
 			continue
 		}
 
-		if seen[strings.ToLower(column)] {
-			errors = append(errors, fmt.Sprintf("Duplicate column '%s' in index definition", column))
+		if seen[strings.ToLower(key.Column)] {
+			errors = append(errors, fmt.Sprintf("Duplicate column '%s' in index definition", key.Column))
+		}
+
+		seen[strings.ToLower(key.Column)] = true
+	}
+
+	included := make(map[string]bool)
+	for _, column := range r.Include {
+		if strings.TrimSpace(column) == "" {
+			errors = append(errors, "Include column name cannot be empty")
+
+			continue
+		}
+
+		if included[strings.ToLower(column)] {
+			errors = append(errors, fmt.Sprintf("Duplicate include column '%s' in index definition", column))
 		}
 
-		seen[strings.ToLower(column)] = true
+		included[strings.ToLower(column)] = true
 	}
 
 	return errors
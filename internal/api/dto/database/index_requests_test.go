@@ -50,6 +50,50 @@ func TestCreateIndexRequest_BindAndValidate_Suite(t *testing.T) {
 		assert.Equal(t, true, r.IsUnique)
 	})
 
+	t.Run("CreateIndexRequest: columns default to btree keys", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":    "test_index",
+			"columns": []string{"column1"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateIndexRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.IndexMethodBtree, r.Method)
+		assert.Equal(t, []IndexKeyRequest{{Column: "column1"}}, r.Keys)
+	})
+
+	t.Run("CreateIndexRequest: valid partial expression index", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":   "lower_email_idx",
+			"method": "BTREE",
+			"keys": []map[string]string{
+				{"expression": "lower(email)"},
+				{"column": "created_at", "order": "desc", "nulls": "last"},
+			},
+			"include":      []string{"id"},
+			"where":        " deleted_at IS NULL ",
+			"concurrently": true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateIndexRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.IndexMethodBtree, r.Method)
+		assert.Equal(t, "DESC", r.Keys[1].Order)
+		assert.Equal(t, "LAST", r.Keys[1].Nulls)
+		assert.Equal(t, "deleted_at IS NULL", r.Where)
+		assert.True(t, r.Concurrently)
+	})
+
 	t.Run("CreateIndexRequest: invalid", func(t *testing.T) {
 		tests := []struct {
 			name     string
@@ -185,6 +229,102 @@ func TestCreateIndexRequest_BindAndValidate_Suite(t *testing.T) {
 				},
 				expected: []string{"Duplicate column 'column1' in index definition"},
 			},
+			{
+				name: "Unknown method",
+				payload: map[string]interface{}{
+					"name":    "test_index",
+					"method":  "spgist2",
+					"columns": []string{"column1"},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Index method must be one of"},
+			},
+			{
+				name: "Both columns and keys",
+				payload: map[string]interface{}{
+					"name":    "test_index",
+					"columns": []string{"column1"},
+					"keys":    []map[string]string{{"column": "column2"}},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Use either columns or keys, not both"},
+			},
+			{
+				name: "Key with column and expression",
+				payload: map[string]interface{}{
+					"name": "test_index",
+					"keys": []map[string]string{{"column": "email", "expression": "lower(email)"}},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Index key takes either a column or an expression, not both"},
+			},
+			{
+				name: "Unique gin index",
+				payload: map[string]interface{}{
+					"name":      "test_index",
+					"method":    "gin",
+					"columns":   []string{"tags"},
+					"is_unique": true,
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Only btree indexes can be unique"},
+			},
+			{
+				name: "Hash index with several keys",
+				payload: map[string]interface{}{
+					"name":    "test_index",
+					"method":  "hash",
+					"columns": []string{"column1", "column2"},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Hash indexes support a single key"},
+			},
+			{
+				name: "Include on brin index",
+				payload: map[string]interface{}{
+					"name":    "test_index",
+					"method":  "brin",
+					"columns": []string{"created_at"},
+					"include": []string{"id"},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Include columns are only supported by btree and gist indexes"},
+			},
+			{
+				name: "Sort order on gist index",
+				payload: map[string]interface{}{
+					"name":   "test_index",
+					"method": "gist",
+					"keys":   []map[string]string{{"column": "location", "order": "desc"}},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Sort order and nulls placement are only supported by btree indexes"},
+			},
+			{
+				name: "Invalid order and nulls",
+				payload: map[string]interface{}{
+					"name": "test_index",
+					"keys": []map[string]string{{"column": "column1", "order": "up", "nulls": "middle"}},
+				},
+				headers: map[string]string{
+					constants.ProjectHeaderKey: dummyProjectUUID,
+				},
+				expected: []string{"Index key order must be ASC or DESC", "Index key nulls must be FIRST or LAST"},
+			},
 		}
 
 		for _, tc := range tests {
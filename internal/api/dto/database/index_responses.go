@@ -0,0 +1,21 @@
+package database
+
+type IndexResponse struct {
+	Name       string                `json:"name"`
+	Definition string                `json:"definition"`
+	Method     string                `json:"method"`
+	IsUnique   bool                  `json:"isUnique"`
+	IsPrimary  bool                  `json:"isPrimary"`
+	IsValid    bool                  `json:"isValid"`
+	Predicate  string                `json:"predicate"`
+	Include    []string              `json:"include"`
+	SizeBytes  int64                 `json:"sizeBytes"`
+	Columns    []IndexColumnResponse `json:"columns"`
+}
+
+type IndexColumnResponse struct {
+	Name         string `json:"name"`
+	IsExpression bool   `json:"isExpression"`
+	Order        string `json:"order,omitempty"`
+	Nulls        string `json:"nulls,omitempty"`
+}
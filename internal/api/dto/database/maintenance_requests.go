@@ -0,0 +1,55 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"strings"
+)
+
+// TableMaintenanceRequest picks one action, restartIdentity and cascade only apply to
+// truncate, full and analyze only to vacuum
+type TableMaintenanceRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Action          string `json:"action"`
+	RestartIdentity bool   `json:"restartIdentity"`
+	Cascade         bool   `json:"cascade"`
+	Full            bool   `json:"full"`
+	Analyze         bool   `json:"analyze"`
+}
+
+func (r *TableMaintenanceRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Action,
+			validation.Required.Error("Action is required"),
+			validation.In(constants.MaintenanceActions...).Error("Action must be one of truncate, vacuum or reindex"),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	if (r.RestartIdentity || r.Cascade) && r.Action != constants.MaintenanceActionTruncate {
+		errors = append(errors, "Restart identity and cascade only apply to truncate")
+	}
+
+	if (r.Full || r.Analyze) && r.Action != constants.MaintenanceActionVacuum {
+		errors = append(errors, "Full and analyze only apply to vacuum")
+	}
+
+	return errors
+}
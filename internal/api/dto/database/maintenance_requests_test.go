@@ -0,0 +1,93 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTableMaintenanceRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("TableMaintenanceRequest: valid truncate", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"action":          "TRUNCATE",
+			"restartIdentity": true,
+			"cascade":         true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.MaintenanceActionTruncate, r.Action)
+		assert.True(t, r.RestartIdentity)
+		assert.True(t, r.Cascade)
+	})
+
+	t.Run("TableMaintenanceRequest: valid vacuum", func(t *testing.T) {
+		payload := map[string]interface{}{"action": "vacuum", "full": true, "analyze": true}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.True(t, r.Full)
+		assert.True(t, r.Analyze)
+	})
+
+	t.Run("TableMaintenanceRequest: missing action", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Action is required")
+	})
+
+	t.Run("TableMaintenanceRequest: unknown action", func(t *testing.T) {
+		payload := map[string]interface{}{"action": "cluster"}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Action must be one of truncate, vacuum or reindex")
+	})
+
+	t.Run("TableMaintenanceRequest: options of another action", func(t *testing.T) {
+		payload := map[string]interface{}{"action": "reindex", "cascade": true, "full": true}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Restart identity and cascade only apply to truncate")
+		pkg.AssertErrorContains(t, errs, "Full and analyze only apply to vacuum")
+	})
+
+	t.Run("TableMaintenanceRequest: missing project header", func(t *testing.T) {
+		payload := map[string]interface{}{"action": "reindex"}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+
+		var r TableMaintenanceRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.NotEmpty(t, errs)
+	})
+}
@@ -2,14 +2,29 @@ package database
 
 import (
 	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/maintenance"
 )
 
 func ToCreateIndexInput(request CreateIndexRequest) database.CreateIndexInput {
+	keys := make([]database.IndexKeyInput, len(request.Keys))
+	for i, key := range request.Keys {
+		keys[i] = database.IndexKeyInput{
+			Column:     key.Column,
+			Expression: key.Expression,
+			Order:      key.Order,
+			Nulls:      key.Nulls,
+		}
+	}
+
 	return database.CreateIndexInput{
-		ProjectUUID: request.ProjectUUID,
-		Name:        request.Name,
-		Columns:     request.Columns,
-		IsUnique:    request.IsUnique,
+		ProjectUUID:  request.ProjectUUID,
+		Name:         request.Name,
+		Method:       request.Method,
+		Keys:         keys,
+		Include:      request.Include,
+		Where:        request.Where,
+		IsUnique:     request.IsUnique,
+		Concurrently: request.Concurrently,
 	}
 }
 
@@ -30,8 +45,18 @@ func ToRenameColumnInput(request RenameColumnRequest) database.RenameColumnInput
 func ToCreateTableInput(request CreateTableRequest) database.CreateTableInput {
 	return database.CreateTableInput{
 		ProjectUUID: request.ProjectUUID,
+		Schema:      request.Schema,
 		Name:        request.Name,
 		Columns:     request.Columns,
+		Description: request.Description,
+		Partition:   request.Partition,
+	}
+}
+
+func ToUpdateTableDescriptionInput(request UpdateTableDescriptionRequest) database.UpdateTableDescriptionInput {
+	return database.UpdateTableDescriptionInput{
+		ProjectUUID: request.ProjectUUID,
+		Description: request.Description,
 	}
 }
 
@@ -45,6 +70,7 @@ func ToRenameTableInput(request RenameTableRequest) database.RenameTableInput {
 func ToUploadTableInput(request UploadTableRequest) database.UploadTableInput {
 	return database.UploadTableInput{
 		ProjectUUID: request.ProjectUUID,
+		Schema:      request.Schema,
 		Name:        request.Name,
 		File:        request.File,
 	}
@@ -60,11 +86,349 @@ func ToCreateFunctionInput(request CreateFunctionRequest) database.CreateFunctio
 	}
 
 	return database.CreateFunctionInput{
+		ProjectUUID:     request.ProjectUUID,
+		Parameters:      parameters,
+		Name:            request.Name,
+		Definition:      request.Definition,
+		Language:        request.Language,
+		ReturnType:      request.ReturnType,
+		Volatility:      request.Volatility,
+		SecurityDefiner: request.SecurityDefiner,
+		SearchPath:      request.SearchPath,
+	}
+}
+
+func ToExecuteFunctionInput(request ExecuteFunctionRequest) database.ExecuteFunctionInput {
+	arguments := make([]database.FunctionArgument, len(request.Arguments))
+	for i, argument := range request.Arguments {
+		arguments[i] = database.FunctionArgument{
+			Type:  argument.Type,
+			Value: argument.Value,
+		}
+	}
+
+	return database.ExecuteFunctionInput{
+		ProjectUUID: request.ProjectUUID,
+		Arguments:   arguments,
+		ReadOnly:    request.ReadOnly,
+		MaxRows:     request.MaxRows,
+	}
+}
+
+func ToExportTableInput(request ExportTableRequest) database.ExportTableInput {
+	return database.ExportTableInput{
+		ProjectUUID:   request.ProjectUUID,
+		Format:        request.Format,
+		Columns:       request.Columns,
+		Filters:       request.Filters,
+		Order:         request.Order,
+		Async:         request.Async,
+		ContainerUUID: request.ContainerUUID,
+	}
+}
+
+func ToSeedTableInput(request SeedTableRequest) database.SeedTableInput {
+	return database.SeedTableInput{
+		ProjectUUID: request.ProjectUUID,
+		Count:       request.Count,
+	}
+}
+
+func ToCreateMigrationInput(request CreateMigrationRequest) database.CreateMigrationInput {
+	return database.CreateMigrationInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+		UpSQL:       request.Up,
+		DownSQL:     request.Down,
+	}
+}
+
+func ToApplyMigrationInput(request ApplyMigrationRequest) database.ApplyMigrationInput {
+	return database.ApplyMigrationInput{
+		ProjectUUID: request.ProjectUUID,
+		Version:     request.Version,
+	}
+}
+
+func ToRollbackMigrationInput(request RollbackMigrationRequest) database.RollbackMigrationInput {
+	return database.RollbackMigrationInput{
+		ProjectUUID: request.ProjectUUID,
+		Steps:       request.Steps,
+	}
+}
+
+func ToSchemaDiffInput(request SchemaDiffRequest) database.SchemaDiffInput {
+	return database.SchemaDiffInput{
+		ProjectUUID:       request.ProjectUUID,
+		TargetProjectUUID: request.TargetProjectUUID,
+	}
+}
+
+func ToCreateConstraintInput(request CreateConstraintRequest) database.CreateConstraintInput {
+	return database.CreateConstraintInput{
+		ProjectUUID:       request.ProjectUUID,
+		Name:              request.Name,
+		Type:              request.Type,
+		Columns:           request.Columns,
+		ReferenceTable:    request.ReferenceTable,
+		ReferenceColumns:  request.ReferenceColumns,
+		OnDelete:          request.OnDelete,
+		OnUpdate:          request.OnUpdate,
+		Expression:        request.Expression,
+		Deferrable:        request.Deferrable,
+		InitiallyDeferred: request.InitiallyDeferred,
+		NotValid:          request.NotValid,
+	}
+}
+
+func ToCreateTriggerInput(request CreateTriggerRequest) database.CreateTriggerInput {
+	return database.CreateTriggerInput{
+		ProjectUUID:   request.ProjectUUID,
+		Name:          request.Name,
+		Timing:        request.Timing,
+		Level:         request.Level,
+		Events:        request.Events,
+		UpdateColumns: request.UpdateColumns,
+		Condition:     request.Condition,
+		Function:      request.Function,
+	}
+}
+
+func ToCreatePolicyInput(request CreatePolicyRequest) database.CreatePolicyInput {
+	return database.CreatePolicyInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+		Command:     request.Command,
+		Restrictive: request.Restrictive,
+		Roles:       request.Roles,
+		Using:       request.Using,
+		WithCheck:   request.WithCheck,
+	}
+}
+
+func ToUpdatePolicyInput(request UpdatePolicyRequest) database.UpdatePolicyInput {
+	return database.UpdatePolicyInput{
+		ProjectUUID: request.ProjectUUID,
+		Command:     request.Command,
+		Restrictive: request.Restrictive,
+		Roles:       request.Roles,
+		Using:       request.Using,
+		WithCheck:   request.WithCheck,
+	}
+}
+
+func ToUpdateRowLevelSecurityInput(request UpdateRowLevelSecurityRequest) database.UpdateRowLevelSecurityInput {
+	return database.UpdateRowLevelSecurityInput{
+		ProjectUUID: request.ProjectUUID,
+		Enabled:     request.Enabled,
+		Forced:      request.Forced,
+	}
+}
+
+func ToTestPolicyInput(request TestPolicyRequest) database.TestPolicyInput {
+	return database.TestPolicyInput{
 		ProjectUUID: request.ProjectUUID,
-		Parameters:  parameters,
+		Role:        request.Role,
+		Claims:      request.Claims,
+		Query:       request.Query,
+		MaxRows:     request.MaxRows,
+	}
+}
+
+func ToCreateTypeInput(request CreateTypeRequest) database.CreateTypeInput {
+	attributes := make([]database.TypeAttribute, len(request.Attributes))
+	for i, attribute := range request.Attributes {
+		attributes[i] = database.TypeAttribute{Name: attribute.Name, Type: attribute.Type}
+	}
+
+	return database.CreateTypeInput{
+		ProjectUUID: request.ProjectUUID,
+		Schema:      request.Schema,
 		Name:        request.Name,
+		Kind:        request.Kind,
+		Values:      request.Values,
+		Attributes:  attributes,
+	}
+}
+
+func ToAlterTypeInput(request AlterTypeRequest) database.AlterTypeInput {
+	return database.AlterTypeInput{
+		ProjectUUID: request.ProjectUUID,
+		Action:      request.Action,
+		Value:       request.Value,
+		NewValue:    request.NewValue,
+		Before:      request.Before,
+		After:       request.After,
+	}
+}
+
+func ToCreateViewInput(request CreateViewRequest) database.CreateViewInput {
+	return database.CreateViewInput{
+		ProjectUUID:            request.ProjectUUID,
+		Schema:                 request.Schema,
+		Name:                   request.Name,
+		Definition:             request.Definition,
+		Materialized:           request.Materialized,
+		SkipData:               request.SkipData,
+		RefreshIntervalMinutes: request.RefreshIntervalMinutes,
+		RefreshConcurrently:    request.RefreshConcurrently,
+	}
+}
+
+func ToReplaceViewInput(request ReplaceViewRequest) database.ReplaceViewInput {
+	return database.ReplaceViewInput{
+		ProjectUUID: request.ProjectUUID,
 		Definition:  request.Definition,
-		Language:    request.Language,
-		ReturnType:  request.ReturnType,
+	}
+}
+
+func ToRefreshViewInput(request RefreshViewRequest) database.RefreshViewInput {
+	return database.RefreshViewInput{
+		ProjectUUID:  request.ProjectUUID,
+		Concurrently: request.Concurrently,
+	}
+}
+
+func ToScheduleViewRefreshInput(request ScheduleViewRefreshRequest) database.ScheduleViewRefreshInput {
+	return database.ScheduleViewRefreshInput{
+		ProjectUUID:     request.ProjectUUID,
+		IntervalMinutes: request.IntervalMinutes,
+		Concurrently:    request.Concurrently,
+	}
+}
+
+func ToCreateRoleInput(request CreateRoleRequest) database.CreateRoleInput {
+	return database.CreateRoleInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+		Inherit:     request.Inherit,
+		MemberOf:    request.MemberOf,
+	}
+}
+
+func ToGrantInput(request GrantRequest) database.GrantInput {
+	return database.GrantInput{
+		ProjectUUID:     request.ProjectUUID,
+		ObjectType:      request.ObjectType,
+		Object:          request.Object,
+		Columns:         request.Columns,
+		Privileges:      request.Privileges,
+		WithGrantOption: request.WithGrantOption,
+	}
+}
+
+func ToIssueRoleTokenInput(request IssueRoleTokenRequest) database.IssueRoleTokenInput {
+	return database.IssueRoleTokenInput{
+		ProjectUUID:      request.ProjectUUID,
+		ExpiresInSeconds: request.ExpiresInSeconds,
+		Claims:           request.Claims,
+	}
+}
+
+func ToEnableExtensionInput(request EnableExtensionRequest) database.EnableExtensionInput {
+	return database.EnableExtensionInput{
+		ProjectUUID: request.ProjectUUID,
+		Schema:      request.Schema,
+	}
+}
+
+func ToDisableExtensionInput(request DisableExtensionRequest) database.DisableExtensionInput {
+	return database.DisableExtensionInput{
+		ProjectUUID: request.ProjectUUID,
+		Cascade:     request.Cascade,
+	}
+}
+
+func ToCreateSchemaInput(request CreateSchemaRequest) database.CreateSchemaInput {
+	return database.CreateSchemaInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+	}
+}
+
+func ToRenameSchemaInput(request RenameSchemaRequest) database.RenameSchemaInput {
+	return database.RenameSchemaInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+	}
+}
+
+func ToDropSchemaInput(request DropSchemaRequest) database.DropSchemaInput {
+	return database.DropSchemaInput{
+		ProjectUUID: request.ProjectUUID,
+		Cascade:     request.Cascade,
+	}
+}
+
+func ToExposeSchemasInput(request ExposeSchemasRequest) database.ExposeSchemasInput {
+	return database.ExposeSchemasInput{
+		ProjectUUID: request.ProjectUUID,
+		Schemas:     request.Schemas,
+	}
+}
+
+func ToCreatePartitionInput(request CreatePartitionRequest) database.CreatePartitionInput {
+	return database.CreatePartitionInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        request.Name,
+		Bounds:      request.Bounds,
+	}
+}
+
+func ToAttachPartitionInput(request AttachPartitionRequest) database.AttachPartitionInput {
+	return database.AttachPartitionInput{
+		ProjectUUID: request.ProjectUUID,
+		Bounds:      request.Bounds,
+	}
+}
+
+func ToDetachPartitionInput(request DetachPartitionRequest) database.DetachPartitionInput {
+	return database.DetachPartitionInput{
+		ProjectUUID:  request.ProjectUUID,
+		Concurrently: request.Concurrently,
+	}
+}
+
+func ToUpdatePartitionPolicyInput(request UpdatePartitionPolicyRequest) database.UpdatePartitionPolicyInput {
+	return database.UpdatePartitionPolicyInput{
+		ProjectUUID: request.ProjectUUID,
+		Interval:    request.Interval,
+		Premake:     *request.Premake,
+		Retention:   request.Retention,
+	}
+}
+
+func ToSchemaGraphInput(request SchemaGraphRequest) database.SchemaGraphInput {
+	return database.SchemaGraphInput{
+		ProjectUUID: request.ProjectUUID,
+		Schemas:     request.Schemas,
+	}
+}
+
+func ToProjectDDLInput(request ProjectDDLRequest) database.ProjectDDLInput {
+	return database.ProjectDDLInput{
+		ProjectUUID: request.ProjectUUID,
+		Schemas:     request.Schemas,
+	}
+}
+
+func ToDropInput(request DropRequest) database.DropInput {
+	return database.DropInput{
+		ProjectUUID:   request.ProjectUUID,
+		DryRun:        request.DryRun,
+		Cascade:       request.Cascade,
+		Snapshot:      request.Snapshot,
+		RetentionDays: request.RetentionDays,
+	}
+}
+
+func ToTableMaintenanceInput(request TableMaintenanceRequest) maintenance.RunInput {
+	return maintenance.RunInput{
+		ProjectUUID:     request.ProjectUUID,
+		Action:          request.Action,
+		RestartIdentity: request.RestartIdentity,
+		Cascade:         request.Cascade,
+		Full:            request.Full,
+		Analyze:         request.Analyze,
 	}
 }
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+)
+
+type CreateMigrationRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"name"`
+	Up          string    `json:"up"`
+	Down        string    `json:"down"`
+}
+
+type ApplyMigrationRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Version     int       `json:"version"`
+}
+
+type RollbackMigrationRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Steps       int       `json:"steps"`
+}
+
+func (r *CreateMigrationRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Migration name is required"),
+			validation.Length(
+				constants.MinMigrationNameLength, constants.MaxMigrationNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Migration name must be between %d and %d characters",
+					constants.MinMigrationNameLength,
+					constants.MaxMigrationNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Migration name must be alphanumeric with underscores"),
+		),
+		validation.Field(&r.Up, validation.Required.Error("Up migration SQL is required")),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *ApplyMigrationRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Version, validation.Min(0).Error("Version cannot be negative")),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *RollbackMigrationRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Steps, validation.Min(0).Error("Steps cannot be negative")),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
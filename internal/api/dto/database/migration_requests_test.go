@@ -0,0 +1,155 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateMigrationRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateMigrationRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name": "add_status_to_orders",
+			"up":   "ALTER TABLE orders ADD COLUMN status text;",
+			"down": "ALTER TABLE orders DROP COLUMN status;",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+		assert.Equal(t, payload["name"], r.Name)
+		assert.Equal(t, payload["up"], r.Up)
+		assert.Equal(t, payload["down"], r.Down)
+	})
+
+	t.Run("CreateMigrationRequest: valid without down", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name": "drop_legacy_table",
+			"up":   "DROP TABLE legacy;",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "", r.Down)
+	})
+
+	t.Run("CreateMigrationRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "invalid",
+				payload:     map[string]interface{}{"name": "valid_name", "up": "SELECT 1"},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing name",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"up": "SELECT 1"},
+				expected:    "Migration name is required",
+			},
+			{
+				name:        "Name too long",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": strings.Repeat("a", 256), "up": "SELECT 1"},
+				expected:    "Migration name must be between 3 and 255 characters",
+			},
+			{
+				name:        "Invalid name characters",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "add-status", "up": "SELECT 1"},
+				expected:    "Migration name must be alphanumeric with underscores",
+			},
+			{
+				name:        "Missing up",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "valid_name"},
+				expected:    "Up migration SQL is required",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r CreateMigrationRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestApplyAndRollbackMigrationRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ApplyMigrationRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"version": 4})
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r ApplyMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, 4, r.Version)
+	})
+
+	t.Run("ApplyMigrationRequest: negative version", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"version": -1})
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r ApplyMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Version cannot be negative")
+	})
+
+	t.Run("RollbackMigrationRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"steps": 2})
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r RollbackMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, 2, r.Steps)
+	})
+
+	t.Run("RollbackMigrationRequest: negative steps", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"steps": -3})
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r RollbackMigrationRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Steps cannot be negative")
+	})
+}
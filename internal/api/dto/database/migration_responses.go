@@ -0,0 +1,17 @@
+package database
+
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
+
+type MigrationResponse struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Up        string    `json:"up"`
+	Down      string    `json:"down"`
+	Source    string    `json:"source"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	AppliedAt null.Time `json:"appliedAt" swaggertype:"string"`
+}
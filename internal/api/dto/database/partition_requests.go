@@ -0,0 +1,181 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type CreatePartitionRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Name   string                        `json:"name"`
+	Bounds database.PartitionBoundsInput `json:"bounds"`
+}
+
+type AttachPartitionRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Bounds database.PartitionBoundsInput `json:"bounds"`
+}
+
+type DetachPartitionRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Concurrently bool `json:"concurrently"`
+}
+
+type UpdatePartitionPolicyRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Interval  string `json:"interval"`
+	Premake   *int   `json:"premake"`
+	Retention int    `json:"retention"`
+}
+
+func (r *CreatePartitionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Name is required"),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Partition name must be alphanumeric with underscores"),
+			validation.Length(
+				constants.MinTableNameLength, constants.MaxTableNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Name must be between %d and %d characters",
+					constants.MinTableNameLength,
+					constants.MaxTableNameLength,
+				),
+			),
+			validation.By(validateTableName),
+		),
+		validation.Field(&r.Bounds, validation.By(validatePartitionBounds)),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *AttachPartitionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	err := validation.ValidateStruct(r,
+		validation.Field(&r.Bounds, validation.By(validatePartitionBounds)),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *DetachPartitionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+// BindAndValidate falls back to DefaultPartitionPremake when the body leaves premake out
+func (r *UpdatePartitionPolicyRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Interval = strings.ToLower(strings.TrimSpace(r.Interval))
+	if r.Premake == nil {
+		premake := constants.DefaultPartitionPremake
+		r.Premake = &premake
+	}
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Interval,
+			validation.Required.Error("Interval is required"),
+			validation.In(constants.PartitionIntervals...).Error("Interval must be one of day, week or month"),
+		),
+		validation.Field(
+			&r.Premake,
+			validation.Min(0).Error(fmt.Sprintf("Premake must be between 0 and %d", constants.MaxPartitionPremake)),
+			validation.Max(constants.MaxPartitionPremake).Error(fmt.Sprintf("Premake must be between 0 and %d", constants.MaxPartitionPremake)),
+		),
+		validation.Field(
+			&r.Retention,
+			validation.Min(0).Error(fmt.Sprintf("Retention must be between 0 and %d", constants.MaxPartitionRetention)),
+			validation.Max(constants.MaxPartitionRetention).Error(fmt.Sprintf("Retention must be between 0 and %d", constants.MaxPartitionRetention)),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+// validatePartitionSpec checks the partition key against the columns of the new table,
+// list partitioning only supports a single key column
+func validatePartitionSpec(spec *database.PartitionSpecInput, columns []database.Column) error {
+	spec.Strategy = strings.ToLower(strings.TrimSpace(spec.Strategy))
+
+	if err := validation.Validate(
+		spec.Strategy,
+		validation.Required.Error("Partition strategy is required"),
+		validation.In(constants.PartitionStrategies...).Error("Partition strategy must be one of range, list or hash"),
+	); err != nil {
+		return err
+	}
+
+	if len(spec.Columns) == 0 {
+		return fmt.Errorf("partition columns are required")
+	}
+
+	if spec.Strategy == constants.PartitionStrategyList && len(spec.Columns) > 1 {
+		return fmt.Errorf("list partitioning takes exactly one column")
+	}
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+
+	for _, column := range spec.Columns {
+		if !slices.Contains(names, column) {
+			return fmt.Errorf("partition column '%s' is not a column of the table", column)
+		}
+	}
+
+	return nil
+}
+
+// validatePartitionBounds only checks that bounds are given, whether they fit
+// the strategy of the parent table is checked once it is known
+func validatePartitionBounds(value interface{}) error {
+	bounds := value.(database.PartitionBoundsInput)
+
+	if !bounds.Default && len(bounds.From) == 0 && len(bounds.To) == 0 && len(bounds.In) == 0 && bounds.Modulus == 0 {
+		return fmt.Errorf("bounds are required")
+	}
+
+	return nil
+}
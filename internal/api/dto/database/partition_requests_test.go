@@ -0,0 +1,175 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreatePartitionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreatePartitionRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":   "events_2025",
+			"bounds": map[string]interface{}{"from": []string{"2025-01-01"}, "to": []string{"2026-01-01"}},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreatePartitionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "events_2025", r.Name)
+		assert.Equal(t, []string{"2025-01-01"}, r.Bounds.From)
+	})
+
+	t.Run("CreatePartitionRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			headers  map[string]string
+			expected string
+		}{
+			{
+				name:     "Missing project header",
+				payload:  map[string]interface{}{"name": "events_eu", "bounds": map[string]interface{}{"default": true}},
+				headers:  map[string]string{},
+				expected: "invalid project UUID",
+			},
+			{
+				name:     "Missing name",
+				payload:  map[string]interface{}{"bounds": map[string]interface{}{"default": true}},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Name is required",
+			},
+			{
+				name:     "Invalid name",
+				payload:  map[string]interface{}{"name": "events-eu", "bounds": map[string]interface{}{"default": true}},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Partition name must be alphanumeric with underscores",
+			},
+			{
+				name:     "Missing bounds",
+				payload:  map[string]interface{}{"name": "events_eu"},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "bounds are required",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				for key, value := range tt.headers {
+					ctx.Request().Header.Set(key, value)
+				}
+
+				var r CreatePartitionRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestAttachPartitionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("AttachPartitionRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{"bounds": map[string]interface{}{"in": []string{"eu", "uk"}}}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r AttachPartitionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, []string{"eu", "uk"}, r.Bounds.In)
+	})
+
+	t.Run("AttachPartitionRequest: missing bounds", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r AttachPartitionRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "bounds are required")
+	})
+}
+
+func TestUpdatePartitionPolicyRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("UpdatePartitionPolicyRequest: defaults premake", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"interval": "Month", "retention": 12})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r UpdatePartitionPolicyRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.PartitionIntervalMonth, r.Interval)
+		assert.Equal(t, constants.DefaultPartitionPremake, *r.Premake)
+		assert.Equal(t, 12, r.Retention)
+	})
+
+	t.Run("UpdatePartitionPolicyRequest: keeps zero premake", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"interval": "day", "premake": 0})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r UpdatePartitionPolicyRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, 0, *r.Premake)
+	})
+
+	t.Run("UpdatePartitionPolicyRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Missing interval",
+				payload:  map[string]interface{}{},
+				expected: "Interval is required",
+			},
+			{
+				name:     "Unknown interval",
+				payload:  map[string]interface{}{"interval": "year"},
+				expected: "Interval must be one of day, week or month",
+			},
+			{
+				name:     "Premake above maximum",
+				payload:  map[string]interface{}{"interval": "day", "premake": constants.MaxPartitionPremake + 1},
+				expected: "Premake must be between 0 and",
+			},
+			{
+				name:     "Negative retention",
+				payload:  map[string]interface{}{"interval": "week", "retention": -1},
+				expected: "Retention must be between 0 and",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, tt.payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r UpdatePartitionPolicyRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package database
+
+import "time"
+
+type PartitionResponse struct {
+	Schema        string `json:"schema"`
+	Name          string `json:"name"`
+	FullName      string `json:"fullName"`
+	Bounds        string `json:"bounds"`
+	Partitioned   bool   `json:"partitioned"`
+	EstimatedRows int64  `json:"estimatedRows"`
+	TotalSize     string `json:"totalSize"`
+}
+
+type PartitionPolicyResponse struct {
+	Interval  string     `json:"interval"`
+	Premake   int        `json:"premake"`
+	Retention int        `json:"retention"`
+	NextRunAt time.Time  `json:"nextRunAt"`
+	LastRunAt *time.Time `json:"lastRunAt"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+type PartitionedTableResponse struct {
+	Schema        string                   `json:"schema"`
+	Name          string                   `json:"name"`
+	Strategy      string                   `json:"strategy"`
+	Columns       []string                 `json:"columns"`
+	KeyDefinition string                   `json:"keyDefinition"`
+	Partitions    []PartitionResponse      `json:"partitions"`
+	Policy        *PartitionPolicyResponse `json:"policy"`
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type CreatePolicyRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Name        string   `json:"name"`
+	Command     string   `json:"command"`
+	Restrictive bool     `json:"restrictive"`
+	Roles       []string `json:"roles"`
+	Using       string   `json:"using"`
+	WithCheck   string   `json:"withCheck"`
+}
+
+type UpdatePolicyRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Command     string   `json:"command"`
+	Restrictive bool     `json:"restrictive"`
+	Roles       []string `json:"roles"`
+	Using       string   `json:"using"`
+	WithCheck   string   `json:"withCheck"`
+}
+
+type UpdateRowLevelSecurityRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Enabled bool `json:"enabled"`
+	Forced  bool `json:"forced"`
+}
+
+type TestPolicyRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Role    string                 `json:"role"`
+	Claims  map[string]interface{} `json:"claims"`
+	Query   string                 `json:"query"`
+	MaxRows int                    `json:"maxRows"`
+}
+
+func (r *CreatePolicyRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Command, r.Using, r.WithCheck = normalizePolicy(r.Command, r.Using, r.WithCheck)
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Policy name is required"),
+			validation.Length(
+				constants.MinPolicyNameLength, constants.MaxPolicyNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Policy name must be between %d and %d characters",
+					constants.MinPolicyNameLength,
+					constants.MaxPolicyNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Policy name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Command,
+			validation.In(constants.PolicyCommands...).Error("Policy command must be one of ALL, SELECT, INSERT, UPDATE or DELETE"),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return validatePolicyCombinations(r.Command, r.Roles, r.Using, r.WithCheck)
+}
+
+func (r *UpdatePolicyRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Command, r.Using, r.WithCheck = normalizePolicy(r.Command, r.Using, r.WithCheck)
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Command,
+			validation.In(constants.PolicyCommands...).Error("Policy command must be one of ALL, SELECT, INSERT, UPDATE or DELETE"),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return validatePolicyCombinations(r.Command, r.Roles, r.Using, r.WithCheck)
+}
+
+func (r *UpdateRowLevelSecurityRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	if r.Forced && !r.Enabled {
+		return []string{"Row level security must be enabled to be forced"}
+	}
+
+	return nil
+}
+
+func (r *TestPolicyRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Role = strings.TrimSpace(r.Role)
+	r.Query = strings.TrimSpace(r.Query)
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Role,
+			validation.Required.Error("Role is required"),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Role must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.MaxRows,
+			validation.Min(0).Error("Max rows cannot be negative"),
+			validation.Max(constants.MaxQueryRows).Error(
+				fmt.Sprintf("Max rows cannot be more than %d", constants.MaxQueryRows),
+			),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+// normalizePolicy defaults the command to ALL, like postgres does
+func normalizePolicy(command, using, withCheck string) (string, string, string) {
+	command = strings.ToUpper(strings.TrimSpace(command))
+	if command == "" {
+		command = constants.PolicyCommandAll
+	}
+
+	return command, strings.TrimSpace(using), strings.TrimSpace(withCheck)
+}
+
+// validatePolicyCombinations rejects expressions postgres does not allow for the command
+func validatePolicyCombinations(command string, roles []string, using, withCheck string) []string {
+	var errors []string
+
+	if using == "" && withCheck == "" {
+		errors = append(errors, "At least one of using or withCheck is required")
+	}
+
+	if command == constants.PolicyCommandInsert && using != "" {
+		errors = append(errors, "INSERT policies only take a withCheck expression")
+	}
+
+	if (command == constants.PolicyCommandSelect || command == constants.PolicyCommandDelete) && withCheck != "" {
+		errors = append(errors, fmt.Sprintf("%s policies only take a using expression", command))
+	}
+
+	rolePattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+	seenRoles := make(map[string]bool)
+	for _, role := range roles {
+		if !rolePattern.MatchString(role) {
+			errors = append(errors, fmt.Sprintf("Role '%s' must be alphanumeric with underscores", role))
+
+			continue
+		}
+
+		if seenRoles[role] {
+			errors = append(errors, fmt.Sprintf("Duplicate role '%s' in policy definition", role))
+		}
+
+		seenRoles[role] = true
+	}
+
+	return errors
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreatePolicyRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreatePolicyRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":  "orders_read",
+			"roles": []string{"authenticated"},
+			"using": " owner_id = auth_uid() ",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreatePolicyRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.PolicyCommandAll, r.Command)
+		assert.Equal(t, "owner_id = auth_uid()", r.Using)
+	})
+
+	t.Run("CreatePolicyRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Missing name",
+				payload:  map[string]interface{}{"using": "true"},
+				expected: "Policy name is required",
+			},
+			{
+				name:     "Unknown command",
+				payload:  map[string]interface{}{"name": "orders_read", "command": "merge", "using": "true"},
+				expected: "Policy command must be one of ALL, SELECT, INSERT, UPDATE or DELETE",
+			},
+			{
+				name:     "Missing expressions",
+				payload:  map[string]interface{}{"name": "orders_read"},
+				expected: "At least one of using or withCheck is required",
+			},
+			{
+				name:     "Insert with using",
+				payload:  map[string]interface{}{"name": "orders_insert", "command": "insert", "using": "true"},
+				expected: "INSERT policies only take a withCheck expression",
+			},
+			{
+				name:     "Select with check",
+				payload:  map[string]interface{}{"name": "orders_read", "command": "SELECT", "withCheck": "true"},
+				expected: "SELECT policies only take a using expression",
+			},
+			{
+				name:     "Invalid role",
+				payload:  map[string]interface{}{"name": "orders_read", "using": "true", "roles": []string{"bad role"}},
+				expected: "Role 'bad role' must be alphanumeric with underscores",
+			},
+			{
+				name:     "Duplicate role",
+				payload:  map[string]interface{}{"name": "orders_read", "using": "true", "roles": []string{"anon", "anon"}},
+				expected: "Duplicate role 'anon' in policy definition",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r CreatePolicyRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestUpdateRowLevelSecurityRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("UpdateRowLevelSecurityRequest: forced without enabled", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"forced": true})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r UpdateRowLevelSecurityRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Row level security must be enabled to be forced")
+	})
+}
+
+func TestTestPolicyRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("TestPolicyRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"role":   "authenticated",
+			"claims": map[string]interface{}{"sub": "42"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TestPolicyRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "42", r.Claims["sub"])
+	})
+
+	t.Run("TestPolicyRequest: missing role", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"maxRows": 10})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r TestPolicyRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Role is required")
+	})
+}
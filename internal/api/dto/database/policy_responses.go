@@ -0,0 +1,27 @@
+package database
+
+import (
+	"fluxend/internal/domain/database"
+)
+
+type PolicyResponse struct {
+	Name        string   `json:"name"`
+	Command     string   `json:"command"`
+	Restrictive bool     `json:"restrictive"`
+	Roles       []string `json:"roles"`
+	Using       string   `json:"using,omitempty"`
+	WithCheck   string   `json:"withCheck,omitempty"`
+}
+
+type RowLevelSecurityResponse struct {
+	Enabled bool `json:"enabled"`
+	Forced  bool `json:"forced"`
+}
+
+type PolicyTestResponse struct {
+	Role      string                  `json:"role"`
+	Columns   []database.ResultColumn `json:"columns"`
+	Rows      [][]interface{}         `json:"rows"`
+	RowCount  int                     `json:"rowCount"`
+	Truncated bool                    `json:"truncated"`
+}
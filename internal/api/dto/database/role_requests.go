@@ -0,0 +1,254 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var grantObjectPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)?$`)
+
+type CreateRoleRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"name"`
+	Inherit     bool      `json:"inherit"`
+	MemberOf    []string  `json:"memberOf"`
+}
+
+type GrantRequest struct {
+	dto.BaseRequest
+	ProjectUUID     uuid.UUID `json:"-"`
+	ObjectType      string    `json:"objectType"`
+	Object          string    `json:"object"`
+	Columns         []string  `json:"columns"`
+	Privileges      []string  `json:"privileges"`
+	WithGrantOption bool      `json:"withGrantOption"`
+}
+
+type IssueRoleTokenRequest struct {
+	dto.BaseRequest
+	ProjectUUID      uuid.UUID              `json:"-"`
+	ExpiresInSeconds int                    `json:"expiresInSeconds"`
+	Claims           map[string]interface{} `json:"claims"`
+}
+
+func (r *CreateRoleRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = strings.TrimSpace(r.Name)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Role name is required"),
+			validation.Length(
+				constants.MinRoleNameLength, constants.MaxRoleNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Role name must be between %d and %d characters",
+					constants.MinRoleNameLength,
+					constants.MaxRoleNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Role name must be alphanumeric with underscores"),
+			validation.By(validateRoleNameNotReserved),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	rolePattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+	seenRoles := make(map[string]bool)
+	for _, role := range r.MemberOf {
+		if !rolePattern.MatchString(role) {
+			errors = append(errors, fmt.Sprintf("Role '%s' must be alphanumeric with underscores", role))
+
+			continue
+		}
+
+		if role == r.Name {
+			errors = append(errors, "A role cannot be a member of itself")
+		}
+
+		if seenRoles[role] {
+			errors = append(errors, fmt.Sprintf("Duplicate role '%s' in memberOf", role))
+		}
+
+		seenRoles[role] = true
+	}
+
+	return errors
+}
+
+func (r *GrantRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.ObjectType = strings.ToLower(strings.TrimSpace(r.ObjectType))
+	r.Object = strings.TrimSpace(r.Object)
+	for i, privilege := range r.Privileges {
+		r.Privileges[i] = strings.ToUpper(strings.TrimSpace(privilege))
+	}
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.ObjectType,
+			validation.Required.Error("Object type is required"),
+			validation.In(constants.GrantObjectTypes...).Error("Object type must be one of table, column, schema, function or sequence"),
+		),
+		validation.Field(
+			&r.Object,
+			validation.Required.Error("Object is required"),
+			validation.Match(grantObjectPattern).Error("Object must be a name, optionally schema qualified"),
+		),
+		validation.Field(
+			&r.Privileges,
+			validation.Required.Error("At least one privilege is required"),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return r.validateCombinations()
+}
+
+// validateCombinations checks privileges and columns against what postgres accepts for the object type
+func (r *GrantRequest) validateCombinations() []string {
+	var errors []string
+
+	if r.ObjectType == constants.GrantObjectSchema && strings.Contains(r.Object, ".") {
+		errors = append(errors, "Schema object must not be schema qualified")
+	}
+
+	if r.ObjectType == constants.GrantObjectColumn && len(r.Columns) == 0 {
+		errors = append(errors, "At least one column is required for column grants")
+	}
+
+	if r.ObjectType != constants.GrantObjectColumn && len(r.Columns) > 0 {
+		errors = append(errors, "Columns are only allowed for column grants")
+	}
+
+	columnPattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+	for _, column := range r.Columns {
+		if !columnPattern.MatchString(column) {
+			errors = append(errors, fmt.Sprintf("Column '%s' must be alphanumeric with underscores", column))
+		}
+	}
+
+	allowed := constants.GrantPrivileges[r.ObjectType]
+	seenPrivileges := make(map[string]bool)
+	for _, privilege := range r.Privileges {
+		if !slices.Contains(allowed, privilege) {
+			errors = append(errors, fmt.Sprintf(
+				"Privilege '%s' must be one of %s for %s grants", privilege, strings.Join(allowed, ", "), r.ObjectType,
+			))
+
+			continue
+		}
+
+		if seenPrivileges[privilege] {
+			errors = append(errors, fmt.Sprintf("Duplicate privilege '%s' in grant", privilege))
+		}
+
+		seenPrivileges[privilege] = true
+	}
+
+	if seenPrivileges[constants.PrivilegeAll] && len(r.Privileges) > 1 {
+		errors = append(errors, "ALL cannot be combined with other privileges")
+	}
+
+	return errors
+}
+
+func (r *IssueRoleTokenRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.ExpiresInSeconds,
+			validation.Min(0).Error("Expiry cannot be negative"),
+			validation.Max(constants.MaxRoleTokenTTLInSeconds).Error(
+				fmt.Sprintf("Expiry cannot be more than %d seconds", constants.MaxRoleTokenTTLInSeconds),
+			),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+
+	claims := make([]string, 0, len(r.Claims))
+	for claim := range r.Claims {
+		claims = append(claims, claim)
+	}
+
+	slices.Sort(claims)
+	for _, claim := range claims {
+		switch {
+		case slices.Contains(constants.RoleTokenServerClaims, claim):
+			errors = append(errors, fmt.Sprintf("Claim '%s' is set by the server", claim))
+		case !slices.Contains(constants.RoleTokenAllowedClaims, claim):
+			errors = append(errors, fmt.Sprintf(
+				"Claim '%s' is not allowed, custom claims must be one of %s",
+				claim,
+				strings.Join(constants.RoleTokenAllowedClaims, ", "),
+			))
+		}
+	}
+
+	return errors
+}
+
+// validateRoleNameNotReserved keeps postgres, fluxend and per user roles out of project hands
+func validateRoleNameNotReserved(value interface{}) error {
+	name := strings.ToLower(value.(string))
+
+	if slices.Contains(constants.ReservedRoleNames, name) {
+		return validation.NewError("role_reserved", fmt.Sprintf("Role name '%s' is reserved", name))
+	}
+
+	for _, prefix := range constants.ReservedRolePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return validation.NewError("role_reserved", fmt.Sprintf("Role name cannot start with '%s'", prefix))
+		}
+	}
+
+	return nil
+}
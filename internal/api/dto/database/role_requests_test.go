@@ -0,0 +1,215 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateRoleRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateRoleRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":     " authenticated ",
+			"inherit":  true,
+			"memberOf": []string{"web_anon"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateRoleRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "authenticated", r.Name)
+	})
+
+	t.Run("CreateRoleRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Missing name",
+				payload:  map[string]interface{}{},
+				expected: "Role name is required",
+			},
+			{
+				name:     "Reserved name",
+				payload:  map[string]interface{}{"name": "authenticator"},
+				expected: "Role name 'authenticator' is reserved",
+			},
+			{
+				name:     "Reserved prefix",
+				payload:  map[string]interface{}{"name": "pg_reader"},
+				expected: "Role name cannot start with 'pg_'",
+			},
+			{
+				name:     "Member of itself",
+				payload:  map[string]interface{}{"name": "service", "memberOf": []string{"service"}},
+				expected: "A role cannot be a member of itself",
+			},
+			{
+				name:     "Duplicate membership",
+				payload:  map[string]interface{}{"name": "service", "memberOf": []string{"anon", "anon"}},
+				expected: "Duplicate role 'anon' in memberOf",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r CreateRoleRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestGrantRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("GrantRequest: valid column grant", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"objectType": "Column",
+			"object":     "public.profiles",
+			"columns":    []string{"name"},
+			"privileges": []string{"select", "update"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r GrantRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.GrantObjectColumn, r.ObjectType)
+		assert.Equal(t, []string{"SELECT", "UPDATE"}, r.Privileges)
+	})
+
+	t.Run("GrantRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Unknown object type",
+				payload:  map[string]interface{}{"objectType": "view", "object": "orders", "privileges": []string{"SELECT"}},
+				expected: "Object type must be one of table, column, schema, function or sequence",
+			},
+			{
+				name:     "Missing privileges",
+				payload:  map[string]interface{}{"objectType": "table", "object": "orders"},
+				expected: "At least one privilege is required",
+			},
+			{
+				name:     "Privilege not valid for object type",
+				payload:  map[string]interface{}{"objectType": "schema", "object": "api", "privileges": []string{"SELECT"}},
+				expected: "Privilege 'SELECT' must be one of USAGE, CREATE, ALL for schema grants",
+			},
+			{
+				name:     "Column grant without columns",
+				payload:  map[string]interface{}{"objectType": "column", "object": "orders", "privileges": []string{"SELECT"}},
+				expected: "At least one column is required for column grants",
+			},
+			{
+				name: "Columns on table grant",
+				payload: map[string]interface{}{
+					"objectType": "table", "object": "orders", "columns": []string{"total"}, "privileges": []string{"SELECT"},
+				},
+				expected: "Columns are only allowed for column grants",
+			},
+			{
+				name:     "ALL combined",
+				payload:  map[string]interface{}{"objectType": "table", "object": "orders", "privileges": []string{"ALL", "SELECT"}},
+				expected: "ALL cannot be combined with other privileges",
+			},
+			{
+				name:     "Qualified schema",
+				payload:  map[string]interface{}{"objectType": "schema", "object": "a.b", "privileges": []string{"USAGE"}},
+				expected: "Schema object must not be schema qualified",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r GrantRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestIssueRoleTokenRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("IssueRoleTokenRequest: server claims", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"claims": map[string]interface{}{"role": "postgres", "sub": "42"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r IssueRoleTokenRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Claim 'role' is set by the server")
+	})
+	t.Run("IssueRoleTokenRequest: allowed claims", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"claims": map[string]interface{}{"sub": "42", "app_metadata": map[string]interface{}{"tenant": "acme"}},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r IssueRoleTokenRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+	})
+
+	t.Run("IssueRoleTokenRequest: platform claims", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"claims": map[string]interface{}{"uuid": dummyProjectUUID, "version": 1, "role_id": 1, "aud": "fluxend", "iss": "fluxend"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r IssueRoleTokenRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 5)
+		pkg.AssertErrorContains(t, errs, "Claim 'aud' is set by the server")
+		pkg.AssertErrorContains(t, errs, "Claim 'iss' is not allowed, custom claims must be one of sub, email, phone, app_metadata, user_metadata")
+		pkg.AssertErrorContains(t, errs, "Claim 'role_id' is not allowed, custom claims must be one of sub, email, phone, app_metadata, user_metadata")
+		pkg.AssertErrorContains(t, errs, "Claim 'uuid' is not allowed, custom claims must be one of sub, email, phone, app_metadata, user_metadata")
+		pkg.AssertErrorContains(t, errs, "Claim 'version' is not allowed, custom claims must be one of sub, email, phone, app_metadata, user_metadata")
+	})
+}
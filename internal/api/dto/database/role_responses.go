@@ -0,0 +1,24 @@
+package database
+
+import (
+	"time"
+)
+
+type RoleResponse struct {
+	Name     string   `json:"name"`
+	Inherit  bool     `json:"inherit"`
+	MemberOf []string `json:"memberOf"`
+}
+
+type RoleGrantResponse struct {
+	ObjectType string   `json:"objectType"`
+	Object     string   `json:"object"`
+	Column     string   `json:"column,omitempty"`
+	Privileges []string `json:"privileges"`
+}
+
+type RoleTokenResponse struct {
+	Role      string    `json:"role"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SchemaDiffRequest struct {
+	dto.BaseRequest
+	ProjectUUID       uuid.UUID `json:"-"`
+	TargetProjectUUID uuid.UUID `json:"-"`
+}
+
+func (r *SchemaDiffRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	targetProjectUUID, err := r.GetUUIDPathParam(c, "targetProjectUUID", true)
+	if err != nil {
+		return []string{"Invalid target project UUID"}
+	}
+
+	if projectUUID == targetProjectUUID {
+		return []string{"Source and target project must be different"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.TargetProjectUUID = targetProjectUUID
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+const dummyTargetProjectUUID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestSchemaDiffRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("SchemaDiffRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID", "targetProjectUUID")
+		ctx.SetParamValues(dummyProjectUUID, dummyTargetProjectUUID)
+
+		var r SchemaDiffRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+		assert.Equal(t, dummyTargetProjectUUID, r.TargetProjectUUID.String())
+	})
+
+	t.Run("SchemaDiffRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name              string
+			projectUUID       string
+			targetProjectUUID string
+			expected          string
+		}{
+			{
+				name:              "Invalid project UUID",
+				projectUUID:       "invalid",
+				targetProjectUUID: dummyTargetProjectUUID,
+				expected:          "Invalid project UUID",
+			},
+			{
+				name:              "Invalid target project UUID",
+				projectUUID:       dummyProjectUUID,
+				targetProjectUUID: "invalid",
+				expected:          "Invalid target project UUID",
+			},
+			{
+				name:              "Same project",
+				projectUUID:       dummyProjectUUID,
+				targetProjectUUID: dummyProjectUUID,
+				expected:          "Source and target project must be different",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+				ctx.SetParamNames("projectUUID", "targetProjectUUID")
+				ctx.SetParamValues(tt.projectUUID, tt.targetProjectUUID)
+
+				var r SchemaDiffRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package database
+
+type SchemaChangeResponse struct {
+	Object string `json:"object"`
+	Action string `json:"action"`
+	Name   string `json:"name"`
+}
+
+type SchemaDiffResponse struct {
+	Changes    []SchemaChangeResponse `json:"changes"`
+	Statements []string               `json:"statements"`
+	Script     string                 `json:"script"`
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"slices"
+	"strings"
+)
+
+type SchemaGraphRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Format      string    `json:"-"`
+	Schemas     []string  `json:"-"`
+}
+
+// BindAndValidate reads the options from the query string, e.g. ?format=mermaid&schema=public,billing
+func (r *SchemaGraphRequest) BindAndValidate(c echo.Context) []string {
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	var errors []string
+
+	r.Format = strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if r.Format == "" {
+		r.Format = constants.SchemaGraphFormatJSON
+	}
+
+	if !slices.Contains(constants.SchemaGraphFormats, r.Format) {
+		errors = append(errors, fmt.Sprintf(
+			"Format '%s' is not supported, allowed formats are: %s",
+			r.Format,
+			strings.Join(constants.SchemaGraphFormats, ", "),
+		))
+	}
+
+	schemas, schemaErrors := bindSchemaFilter(c)
+	r.Schemas = schemas
+	errors = append(errors, schemaErrors...)
+
+	return errors
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestSchemaGraphRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("SchemaGraphRequest: defaults to json", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r SchemaGraphRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.SchemaGraphFormatJSON, r.Format)
+		assert.Empty(t, r.Schemas)
+	})
+
+	t.Run("SchemaGraphRequest: valid with options", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+		ctx.Request().URL.RawQuery = "format=Mermaid&schema=public,billing,public"
+
+		var r SchemaGraphRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.SchemaGraphFormatMermaid, r.Format)
+		assert.Equal(t, []string{"public", "billing"}, r.Schemas)
+	})
+
+	t.Run("SchemaGraphRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    string
+			expected string
+		}{
+			{name: "Unknown format", query: "format=png", expected: "Format 'png' is not supported"},
+			{name: "Invalid schema", query: "schema=public,bad-name", expected: "Schema name must be alphanumeric with underscores"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+				ctx.Request().URL.RawQuery = tt.query
+
+				var r SchemaGraphRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+
+	t.Run("SchemaGraphRequest: invalid project UUID", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues("invalid")
+
+		var r SchemaGraphRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Invalid project UUID")
+	})
+}
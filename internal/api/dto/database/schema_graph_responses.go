@@ -0,0 +1,64 @@
+package database
+
+type SchemaGraphColumnResponse struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	NotNull     bool   `json:"notNull"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+	Unique      bool   `json:"unique"`
+	Foreign     bool   `json:"foreign"`
+}
+
+type SchemaGraphIndexResponse struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Method  string   `json:"method"`
+	Unique  bool     `json:"unique"`
+	Primary bool     `json:"primary"`
+}
+
+type SchemaGraphTableResponse struct {
+	Schema      string                      `json:"schema"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Columns     []SchemaGraphColumnResponse `json:"columns"`
+	PrimaryKey  []string                    `json:"primaryKey"`
+	Indexes     []SchemaGraphIndexResponse  `json:"indexes"`
+}
+
+type SchemaGraphRelationResponse struct {
+	Name        string   `json:"name"`
+	FromTable   string   `json:"fromTable"`
+	FromColumns []string `json:"fromColumns"`
+	ToTable     string   `json:"toTable"`
+	ToColumns   []string `json:"toColumns"`
+	OnDelete    string   `json:"onDelete"`
+	OnUpdate    string   `json:"onUpdate"`
+	Optional    bool     `json:"optional"`
+	OneToOne    bool     `json:"oneToOne"`
+}
+
+type SchemaGraphViewResponse struct {
+	Schema       string                      `json:"schema"`
+	Name         string                      `json:"name"`
+	Materialized bool                        `json:"materialized"`
+	Columns      []SchemaGraphColumnResponse `json:"columns"`
+}
+
+type SchemaGraphFunctionResponse struct {
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+	Arguments  string `json:"arguments"`
+	ReturnType string `json:"returnType"`
+	Language   string `json:"language"`
+}
+
+type SchemaGraphResponse struct {
+	Schemas   []string                      `json:"schemas"`
+	Tables    []SchemaGraphTableResponse    `json:"tables"`
+	Relations []SchemaGraphRelationResponse `json:"relations"`
+	Views     []SchemaGraphViewResponse     `json:"views"`
+	Functions []SchemaGraphFunctionResponse `json:"functions"`
+}
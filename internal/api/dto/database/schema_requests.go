@@ -0,0 +1,221 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type CreateSchemaRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"name"`
+}
+
+type RenameSchemaRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	CurrentName string    `json:"-"`
+	Name        string    `json:"name"`
+}
+
+type DropSchemaRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Name        string    `json:"-"`
+	Cascade     bool      `query:"cascade"`
+}
+
+type ExposeSchemasRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Schemas     []string  `json:"schemas"`
+}
+
+func (r *CreateSchemaRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = strings.TrimSpace(r.Name)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Name, append(schemaNameRules(), validation.By(validateSchemaNameNotReserved))...),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *RenameSchemaRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.CurrentName = c.Param("schemaName")
+	r.Name = strings.TrimSpace(r.Name)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.CurrentName, validation.By(validateSchemaNameNotReserved)),
+		validation.Field(&r.Name, append(schemaNameRules(), validation.By(validateSchemaNameNotReserved))...),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *DropSchemaRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Name = c.Param("schemaName")
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.By(validateSchemaNameNotReserved)),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *ExposeSchemasRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Schemas, validation.Required.Error("At least one schema has to be exposed")),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	schemaPattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+
+	seen := make(map[string]bool)
+	for i, schema := range r.Schemas {
+		schema = strings.TrimSpace(schema)
+		r.Schemas[i] = schema
+
+		if !schemaPattern.MatchString(schema) {
+			errors = append(errors, fmt.Sprintf("Schema name '%s' must be alphanumeric with underscores", schema))
+
+			continue
+		}
+
+		if err := validateSchemaNameNotInternal(schema); err != nil {
+			errors = append(errors, err.Error())
+		}
+
+		if seen[schema] {
+			errors = append(errors, fmt.Sprintf("Schema '%s' is listed more than once", schema))
+		}
+
+		seen[schema] = true
+	}
+
+	return errors
+}
+
+// bindSchemaFilter reads a comma separated list of schemas from the schema query parameter
+func bindSchemaFilter(c echo.Context) ([]string, []string) {
+	var schemas, errors []string
+
+	filter := strings.TrimSpace(c.QueryParam("schema"))
+	if filter == "" {
+		return schemas, errors
+	}
+
+	for _, schema := range strings.Split(filter, ",") {
+		schema = strings.TrimSpace(schema)
+		if err := validation.Validate(schema, schemaNameRules()...); err != nil {
+			errors = append(errors, err.Error())
+
+			continue
+		}
+
+		if !slices.Contains(schemas, schema) {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas, errors
+}
+
+func schemaNameRules() []validation.Rule {
+	return []validation.Rule{
+		validation.Required.Error("Schema name is required"),
+		validation.Length(
+			constants.MinSchemaNameLength, constants.MaxSchemaNameLength,
+		).Error(
+			fmt.Sprintf(
+				"Schema name must be between %d and %d characters",
+				constants.MinSchemaNameLength,
+				constants.MaxSchemaNameLength,
+			),
+		),
+		validation.Match(
+			regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+		).Error("Schema name must be alphanumeric with underscores"),
+	}
+}
+
+// validateSchemaNameNotReserved guards schemas that cannot be created, renamed or dropped, public included
+func validateSchemaNameNotReserved(value interface{}) error {
+	name := strings.ToLower(value.(string))
+
+	if slices.Contains(constants.ReservedSchemaNames, name) {
+		return validation.NewError("schema_reserved", fmt.Sprintf("Schema name '%s' is reserved", name))
+	}
+
+	return validateSchemaNameNotInternal(name)
+}
+
+// validateSchemaNameNotInternal guards schemas tables cannot be created in and PostgREST cannot expose
+func validateSchemaNameNotInternal(value interface{}) error {
+	name := strings.ToLower(value.(string))
+
+	if name != pkg.DefaultSchema && slices.Contains(constants.ReservedSchemaNames, name) {
+		return validation.NewError("schema_reserved", fmt.Sprintf("Schema '%s' is reserved", name))
+	}
+
+	for _, prefix := range constants.ReservedSchemaPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return validation.NewError("schema_reserved", fmt.Sprintf("Schema name cannot start with '%s'", prefix))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateSchemaRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateSchemaRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"name": " api "})
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateSchemaRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "api", r.Name)
+	})
+
+	t.Run("CreateSchemaRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			schemaName string
+			expected   string
+		}{
+			{name: "Missing name", schemaName: "", expected: "Schema name is required"},
+			{name: "Invalid characters", schemaName: "my-schema", expected: "Schema name must be alphanumeric with underscores"},
+			{name: "Reserved name", schemaName: "public", expected: "Schema name 'public' is reserved"},
+			{name: "Fluxend schema", schemaName: "Fluxend", expected: "Schema name 'fluxend' is reserved"},
+			{name: "Postgres prefix", schemaName: "pg_temp_api", expected: "Schema name cannot start with 'pg_'"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"name": tt.schemaName})
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r CreateSchemaRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestRenameSchemaRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("RenameSchemaRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"name": "private"})
+		ctx.SetParamNames("projectUUID", "schemaName")
+		ctx.SetParamValues(dummyProjectUUID, "internal")
+
+		var r RenameSchemaRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "internal", r.CurrentName)
+		assert.Equal(t, "private", r.Name)
+	})
+
+	t.Run("RenameSchemaRequest: public cannot be renamed", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"name": "api"})
+		ctx.SetParamNames("projectUUID", "schemaName")
+		ctx.SetParamValues(dummyProjectUUID, "public")
+
+		var r RenameSchemaRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Schema name 'public' is reserved")
+	})
+}
+
+func TestDropSchemaRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("DropSchemaRequest: cascade from query", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+		ctx.QueryParams().Set("cascade", "true")
+		ctx.SetParamNames("projectUUID", "schemaName")
+		ctx.SetParamValues(dummyProjectUUID, "api")
+
+		var r DropSchemaRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "api", r.Name)
+		assert.True(t, r.Cascade)
+	})
+
+	t.Run("DropSchemaRequest: information_schema cannot be dropped", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodDelete, nil)
+		ctx.SetParamNames("projectUUID", "schemaName")
+		ctx.SetParamValues(dummyProjectUUID, "information_schema")
+
+		var r DropSchemaRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Schema name 'information_schema' is reserved")
+	})
+}
+
+func TestExposeSchemasRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ExposeSchemasRequest: valid with public", func(t *testing.T) {
+		payload := map[string]interface{}{"schemas": []string{"api", " public "}}
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r ExposeSchemasRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, []string{"api", "public"}, r.Schemas)
+	})
+
+	t.Run("ExposeSchemasRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			schemas  []string
+			expected string
+		}{
+			{name: "Empty list", schemas: []string{}, expected: "At least one schema has to be exposed"},
+			{name: "Internal schema", schemas: []string{"api", "fluxend"}, expected: "Schema 'fluxend' is reserved"},
+			{name: "Postgres schema", schemas: []string{"pg_catalog"}, expected: "Schema name cannot start with 'pg_'"},
+			{name: "Duplicate schema", schemas: []string{"api", "api"}, expected: "Schema 'api' is listed more than once"},
+			{name: "Invalid name", schemas: []string{"api;drop"}, expected: "Schema name 'api;drop' must be alphanumeric with underscores"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"schemas": tt.schemas})
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r ExposeSchemasRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package database
+
+type SchemaResponse struct {
+	Name       string `json:"name"`
+	Owner      string `json:"owner"`
+	TableCount int    `json:"tableCount"`
+	Comment    string `json:"comment"`
+	Exposed    bool   `json:"exposed"`
+}
+
+type ExposedSchemasResponse struct {
+	Schemas []string `json:"schemas"`
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type SeedTableRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Count int `json:"count"`
+}
+
+// BindAndValidate falls back to DefaultSeedRows when the body leaves count out
+func (r *SeedTableRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	if r.Count == 0 {
+		r.Count = constants.DefaultSeedRows
+	}
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Count,
+			validation.Min(1).Error(fmt.Sprintf("Count must be between 1 and %d", constants.MaxSeedRows)),
+			validation.Max(constants.MaxSeedRows).Error(fmt.Sprintf("Count must be between 1 and %d", constants.MaxSeedRows)),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
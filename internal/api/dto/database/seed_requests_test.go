@@ -0,0 +1,79 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestSeedTableRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("SeedTableRequest: defaults count", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r SeedTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.DefaultSeedRows, r.Count)
+	})
+
+	t.Run("SeedTableRequest: valid", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, map[string]interface{}{"count": 250})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r SeedTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, 250, r.Count)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+	})
+
+	t.Run("SeedTableRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			headers  map[string]string
+			expected string
+		}{
+			{
+				name:     "Missing project header",
+				payload:  map[string]interface{}{"count": 10},
+				headers:  map[string]string{},
+				expected: "invalid project UUID",
+			},
+			{
+				name:     "Negative count",
+				payload:  map[string]interface{}{"count": -5},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Count must be between 1 and",
+			},
+			{
+				name:     "Count above maximum",
+				payload:  map[string]interface{}{"count": constants.MaxSeedRows + 1},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Count must be between 1 and",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				for key, value := range tt.headers {
+					ctx.Request().Header.Set(key, value)
+				}
+
+				var r SeedTableRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
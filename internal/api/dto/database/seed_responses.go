@@ -0,0 +1,7 @@
+package database
+
+type SeedResponse struct {
+	Requested int   `json:"requested"`
+	Inserted  int64 `json:"inserted"`
+	Skipped   int64 `json:"skipped"`
+}
@@ -4,6 +4,7 @@ import (
 	"fluxend/internal/api/dto"
 	"fluxend/internal/config/constants"
 	columnDomain "fluxend/internal/domain/database"
+	"fluxend/pkg"
 	"strings"
 
 	"fmt"
@@ -15,8 +16,13 @@ import (
 
 type CreateTableRequest struct {
 	dto.DefaultRequestWithProjectHeader
-	Name    string                `json:"name"`
-	Columns []columnDomain.Column `json:"columns"`
+	Schema      string                `json:"schema"`
+	Name        string                `json:"name"`
+	Columns     []columnDomain.Column `json:"columns"`
+	Description string                `json:"description"`
+
+	// Partition is only set for partitioned tables
+	Partition *columnDomain.PartitionSpecInput `json:"partition"`
 }
 
 type RenameTableRequest struct {
@@ -24,10 +30,16 @@ type RenameTableRequest struct {
 	Name string `json:"name"`
 }
 
+type UpdateTableDescriptionRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Description string `json:"description"`
+}
+
 type UploadTableRequest struct {
 	dto.DefaultRequestWithProjectHeader
-	Name string                `form:"name"`
-	File *multipart.FileHeader `form:"file"`
+	Schema string                `form:"schema"`
+	Name   string                `form:"name"`
+	File   *multipart.FileHeader `form:"file"`
 }
 
 func (r *UploadTableRequest) BindAndValidate(c echo.Context) []string {
@@ -36,9 +48,14 @@ func (r *UploadTableRequest) BindAndValidate(c echo.Context) []string {
 		return []string{"File is required"}
 	}
 
+	r.Schema = strings.TrimSpace(c.FormValue("schema"))
 	r.Name = c.FormValue("name")
 	r.File = file
 
+	if r.Schema == "" {
+		r.Schema = pkg.DefaultSchema
+	}
+
 	if err := r.WithProjectHeader(c); err != nil {
 		return []string{err.Error()}
 	}
@@ -54,6 +71,7 @@ func (r *UploadTableRequest) BindAndValidate(c echo.Context) []string {
 
 func (r *UploadTableRequest) validate() error {
 	return validation.ValidateStruct(r,
+		validation.Field(&r.Schema, tableSchemaRules()...),
 		validation.Field(
 			&r.Name,
 			validation.Required.Error("Name is required"),
@@ -118,6 +136,13 @@ func (r *CreateTableRequest) BindAndValidate(c echo.Context) []string {
 		return []string{err.Error()}
 	}
 
+	r.Schema = strings.TrimSpace(r.Schema)
+	if r.Schema == "" {
+		r.Schema = pkg.DefaultSchema
+	}
+
+	r.Description = strings.TrimSpace(r.Description)
+
 	var errors []string
 
 	if err := r.validate(); err != nil {
@@ -134,11 +159,18 @@ func (r *CreateTableRequest) BindAndValidate(c echo.Context) []string {
 		}
 	}
 
+	if r.Partition != nil {
+		if err := validatePartitionSpec(r.Partition, r.Columns); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
 	return errors
 }
 
 func (r *CreateTableRequest) validate() error {
 	return validation.ValidateStruct(r,
+		validation.Field(&r.Schema, tableSchemaRules()...),
 		validation.Field(
 			&r.Name,
 			validation.Required.Error("Name is required"),
@@ -160,9 +192,47 @@ func (r *CreateTableRequest) validate() error {
 			&r.Columns,
 			validation.Required.Error("Columns are required"),
 		),
+		validation.Field(&r.Description, tableDescriptionRules()...),
 	)
 }
 
+func (r *UpdateTableDescriptionRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Description = strings.TrimSpace(r.Description)
+
+	err := validation.ValidateStruct(r,
+		validation.Field(&r.Description, tableDescriptionRules()...),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+// tableDescriptionRules allows empty descriptions, they remove the table comment
+func tableDescriptionRules() []validation.Rule {
+	return []validation.Rule{
+		validation.Length(0, constants.MaxTableDescriptionLength).Error(
+			fmt.Sprintf("Description must be at most %d characters", constants.MaxTableDescriptionLength),
+		),
+	}
+}
+
+// tableSchemaRules allows any schema but the internal ones, public is the default
+func tableSchemaRules() []validation.Rule {
+	return []validation.Rule{
+		validation.Match(
+			regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+		).Error("Schema name must be alphanumeric with underscores"),
+		validation.By(validateSchemaNameNotInternal),
+	}
+}
+
 func validateTableName(value interface{}) error {
 	name := value.(string)
 
@@ -11,6 +11,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -131,11 +132,29 @@ func TestCreateTableRequest_BindAndValidate_Suite(t *testing.T) {
 
 		assert.Len(t, errs, 0)
 		assert.Equal(t, payload["name"], r.Name)
+		assert.Equal(t, "public", r.Schema)
 		assert.Len(t, r.Columns, 2)
 		assert.Equal(t, "valid_column", r.Columns[0].Name)
 		assert.Equal(t, constants.ColumnTypeVarchar, r.Columns[0].Type)
 	})
 
+	t.Run("CreateTableRequest: valid in another schema", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"schema":  "api",
+			"name":    "valid_table",
+			"columns": createValidColumns(),
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "api", r.Schema)
+	})
+
 	t.Run("CreateTableRequest: valid with foreign key", func(t *testing.T) {
 		columns := []database.Column{
 			{
@@ -165,6 +184,122 @@ func TestCreateTableRequest_BindAndValidate_Suite(t *testing.T) {
 		assert.True(t, r.Columns[0].Foreign)
 	})
 
+	t.Run("CreateTableRequest: valid with description", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":        "valid_table",
+			"columns":     createValidColumns(),
+			"description": "  Orders placed through the shop  ",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "Orders placed through the shop", r.Description)
+	})
+
+	t.Run("CreateTableRequest: description too long", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":        "valid_table",
+			"columns":     createValidColumns(),
+			"description": strings.Repeat("d", constants.MaxTableDescriptionLength+1),
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Description must be at most")
+	})
+
+	t.Run("CreateTableRequest: column description too long", func(t *testing.T) {
+		columns := createValidColumns()
+		columns[0].Description = strings.Repeat("d", constants.MaxColumnDescriptionLength+1)
+
+		payload := map[string]interface{}{
+			"name":    "valid_table",
+			"columns": columns,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		pkg.AssertErrorContains(t, errs, "Column description must be at most")
+	})
+
+	t.Run("CreateTableRequest: valid partitioned", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":      "events",
+			"columns":   createValidColumns(),
+			"partition": map[string]interface{}{"strategy": "RANGE", "columns": []string{"another_column"}},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTableRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.PartitionStrategyRange, r.Partition.Strategy)
+		assert.Equal(t, []string{"another_column"}, r.Partition.Columns)
+	})
+
+	t.Run("CreateTableRequest: invalid partition", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			partition map[string]interface{}
+			expected  string
+		}{
+			{
+				name:      "Unknown strategy",
+				partition: map[string]interface{}{"strategy": "interval", "columns": []string{"valid_column"}},
+				expected:  "Partition strategy must be one of range, list or hash",
+			},
+			{
+				name:      "Missing columns",
+				partition: map[string]interface{}{"strategy": "hash"},
+				expected:  "partition columns are required",
+			},
+			{
+				name:      "Unknown column",
+				partition: map[string]interface{}{"strategy": "range", "columns": []string{"created_at"}},
+				expected:  "partition column 'created_at' is not a column of the table",
+			},
+			{
+				name:      "List with several columns",
+				partition: map[string]interface{}{"strategy": "list", "columns": []string{"valid_column", "another_column"}},
+				expected:  "list partitioning takes exactly one column",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				payload := map[string]interface{}{
+					"name":      "events",
+					"columns":   createValidColumns(),
+					"partition": tt.partition,
+				}
+
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r CreateTableRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+
 	t.Run("CreateTableRequest: invalid", func(t *testing.T) {
 		// Test table name validation using shared test cases
 		for _, tc := range tableNameValidationTests {
@@ -232,6 +367,24 @@ func TestCreateTableRequest_BindAndValidate_Suite(t *testing.T) {
 				},
 				expected: []string{"column type 'invalid_type' is not allowed"},
 			},
+			{
+				name: "Internal schema",
+				payload: map[string]interface{}{
+					"schema":  "pg_catalog",
+					"name":    "test_table",
+					"columns": createValidColumns(),
+				},
+				expected: []string{"Schema name cannot start with 'pg_'"},
+			},
+			{
+				name: "Invalid schema name",
+				payload: map[string]interface{}{
+					"schema":  "my-schema",
+					"name":    "test_table",
+					"columns": createValidColumns(),
+				},
+				expected: []string{"Schema name must be alphanumeric with underscores"},
+			},
 		}
 
 		for _, tc := range additionalTests {
@@ -315,3 +468,54 @@ func TestUploadTableRequest_BindAndValidate_Suite(t *testing.T) {
 		})
 	})
 }
+
+func TestUpdateTableDescriptionRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("UpdateTableDescriptionRequest: empty description clears it", func(t *testing.T) {
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, map[string]interface{}{"description": ""})
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r UpdateTableDescriptionRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "", r.Description)
+	})
+
+	t.Run("UpdateTableDescriptionRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			headers  map[string]string
+			expected string
+		}{
+			{
+				name:     "Missing project header",
+				payload:  map[string]interface{}{"description": "Orders"},
+				headers:  map[string]string{},
+				expected: "invalid project UUID",
+			},
+			{
+				name:     "Description too long",
+				payload:  map[string]interface{}{"description": strings.Repeat("d", constants.MaxTableDescriptionLength+1)},
+				headers:  map[string]string{constants.ProjectHeaderKey: dummyProjectUUID},
+				expected: "Description must be at most",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, tt.payload)
+				for key, value := range tt.headers {
+					ctx.Request().Header.Set(key, value)
+				}
+
+				var r UpdateTableDescriptionRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -6,4 +6,7 @@ type TableResponse struct {
 	Schema        string `json:"schema"`
 	EstimatedRows int    `json:"estimatedRows"`
 	TotalSize     string `json:"totalSize"`
+	Partitioned   bool   `json:"partitioned"`
+	PartitionOf   string `json:"partitionOf"`
+	Description   string `json:"description"`
 }
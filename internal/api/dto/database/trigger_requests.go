@@ -0,0 +1,150 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+var triggerFunctionPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)?$`)
+
+type CreateTriggerRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Name          string   `json:"name"`
+	Timing        string   `json:"timing"`
+	Level         string   `json:"level"`
+	Events        []string `json:"events"`
+	UpdateColumns []string `json:"updateColumns"`
+	Condition     string   `json:"condition"`
+	Function      string   `json:"function"`
+}
+
+func (r *CreateTriggerRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	r.Timing = strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(r.Timing)), "_", " ")
+	r.Level = strings.ToUpper(strings.TrimSpace(r.Level))
+	if r.Level == "" {
+		r.Level = constants.TriggerLevelRow
+	}
+
+	for i, event := range r.Events {
+		r.Events[i] = strings.ToUpper(strings.TrimSpace(event))
+	}
+
+	r.Condition = strings.TrimSpace(r.Condition)
+
+	err := validation.ValidateStruct(r,
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Trigger name is required"),
+			validation.Length(
+				constants.MinTriggerNameLength, constants.MaxTriggerNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Trigger name must be between %d and %d characters",
+					constants.MinTriggerNameLength,
+					constants.MaxTriggerNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Trigger name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Timing,
+			validation.Required.Error("Trigger timing is required"),
+			validation.In(constants.TriggerTimings...).Error("Trigger timing must be one of BEFORE, AFTER or INSTEAD OF"),
+		),
+		validation.Field(
+			&r.Level,
+			validation.In(constants.TriggerLevels...).Error("Trigger level must be one of ROW or STATEMENT"),
+		),
+		validation.Field(
+			&r.Events,
+			validation.Required.Error("At least one event is required"),
+		),
+		validation.Field(
+			&r.Function,
+			validation.Required.Error("Trigger function is required"),
+			validation.Match(triggerFunctionPattern).Error("Trigger function must be a function name, optionally schema qualified"),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return r.validateCombinations()
+}
+
+// validateCombinations rejects events and options postgres does not support for the chosen timing and level
+func (r *CreateTriggerRequest) validateCombinations() []string {
+	var errors []string
+
+	isInsteadOf := r.Timing == constants.TriggerTimingInsteadOf
+	isRowLevel := r.Level == constants.TriggerLevelRow
+
+	seenEvents := make(map[string]bool)
+	for _, event := range r.Events {
+		if err := validation.Validate(event, validation.In(constants.TriggerEvents...)); err != nil {
+			errors = append(errors, fmt.Sprintf("Trigger event '%s' must be one of INSERT, UPDATE, DELETE or TRUNCATE", event))
+
+			continue
+		}
+
+		if seenEvents[event] {
+			errors = append(errors, fmt.Sprintf("Duplicate event '%s' in trigger definition", event))
+		}
+
+		seenEvents[event] = true
+	}
+
+	if seenEvents[constants.TriggerEventTruncate] && isRowLevel {
+		errors = append(errors, "TRUNCATE triggers must be statement level")
+	}
+
+	if isInsteadOf && !isRowLevel {
+		errors = append(errors, "INSTEAD OF triggers must be row level")
+	}
+
+	if isInsteadOf && r.Condition != "" {
+		errors = append(errors, "INSTEAD OF triggers cannot have a condition")
+	}
+
+	if len(r.UpdateColumns) > 0 && !seenEvents[constants.TriggerEventUpdate] {
+		errors = append(errors, "Update columns are only allowed with the UPDATE event")
+	}
+
+	if len(r.UpdateColumns) > 0 && isInsteadOf {
+		errors = append(errors, "INSTEAD OF triggers cannot be limited to update columns")
+	}
+
+	seenColumns := make(map[string]bool)
+	for _, column := range r.UpdateColumns {
+		if strings.TrimSpace(column) == "" {
+			errors = append(errors, "Column name in trigger cannot be empty")
+
+			continue
+		}
+
+		if seenColumns[strings.ToLower(column)] {
+			errors = append(errors, fmt.Sprintf("Duplicate column '%s' in trigger definition", column))
+		}
+
+		seenColumns[strings.ToLower(column)] = true
+	}
+
+	return errors
+}
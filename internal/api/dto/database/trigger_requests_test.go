@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateTriggerRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateTriggerRequest: valid", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":          "orders_touch",
+			"timing":        "before",
+			"events":        []string{"insert", "Update"},
+			"updateColumns": []string{"status"},
+			"condition":     " NEW.total > 0 ",
+			"function":      "public.touch_updated_at",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTriggerRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.TriggerTimingBefore, r.Timing)
+		assert.Equal(t, constants.TriggerLevelRow, r.Level)
+		assert.Equal(t, []string{"INSERT", "UPDATE"}, r.Events)
+		assert.Equal(t, "NEW.total > 0", r.Condition)
+	})
+
+	t.Run("CreateTriggerRequest: valid instead of", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":     "active_users_insert",
+			"timing":   "instead_of",
+			"events":   []string{"INSERT"},
+			"function": "insert_active_user",
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+		var r CreateTriggerRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, constants.TriggerTimingInsteadOf, r.Timing)
+	})
+
+	t.Run("CreateTriggerRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Missing name",
+				payload:  map[string]interface{}{"timing": "AFTER", "events": []string{"INSERT"}, "function": "fn"},
+				expected: "Trigger name is required",
+			},
+			{
+				name:     "Unknown timing",
+				payload:  map[string]interface{}{"name": "orders_audit", "timing": "DURING", "events": []string{"INSERT"}, "function": "fn"},
+				expected: "Trigger timing must be one of BEFORE, AFTER or INSTEAD OF",
+			},
+			{
+				name: "Unknown level",
+				payload: map[string]interface{}{
+					"name": "orders_audit", "timing": "AFTER", "level": "TABLE", "events": []string{"INSERT"}, "function": "fn",
+				},
+				expected: "Trigger level must be one of ROW or STATEMENT",
+			},
+			{
+				name:     "Missing events",
+				payload:  map[string]interface{}{"name": "orders_audit", "timing": "AFTER", "function": "fn"},
+				expected: "At least one event is required",
+			},
+			{
+				name:     "Unknown event",
+				payload:  map[string]interface{}{"name": "orders_audit", "timing": "AFTER", "events": []string{"SELECT"}, "function": "fn"},
+				expected: "Trigger event 'SELECT' must be one of INSERT, UPDATE, DELETE or TRUNCATE",
+			},
+			{
+				name: "Duplicate event",
+				payload: map[string]interface{}{
+					"name": "orders_audit", "timing": "AFTER", "events": []string{"INSERT", "insert"}, "function": "fn",
+				},
+				expected: "Duplicate event 'INSERT' in trigger definition",
+			},
+			{
+				name:     "Invalid function name",
+				payload:  map[string]interface{}{"name": "orders_audit", "timing": "AFTER", "events": []string{"INSERT"}, "function": "fn()"},
+				expected: "Trigger function must be a function name, optionally schema qualified",
+			},
+			{
+				name:     "Row level truncate",
+				payload:  map[string]interface{}{"name": "orders_audit", "timing": "AFTER", "events": []string{"TRUNCATE"}, "function": "fn"},
+				expected: "TRUNCATE triggers must be statement level",
+			},
+			{
+				name: "Statement level instead of",
+				payload: map[string]interface{}{
+					"name": "orders_audit", "timing": "INSTEAD OF", "level": "STATEMENT", "events": []string{"INSERT"}, "function": "fn",
+				},
+				expected: "INSTEAD OF triggers must be row level",
+			},
+			{
+				name: "Instead of with condition",
+				payload: map[string]interface{}{
+					"name": "orders_audit", "timing": "INSTEAD OF", "events": []string{"INSERT"}, "condition": "true", "function": "fn",
+				},
+				expected: "INSTEAD OF triggers cannot have a condition",
+			},
+			{
+				name: "Update columns without update event",
+				payload: map[string]interface{}{
+					"name": "orders_audit", "timing": "AFTER", "events": []string{"INSERT"}, "updateColumns": []string{"status"}, "function": "fn",
+				},
+				expected: "Update columns are only allowed with the UPDATE event",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.Request().Header.Set(constants.ProjectHeaderKey, dummyProjectUUID)
+
+				var r CreateTriggerRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
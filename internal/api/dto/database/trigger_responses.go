@@ -0,0 +1,13 @@
+package database
+
+type TriggerResponse struct {
+	Name          string   `json:"name"`
+	Timing        string   `json:"timing"`
+	Level         string   `json:"level"`
+	Events        []string `json:"events"`
+	UpdateColumns []string `json:"updateColumns,omitempty"`
+	Condition     string   `json:"condition,omitempty"`
+	Function      string   `json:"function"`
+	Enabled       bool     `json:"enabled"`
+	Definition    string   `json:"definition"`
+}
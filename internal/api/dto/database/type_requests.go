@@ -0,0 +1,218 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type typeAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type CreateTypeRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID       `json:"-"`
+	Schema      string          `json:"schema"`
+	Name        string          `json:"name"`
+	Kind        string          `json:"kind"`
+	Values      []string        `json:"values"`
+	Attributes  []typeAttribute `json:"attributes"`
+}
+
+type AlterTypeRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Action      string    `json:"action"`
+	Value       string    `json:"value"`
+	NewValue    string    `json:"newValue"`
+	Before      string    `json:"before"`
+	After       string    `json:"after"`
+}
+
+func (r *CreateTypeRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Kind = strings.ToLower(strings.TrimSpace(r.Kind))
+	if r.Schema == "" {
+		r.Schema = pkg.DefaultSchema
+	}
+
+	isEnum := r.Kind == constants.TypeKindEnum
+	isComposite := r.Kind == constants.TypeKindComposite
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Schema,
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Schema name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("Type name is required"),
+			validation.Length(
+				constants.MinTypeNameLength, constants.MaxTypeNameLength,
+			).Error(
+				fmt.Sprintf(
+					"Type name must be between %d and %d characters",
+					constants.MinTypeNameLength,
+					constants.MaxTypeNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Type name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Kind,
+			validation.Required.Error("Type kind is required"),
+			validation.In(constants.TypeKinds...).Error("Type kind must be one of enum or composite"),
+		),
+		validation.Field(
+			&r.Values,
+			validation.When(isEnum, validation.Required.Error("At least one value is required for enum types")),
+		),
+		validation.Field(
+			&r.Attributes,
+			validation.When(isComposite, validation.Required.Error("At least one attribute is required for composite types")),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return r.validateCombinations()
+}
+
+// validateCombinations checks the values or attributes that belong to the chosen kind
+func (r *CreateTypeRequest) validateCombinations() []string {
+	var errors []string
+
+	if r.Kind == constants.TypeKindEnum && len(r.Attributes) > 0 {
+		errors = append(errors, "Attributes are only allowed for composite types")
+	}
+
+	if r.Kind == constants.TypeKindComposite && len(r.Values) > 0 {
+		errors = append(errors, "Values are only allowed for enum types")
+	}
+
+	seenValues := make(map[string]bool)
+	for _, value := range r.Values {
+		if err := validateEnumValue(value); err != nil {
+			errors = append(errors, err.Error())
+
+			continue
+		}
+
+		if seenValues[value] {
+			errors = append(errors, fmt.Sprintf("Duplicate value '%s' in enum definition", value))
+		}
+
+		seenValues[value] = true
+	}
+
+	attributePattern := regexp.MustCompile(constants.AlphanumericWithUnderscorePattern)
+	seenAttributes := make(map[string]bool)
+	for _, attribute := range r.Attributes {
+		if !attributePattern.MatchString(attribute.Name) {
+			errors = append(errors, fmt.Sprintf("Attribute name '%s' must be alphanumeric with underscores", attribute.Name))
+
+			continue
+		}
+
+		if seenAttributes[strings.ToLower(attribute.Name)] {
+			errors = append(errors, fmt.Sprintf("Duplicate attribute '%s' in composite definition", attribute.Name))
+		}
+
+		seenAttributes[strings.ToLower(attribute.Name)] = true
+
+		if err := validateType(attribute.Type); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
+	return errors
+}
+
+func (r *AlterTypeRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+
+	isRename := r.Action == constants.TypeActionRenameValue
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Action,
+			validation.Required.Error("Action is required"),
+			validation.In(constants.TypeActions...).Error("Action must be one of add_value or rename_value"),
+		),
+		validation.Field(
+			&r.Value,
+			validation.Required.Error("Value is required"),
+			validation.By(func(value interface{}) error {
+				return validateEnumValue(value.(string))
+			}),
+		),
+		validation.Field(
+			&r.NewValue,
+			validation.When(isRename, validation.Required.Error("New value is required when renaming a value")),
+			validation.When(isRename, validation.By(func(value interface{}) error {
+				return validateEnumValue(value.(string))
+			})),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	if r.Before != "" && r.After != "" {
+		errors = append(errors, "Only one of before or after can be set")
+	}
+
+	if isRename && (r.Before != "" || r.After != "") {
+		errors = append(errors, "Before and after are only allowed when adding a value")
+	}
+
+	if !isRename && r.NewValue != "" {
+		errors = append(errors, "New value is only allowed when renaming a value")
+	}
+
+	return errors
+}
+
+func validateEnumValue(value string) error {
+	if value == "" || len(value) > constants.MaxEnumValueLength {
+		return fmt.Errorf("Enum value '%s' must be between 1 and %d characters", value, constants.MaxEnumValueLength)
+	}
+
+	return nil
+}
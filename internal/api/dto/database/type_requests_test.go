@@ -0,0 +1,221 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateTypeRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateTypeRequest: valid enum", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":   "order_status",
+			"kind":   "Enum",
+			"values": []string{"pending", "shipped", "delivered"},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateTypeRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+		assert.Equal(t, pkg.DefaultSchema, r.Schema)
+		assert.Equal(t, constants.TypeKindEnum, r.Kind)
+		assert.Len(t, r.Values, 3)
+	})
+
+	t.Run("CreateTypeRequest: valid composite", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"schema": "sales",
+			"name":   "address",
+			"kind":   "composite",
+			"attributes": []map[string]string{
+				{"name": "street", "type": "varchar(255)"},
+				{"name": "zip", "type": "char(5)"},
+				{"name": "status", "type": "sales.address_status"},
+			},
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateTypeRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, "sales", r.Schema)
+		assert.Len(t, r.Attributes, 3)
+	})
+
+	t.Run("CreateTypeRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "invalid",
+				payload:     map[string]interface{}{"name": "order_status", "kind": "enum", "values": []string{"a"}},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing name",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"kind": "enum", "values": []string{"a"}},
+				expected:    "Type name is required",
+			},
+			{
+				name:        "Invalid name characters",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "order-status", "kind": "enum", "values": []string{"a"}},
+				expected:    "Type name must be alphanumeric with underscores",
+			},
+			{
+				name:        "Unknown kind",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "order_status", "kind": "range"},
+				expected:    "Type kind must be one of enum or composite",
+			},
+			{
+				name:        "Enum without values",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "order_status", "kind": "enum"},
+				expected:    "At least one value is required for enum types",
+			},
+			{
+				name:        "Composite without attributes",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "address", "kind": "composite"},
+				expected:    "At least one attribute is required for composite types",
+			},
+			{
+				name:        "Duplicate enum value",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "order_status", "kind": "enum", "values": []string{"a", "a"}},
+				expected:    "Duplicate value 'a' in enum definition",
+			},
+			{
+				name:        "Enum value too long",
+				projectUUID: dummyProjectUUID,
+				payload: map[string]interface{}{
+					"name": "order_status", "kind": "enum", "values": []string{strings.Repeat("a", 64)},
+				},
+				expected: "must be between 1 and 63 characters",
+			},
+			{
+				name:        "Enum with attributes",
+				projectUUID: dummyProjectUUID,
+				payload: map[string]interface{}{
+					"name": "order_status", "kind": "enum", "values": []string{"a"},
+					"attributes": []map[string]string{{"name": "street", "type": "text"}},
+				},
+				expected: "Attributes are only allowed for composite types",
+			},
+			{
+				name:        "Invalid attribute type",
+				projectUUID: dummyProjectUUID,
+				payload: map[string]interface{}{
+					"name": "address", "kind": "composite",
+					"attributes": []map[string]string{{"name": "street", "type": "money"}},
+				},
+				expected: "column type 'money' is not allowed",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r CreateTypeRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestAlterTypeRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("AlterTypeRequest: valid", func(t *testing.T) {
+		payloads := []map[string]interface{}{
+			{"action": "add_value", "value": "returned"},
+			{"action": "add_value", "value": "returned", "after": "delivered"},
+			{"action": "rename_value", "value": "shipped", "newValue": "dispatched"},
+		}
+
+		for _, payload := range payloads {
+			ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, payload)
+			ctx.SetParamNames("projectUUID")
+			ctx.SetParamValues(dummyProjectUUID)
+
+			var r AlterTypeRequest
+			errs := r.BindAndValidate(ctx)
+
+			assert.Len(t, errs, 0)
+		}
+	})
+
+	t.Run("AlterTypeRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Unknown action",
+				payload:  map[string]interface{}{"action": "drop_value", "value": "a"},
+				expected: "Action must be one of add_value or rename_value",
+			},
+			{
+				name:     "Missing value",
+				payload:  map[string]interface{}{"action": "add_value"},
+				expected: "Value is required",
+			},
+			{
+				name:     "Rename without new value",
+				payload:  map[string]interface{}{"action": "rename_value", "value": "a"},
+				expected: "New value is required when renaming a value",
+			},
+			{
+				name:     "Both before and after",
+				payload:  map[string]interface{}{"action": "add_value", "value": "a", "before": "b", "after": "c"},
+				expected: "Only one of before or after can be set",
+			},
+			{
+				name:     "Position on rename",
+				payload:  map[string]interface{}{"action": "rename_value", "value": "a", "newValue": "b", "after": "c"},
+				expected: "Before and after are only allowed when adding a value",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r AlterTypeRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package database
+
+type TypeAttributeResponse struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type TypeResponse struct {
+	Schema     string                  `json:"schema"`
+	Name       string                  `json:"name"`
+	FullName   string                  `json:"fullName"`
+	Kind       string                  `json:"kind"`
+	Values     []string                `json:"values,omitempty"`
+	Attributes []TypeAttributeResponse `json:"attributes,omitempty"`
+}
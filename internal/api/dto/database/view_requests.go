@@ -0,0 +1,206 @@
+package database
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strings"
+)
+
+type CreateViewRequest struct {
+	dto.BaseRequest
+	ProjectUUID            uuid.UUID `json:"-"`
+	Schema                 string    `json:"schema"`
+	Name                   string    `json:"name"`
+	Definition             string    `json:"definition"`
+	Materialized           bool      `json:"materialized"`
+	SkipData               bool      `json:"skipData"`
+	RefreshIntervalMinutes int       `json:"refreshIntervalMinutes"`
+	RefreshConcurrently    bool      `json:"refreshConcurrently"`
+}
+
+type ReplaceViewRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Definition  string    `json:"definition"`
+}
+
+type RefreshViewRequest struct {
+	dto.BaseRequest
+	ProjectUUID  uuid.UUID `json:"-"`
+	Concurrently bool      `json:"concurrently"`
+}
+
+type ScheduleViewRefreshRequest struct {
+	dto.BaseRequest
+	ProjectUUID     uuid.UUID `json:"-"`
+	IntervalMinutes int       `json:"intervalMinutes"`
+	Concurrently    bool      `json:"concurrently"`
+}
+
+func (r *CreateViewRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Definition = strings.TrimSpace(r.Definition)
+	if r.Schema == "" {
+		r.Schema = pkg.DefaultSchema
+	}
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Schema,
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("Schema name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Name,
+			validation.Required.Error("View name is required"),
+			validation.Length(
+				constants.MinViewNameLength, constants.MaxViewNameLength,
+			).Error(
+				fmt.Sprintf(
+					"View name must be between %d and %d characters",
+					constants.MinViewNameLength,
+					constants.MaxViewNameLength,
+				),
+			),
+			validation.Match(
+				regexp.MustCompile(constants.AlphanumericWithUnderscorePattern),
+			).Error("View name must be alphanumeric with underscores"),
+		),
+		validation.Field(
+			&r.Definition,
+			validation.Required.Error("View definition is required"),
+		),
+		validation.Field(
+			&r.RefreshIntervalMinutes,
+			validation.By(validateRefreshInterval),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return r.validateCombinations()
+}
+
+// validateCombinations rejects options that only apply to materialized views
+func (r *CreateViewRequest) validateCombinations() []string {
+	if r.Materialized {
+		return nil
+	}
+
+	var errors []string
+	if r.SkipData {
+		errors = append(errors, "Skip data is only allowed for materialized views")
+	}
+
+	if r.RefreshIntervalMinutes > 0 || r.RefreshConcurrently {
+		errors = append(errors, "Refresh schedules are only allowed for materialized views")
+	}
+
+	return errors
+}
+
+func (r *ReplaceViewRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+	r.Definition = strings.TrimSpace(r.Definition)
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.Definition,
+			validation.Required.Error("View definition is required"),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+func (r *RefreshViewRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	return nil
+}
+
+func (r *ScheduleViewRefreshRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(
+			&r.IntervalMinutes,
+			validation.By(validateRefreshInterval),
+		),
+	)
+
+	errors := r.ExtractValidationErrors(err)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	if r.IntervalMinutes == 0 && r.Concurrently {
+		return []string{"Concurrently requires a refresh interval"}
+	}
+
+	return nil
+}
+
+// validateRefreshInterval allows zero, meaning no schedule, or an interval within the supported range
+func validateRefreshInterval(value interface{}) error {
+	interval := value.(int)
+	if interval == 0 {
+		return nil
+	}
+
+	if interval < constants.MinViewRefreshIntervalMinutes || interval > constants.MaxViewRefreshIntervalMinutes {
+		return fmt.Errorf(
+			"Refresh interval must be between %d and %d minutes",
+			constants.MinViewRefreshIntervalMinutes,
+			constants.MaxViewRefreshIntervalMinutes,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCreateViewRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("CreateViewRequest: valid materialized view", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":                   "daily_sales",
+			"definition":             "  SELECT day, sum(total) FROM orders GROUP BY day ",
+			"materialized":           true,
+			"refreshIntervalMinutes": 60,
+			"refreshConcurrently":    true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(dummyProjectUUID)
+
+		var r CreateViewRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, dummyProjectUUID, r.ProjectUUID.String())
+		assert.Equal(t, pkg.DefaultSchema, r.Schema)
+		assert.Equal(t, "SELECT day, sum(total) FROM orders GROUP BY day", r.Definition)
+		assert.Equal(t, 60, r.RefreshIntervalMinutes)
+	})
+
+	t.Run("CreateViewRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "invalid",
+				payload:     map[string]interface{}{"name": "active_users", "definition": "SELECT 1"},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing name",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"definition": "SELECT 1"},
+				expected:    "View name is required",
+			},
+			{
+				name:        "Invalid name characters",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "active-users", "definition": "SELECT 1"},
+				expected:    "View name must be alphanumeric with underscores",
+			},
+			{
+				name:        "Missing definition",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "active_users", "definition": "   "},
+				expected:    "View definition is required",
+			},
+			{
+				name:        "Refresh interval too short",
+				projectUUID: dummyProjectUUID,
+				payload: map[string]interface{}{
+					"name": "daily_sales", "definition": "SELECT 1", "materialized": true, "refreshIntervalMinutes": 1,
+				},
+				expected: "Refresh interval must be between 5 and 10080 minutes",
+			},
+			{
+				name:        "Schedule on a plain view",
+				projectUUID: dummyProjectUUID,
+				payload: map[string]interface{}{
+					"name": "active_users", "definition": "SELECT 1", "refreshIntervalMinutes": 60,
+				},
+				expected: "Refresh schedules are only allowed for materialized views",
+			},
+			{
+				name:        "Skip data on a plain view",
+				projectUUID: dummyProjectUUID,
+				payload:     map[string]interface{}{"name": "active_users", "definition": "SELECT 1", "skipData": true},
+				expected:    "Skip data is only allowed for materialized views",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r CreateViewRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestScheduleViewRefreshRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ScheduleViewRefreshRequest: valid", func(t *testing.T) {
+		payloads := []map[string]interface{}{
+			{"intervalMinutes": 0},
+			{"intervalMinutes": 15, "concurrently": true},
+		}
+
+		for _, payload := range payloads {
+			ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, payload)
+			ctx.SetParamNames("projectUUID")
+			ctx.SetParamValues(dummyProjectUUID)
+
+			var r ScheduleViewRefreshRequest
+			errs := r.BindAndValidate(ctx)
+
+			assert.Len(t, errs, 0)
+		}
+	})
+
+	t.Run("ScheduleViewRefreshRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			payload  map[string]interface{}
+			expected string
+		}{
+			{
+				name:     "Interval too long",
+				payload:  map[string]interface{}{"intervalMinutes": 20000},
+				expected: "Refresh interval must be between 5 and 10080 minutes",
+			},
+			{
+				name:     "Concurrently without interval",
+				payload:  map[string]interface{}{"concurrently": true},
+				expected: "Concurrently requires a refresh interval",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPut, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(dummyProjectUUID)
+
+				var r ScheduleViewRefreshRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package database
+
+import "time"
+
+type ViewRefreshScheduleResponse struct {
+	IntervalMinutes int        `json:"intervalMinutes"`
+	Concurrently    bool       `json:"concurrently"`
+	NextRefreshAt   time.Time  `json:"nextRefreshAt"`
+	LastRefreshedAt *time.Time `json:"lastRefreshedAt"`
+	LastError       string     `json:"lastError,omitempty"`
+}
+
+type ViewResponse struct {
+	Schema         string                       `json:"schema"`
+	Name           string                       `json:"name"`
+	FullName       string                       `json:"fullName"`
+	Materialized   bool                         `json:"materialized"`
+	Definition     string                       `json:"definition"`
+	Populated      bool                         `json:"populated"`
+	HasUniqueIndex bool                         `json:"hasUniqueIndex"`
+	Schedule       *ViewRefreshScheduleResponse `json:"schedule"`
+}
@@ -0,0 +1,20 @@
+package job
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+type Response struct {
+	Uuid        uuid.UUID       `json:"uuid"`
+	ProjectUuid uuid.UUID       `json:"projectUuid"`
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Result      json.RawMessage `json:"result" swaggertype:"object"`
+	Error       string          `json:"error"`
+	CreatedBy   uuid.UUID       `json:"createdBy"`
+	CreatedAt   string          `json:"createdAt"`
+	StartedAt   string          `json:"startedAt"`
+	FinishedAt  string          `json:"finishedAt"`
+}
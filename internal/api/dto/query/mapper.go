@@ -0,0 +1,24 @@
+package query
+
+import (
+	"fluxend/internal/domain/query"
+)
+
+func ToExecuteInput(request *ExecuteRequest) query.ExecuteInput {
+	return query.ExecuteInput{
+		ProjectUUID: request.ProjectUUID,
+		Query:       request.Query,
+		ReadOnly:    request.ReadOnly,
+		TimeoutInMs: request.TimeoutInMs,
+		MaxRows:     request.MaxRows,
+	}
+}
+
+func ToExplainInput(request *ExplainRequest) query.ExplainInput {
+	return query.ExplainInput{
+		ProjectUUID: request.ProjectUUID,
+		Query:       request.Query,
+		Analyze:     request.Analyze,
+		TimeoutInMs: request.TimeoutInMs,
+	}
+}
@@ -0,0 +1,86 @@
+package query
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ExecuteRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Query       string    `json:"query"`
+	ReadOnly    bool      `json:"readOnly"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+	MaxRows     int       `json:"maxRows"`
+}
+
+func (r *ExecuteRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Query, validation.Required.Error("Query is required")),
+		validation.Field(
+			&r.TimeoutInMs,
+			validation.Min(0).Error("Timeout cannot be negative"),
+			validation.Max(constants.MaxQueryTimeoutInMs).Error(
+				fmt.Sprintf("Timeout cannot exceed %d milliseconds", constants.MaxQueryTimeoutInMs),
+			),
+		),
+		validation.Field(
+			&r.MaxRows,
+			validation.Min(0).Error("Max rows cannot be negative"),
+			validation.Max(constants.MaxQueryRows).Error(
+				fmt.Sprintf("Max rows cannot exceed %d", constants.MaxQueryRows),
+			),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
+
+type ExplainRequest struct {
+	dto.BaseRequest
+	ProjectUUID uuid.UUID `json:"-"`
+	Query       string    `json:"query"`
+	Analyze     bool      `json:"analyze"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+}
+
+func (r *ExplainRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	projectUUID, err := r.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return []string{"Invalid project UUID"}
+	}
+
+	r.ProjectUUID = projectUUID
+
+	err = validation.ValidateStruct(r,
+		validation.Field(&r.Query, validation.Required.Error("Query is required")),
+		validation.Field(
+			&r.TimeoutInMs,
+			validation.Min(0).Error("Timeout cannot be negative"),
+			validation.Max(constants.MaxQueryTimeoutInMs).Error(
+				fmt.Sprintf("Timeout cannot exceed %d milliseconds", constants.MaxQueryTimeoutInMs),
+			),
+		),
+	)
+
+	return r.ExtractValidationErrors(err)
+}
@@ -0,0 +1,156 @@
+package query
+
+import (
+	"fluxend/pkg"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestExecuteRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ExecuteRequest: valid", func(t *testing.T) {
+		projectUUID := uuid.New()
+		payload := map[string]interface{}{
+			"query":       "SELECT * FROM users",
+			"readOnly":    true,
+			"timeoutInMs": 2000,
+			"maxRows":     100,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(projectUUID.String())
+
+		var r ExecuteRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, projectUUID, r.ProjectUUID)
+		assert.Equal(t, payload["query"], r.Query)
+		assert.True(t, r.ReadOnly)
+		assert.Equal(t, 2000, r.TimeoutInMs)
+		assert.Equal(t, 100, r.MaxRows)
+	})
+
+	t.Run("ExecuteRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "not-a-uuid",
+				payload:     map[string]interface{}{"query": "SELECT 1"},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing query",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{},
+				expected:    "Query is required",
+			},
+			{
+				name:        "Negative timeout",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"query": "SELECT 1", "timeoutInMs": -1},
+				expected:    "Timeout cannot be negative",
+			},
+			{
+				name:        "Timeout too large",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"query": "SELECT 1", "timeoutInMs": 600000},
+				expected:    "Timeout cannot exceed",
+			},
+			{
+				name:        "Too many rows",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"query": "SELECT 1", "maxRows": 1000000},
+				expected:    "Max rows cannot exceed",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r ExecuteRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
+
+func TestExplainRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ExplainRequest: valid", func(t *testing.T) {
+		projectUUID := uuid.New()
+		payload := map[string]interface{}{
+			"query":   "SELECT * FROM orders WHERE status = 'shipped'",
+			"analyze": true,
+		}
+
+		ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, payload)
+		ctx.SetParamNames("projectUUID")
+		ctx.SetParamValues(projectUUID.String())
+
+		var r ExplainRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, projectUUID, r.ProjectUUID)
+		assert.Equal(t, payload["query"], r.Query)
+		assert.True(t, r.Analyze)
+	})
+
+	t.Run("ExplainRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			payload     map[string]interface{}
+			expected    string
+		}{
+			{
+				name:        "Invalid project UUID",
+				projectUUID: "",
+				payload:     map[string]interface{}{"query": "SELECT 1"},
+				expected:    "Invalid project UUID",
+			},
+			{
+				name:        "Missing query",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"analyze": true},
+				expected:    "Query is required",
+			},
+			{
+				name:        "Timeout too large",
+				projectUUID: uuid.New().String(),
+				payload:     map[string]interface{}{"query": "SELECT 1", "timeoutInMs": 600000},
+				expected:    "Timeout cannot exceed",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := pkg.CreateFakeRequestContext(t, e, http.MethodPost, tt.payload)
+				ctx.SetParamNames("projectUUID")
+				ctx.SetParamValues(tt.projectUUID)
+
+				var r ExplainRequest
+				errs := r.BindAndValidate(ctx)
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
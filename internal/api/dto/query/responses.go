@@ -0,0 +1,24 @@
+package query
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/query"
+)
+
+type Response struct {
+	Columns    []database.ResultColumn `json:"columns"`
+	Rows       [][]interface{}         `json:"rows"`
+	RowCount   int                     `json:"rowCount"`
+	Truncated  bool                    `json:"truncated"`
+	ReadOnly   bool                    `json:"readOnly"`
+	DurationMs int64                   `json:"durationMs"`
+}
+
+type PlanResponse struct {
+	Root          query.PlanNode          `json:"root"`
+	PlanningTime  *float64                `json:"planningTime"`
+	ExecutionTime *float64                `json:"executionTime"`
+	Analyzed      bool                    `json:"analyzed"`
+	SeqScans      []query.SeqScan         `json:"seqScans"`
+	Suggestions   []query.IndexSuggestion `json:"suggestions"`
+}
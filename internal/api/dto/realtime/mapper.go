@@ -0,0 +1,35 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fluxend/internal/domain/realtime"
+)
+
+func ToUpdateTableInput(request *UpdateTableRequest) realtime.UpdateTableInput {
+	return realtime.UpdateTableInput{
+		ProjectUUID: request.ProjectUUID,
+		Enabled:     request.Enabled,
+	}
+}
+
+func ToSubscribeInput(request *SubscribeRequest) realtime.SubscribeInput {
+	return realtime.SubscribeInput{
+		ProjectUUID:   request.ProjectUUID,
+		FullTableName: request.FullTableName,
+		Events:        request.Events,
+		Filters:       request.Filters,
+		LastEventID:   request.LastEventID,
+	}
+}
+
+// ToAccess hands the claims of a project token over the way PostgREST does, as JSON readable through current_setting
+func ToAccess(claims map[string]interface{}) (*realtime.Access, error) {
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	role, _ := claims["role"].(string)
+
+	return &realtime.Access{Role: role, Claims: string(encoded)}, nil
+}
@@ -0,0 +1,89 @@
+package realtime
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/realtime"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"slices"
+	"strings"
+)
+
+type UpdateTableRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	Enabled bool `json:"enabled"`
+}
+
+// SubscribeRequest is read from the query string, browsers cannot send a body or custom headers
+// when opening an event stream
+type SubscribeRequest struct {
+	dto.DefaultRequestWithProjectHeader
+	FullTableName string
+	Events        []string
+	Filters       []realtime.Filter
+	LastEventID   string
+}
+
+func (r *UpdateTableRequest) BindAndValidate(c echo.Context) []string {
+	if err := c.Bind(r); err != nil {
+		return []string{"Invalid request payload"}
+	}
+
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+func (r *SubscribeRequest) BindAndValidate(c echo.Context) []string {
+	if err := r.WithProjectHeader(c); err != nil {
+		return []string{err.Error()}
+	}
+
+	var errs []string
+
+	r.FullTableName = strings.TrimSpace(c.QueryParam("table"))
+	if r.FullTableName == "" {
+		errs = append(errs, "Table is required")
+	}
+
+	for _, event := range strings.Split(c.QueryParam("events"), ",") {
+		event = strings.ToUpper(strings.TrimSpace(event))
+		if event == "" {
+			continue
+		}
+
+		if !slices.Contains(constants.RealtimeEvents, event) {
+			errs = append(errs, fmt.Sprintf(
+				"Event '%s' is not supported, use one of: %s",
+				event,
+				strings.Join(constants.RealtimeEvents, ", "),
+			))
+
+			continue
+		}
+
+		r.Events = append(r.Events, event)
+	}
+
+	for _, expression := range c.QueryParams()["filter"] {
+		filter, err := realtime.ParseFilter(expression)
+		if err != nil {
+			errs = append(errs, "Invalid filter: "+err.Error())
+
+			continue
+		}
+
+		r.Filters = append(r.Filters, filter)
+	}
+
+	// EventSource sends Last-Event-ID by itself when it reconnects
+	r.LastEventID = c.Request().Header.Get("Last-Event-ID")
+	if r.LastEventID == "" {
+		r.LastEventID = c.QueryParam("lastEventId")
+	}
+
+	return errs
+}
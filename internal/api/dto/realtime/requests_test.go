@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func newSubscribeContext(t *testing.T, e *echo.Echo, projectUUID, query string) echo.Context {
+	ctx := pkg.CreateFakeRequestContext(t, e, http.MethodGet, nil)
+	ctx.Request().Header.Set("X-Project", projectUUID)
+	ctx.Request().URL.RawQuery = query
+
+	return ctx
+}
+
+func TestSubscribeRequest_BindAndValidate_Suite(t *testing.T) {
+	e := echo.New()
+
+	t.Run("SubscribeRequest: valid", func(t *testing.T) {
+		projectUUID := uuid.New()
+		ctx := newSubscribeContext(t, e, projectUUID.String(), "table=public.orders&events=insert,%20UPDATE&filter=status%3Deq.shipped&filter=total%3Dgt.10")
+		ctx.Request().Header.Set("Last-Event-ID", "1-42")
+
+		var r SubscribeRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Equal(t, projectUUID, r.ProjectUUID)
+		assert.Equal(t, "public.orders", r.FullTableName)
+		assert.Equal(t, []string{constants.RealtimeEventInsert, constants.RealtimeEventUpdate}, r.Events)
+		assert.Len(t, r.Filters, 2)
+		assert.Equal(t, "total", r.Filters[1].Column)
+		assert.Equal(t, "1-42", r.LastEventID)
+	})
+
+	t.Run("SubscribeRequest: last event id from query", func(t *testing.T) {
+		ctx := newSubscribeContext(t, e, uuid.New().String(), "table=orders&lastEventId=1-7")
+
+		var r SubscribeRequest
+		errs := r.BindAndValidate(ctx)
+
+		assert.Len(t, errs, 0)
+		assert.Empty(t, r.Events)
+		assert.Equal(t, "1-7", r.LastEventID)
+	})
+
+	t.Run("SubscribeRequest: invalid", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			projectUUID string
+			query       string
+			expected    string
+		}{
+			{name: "Invalid project UUID", projectUUID: "not-a-uuid", query: "table=orders", expected: "invalid project UUID"},
+			{name: "Missing table", projectUUID: uuid.New().String(), query: "events=INSERT", expected: "Table is required"},
+			{name: "Unknown event", projectUUID: uuid.New().String(), query: "table=orders&events=TRUNCATE", expected: "Event 'TRUNCATE' is not supported"},
+			{name: "Invalid filter", projectUUID: uuid.New().String(), query: "table=orders&filter=status%3Dlike.x", expected: "Invalid filter"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var r SubscribeRequest
+				errs := r.BindAndValidate(newSubscribeContext(t, e, tt.projectUUID, tt.query))
+
+				pkg.AssertErrorContains(t, errs, tt.expected)
+			})
+		}
+	})
+}
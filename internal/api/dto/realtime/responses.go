@@ -0,0 +1,7 @@
+package realtime
+
+type TableResponse struct {
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+	Enabled bool   `json:"enabled"`
+}
@@ -14,12 +14,14 @@ import (
 
 type ColumnHandler struct {
 	columnService database.ColumnService
+	dropService   database.DropService
 }
 
 func NewColumnHandler(injector *do.Injector) (*ColumnHandler, error) {
 	columnService := do.MustInvoke[database.ColumnService](injector)
+	dropService := do.MustInvoke[database.DropService](injector)
 
-	return &ColumnHandler{columnService: columnService}, nil
+	return &ColumnHandler{columnService: columnService, dropService: dropService}, nil
 }
 
 // List retrieves all columns within a project.
@@ -104,10 +106,10 @@ func (ch *ColumnHandler) Store(c echo.Context) error {
 	return response.CreatedResponse(c, mapper.ToColumnResourceCollection(columns))
 }
 
-// Update modifies column types in a table and deletes others
+// Update modifies column types, defaults and nullability in a table and deletes others
 //
 // @Summary Modify columns
-// @Description Update the data type of existing columns in a specified table and remove any columns not included in the request.
+// @Description Update the data type, default value and nullability of existing columns in a specified table and remove any columns not included in the request. Type changes that could lose data, such as numeric to integer, timestamptz to timestamp or a shorter varchar, are refused.
 // @Tags Columns
 //
 // @Accept json
@@ -194,7 +196,7 @@ func (ch *ColumnHandler) Rename(c echo.Context) error {
 // Delete removes a column from a table.
 //
 // @Summary Delete column
-// @Description Permanently delete a specific column from a given table.
+// @Description Drop a column after checking the indexes, views, foreign keys and functions depending on it. A dry run returns the dependencies and the exact SQL without dropping anything, dependents requiring cascade refuse the drop unless cascade is set. A snapshot keeps the column values in the trash for the retention days, it needs a primary key that doesn't include the column.
 // @Tags Columns
 //
 // @Accept json
@@ -204,8 +206,13 @@ func (ch *ColumnHandler) Rename(c echo.Context) error {
 // @Param X-Project header string true "Project UUID"
 //
 // @Param fullTableName path string true "Full table name"
-// @Param column_name path string true "Column Name"
+// @Param columnName path string true "Column Name"
+// @Param dryRun query bool false "Only return the plan"
+// @Param cascade query bool false "Also drop dependent objects"
+// @Param snapshot query bool false "Keep a restorable copy in the trash"
+// @Param retentionDays query int false "Days the snapshot is kept, defaults to 7"
 //
+// @Success 200 {object} response.Response{content=database.DropPlanResponse} "Drop plan, returned for dry runs and snapshots"
 // @Success 204 "Column deleted successfully"
 // @Failure 400 "Invalid input"
 // @Failure 401 "Unauthorized"
@@ -214,7 +221,7 @@ func (ch *ColumnHandler) Rename(c echo.Context) error {
 //
 // @Router /tables/{fullTableName}/columns/{columnName} [delete]
 func (ch *ColumnHandler) Delete(c echo.Context) error {
-	var request dto.DefaultRequestWithProjectHeader
+	var request databaseDto.DropRequest
 	if err := request.BindAndValidate(c); err != nil {
 		return response.UnprocessableResponse(c, err)
 	}
@@ -226,10 +233,15 @@ func (ch *ColumnHandler) Delete(c echo.Context) error {
 		return response.BadRequestResponse(c, err.Error())
 	}
 
-	if _, err := ch.columnService.Delete(columnName, fullTableName, request.ProjectUUID, authUser); err != nil {
+	plan, err := ch.dropService.Column(columnName, fullTableName, databaseDto.ToDropInput(request), authUser)
+	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
 
+	if plan.DryRun || plan.Snapshot != nil {
+		return response.SuccessResponse(c, mapper.ToDropPlanResource(&plan))
+	}
+
 	return response.DeletedResponse(c, nil)
 }
 
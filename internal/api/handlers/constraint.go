@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	databaseDomain "fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type ConstraintHandler struct {
+	constraintService databaseDomain.ConstraintService
+}
+
+func NewConstraintHandler(injector *do.Injector) (*ConstraintHandler, error) {
+	constraintService := do.MustInvoke[databaseDomain.ConstraintService](injector)
+
+	return &ConstraintHandler{constraintService: constraintService}, nil
+}
+
+// List Constraints
+//
+// @Summary List constraints
+// @Description Retrieve primary key, unique, foreign key, check and exclusion constraints of a table.
+// @Tags Constraints
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=[]database.ConstraintResponse} "List of constraints"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/constraints [get]
+func (ch *ConstraintHandler) List(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	constraints, err := ch.constraintService.List(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToConstraintResourceCollection(constraints))
+}
+
+// Show Constraint
+//
+// @Summary Retrieve constraint
+// @Description Retrieve a single constraint of a table, including referential actions and validation state.
+// @Tags Constraints
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param constraintName path string true "Constraint name"
+//
+// @Success 200 {object} response.Response{content=database.ConstraintResponse} "Constraint details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Constraint not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/constraints/{constraintName} [get]
+func (ch *ConstraintHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	constraint, err := ch.constraintService.GetByName(c.Param("constraintName"), fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToConstraintResource(&constraint))
+}
+
+// Store Constraint
+//
+// @Summary Create constraint
+// @Description Add a named primary key, unique, foreign key or check constraint. Composite keys, referential actions, deferrable constraints and NOT VALID are supported.
+// @Tags Constraints
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param constraint body database.CreateConstraintRequest true "Constraint details JSON"
+//
+// @Success 201 {object} response.Response{content=database.ConstraintResponse} "Constraint created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/constraints [post]
+func (ch *ConstraintHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateConstraintRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	constraint, err := ch.constraintService.Create(fullTableName, databaseDto.ToCreateConstraintInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToConstraintResource(&constraint))
+}
+
+// Validate Constraint
+//
+// @Summary Validate constraint
+// @Description Check existing rows against a constraint that was created with NOT VALID.
+// @Tags Constraints
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param constraintName path string true "Constraint name"
+//
+// @Success 200 {object} response.Response{content=database.ConstraintResponse} "Constraint validated"
+// @Failure 400 {object} response.BadRequestErrorResponse "Existing rows violate the constraint"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Constraint not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/constraints/{constraintName}/validate [post]
+func (ch *ConstraintHandler) Validate(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	constraint, err := ch.constraintService.Validate(c.Param("constraintName"), fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToConstraintResource(&constraint))
+}
+
+// Delete Constraint
+//
+// @Summary Delete constraint
+// @Description Drop a named constraint from a table.
+// @Tags Constraints
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param constraintName path string true "Constraint name"
+//
+// @Success 204 "Constraint deleted successfully"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Constraint not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/constraints/{constraintName} [delete]
+func (ch *ConstraintHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	if _, err := ch.constraintService.Delete(c.Param("constraintName"), fullTableName, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	cronDto "fluxend/internal/api/dto/cron"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/cron"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type CronHandler struct {
+	cronService cron.Service
+}
+
+func NewCronHandler(injector *do.Injector) (*CronHandler, error) {
+	cronService := do.MustInvoke[cron.Service](injector)
+
+	return &CronHandler{cronService: cronService}, nil
+}
+
+// List retrieves all cron jobs of a project
+//
+// @Summary List cron jobs
+// @Description Retrieve the scheduled SQL jobs of the specified project
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {array} response.Response{content=[]cron.JobResponse} "List of cron jobs"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs [get]
+func (ch *CronHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	jobs, err := ch.cronService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToCronJobResourceCollection(jobs))
+}
+
+// Store creates a new cron job
+//
+// @Summary Create cron job
+// @Description Schedule a SQL statement with a standard five field cron expression, evaluated in UTC
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param job body cron.CreateRequest true "Name, schedule, command and timeout"
+//
+// @Success 201 {object} response.Response{content=cron.JobResponse} "Cron job created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs [post]
+func (ch *CronHandler) Store(c echo.Context) error {
+	var request cronDto.CreateRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	job, err := ch.cronService.Create(cronDto.ToCreateJobInput(&request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToCronJobResource(&job))
+}
+
+// Pause stops a cron job from being scheduled
+//
+// @Summary Pause cron job
+// @Description Stop scheduling the job, runs that are already going are not interrupted
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param jobUUID path string true "Cron job UUID"
+//
+// @Success 200 {object} response.Response{content=cron.JobResponse} "Cron job paused"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Cron job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs/{jobUUID}/pause [post]
+func (ch *CronHandler) Pause(c echo.Context) error {
+	return ch.setEnabled(c, false)
+}
+
+// Resume schedules a paused cron job again
+//
+// @Summary Resume cron job
+// @Description Schedule the job again from now on, runs missed while paused are not caught up
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param jobUUID path string true "Cron job UUID"
+//
+// @Success 200 {object} response.Response{content=cron.JobResponse} "Cron job resumed"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Cron job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs/{jobUUID}/resume [post]
+func (ch *CronHandler) Resume(c echo.Context) error {
+	return ch.setEnabled(c, true)
+}
+
+// Run triggers a cron job right away
+//
+// @Summary Run cron job now
+// @Description Start a run outside of the schedule. The run is returned while it is going, a run started while the previous one is still going is recorded as skipped
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param jobUUID path string true "Cron job UUID"
+//
+// @Success 202 {object} response.Response{content=cron.RunResponse} "Run started"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Cron job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs/{jobUUID}/run [post]
+func (ch *CronHandler) Run(c echo.Context) error {
+	var request cronDto.JobRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	run, err := ch.cronService.Trigger(request.JobUUID, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.AcceptedResponse(c, mapper.ToCronRunResource(&run))
+}
+
+// ListRuns retrieves the run history of a cron job
+//
+// @Summary List cron job runs
+// @Description Retrieve the latest runs of a job with their status, duration, affected rows and errors, newest first
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param jobUUID path string true "Cron job UUID"
+//
+// @Success 200 {array} response.Response{content=[]cron.RunResponse} "List of runs"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Cron job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs/{jobUUID}/runs [get]
+func (ch *CronHandler) ListRuns(c echo.Context) error {
+	var request cronDto.JobRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	runs, err := ch.cronService.ListRuns(request.JobUUID, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToCronRunResourceCollection(runs))
+}
+
+// Delete removes a cron job and its run history
+//
+// @Summary Delete cron job
+// @Description Permanently delete a cron job together with its run history
+// @Tags Cron
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param jobUUID path string true "Cron job UUID"
+//
+// @Success 204 "Cron job deleted"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Cron job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/cron-jobs/{jobUUID} [delete]
+func (ch *CronHandler) Delete(c echo.Context) error {
+	var request cronDto.JobRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	if _, err := ch.cronService.Delete(request.JobUUID, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+func (ch *CronHandler) setEnabled(c echo.Context, enabled bool) error {
+	var request cronDto.JobRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	job, err := ch.cronService.SetEnabled(request.JobUUID, enabled, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToCronJobResource(&job))
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"net/http"
+)
+
+type DDLHandler struct {
+	ddlService database.DDLService
+}
+
+func NewDDLHandler(injector *do.Injector) (*DDLHandler, error) {
+	ddlService := do.MustInvoke[database.DDLService](injector)
+
+	return &DDLHandler{ddlService: ddlService}, nil
+}
+
+// Table returns the DDL of a table
+//
+// @Summary Table DDL
+// @Description Generate the CREATE TABLE statement of a table with its constraints, indexes, triggers, row level security, comments and grants. Partitioned tables come with their partitions. Format sql downloads the script.
+// @Tags Tables
+//
+// @Accept json
+// @Produce json,plain
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param format query string false "json (default) or sql"
+//
+// @Success 200 {object} response.Response{content=database.DDLResponse} "Table DDL"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/ddl [get]
+func (dh *DDLHandler) Table(c echo.Context) error {
+	var request databaseDto.TableDDLRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	ddl, err := dh.ddlService.Table(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return dh.respond(c, request.Format, fullTableName+".sql", ddl)
+}
+
+// Project returns a schema only dump of a project database
+//
+// @Summary Project DDL
+// @Description Generate the DDL of a project without data: schemas, extensions, types, functions, tables, views, triggers, policies, comments and grants, ordered so the script can run on an empty database. Format sql downloads the script.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json,plain
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param format query string false "json (default) or sql"
+// @Param schema query string false "Comma separated schemas, all user schemas by default"
+//
+// @Success 200 {object} response.Response{content=database.DDLResponse} "Project DDL"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Schema not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schema/ddl [get]
+func (dh *DDLHandler) Project(c echo.Context) error {
+	var request databaseDto.ProjectDDLRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	ddl, err := dh.ddlService.Project(databaseDto.ToProjectDDLInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return dh.respond(c, request.Format, "schema.sql", ddl)
+}
+
+func (dh *DDLHandler) respond(c echo.Context, format, fileName string, ddl database.DDL) error {
+	if format == constants.DDLFormatJSON {
+		return response.SuccessResponse(c, mapper.ToDDLResource(ddl))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+
+	return c.Blob(http.StatusOK, database.ExportContentType(constants.ExportFormatSQL), []byte(ddl.Script()))
+}
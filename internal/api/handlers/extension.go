@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type ExtensionHandler struct {
+	extensionService database.ExtensionService
+}
+
+func NewExtensionHandler(injector *do.Injector) (*ExtensionHandler, error) {
+	extensionService := do.MustInvoke[database.ExtensionService](injector)
+
+	return &ExtensionHandler{extensionService: extensionService}, nil
+}
+
+// List retrieves the extensions of a project
+//
+// @Summary List extensions
+// @Description Retrieve the extensions available on the server, whether they are installed in the project and whether the allowlist lets them be enabled
+// @Tags Extensions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {object} response.Response{content=[]database.ExtensionResponse} "List of extensions"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/extensions [get]
+func (eh *ExtensionHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	extensions, err := eh.extensionService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToExtensionResourceCollection(extensions))
+}
+
+// Enable installs an extension
+//
+// @Summary Enable extension
+// @Description Install an allowlisted extension in the project database, optionally into a given schema. The allowlist is the extensionAllowlist admin setting.
+// @Tags Extensions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param extensionName path string true "Extension name"
+//
+// @Param extension body database.EnableExtensionRequest false "Extension options"
+//
+// @Success 201 {object} response.Response{content=database.ExtensionResponse} "Extension enabled"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Extension not allowed"
+// @Failure 404 {object} response.NotFoundErrorResponse "Extension not available"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/extensions/{extensionName} [post]
+func (eh *ExtensionHandler) Enable(c echo.Context) error {
+	var request databaseDto.EnableExtensionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	extension, err := eh.extensionService.Enable(request.Name, databaseDto.ToEnableExtensionInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToExtensionResource(&extension))
+}
+
+// Disable drops an extension
+//
+// @Summary Disable extension
+// @Description Drop an extension from the project database. Objects using it, such as citext columns, block the drop unless cascade is set, in which case they are dropped too.
+// @Tags Extensions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param extensionName path string true "Extension name"
+// @Param cascade query bool false "Drop objects that depend on the extension"
+//
+// @Success 204 "Extension disabled"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Extension not available"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/extensions/{extensionName} [delete]
+func (eh *ExtensionHandler) Disable(c echo.Context) error {
+	var request databaseDto.DisableExtensionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	if _, err := eh.extensionService.Disable(request.Name, databaseDto.ToDisableExtensionInput(request), authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
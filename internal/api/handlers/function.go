@@ -77,6 +77,7 @@ func (fh *FunctionHandler) List(c echo.Context) error {
 //
 // @Param schema path string true "Schema name"
 // @Param functionName path string true "Function name"
+// @Param args query string false "Comma separated argument types of the overload, e.g. integer,text"
 //
 // @Success 200 {object} response.Response{content=database.FunctionResponse} "Function details"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
@@ -85,7 +86,7 @@ func (fh *FunctionHandler) List(c echo.Context) error {
 //
 // @Router /functions/{schema}/{functionName} [get]
 func (fh *FunctionHandler) Show(c echo.Context) error {
-	var request dto.DefaultRequestWithProjectHeader
+	var request databaseDto.FunctionSignatureRequest
 	if err := request.BindAndValidate(c); err != nil {
 		return response.UnprocessableResponse(c, err)
 	}
@@ -102,7 +103,7 @@ func (fh *FunctionHandler) Show(c echo.Context) error {
 		return response.BadRequestResponse(c, "Function name is required")
 	}
 
-	fetchedFunction, err := fh.functionService.GetByName(functionName, schema, request.ProjectUUID, authUser)
+	fetchedFunction, err := fh.functionService.GetByName(functionName, schema, request.ArgumentTypes, request.ProjectUUID, authUser)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -152,6 +153,101 @@ func (fh *FunctionHandler) Store(c echo.Context) error {
 	return response.CreatedResponse(c, mapper.ToFunctionResource(&createdFunction))
 }
 
+// Update replaces a function
+//
+// @Summary Replace function
+// @Description Create or replace the overload of a function matching the parameter types, other overloads are left untouched. Postgres rejects replacements that change the return type.
+// @Tags Functions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param Header X-Project header string true "Project UUID"
+//
+// @Param schema path string true "Schema name"
+// @Param functionName path string true "Function name"
+// @Param function body database.UpdateFunctionRequest true "Function details"
+//
+// @Success 200 {object} response.Response{content=database.FunctionResponse} "Function replaced"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /functions/{schema}/{functionName} [put]
+func (fh *FunctionHandler) Update(c echo.Context) error {
+	var request databaseDto.UpdateFunctionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	schema := c.Param("schema")
+	if schema == "" {
+		return response.BadRequestResponse(c, "Schema is required")
+	}
+
+	replacedFunction, err := fh.functionService.Replace(schema, databaseDto.ToCreateFunctionInput(request.CreateFunctionRequest), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToFunctionResource(&replacedFunction))
+}
+
+// Execute calls a function
+//
+// @Summary Execute function
+// @Description Call a function with typed JSON arguments and return its result set. The argument types pick the overload. Users below the developer role always run in a read-only transaction, read-only calls are rolled back.
+// @Tags Functions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param Header X-Project header string true "Project UUID"
+//
+// @Param schema path string true "Schema name"
+// @Param functionName path string true "Function name"
+// @Param execute body database.ExecuteFunctionRequest true "Arguments with their types"
+//
+// @Success 200 {object} response.Response{content=database.FunctionResultResponse} "Function result"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /functions/{schema}/{functionName}/execute [post]
+func (fh *FunctionHandler) Execute(c echo.Context) error {
+	var request databaseDto.ExecuteFunctionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	schema := c.Param("schema")
+	if schema == "" {
+		return response.BadRequestResponse(c, "Schema is required")
+	}
+
+	functionName := c.Param("functionName")
+	if functionName == "" {
+		return response.BadRequestResponse(c, "Function name is required")
+	}
+
+	result, err := fh.functionService.Execute(
+		c.Request().Context(), functionName, schema, databaseDto.ToExecuteFunctionInput(request), authUser,
+	)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToFunctionResultResource(&result))
+}
+
 // Delete removes a function
 //
 // @Summary Delete function
@@ -167,15 +263,16 @@ func (fh *FunctionHandler) Store(c echo.Context) error {
 // @Param projectUUID path string true "Project UUID"
 // @Param schema path string true "Schema name"
 // @Param functionName path string true "Function name"
+// @Param args query string false "Comma separated argument types of the overload, e.g. integer,text"
 //
-// @Success 204 "Form deleted"
+// @Success 204 "Function deleted"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
 // @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
 // @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
 //
 // @Router /functions/{schema}/{functionName} [delete]
 func (fh *FunctionHandler) Delete(c echo.Context) error {
-	var request dto.DefaultRequestWithProjectHeader
+	var request databaseDto.FunctionSignatureRequest
 	if err := request.BindAndValidate(c); err != nil {
 		return response.UnprocessableResponse(c, err)
 	}
@@ -192,7 +289,7 @@ func (fh *FunctionHandler) Delete(c echo.Context) error {
 		return response.BadRequestResponse(c, "Function name is required")
 	}
 
-	if _, err := fh.functionService.Delete(schema, functionName, request.ProjectUUID, authUser); err != nil {
+	if _, err := fh.functionService.Delete(functionName, schema, request.ArgumentTypes, request.ProjectUUID, authUser); err != nil {
 		return response.ErrorResponse(c, err)
 	}
 
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fluxend/internal/api/dto"
 	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
 	"fluxend/internal/api/response"
 	databaseDomain "fluxend/internal/domain/database"
 	"fluxend/pkg/auth"
@@ -32,7 +33,7 @@ func NewIndexHandler(injector *do.Injector) (*IndexHandler, error) {
 // @Param Authorization header string true "Bearer Token"
 // @Param tableUUID path string true "Table UUID"
 //
-// @Success 200 {object} response.Response{content=[]dto.GenericResponse} "List of indexes"
+// @Success 200 {object} response.Response{content=[]database.IndexResponse} "List of indexes"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
 // @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
 // @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
@@ -56,7 +57,7 @@ func (ih *IndexHandler) List(c echo.Context) error {
 		return response.ErrorResponse(c, err)
 	}
 
-	return response.SuccessResponse(c, dto.GenericResourceCollection(indexes))
+	return response.SuccessResponse(c, mapper.ToIndexResourceCollection(indexes))
 }
 
 // Show Index
@@ -72,7 +73,7 @@ func (ih *IndexHandler) List(c echo.Context) error {
 // @Param tableUUID path string true "Table UUID"
 // @Param index_name path string true "Index Name"
 //
-// @Success 200 {object} response.Response{content=dto.GenericResponse} "Index details"
+// @Success 200 {object} response.Response{content=database.IndexResponse} "Index details"
 // @Failure 400 "Invalid input"
 // @Failure 401 "Unauthorized"
 // @Failure 404 "Index not found"
@@ -99,13 +100,15 @@ func (ih *IndexHandler) Show(c echo.Context) error {
 		return response.ErrorResponse(c, err)
 	}
 
-	return response.SuccessResponse(c, dto.GenericResource(&index))
+	return response.SuccessResponse(c, mapper.ToIndexResource(&index))
 }
 
 // Store Index
 //
 // @Summary Create index
-// @Description Add an index to a specified table within a project.
+// @Description Add an index to a specified table within a project. Supports btree, hash, gin, gist and brin
+// @Description methods, expression keys, sort order, INCLUDE columns and partial indexes with a WHERE predicate.
+// @Description Concurrent builds run in the background, the response is then a job to poll at /jobs/{jobUUID}.
 // @Tags Indexes
 //
 // @Accept json
@@ -115,7 +118,8 @@ func (ih *IndexHandler) Show(c echo.Context) error {
 // @Param tableUUID path string true "Table UUID"
 // @Param index body database.CreateIndexRequest true "Index details JSON"
 //
-// @Success 201 {object} response.Response{content=dto.GenericResponse} "Index created"
+// @Success 201 {object} response.Response{content=database.IndexResponse} "Index created"
+// @Success 202 {object} response.Response{content=job.Response} "Concurrent index build started"
 // @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
 // @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
@@ -135,12 +139,16 @@ func (ih *IndexHandler) Store(c echo.Context) error {
 		return response.BadRequestResponse(c, "Table name is required")
 	}
 
-	index, err := ih.indexService.Create(fullTableName, databaseDto.ToCreateIndexInput(request), authUser)
+	index, buildJob, err := ih.indexService.Create(fullTableName, databaseDto.ToCreateIndexInput(request), authUser)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
 
-	return response.CreatedResponse(c, dto.GenericResource(index))
+	if buildJob != nil {
+		return response.AcceptedResponse(c, mapper.ToJobResource(buildJob))
+	}
+
+	return response.CreatedResponse(c, mapper.ToIndexResource(&index))
 }
 
 // Delete Index
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/job"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type JobHandler struct {
+	jobService job.Service
+}
+
+func NewJobHandler(injector *do.Injector) (*JobHandler, error) {
+	jobService := do.MustInvoke[job.Service](injector)
+
+	return &JobHandler{jobService: jobService}, nil
+}
+
+// List retrieves all jobs for a project
+//
+// @Summary List jobs
+// @Description Retrieve background jobs of the specified project, newest first
+// @Tags Jobs
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+//
+// @Param type query string false "Only return jobs of this type, e.g. index_build"
+//
+// @Success 200 {array} response.Response{content=[]job.Response} "List of jobs"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /jobs [get]
+func (jh *JobHandler) List(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	jobs, err := jh.jobService.List(request.ProjectUUID, c.QueryParam("type"), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToJobResourceCollection(jobs))
+}
+
+// Show retrieves details of a specific job
+//
+// @Summary Retrieve job
+// @Description Get the status, payload and result of a background job
+// @Tags Jobs
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+//
+// @Param jobUUID path string true "Job UUID"
+//
+// @Success 200 {object} response.Response{content=job.Response} "Job details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Job not found response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /jobs/{jobUUID} [get]
+func (jh *JobHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	jobUUID, err := request.GetUUIDPathParam(c, "jobUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	fetchedJob, err := jh.jobService.GetByUUID(jobUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToJobResource(&fetchedJob))
+}
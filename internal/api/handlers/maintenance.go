@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/maintenance"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type MaintenanceHandler struct {
+	maintenanceService maintenance.Service
+}
+
+func NewMaintenanceHandler(injector *do.Injector) (*MaintenanceHandler, error) {
+	maintenanceService := do.MustInvoke[maintenance.Service](injector)
+
+	return &MaintenanceHandler{maintenanceService: maintenanceService}, nil
+}
+
+// Run Table Maintenance
+//
+// @Summary Run table maintenance
+// @Description Run TRUNCATE (with restartIdentity and cascade), VACUUM (with full and analyze) or REINDEX on a table.
+// @Description The action runs in the background, the response is a job to poll at /jobs/{jobUUID}. Once done the job
+// @Description result holds the table size before and after the action.
+// @Tags Tables
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param maintenance body database.TableMaintenanceRequest true "Action and its options"
+//
+// @Success 202 {object} response.Response{content=job.Response} "Maintenance job started"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/maintenance [post]
+func (mh *MaintenanceHandler) Run(c echo.Context) error {
+	var request databaseDto.TableMaintenanceRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	maintenanceJob, err := mh.maintenanceService.Run(fullTableName, databaseDto.ToTableMaintenanceInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.AcceptedResponse(c, mapper.ToJobResource(&maintenanceJob))
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type MigrationHandler struct {
+	migrationService database.MigrationService
+}
+
+func NewMigrationHandler(injector *do.Injector) (*MigrationHandler, error) {
+	migrationService := do.MustInvoke[database.MigrationService](injector)
+
+	return &MigrationHandler{migrationService: migrationService}, nil
+}
+
+// List retrieves the migration history of a project
+//
+// @Summary List migrations
+// @Description Retrieve recorded and uploaded migrations of a project, ordered by version
+// @Tags Migrations
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {object} response.Response{content=[]database.MigrationResponse} "List of migrations"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/migrations [get]
+func (mh *MigrationHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	migrations, err := mh.migrationService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToMigrationResourceCollection(migrations))
+}
+
+// Store uploads a hand-written migration
+//
+// @Summary Create migration
+// @Description Upload up and down SQL as a pending migration. It is executed by the apply endpoint.
+// @Tags Migrations
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param migration body database.CreateMigrationRequest true "Migration name, up and down SQL"
+//
+// @Success 201 {object} response.Response{content=database.MigrationResponse} "Migration created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/migrations [post]
+func (mh *MigrationHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateMigrationRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	migration, err := mh.migrationService.Create(databaseDto.ToCreateMigrationInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToMigrationResource(&migration))
+}
+
+// Apply runs pending migrations
+//
+// @Summary Apply migrations
+// @Description Apply pending and rolled back migrations in version order, optionally up to a given version
+// @Tags Migrations
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param options body database.ApplyMigrationRequest false "Target version, 0 applies everything"
+//
+// @Success 200 {object} response.Response{content=[]database.MigrationResponse} "Applied migrations"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/migrations/apply [post]
+func (mh *MigrationHandler) Apply(c echo.Context) error {
+	var request databaseDto.ApplyMigrationRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	migrations, err := mh.migrationService.Apply(databaseDto.ToApplyMigrationInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToMigrationResourceCollection(migrations))
+}
+
+// Rollback reverts the latest applied migrations
+//
+// @Summary Roll back migrations
+// @Description Run the down SQL of the latest applied migrations, newest first
+// @Tags Migrations
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param options body database.RollbackMigrationRequest false "Number of migrations to roll back, defaults to 1"
+//
+// @Success 200 {object} response.Response{content=[]database.MigrationResponse} "Rolled back migrations"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/migrations/rollback [post]
+func (mh *MigrationHandler) Rollback(c echo.Context) error {
+	var request databaseDto.RollbackMigrationRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	migrations, err := mh.migrationService.Rollback(databaseDto.ToRollbackMigrationInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToMigrationResourceCollection(migrations))
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	databaseDomain "fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type PartitionHandler struct {
+	partitionService databaseDomain.PartitionService
+}
+
+func NewPartitionHandler(injector *do.Injector) (*PartitionHandler, error) {
+	partitionService := do.MustInvoke[databaseDomain.PartitionService](injector)
+
+	return &PartitionHandler{partitionService: partitionService}, nil
+}
+
+// List Partitions
+//
+// @Summary List partitions
+// @Description Retrieve the partition key, the partitions and the maintenance policy of a partitioned table.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=database.PartitionedTableResponse} "Partitioned table"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions [get]
+func (ph *PartitionHandler) List(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	table, err := ph.partitionService.List(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPartitionedTableResource(&table))
+}
+
+// Store Partition
+//
+// @Summary Create partition
+// @Description Create a partition of a partitioned table. Range partitions take from and to, list partitions take in, hash partitions take modulus and remainder.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param partition body database.CreatePartitionRequest true "Partition name and bounds"
+//
+// @Success 201 {object} response.Response{content=database.PartitionResponse} "Partition created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions [post]
+func (ph *PartitionHandler) Store(c echo.Context) error {
+	var request databaseDto.CreatePartitionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	partition, err := ph.partitionService.Create(fullTableName, databaseDto.ToCreatePartitionInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToPartitionResource(&partition))
+}
+
+// Attach Partition
+//
+// @Summary Attach partition
+// @Description Attach an existing table of the same schema as a partition. Postgres scans the table to check its rows fit the bounds.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param partitionName path string true "Name of the table to attach"
+// @Param bounds body database.AttachPartitionRequest true "Partition bounds"
+//
+// @Success 200 {object} response.Response{content=database.PartitionResponse} "Partition attached"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions/{partitionName}/attach [post]
+func (ph *PartitionHandler) Attach(c echo.Context) error {
+	var request databaseDto.AttachPartitionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	partitionName := c.Param("partitionName")
+	if partitionName == "" {
+		return response.BadRequestResponse(c, "Partition name is required")
+	}
+
+	partition, err := ph.partitionService.Attach(fullTableName, partitionName, databaseDto.ToAttachPartitionInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPartitionResource(&partition))
+}
+
+// Detach Partition
+//
+// @Summary Detach partition
+// @Description Detach a partition, it stays behind as a regular table. Concurrent detaching avoids blocking queries on the parent table.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param partitionName path string true "Partition name"
+// @Param options body database.DetachPartitionRequest false "Detach options"
+//
+// @Success 204 "Partition detached"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Partition not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions/{partitionName}/detach [post]
+func (ph *PartitionHandler) Detach(c echo.Context) error {
+	var request databaseDto.DetachPartitionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	partitionName := c.Param("partitionName")
+	if partitionName == "" {
+		return response.BadRequestResponse(c, "Partition name is required")
+	}
+
+	if _, err := ph.partitionService.Detach(fullTableName, partitionName, databaseDto.ToDetachPartitionInput(request), authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+// UpdatePolicy Partition maintenance
+//
+// @Summary Set partition maintenance
+// @Description Pre-create partitions for the coming intervals and drop the ones older than the retention. Needs a table partitioned by range on a single date or timestamp column, runs right away and then hourly.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param policy body database.UpdatePartitionPolicyRequest true "Interval, premake and retention"
+//
+// @Success 200 {object} response.Response{content=database.PartitionedTableResponse} "Partitioned table"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions/maintenance [put]
+func (ph *PartitionHandler) UpdatePolicy(c echo.Context) error {
+	var request databaseDto.UpdatePartitionPolicyRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	table, err := ph.partitionService.UpdatePolicy(fullTableName, databaseDto.ToUpdatePartitionPolicyInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPartitionedTableResource(&table))
+}
+
+// DeletePolicy Partition maintenance
+//
+// @Summary Remove partition maintenance
+// @Description Stop maintaining the partitions of a table, existing partitions are kept.
+// @Tags Partitions
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+//
+// @Success 204 "Maintenance removed"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/partitions/maintenance [delete]
+func (ph *PartitionHandler) DeletePolicy(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	if _, err := ph.partitionService.DeletePolicy(fullTableName, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
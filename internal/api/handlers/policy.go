@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	databaseDomain "fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type PolicyHandler struct {
+	policyService databaseDomain.PolicyService
+}
+
+func NewPolicyHandler(injector *do.Injector) (*PolicyHandler, error) {
+	policyService := do.MustInvoke[databaseDomain.PolicyService](injector)
+
+	return &PolicyHandler{policyService: policyService}, nil
+}
+
+// ShowRowLevelSecurity Row Level Security
+//
+// @Summary Retrieve row level security
+// @Description Retrieve whether row level security is enabled on a table and whether it also applies to the table owner.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=database.RowLevelSecurityResponse} "Row level security state"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/rls [get]
+func (ph *PolicyHandler) ShowRowLevelSecurity(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	security, err := ph.policyService.GetRowLevelSecurity(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRowLevelSecurityResource(&security))
+}
+
+// UpdateRowLevelSecurity Row Level Security
+//
+// @Summary Update row level security
+// @Description Enable, disable or force row level security on a table. Once enabled, roles only see the rows a policy grants them, so add policies before exposing the table.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param security body database.UpdateRowLevelSecurityRequest true "Row level security JSON"
+//
+// @Success 200 {object} response.Response{content=database.RowLevelSecurityResponse} "Row level security updated"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/rls [put]
+func (ph *PolicyHandler) UpdateRowLevelSecurity(c echo.Context) error {
+	var request databaseDto.UpdateRowLevelSecurityRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	security, err := ph.policyService.UpdateRowLevelSecurity(fullTableName, databaseDto.ToUpdateRowLevelSecurityInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRowLevelSecurityResource(&security))
+}
+
+// List Policies
+//
+// @Summary List policies
+// @Description Retrieve the row level security policies of a table.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=[]database.PolicyResponse} "List of policies"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies [get]
+func (ph *PolicyHandler) List(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	policies, err := ph.policyService.List(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPolicyResourceCollection(policies))
+}
+
+// Show Policy
+//
+// @Summary Retrieve policy
+// @Description Retrieve a single row level security policy of a table.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param policyName path string true "Policy name"
+//
+// @Success 200 {object} response.Response{content=database.PolicyResponse} "Policy details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Policy not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies/{policyName} [get]
+func (ph *PolicyHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	policy, err := ph.policyService.GetByName(c.Param("policyName"), fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPolicyResource(&policy))
+}
+
+// Store Policy
+//
+// @Summary Create policy
+// @Description Add a row level security policy for a command and a list of roles, public applies it to every role. USING filters the rows a role can see, WITH CHECK the rows it can write.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param policy body database.CreatePolicyRequest true "Policy details JSON"
+//
+// @Success 201 {object} response.Response{content=database.PolicyResponse} "Policy created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies [post]
+func (ph *PolicyHandler) Store(c echo.Context) error {
+	var request databaseDto.CreatePolicyRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	policy, err := ph.policyService.Create(fullTableName, databaseDto.ToCreatePolicyInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToPolicyResource(&policy))
+}
+
+// Update Policy
+//
+// @Summary Update policy
+// @Description Replace the command, roles and expressions of a policy in one transaction.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param policyName path string true "Policy name"
+// @Param policy body database.UpdatePolicyRequest true "Policy details JSON"
+//
+// @Success 200 {object} response.Response{content=database.PolicyResponse} "Policy updated"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Policy not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies/{policyName} [put]
+func (ph *PolicyHandler) Update(c echo.Context) error {
+	var request databaseDto.UpdatePolicyRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	policy, err := ph.policyService.Update(c.Param("policyName"), fullTableName, databaseDto.ToUpdatePolicyInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPolicyResource(&policy))
+}
+
+// Delete Policy
+//
+// @Summary Delete policy
+// @Description Drop a row level security policy from a table.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param policyName path string true "Policy name"
+//
+// @Success 204 "Policy deleted successfully"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Policy not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies/{policyName} [delete]
+func (ph *PolicyHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	if _, err := ph.policyService.Delete(c.Param("policyName"), fullTableName, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+// Test Policies
+//
+// @Summary Test policies
+// @Description Run a query as a role with the given JWT claims, the way PostgREST runs end user requests, and return the rows that role gets. Claims are readable through current_setting('request.jwt.claims'). The query defaults to selecting the whole table and is always rolled back. Only web_anon and roles of the project can be tested, the query runs without superuser privileges. Requires permission to update the project.
+// @Tags Policies
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param test body database.TestPolicyRequest true "Role, claims and query JSON"
+//
+// @Success 200 {object} response.Response{content=database.PolicyTestResponse} "Rows visible to the role"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/policies/test [post]
+func (ph *PolicyHandler) Test(c echo.Context) error {
+	var request databaseDto.TestPolicyRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	result, err := ph.policyService.Test(c.Request().Context(), fullTableName, databaseDto.ToTestPolicyInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPolicyTestResource(&result))
+}
@@ -308,7 +308,7 @@ func (ph *ProjectHandler) StoreLogs(c echo.Context) error {
 // GenerateOpenAPI generate OpenAPI docs for project
 //
 // @Summary OpenAPI projects
-// @Description Generate OpenAPI documentation for a project
+// @Description Generate OpenAPI documentation for the tables and views of every exposed schema of a project. Relations outside the default schema are sent with the Accept-Profile or Content-Profile header.
 // @Tags Projects
 //
 // @Accept json
@@ -317,7 +317,7 @@ func (ph *ProjectHandler) StoreLogs(c echo.Context) error {
 // @Param Authorization header string true "Bearer Token"
 // @Param projectUUID path string true "Project UUID"
 //
-// @Param tables query string false "Comma-separated list of tables to include in OpenAPI"
+// @Param tables query string false "Comma-separated list of tables to include in OpenAPI, optionally schema qualified"
 //
 // @Success 200 {object} response.Response "OpenAPI documentation response"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
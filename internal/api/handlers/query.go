@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	queryDto "fluxend/internal/api/dto/query"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/query"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type QueryHandler struct {
+	queryService query.Service
+}
+
+func NewQueryHandler(injector *do.Injector) (*QueryHandler, error) {
+	queryService := do.MustInvoke[query.Service](injector)
+
+	return &QueryHandler{queryService: queryService}, nil
+}
+
+// Execute runs raw SQL against the project database
+//
+// @Summary Execute SQL
+// @Description Run an ad-hoc SQL statement on the project database. Explorer users are limited to read-only transactions that run as a role which can only read tables.
+// @Tags Projects
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param query body query.ExecuteRequest true "SQL statement and execution options"
+//
+// @Success 200 {object} response.Response{content=query.Response} "Query result"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/sql [post]
+func (qh *QueryHandler) Execute(c echo.Context) error {
+	var request queryDto.ExecuteRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	result, err := qh.queryService.Execute(c.Request().Context(), queryDto.ToExecuteInput(&request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToQueryResource(&result))
+}
+
+// Explain returns the query plan for a statement
+//
+// @Summary Explain SQL
+// @Description Return the structured plan of a statement. With analyze=true the statement is executed inside a transaction that is always rolled back. Sequential scans on large tables are flagged and indexes are suggested.
+// @Tags Projects
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param query body query.ExplainRequest true "SQL statement and explain options"
+//
+// @Success 200 {object} response.Response{content=query.PlanResponse} "Query plan"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/sql/explain [post]
+func (qh *QueryHandler) Explain(c echo.Context) error {
+	var request queryDto.ExplainRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	plan, err := qh.queryService.Explain(c.Request().Context(), queryDto.ToExplainInput(&request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToPlanResource(&plan))
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fluxend/internal/api/dto"
+	realtimeDto "fluxend/internal/api/dto/realtime"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/middlewares"
+	"fluxend/internal/api/response"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/realtime"
+	"fluxend/pkg/auth"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"net/http"
+	"time"
+)
+
+type RealtimeHandler struct {
+	realtimeService realtime.Service
+}
+
+func NewRealtimeHandler(injector *do.Injector) (*RealtimeHandler, error) {
+	realtimeService := do.MustInvoke[realtime.Service](injector)
+
+	return &RealtimeHandler{realtimeService: realtimeService}, nil
+}
+
+// ListTables retrieves the tables that publish their changes
+//
+// @Summary List realtime tables
+// @Description Retrieve the tables of the project whose row changes can be subscribed to
+// @Tags Realtime
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+//
+// @Success 200 {array} response.Response{content=[]realtime.TableResponse} "List of realtime tables"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /realtime/tables [get]
+func (rh *RealtimeHandler) ListTables(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	tables, err := rh.realtimeService.ListTables(request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRealtimeTableResourceCollection(tables))
+}
+
+// ShowTable retrieves whether a table publishes its changes
+//
+// @Summary Retrieve realtime state
+// @Description Retrieve whether row changes of a table can be subscribed to
+// @Tags Realtime
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=realtime.TableResponse} "Realtime state"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/realtime [get]
+func (rh *RealtimeHandler) ShowTable(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	table, err := rh.realtimeService.GetTable(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRealtimeTableResource(&table))
+}
+
+// UpdateTable turns publishing of row changes on or off
+//
+// @Summary Update realtime state
+// @Description Enable or disable realtime for a table. Enabling adds a trigger that publishes every inserted, updated and deleted row once its transaction commits.
+// @Tags Realtime
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param realtime body realtime.UpdateTableRequest true "Realtime JSON"
+//
+// @Success 200 {object} response.Response{content=realtime.TableResponse} "Realtime state updated"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/realtime [put]
+func (rh *RealtimeHandler) UpdateTable(c echo.Context) error {
+	var request realtimeDto.UpdateTableRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	table, err := rh.realtimeService.UpdateTable(fullTableName, realtimeDto.ToUpdateTableInput(&request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRealtimeTableResource(&table))
+}
+
+// Stream sends the row changes of a table as server-sent events
+//
+// @Summary Subscribe to changes
+// @Description Stream row changes of a realtime table as server-sent events. Browsers may pass the token and project as access_token and project query parameters. Every change carries an id, reconnecting with Last-Event-ID replays what was missed while it is still buffered, otherwise a resync event asks the client to reload. Subscribers that fall behind are sent a close event and disconnected. Project frontends may subscribe with a project token instead, its role needs select on the table. Their changes are read back as that role with its claims, rows hidden by row level security are left out, deleted rows and the old row of an update only carry the primary key.
+// @Tags Realtime
+//
+// @Produce text/event-stream
+//
+// @Param Authorization header string false "Bearer Token"
+// @Param X-Project header string false "Project UUID"
+// @Param Last-Event-ID header string false "Id of the last event received"
+//
+// @Param table query string true "Table name, e.g. public.orders"
+// @Param events query string false "Comma separated events to receive: INSERT, UPDATE, DELETE"
+// @Param filter query []string false "Row filters like status=eq.shipped, all have to match" collectionFormat(multi)
+//
+// @Success 200 {string} string "Event stream"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /realtime [get]
+func (rh *RealtimeHandler) Stream(c echo.Context) error {
+	var request realtimeDto.SubscribeRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	input := realtimeDto.ToSubscribeInput(&request)
+	if claims, ok := c.Get(middlewares.ProjectClaimsKey).(jwt.MapClaims); ok {
+		access, err := realtimeDto.ToAccess(claims)
+		if err != nil {
+			return response.ErrorResponse(c, err)
+		}
+
+		input.Access = access
+	}
+
+	subscriber, err := rh.realtimeService.Subscribe(input, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	defer rh.realtimeService.Unsubscribe(subscriber)
+
+	stream := c.Response()
+	stream.Header().Set(echo.HeaderContentType, "text/event-stream")
+	stream.Header().Set(echo.HeaderCacheControl, "no-cache")
+	stream.Header().Set(echo.HeaderConnection, "keep-alive")
+	stream.Header().Set("X-Accel-Buffering", "no")
+	stream.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(stream, "retry: %d\n\n", constants.RealtimeClientRetryInMs)
+	stream.Flush()
+
+	heartbeat := time.NewTicker(constants.RealtimeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-subscriber.Done():
+			if subscriber.Reason() != "" {
+				rh.writeEvent(stream, "", constants.RealtimeStreamClose, map[string]string{"reason": subscriber.Reason()})
+			}
+
+			return nil
+		case event := <-subscriber.Events():
+			if err := rh.writeEvent(stream, event.ID, event.Type, event.Change); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			// comments keep proxies from closing an idle stream
+			if _, err := fmt.Fprint(stream, ": ping\n\n"); err != nil {
+				return nil
+			}
+
+			stream.Flush()
+		}
+	}
+}
+
+func (rh *RealtimeHandler) writeEvent(stream *echo.Response, id, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err = fmt.Fprintf(stream, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+
+	if _, err = fmt.Fprintf(stream, "event: %s\ndata: %s\n\n", eventType, payload); err != nil {
+		return err
+	}
+
+	stream.Flush()
+
+	return nil
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type RoleHandler struct {
+	roleService database.RoleService
+}
+
+func NewRoleHandler(injector *do.Injector) (*RoleHandler, error) {
+	roleService := do.MustInvoke[database.RoleService](injector)
+
+	return &RoleHandler{roleService: roleService}, nil
+}
+
+// List retrieves the roles of a project
+//
+// @Summary List roles
+// @Description Retrieve the database roles created for a project along with the roles they are members of
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {object} response.Response{content=[]database.RoleResponse} "List of roles"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles [get]
+func (rh *RoleHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	roles, err := rh.roleService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRoleResourceCollection(roles))
+}
+
+// Show retrieves a single role
+//
+// @Summary Retrieve role
+// @Description Get a database role of a project
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Success 200 {object} response.Response{content=database.RoleResponse} "Role details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName} [get]
+func (rh *RoleHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	role, err := rh.roleService.GetByName(c.Param("roleName"), projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRoleResource(&role))
+}
+
+// Store creates a role
+//
+// @Summary Create role
+// @Description Create a database role PostgREST can switch to, such as anon, authenticated or service. Roles listed in memberOf pass their privileges on when inherit is set.
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param role body database.CreateRoleRequest true "Role definition"
+//
+// @Success 201 {object} response.Response{content=database.RoleResponse} "Role created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles [post]
+func (rh *RoleHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateRoleRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	role, err := rh.roleService.Create(databaseDto.ToCreateRoleInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToRoleResource(&role))
+}
+
+// Delete drops a role
+//
+// @Summary Delete role
+// @Description Drop a role after revoking its privileges, objects it owns are handed over to the project owner
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Success 204 "Role deleted"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName} [delete]
+func (rh *RoleHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	if _, err := rh.roleService.Delete(c.Param("roleName"), projectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+// ListGrants retrieves the privileges of a role
+//
+// @Summary List role grants
+// @Description Retrieve the table, column, schema, function and sequence privileges a role holds. web_anon can be inspected as well.
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Success 200 {object} response.Response{content=[]database.RoleGrantResponse} "List of grants"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName}/grants [get]
+func (rh *RoleHandler) ListGrants(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	grants, err := rh.roleService.ListGrants(c.Param("roleName"), projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRoleGrantResourceCollection(grants))
+}
+
+// Grant gives privileges to a role
+//
+// @Summary Grant privileges
+// @Description Grant privileges on a table, columns of a table, a schema, a function or a sequence. Roles also need USAGE on a schema to reach the objects in it.
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Param grant body database.GrantRequest true "Privileges to grant"
+//
+// @Success 200 {object} response.Response{content=[]database.RoleGrantResponse} "Grants of the role"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName}/grant [post]
+func (rh *RoleHandler) Grant(c echo.Context) error {
+	var request databaseDto.GrantRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	grants, err := rh.roleService.Grant(c.Param("roleName"), databaseDto.ToGrantInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRoleGrantResourceCollection(grants))
+}
+
+// Revoke takes privileges away from a role
+//
+// @Summary Revoke privileges
+// @Description Revoke privileges on a table, columns of a table, a schema, a function or a sequence
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Param revoke body database.GrantRequest true "Privileges to revoke"
+//
+// @Success 200 {object} response.Response{content=[]database.RoleGrantResponse} "Grants of the role"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName}/revoke [post]
+func (rh *RoleHandler) Revoke(c echo.Context) error {
+	var request databaseDto.GrantRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	grants, err := rh.roleService.Revoke(c.Param("roleName"), databaseDto.ToGrantInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToRoleGrantResourceCollection(grants))
+}
+
+// IssueToken signs a PostgREST token for a role
+//
+// @Summary Issue role token
+// @Description Sign a JWT whose role claim makes PostgREST run requests as the given role, extra claims are readable through current_setting('request.jwt.claims'). Expiry defaults to an hour.
+// @Tags Roles
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param roleName path string true "Role name"
+//
+// @Param token body database.IssueRoleTokenRequest true "Token options"
+//
+// @Success 201 {object} response.Response{content=database.RoleTokenResponse} "Token issued"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Role not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/roles/{roleName}/tokens [post]
+func (rh *RoleHandler) IssueToken(c echo.Context) error {
+	var request databaseDto.IssueRoleTokenRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	token, err := rh.roleService.IssueToken(c.Param("roleName"), databaseDto.ToIssueRoleTokenInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToRoleTokenResource(&token))
+}
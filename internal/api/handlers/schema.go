@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type SchemaHandler struct {
+	schemaService database.SchemaService
+}
+
+func NewSchemaHandler(injector *do.Injector) (*SchemaHandler, error) {
+	schemaService := do.MustInvoke[database.SchemaService](injector)
+
+	return &SchemaHandler{schemaService: schemaService}, nil
+}
+
+// List retrieves the schemas of a project
+//
+// @Summary List schemas
+// @Description Retrieve the schemas of the project database with their table count and whether PostgREST exposes them. Postgres' own schemas are left out.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {object} response.Response{content=[]database.SchemaResponse} "List of schemas"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schemas [get]
+func (sh *SchemaHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	schemas, err := sh.schemaService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToSchemaResourceCollection(schemas))
+}
+
+// Store creates a schema
+//
+// @Summary Create schema
+// @Description Create a schema in the project database. New schemas are not exposed through PostgREST until they are added to the exposed schemas.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param schema body database.CreateSchemaRequest true "Schema name"
+//
+// @Success 201 {object} response.Response{content=database.SchemaResponse} "Schema created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schemas [post]
+func (sh *SchemaHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateSchemaRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	schema, err := sh.schemaService.Create(databaseDto.ToCreateSchemaInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToSchemaResource(&schema))
+}
+
+// Rename renames a schema
+//
+// @Summary Rename schema
+// @Description Rename a schema. An exposed schema stays exposed under its new name, which restarts the project's PostgREST.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param schemaName path string true "Schema name"
+//
+// @Param schema body database.RenameSchemaRequest true "New schema name"
+//
+// @Success 200 {object} response.Response{content=database.SchemaResponse} "Schema renamed"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Schema not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schemas/{schemaName} [put]
+func (sh *SchemaHandler) Rename(c echo.Context) error {
+	var request databaseDto.RenameSchemaRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	schema, err := sh.schemaService.Rename(request.CurrentName, databaseDto.ToRenameSchemaInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToSchemaResource(&schema))
+}
+
+// Delete drops a schema
+//
+// @Summary Delete schema
+// @Description Drop a schema. Schemas that still hold objects are only dropped with cascade, which drops those objects too.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param schemaName path string true "Schema name"
+// @Param cascade query bool false "Drop the objects inside the schema"
+//
+// @Success 204 "Schema deleted"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Schema not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schemas/{schemaName} [delete]
+func (sh *SchemaHandler) Delete(c echo.Context) error {
+	var request databaseDto.DropSchemaRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	if _, err := sh.schemaService.Delete(request.Name, databaseDto.ToDropSchemaInput(request), authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+// ListExposed retrieves the schemas PostgREST serves
+//
+// @Summary List exposed schemas
+// @Description Retrieve the schemas the project's PostgREST serves, in order. The first one is used for requests without an Accept-Profile or Content-Profile header.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {object} response.Response{content=database.ExposedSchemasResponse} "Exposed schemas"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/exposed-schemas [get]
+func (sh *SchemaHandler) ListExposed(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	schemas, err := sh.schemaService.ListExposed(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToExposedSchemasResource(schemas))
+}
+
+// UpdateExposed replaces the schemas PostgREST serves
+//
+// @Summary Update exposed schemas
+// @Description Set the schemas the project's PostgREST serves through PGRST_DB_SCHEMA, the first one being the default. PostgREST is restarted to apply the change, roles still need USAGE on the schemas.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param schemas body database.ExposeSchemasRequest true "Exposed schemas in order"
+//
+// @Success 200 {object} response.Response{content=database.ExposedSchemasResponse} "Exposed schemas"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Schema not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/exposed-schemas [put]
+func (sh *SchemaHandler) UpdateExposed(c echo.Context) error {
+	var request databaseDto.ExposeSchemasRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	schemas, err := sh.schemaService.UpdateExposed(databaseDto.ToExposeSchemasInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToExposedSchemasResource(schemas))
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type SchemaDiffHandler struct {
+	schemaDiffService database.SchemaDiffService
+}
+
+func NewSchemaDiffHandler(injector *do.Injector) (*SchemaDiffHandler, error) {
+	schemaDiffService := do.MustInvoke[database.SchemaDiffService](injector)
+
+	return &SchemaDiffHandler{schemaDiffService: schemaDiffService}, nil
+}
+
+// Show compares the schema of two projects
+//
+// @Summary Schema diff
+// @Description Compare tables, columns, constraints, indexes and functions of two projects and return the DDL that makes the target match the source
+// @Tags Schema diff
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Source project UUID"
+// @Param targetProjectUUID path string true "Target project UUID"
+//
+// @Success 200 {object} response.Response{content=database.SchemaDiffResponse} "Schema diff"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schema-diff/{targetProjectUUID} [get]
+func (sh *SchemaDiffHandler) Show(c echo.Context) error {
+	var request databaseDto.SchemaDiffRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	diff, err := sh.schemaDiffService.Diff(databaseDto.ToSchemaDiffInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToSchemaDiffResource(diff))
+}
+
+// Apply promotes the source project schema to the target project
+//
+// @Summary Apply schema diff
+// @Description Recompute the diff and run it against the target project in a single transaction. The change is recorded as a migration of the target project.
+// @Tags Schema diff
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Source project UUID"
+// @Param targetProjectUUID path string true "Target project UUID"
+//
+// @Success 200 {object} response.Response{content=database.SchemaDiffResponse} "Applied schema diff"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schema-diff/{targetProjectUUID}/apply [post]
+func (sh *SchemaDiffHandler) Apply(c echo.Context) error {
+	var request databaseDto.SchemaDiffRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	diff, err := sh.schemaDiffService.Apply(databaseDto.ToSchemaDiffInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToSchemaDiffResource(diff))
+}
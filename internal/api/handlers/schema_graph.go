@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"net/http"
+)
+
+type SchemaGraphHandler struct {
+	schemaGraphService database.SchemaGraphService
+}
+
+func NewSchemaGraphHandler(injector *do.Injector) (*SchemaGraphHandler, error) {
+	schemaGraphService := do.MustInvoke[database.SchemaGraphService](injector)
+
+	return &SchemaGraphHandler{schemaGraphService: schemaGraphService}, nil
+}
+
+// Show returns the entity-relationship graph of a project database
+//
+// @Summary Schema graph
+// @Description Retrieve tables, columns, primary keys, foreign key relations, indexes, views and functions of a project database. Format dbml, mermaid or dot downloads the graph as a diagram source instead.
+// @Tags Schemas
+//
+// @Accept json
+// @Produce json,plain
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param format query string false "json (default), dbml, mermaid or dot"
+// @Param schema query string false "Comma separated schemas, all user schemas by default"
+//
+// @Success 200 {object} response.Response{content=database.SchemaGraphResponse} "Schema graph"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Schema not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/schema/graph [get]
+func (sh *SchemaGraphHandler) Show(c echo.Context) error {
+	var request databaseDto.SchemaGraphRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	graph, err := sh.schemaGraphService.Build(databaseDto.ToSchemaGraphInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	if request.Format == constants.SchemaGraphFormatJSON {
+		return response.SuccessResponse(c, mapper.ToSchemaGraphResource(graph))
+	}
+
+	rendered, err := database.RenderSchemaGraph(graph, request.Format)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "schema."+database.SchemaGraphFileExtension(request.Format)),
+	)
+
+	return c.Blob(http.StatusOK, database.SchemaGraphContentType(request.Format), []byte(rendered))
+}
@@ -6,19 +6,33 @@ import (
 	"fluxend/internal/api/mapper"
 	"fluxend/internal/api/response"
 	"fluxend/internal/domain/database"
+	"fluxend/pkg"
 	"fluxend/pkg/auth"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
+	"net/http"
 )
 
 type TableHandler struct {
-	tableService database.TableService
+	tableService  database.TableService
+	dropService   database.DropService
+	exportService database.ExportService
+	seedService   database.SeedService
 }
 
 func NewTableHandler(injector *do.Injector) (*TableHandler, error) {
 	tableService := do.MustInvoke[database.TableService](injector)
-
-	return &TableHandler{tableService: tableService}, nil
+	dropService := do.MustInvoke[database.DropService](injector)
+	exportService := do.MustInvoke[database.ExportService](injector)
+	seedService := do.MustInvoke[database.SeedService](injector)
+
+	return &TableHandler{
+		tableService:  tableService,
+		dropService:   dropService,
+		exportService: exportService,
+		seedService:   seedService,
+	}, nil
 }
 
 // List retrieves all tables within a project.
@@ -32,6 +46,7 @@ func NewTableHandler(injector *do.Injector) (*TableHandler, error) {
 //
 // @Param Authorization header string true "Bearer Token"
 // @param Header X-Project header string true "Project UUID"
+// @Param schema query string false "Schema name, defaults to public"
 //
 // @Success 200 {object} response.Response{content=[]database.TableResponse} "List of tables"
 // @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
@@ -47,7 +62,12 @@ func (th *TableHandler) List(c echo.Context) error {
 
 	authUser, _ := auth.NewAuth(c).User()
 
-	tables, err := th.tableService.List(request.ProjectUUID, authUser)
+	schema := c.QueryParam("schema")
+	if schema == "" {
+		schema = pkg.DefaultSchema
+	}
+
+	tables, err := th.tableService.List(schema, request.ProjectUUID, authUser)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -257,10 +277,54 @@ func (th *TableHandler) Rename(c echo.Context) error {
 	return response.SuccessResponse(c, mapper.ToTableResource(&renamedTable))
 }
 
-// Delete removes a table permanently from a project.
+// UpdateDescription sets the comment of a table.
+//
+// @Summary Update table description
+// @Description Store a description as the table comment, it shows up in the generated API docs. An empty description removes it.
+// @Tags Tables
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param description body database.UpdateTableDescriptionRequest true "Table description"
+//
+// @Success 200 {object} response.Response{content=database.TableResponse} "Table description updated"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/description [put]
+func (th *TableHandler) UpdateDescription(c echo.Context) error {
+	var request databaseDto.UpdateTableDescriptionRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	updatedTable, err := th.tableService.UpdateDescription(fullTableName, authUser, databaseDto.ToUpdateTableDescriptionInput(request))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTableResource(&updatedTable))
+}
+
+// Delete removes a table from a project.
 //
 // @Summary Delete table
-// @Description Permanently delete a specific table from a given project.
+// @Description Drop a table after checking the views, foreign keys and functions depending on it. A dry run returns the dependencies and the exact SQL without dropping anything, dependents requiring cascade refuse the drop unless cascade is set. A snapshot keeps the table and its rows in the trash for the retention days.
 // @Tags Tables
 //
 // @Accept json
@@ -269,17 +333,22 @@ func (th *TableHandler) Rename(c echo.Context) error {
 // @Param Authorization header string true "Bearer Token"
 // @param Header X-Project header string true "Project UUID"
 //
-// @Param tableUUID path string true "Table UUID"
+// @Param fullTableName path string true "Table name"
+// @Param dryRun query bool false "Only return the plan"
+// @Param cascade query bool false "Also drop dependent objects"
+// @Param snapshot query bool false "Keep a restorable copy in the trash"
+// @Param retentionDays query int false "Days the snapshot is kept, defaults to 7"
 //
+// @Success 200 {object} response.Response{content=database.DropPlanResponse} "Drop plan, returned for dry runs and snapshots"
 // @Success 204 "Table deleted successfully"
-// @Failure 400 "Invalid input"
-// @Failure 401 "Unauthorized"
-// @Failure 404 "Table not found"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Table not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
 //
-// @Router /tables/{tableUUID} [delete]
+// @Router /tables/{fullTableName} [delete]
 func (th *TableHandler) Delete(c echo.Context) error {
-	var request dto.DefaultRequestWithProjectHeader
+	var request databaseDto.DropRequest
 	if err := request.BindAndValidate(c); err != nil {
 		return response.UnprocessableResponse(c, err)
 	}
@@ -291,9 +360,159 @@ func (th *TableHandler) Delete(c echo.Context) error {
 		return response.BadRequestResponse(c, "Table name is required")
 	}
 
-	if _, err := th.tableService.Delete(fullTableName, request.ProjectUUID, authUser); err != nil {
+	plan, err := th.dropService.Table(fullTableName, databaseDto.ToDropInput(request), authUser)
+	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
 
+	if plan.DryRun || plan.Snapshot != nil {
+		return response.SuccessResponse(c, mapper.ToDropPlanResource(&plan))
+	}
+
 	return response.DeletedResponse(c, nil)
 }
+
+// Seed fills a table with generated rows.
+//
+// @Summary Seed table
+// @Description Insert fake rows picked from column names and types. Foreign keys reference randomly sampled parent rows and rows violating a unique constraint are skipped.
+// @Tags Tables
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param count body database.SeedTableRequest true "Number of rows to generate"
+//
+// @Success 201 {object} response.Response{content=database.SeedResponse} "Seed result"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/seed [post]
+func (th *TableHandler) Seed(c echo.Context) error {
+	var request databaseDto.SeedTableRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	result, err := th.seedService.Seed(fullTableName, databaseDto.ToSeedTableInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToSeedResource(result))
+}
+
+// Export streams table rows in the requested format or writes them into a storage container.
+//
+// @Summary Export table
+// @Description Export table rows as csv, json, ndjson or sql. Supports column selection, filters and ordering. With async=true the export is written into a storage container in the background.
+// @Tags Tables
+//
+// @Accept json
+// @Produce text/csv,application/json,application/x-ndjson,application/sql
+//
+// @Param Authorization header string true "Bearer Token"
+// @param Header X-Project header string true "Project UUID"
+//
+// @Param fullTableName path string true "Table name"
+// @Param format query string false "Export format (csv, json, ndjson, sql)"
+// @Param columns query string false "Comma separated list of columns"
+// @Param filter query string false "Filter in column.operator.value form, can be repeated"
+// @Param order query string false "Comma separated list of column.asc or column.desc"
+// @Param async query bool false "Write the export into a storage container"
+// @Param containerUUID query string false "Container UUID, required for async exports"
+//
+// @Success 200 {file} file "Exported rows"
+// @Success 202 {object} response.Response{content=job.Response} "Async export started"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/export [get]
+func (th *TableHandler) Export(c echo.Context) error {
+	var request databaseDto.ExportTableRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	if request.Async {
+		exportJob, err := th.exportService.ExportToContainer(fullTableName, databaseDto.ToExportTableInput(request), authUser)
+		if err != nil {
+			return response.ErrorResponse(c, err)
+		}
+
+		return response.AcceptedResponse(c, mapper.ToJobResource(&exportJob))
+	}
+
+	writer := &exportResponseWriter{
+		context:     c,
+		contentType: database.ExportContentType(request.Format),
+		fileName:    fmt.Sprintf("%s.%s", fullTableName, request.Format),
+	}
+
+	err := th.exportService.Export(c.Request().Context(), fullTableName, databaseDto.ToExportTableInput(request), writer, authUser)
+	if err != nil {
+		// once rows are on the wire the status can no longer change, so the stream is simply cut short
+		if writer.started {
+			return nil
+		}
+
+		return response.ErrorResponse(c, err)
+	}
+
+	if !writer.started {
+		writer.start()
+	}
+
+	return nil
+}
+
+// exportResponseWriter delays sending headers until the first chunk arrives,
+// so failures before that point still produce a regular error response
+type exportResponseWriter struct {
+	context     echo.Context
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (w *exportResponseWriter) start() {
+	res := w.context.Response()
+	res.Header().Set(echo.HeaderContentType, w.contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.fileName))
+	res.WriteHeader(http.StatusOK)
+
+	w.started = true
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+
+	n, err := w.context.Response().Write(p)
+	w.context.Response().Flush()
+
+	return n, err
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	databaseDomain "fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type TrashHandler struct {
+	trashService databaseDomain.TrashService
+}
+
+func NewTrashHandler(injector *do.Injector) (*TrashHandler, error) {
+	trashService := do.MustInvoke[databaseDomain.TrashService](injector)
+
+	return &TrashHandler{trashService: trashService}, nil
+}
+
+// List retrieves the snapshots of dropped tables and columns
+//
+// @Summary List trash
+// @Description Retrieve the tables and columns dropped with a snapshot that can still be restored
+// @Tags Trash
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Success 200 {array} response.Response{content=[]database.TrashSnapshotResponse} "List of snapshots"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 403 {object} response.ForbiddenErrorResponse "Forbidden response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/trash [get]
+func (th *TrashHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	snapshots, err := th.trashService.List(projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTrashSnapshotResourceCollection(snapshots))
+}
+
+// Restore brings a dropped table or column back
+//
+// @Summary Restore from trash
+// @Description Recreate the dropped table or column and copy its rows back. Objects dropped along with cascade are not restored.
+// @Tags Trash
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param snapshotUUID path string true "Snapshot UUID"
+//
+// @Success 200 {object} response.Response{content=database.TrashSnapshotResponse} "Restored snapshot"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Snapshot not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/trash/{snapshotUUID}/restore [post]
+func (th *TrashHandler) Restore(c echo.Context) error {
+	var request databaseDto.TrashSnapshotRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	snapshot, err := th.trashService.Restore(request.SnapshotUUID, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTrashSnapshotResource(&snapshot))
+}
+
+// Delete purges a snapshot before it expires
+//
+// @Summary Purge from trash
+// @Description Drop the snapshot of a table or column for good
+// @Tags Trash
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param snapshotUUID path string true "Snapshot UUID"
+//
+// @Success 204 "Snapshot purged"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Snapshot not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/trash/{snapshotUUID} [delete]
+func (th *TrashHandler) Delete(c echo.Context) error {
+	var request databaseDto.TrashSnapshotRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	if _, err := th.trashService.Delete(request.SnapshotUUID, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
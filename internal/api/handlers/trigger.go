@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	databaseDomain "fluxend/internal/domain/database"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type TriggerHandler struct {
+	triggerService databaseDomain.TriggerService
+}
+
+func NewTriggerHandler(injector *do.Injector) (*TriggerHandler, error) {
+	triggerService := do.MustInvoke[databaseDomain.TriggerService](injector)
+
+	return &TriggerHandler{triggerService: triggerService}, nil
+}
+
+// List Triggers
+//
+// @Summary List triggers
+// @Description Retrieve the triggers of a table with their timing, events, condition and function.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+//
+// @Success 200 {object} response.Response{content=[]database.TriggerResponse} "List of triggers"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers [get]
+func (th *TriggerHandler) List(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	triggers, err := th.triggerService.List(fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTriggerResourceCollection(triggers))
+}
+
+// Show Trigger
+//
+// @Summary Retrieve trigger
+// @Description Retrieve a single trigger of a table, including whether it is enabled.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param triggerName path string true "Trigger name"
+//
+// @Success 200 {object} response.Response{content=database.TriggerResponse} "Trigger details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Trigger not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers/{triggerName} [get]
+func (th *TriggerHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	trigger, err := th.triggerService.GetByName(c.Param("triggerName"), fullTableName, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTriggerResource(&trigger))
+}
+
+// Store Trigger
+//
+// @Summary Create trigger
+// @Description Attach a trigger function to a table. Triggers fire BEFORE, AFTER or INSTEAD OF the given events, per row (the default) or per statement, optionally only when a condition holds. The function must take no arguments and return trigger.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param trigger body database.CreateTriggerRequest true "Trigger details JSON"
+//
+// @Success 201 {object} response.Response{content=database.TriggerResponse} "Trigger created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers [post]
+func (th *TriggerHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateTriggerRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	trigger, err := th.triggerService.Create(fullTableName, databaseDto.ToCreateTriggerInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToTriggerResource(&trigger))
+}
+
+// Enable Trigger
+//
+// @Summary Enable trigger
+// @Description Enable a disabled trigger so it fires again.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param triggerName path string true "Trigger name"
+//
+// @Success 200 {object} response.Response{content=database.TriggerResponse} "Trigger enabled"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Trigger not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers/{triggerName}/enable [post]
+func (th *TriggerHandler) Enable(c echo.Context) error {
+	return th.setEnabled(c, true)
+}
+
+// Disable Trigger
+//
+// @Summary Disable trigger
+// @Description Stop a trigger from firing without dropping it.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param triggerName path string true "Trigger name"
+//
+// @Success 200 {object} response.Response{content=database.TriggerResponse} "Trigger disabled"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Trigger not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers/{triggerName}/disable [post]
+func (th *TriggerHandler) Disable(c echo.Context) error {
+	return th.setEnabled(c, false)
+}
+
+// Delete Trigger
+//
+// @Summary Delete trigger
+// @Description Drop a trigger from a table, the trigger function is kept.
+// @Tags Triggers
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Project header string true "Project UUID"
+// @Param fullTableName path string true "Table name"
+// @Param triggerName path string true "Trigger name"
+//
+// @Success 204 "Trigger deleted successfully"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Trigger not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /tables/{fullTableName}/triggers/{triggerName} [delete]
+func (th *TriggerHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	if _, err := th.triggerService.Delete(c.Param("triggerName"), fullTableName, request.ProjectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+func (th *TriggerHandler) setEnabled(c echo.Context, enabled bool) error {
+	var request dto.DefaultRequestWithProjectHeader
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	fullTableName := c.Param("fullTableName")
+	if fullTableName == "" {
+		return response.BadRequestResponse(c, "Table name is required")
+	}
+
+	trigger, err := th.triggerService.SetEnabled(c.Param("triggerName"), fullTableName, enabled, request.ProjectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTriggerResource(&trigger))
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type TypeHandler struct {
+	typeService database.TypeService
+}
+
+func NewTypeHandler(injector *do.Injector) (*TypeHandler, error) {
+	typeService := do.MustInvoke[database.TypeService](injector)
+
+	return &TypeHandler{typeService: typeService}, nil
+}
+
+// List retrieves the enum and composite types of a schema
+//
+// @Summary List types
+// @Description Retrieve the enum and composite types defined in a schema, enum values are listed in sort order
+// @Tags Types
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param schema query string false "Schema name, defaults to public"
+//
+// @Success 200 {object} response.Response{content=[]database.TypeResponse} "List of types"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/types [get]
+func (th *TypeHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	schema := c.QueryParam("schema")
+	if schema == "" {
+		schema = pkg.DefaultSchema
+	}
+
+	types, err := th.typeService.List(schema, projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTypeResourceCollection(types))
+}
+
+// Show retrieves a single type
+//
+// @Summary Retrieve type
+// @Description Get the values or attributes of an enum or composite type
+// @Tags Types
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullTypeName path string true "Type name, optionally schema qualified"
+//
+// @Success 200 {object} response.Response{content=database.TypeResponse} "Type details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Type not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/types/{fullTypeName} [get]
+func (th *TypeHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	fetchedType, err := th.typeService.GetByName(c.Param("fullTypeName"), projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTypeResource(&fetchedType))
+}
+
+// Store creates an enum or composite type
+//
+// @Summary Create type
+// @Description Create an enum with its values or a composite type with its attributes. The type can then be used as a column type by its schema qualified name.
+// @Tags Types
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param type body database.CreateTypeRequest true "Type definition"
+//
+// @Success 201 {object} response.Response{content=database.TypeResponse} "Type created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/types [post]
+func (th *TypeHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateTypeRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	createdType, err := th.typeService.Create(databaseDto.ToCreateTypeInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToTypeResource(&createdType))
+}
+
+// Update adds or renames an enum value
+//
+// @Summary Alter enum type
+// @Description Add a value, optionally before or after an existing one, or rename a value. Values cannot be removed from an enum.
+// @Tags Types
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullTypeName path string true "Type name, optionally schema qualified"
+//
+// @Param change body database.AlterTypeRequest true "Value to add or rename"
+//
+// @Success 200 {object} response.Response{content=database.TypeResponse} "Type altered"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Type not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/types/{fullTypeName} [put]
+func (th *TypeHandler) Update(c echo.Context) error {
+	var request databaseDto.AlterTypeRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	alteredType, err := th.typeService.Alter(c.Param("fullTypeName"), databaseDto.ToAlterTypeInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToTypeResource(&alteredType))
+}
+
+// Delete drops a type
+//
+// @Summary Delete type
+// @Description Drop an enum or composite type. Types still used by columns cannot be dropped.
+// @Tags Types
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullTypeName path string true "Type name, optionally schema qualified"
+//
+// @Success 204 "Type deleted"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "Type not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/types/{fullTypeName} [delete]
+func (th *TypeHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	if _, err := th.typeService.Delete(c.Param("fullTypeName"), projectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
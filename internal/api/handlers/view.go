@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"fluxend/internal/api/dto"
+	databaseDto "fluxend/internal/api/dto/database"
+	"fluxend/internal/api/mapper"
+	"fluxend/internal/api/response"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg"
+	"fluxend/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+type ViewHandler struct {
+	viewService database.ViewService
+}
+
+func NewViewHandler(injector *do.Injector) (*ViewHandler, error) {
+	viewService := do.MustInvoke[database.ViewService](injector)
+
+	return &ViewHandler{viewService: viewService}, nil
+}
+
+// List retrieves the views of a schema
+//
+// @Summary List views
+// @Description Retrieve the views and materialized views defined in a schema along with their definitions
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param schema query string false "Schema name, defaults to public"
+//
+// @Success 200 {object} response.Response{content=[]database.ViewResponse} "List of views"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views [get]
+func (vh *ViewHandler) List(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	schema := c.QueryParam("schema")
+	if schema == "" {
+		schema = pkg.DefaultSchema
+	}
+
+	views, err := vh.viewService.List(schema, projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToViewResourceCollection(views))
+}
+
+// Show retrieves a single view
+//
+// @Summary Retrieve view
+// @Description Get the definition of a view, materialized views also include their refresh schedule
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullViewName path string true "View name, optionally schema qualified"
+//
+// @Success 200 {object} response.Response{content=database.ViewResponse} "View details"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "View not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views/{fullViewName} [get]
+func (vh *ViewHandler) Show(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	view, err := vh.viewService.GetByName(c.Param("fullViewName"), projectUUID, authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToViewResource(&view))
+}
+
+// Store creates a view or materialized view
+//
+// @Summary Create view
+// @Description Create a view from a SELECT query. Materialized views can skip the initial data load and be given a refresh schedule.
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+//
+// @Param view body database.CreateViewRequest true "View definition"
+//
+// @Success 201 {object} response.Response{content=database.ViewResponse} "View created"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views [post]
+func (vh *ViewHandler) Store(c echo.Context) error {
+	var request databaseDto.CreateViewRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	view, err := vh.viewService.Create(databaseDto.ToCreateViewInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.CreatedResponse(c, mapper.ToViewResource(&view))
+}
+
+// Update replaces the query of a view
+//
+// @Summary Replace view
+// @Description Replace the query behind a view. Materialized views are dropped and recreated, so their indexes have to be created again.
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullViewName path string true "View name, optionally schema qualified"
+//
+// @Param view body database.ReplaceViewRequest true "New view definition"
+//
+// @Success 200 {object} response.Response{content=database.ViewResponse} "View replaced"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "View not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views/{fullViewName} [put]
+func (vh *ViewHandler) Update(c echo.Context) error {
+	var request databaseDto.ReplaceViewRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	view, err := vh.viewService.Replace(c.Param("fullViewName"), databaseDto.ToReplaceViewInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToViewResource(&view))
+}
+
+// Delete drops a view
+//
+// @Summary Delete view
+// @Description Drop a view or materialized view along with its refresh schedule. Views other views depend on cannot be dropped.
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullViewName path string true "View name, optionally schema qualified"
+//
+// @Success 204 "View deleted"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "View not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views/{fullViewName} [delete]
+func (vh *ViewHandler) Delete(c echo.Context) error {
+	var request dto.DefaultRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	projectUUID, err := request.GetUUIDPathParam(c, "projectUUID", true)
+	if err != nil {
+		return response.BadRequestResponse(c, err.Error())
+	}
+
+	if _, err := vh.viewService.Delete(c.Param("fullViewName"), projectUUID, authUser); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.DeletedResponse(c, nil)
+}
+
+// Refresh reloads the data of a materialized view
+//
+// @Summary Refresh materialized view
+// @Description Re-run the query of a materialized view. A concurrent refresh keeps the view readable but needs a populated view with a unique index.
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullViewName path string true "View name, optionally schema qualified"
+//
+// @Param refresh body database.RefreshViewRequest true "Refresh options"
+//
+// @Success 200 {object} response.Response{content=database.ViewResponse} "View refreshed"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "View not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views/{fullViewName}/refresh [post]
+func (vh *ViewHandler) Refresh(c echo.Context) error {
+	var request databaseDto.RefreshViewRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	view, err := vh.viewService.Refresh(c.Param("fullViewName"), databaseDto.ToRefreshViewInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToViewResource(&view))
+}
+
+// Schedule sets the refresh schedule of a materialized view
+//
+// @Summary Schedule materialized view refresh
+// @Description Refresh a materialized view every given number of minutes, an interval of zero removes the schedule
+// @Tags Views
+//
+// @Accept json
+// @Produce json
+//
+// @Param Authorization header string true "Bearer Token"
+// @Param projectUUID path string true "Project UUID"
+// @Param fullViewName path string true "View name, optionally schema qualified"
+//
+// @Param schedule body database.ScheduleViewRefreshRequest true "Refresh schedule"
+//
+// @Success 200 {object} response.Response{content=database.ViewResponse} "Schedule updated"
+// @Failure 422 {object} response.UnprocessableErrorResponse "Unprocessable input response"
+// @Failure 400 {object} response.BadRequestErrorResponse "Bad request response"
+// @Failure 401 {object} response.UnauthorizedErrorResponse "Unauthorized response"
+// @Failure 404 {object} response.NotFoundErrorResponse "View not found"
+// @Failure 500 {object} response.InternalServerErrorResponse "Internal server error response"
+//
+// @Router /projects/{projectUUID}/views/{fullViewName}/schedule [put]
+func (vh *ViewHandler) Schedule(c echo.Context) error {
+	var request databaseDto.ScheduleViewRefreshRequest
+	if err := request.BindAndValidate(c); err != nil {
+		return response.UnprocessableResponse(c, err)
+	}
+
+	authUser, _ := auth.NewAuth(c).User()
+
+	view, err := vh.viewService.Schedule(c.Param("fullViewName"), databaseDto.ToScheduleViewRefreshInput(request), authUser)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, mapper.ToViewResource(&view))
+}
@@ -15,6 +15,7 @@ func ToColumnResource(column *databaseDomain.Column) databaseDto.ColumnResponse
 		Primary:         column.Primary,
 		Unique:          column.Unique,
 		Foreign:         column.Foreign,
+		Description:     column.Description,
 		ReferenceTable:  column.ReferenceTable,
 		ReferenceColumn: column.ReferenceColumn,
 	}
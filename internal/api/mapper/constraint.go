@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToConstraintResource(constraint *databaseDomain.Constraint) databaseDto.ConstraintResponse {
+	return databaseDto.ConstraintResponse{
+		Name:              constraint.Name,
+		Type:              constraint.Type,
+		Definition:        constraint.Definition,
+		Columns:           constraint.Columns,
+		ReferenceTable:    constraint.ReferenceTable,
+		ReferenceColumns:  constraint.ReferenceColumns,
+		OnDelete:          constraint.OnDelete,
+		OnUpdate:          constraint.OnUpdate,
+		Deferrable:        constraint.Deferrable,
+		InitiallyDeferred: constraint.InitiallyDeferred,
+		Validated:         constraint.Validated,
+	}
+}
+
+func ToConstraintResourceCollection(constraints []databaseDomain.Constraint) []databaseDto.ConstraintResponse {
+	resourceConstraints := make([]databaseDto.ConstraintResponse, len(constraints))
+	for i, currentConstraint := range constraints {
+		resourceConstraints[i] = ToConstraintResource(&currentConstraint)
+	}
+
+	return resourceConstraints
+}
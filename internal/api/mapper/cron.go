@@ -0,0 +1,56 @@
+package mapper
+
+import (
+	cronDto "fluxend/internal/api/dto/cron"
+	"fluxend/internal/domain/cron"
+)
+
+func ToCronJobResource(job *cron.Job) cronDto.JobResponse {
+	return cronDto.JobResponse{
+		Uuid:        job.Uuid,
+		ProjectUuid: job.ProjectUuid,
+		Name:        job.Name,
+		Schedule:    job.Schedule,
+		Command:     job.Command,
+		TimeoutInMs: job.TimeoutInMs,
+		Enabled:     job.Enabled,
+		NextRunAt:   job.NextRunAt.Format("2006-01-02 15:04:05"),
+		LastRunAt:   formatOptionalTime(job.LastRunAt),
+		CreatedBy:   job.CreatedBy,
+		UpdatedBy:   job.UpdatedBy,
+		CreatedAt:   job.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   job.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func ToCronJobResourceCollection(jobs []cron.Job) []cronDto.JobResponse {
+	resourceJobs := make([]cronDto.JobResponse, len(jobs))
+	for i, job := range jobs {
+		resourceJobs[i] = ToCronJobResource(&job)
+	}
+
+	return resourceJobs
+}
+
+func ToCronRunResource(run *cron.Run) cronDto.RunResponse {
+	return cronDto.RunResponse{
+		Uuid:         run.Uuid,
+		JobUuid:      run.JobUuid,
+		Trigger:      run.Trigger,
+		Status:       run.Status,
+		RowsAffected: run.RowsAffected,
+		DurationMs:   run.DurationMs,
+		Error:        run.Error,
+		StartedAt:    run.StartedAt.Format("2006-01-02 15:04:05"),
+		FinishedAt:   formatOptionalTime(run.FinishedAt),
+	}
+}
+
+func ToCronRunResourceCollection(runs []cron.Run) []cronDto.RunResponse {
+	resourceRuns := make([]cronDto.RunResponse, len(runs))
+	for i, run := range runs {
+		resourceRuns[i] = ToCronRunResource(&run)
+	}
+
+	return resourceRuns
+}
@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToDDLResource(ddl databaseDomain.DDL) databaseDto.DDLResponse {
+	return databaseDto.DDLResponse{
+		Statements: ddl.Statements,
+		Script:     ddl.Script(),
+	}
+}
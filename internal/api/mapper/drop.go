@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToDropPlanResource(plan *databaseDomain.DropPlan) databaseDto.DropPlanResponse {
+	var snapshot *databaseDto.TrashSnapshotResponse
+	if plan.Snapshot != nil {
+		resource := ToTrashSnapshotResource(plan.Snapshot)
+		snapshot = &resource
+	}
+
+	return databaseDto.DropPlanResponse{
+		Object:               plan.Object,
+		DryRun:               plan.DryRun,
+		Cascade:              plan.Cascade,
+		RequiresCascade:      plan.RequiresCascade(),
+		Dependencies:         toDependencyResources(plan.Dependencies),
+		ReferencingFunctions: toDependencyResources(plan.ReferencingFunctions),
+		Statements:           plan.Statements,
+		Snapshot:             snapshot,
+		Warning:              plan.Warning,
+	}
+}
+
+func ToTrashSnapshotResource(snapshot *databaseDomain.TrashSnapshot) databaseDto.TrashSnapshotResponse {
+	return databaseDto.TrashSnapshotResponse{
+		Uuid:       snapshot.Uuid,
+		Kind:       snapshot.Kind,
+		Schema:     snapshot.Schema,
+		TableName:  snapshot.TableName,
+		ColumnName: snapshot.ColumnName,
+		TrashTable: snapshot.TrashTable,
+		ExpiresAt:  snapshot.ExpiresAt,
+		CreatedBy:  snapshot.CreatedBy,
+		CreatedAt:  snapshot.CreatedAt,
+	}
+}
+
+func ToTrashSnapshotResourceCollection(snapshots []databaseDomain.TrashSnapshot) []databaseDto.TrashSnapshotResponse {
+	resources := make([]databaseDto.TrashSnapshotResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		resources[i] = ToTrashSnapshotResource(&snapshot)
+	}
+
+	return resources
+}
+
+func toDependencyResources(dependencies []databaseDomain.Dependency) []databaseDto.DependencyResponse {
+	resources := make([]databaseDto.DependencyResponse, len(dependencies))
+	for i, dependency := range dependencies {
+		resources[i] = databaseDto.DependencyResponse{
+			Type:            dependency.Type,
+			Schema:          dependency.Schema,
+			Identity:        dependency.Identity,
+			DependsOn:       dependency.DependsOn,
+			Depth:           dependency.Depth,
+			RequiresCascade: dependency.RequiresCascade,
+		}
+	}
+
+	return resources
+}
@@ -7,11 +7,15 @@ import (
 
 func ToFunctionResource(function *databaseDomain.Function) databaseDto.FunctionResponse {
 	return databaseDto.FunctionResponse{
-		Name:       function.Name,
-		Type:       function.Type,
-		DataType:   function.DataType,
-		Definition: function.Definition,
-		Language:   function.Language,
+		Name:            function.Name,
+		Type:            function.Type,
+		DataType:        function.DataType,
+		Arguments:       function.Arguments,
+		Definition:      function.Definition,
+		Language:        function.Language,
+		Volatility:      function.Volatility,
+		SecurityDefiner: function.SecurityDefiner,
+		SearchPath:      function.SearchPath,
 	}
 }
 
@@ -23,3 +27,14 @@ func ToFunctionResourceCollection(functions []databaseDomain.Function) []databas
 
 	return resourceFunctions
 }
+
+func ToFunctionResultResource(result *databaseDomain.FunctionResult) databaseDto.FunctionResultResponse {
+	return databaseDto.FunctionResultResponse{
+		Columns:    result.Columns,
+		Rows:       result.Rows,
+		RowCount:   result.RowCount,
+		Truncated:  result.Truncated,
+		ReadOnly:   result.ReadOnly,
+		DurationMs: result.DurationMs,
+	}
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToIndexResource(index *databaseDomain.Index) databaseDto.IndexResponse {
+	columns := make([]databaseDto.IndexColumnResponse, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = databaseDto.IndexColumnResponse{
+			Name:         column.Name,
+			IsExpression: column.IsExpression,
+			Order:        column.Order,
+			Nulls:        column.Nulls,
+		}
+	}
+
+	include := []string(index.Include)
+	if include == nil {
+		include = []string{}
+	}
+
+	return databaseDto.IndexResponse{
+		Name:       index.Name,
+		Definition: index.Definition,
+		Method:     index.Method,
+		IsUnique:   index.IsUnique,
+		IsPrimary:  index.IsPrimary,
+		IsValid:    index.IsValid,
+		Predicate:  index.Predicate,
+		Include:    include,
+		SizeBytes:  index.SizeBytes,
+		Columns:    columns,
+	}
+}
+
+func ToIndexResourceCollection(indexes []databaseDomain.Index) []databaseDto.IndexResponse {
+	resourceIndexes := make([]databaseDto.IndexResponse, len(indexes))
+	for i, currentIndex := range indexes {
+		resourceIndexes[i] = ToIndexResource(&currentIndex)
+	}
+
+	return resourceIndexes
+}
@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	jobDto "fluxend/internal/api/dto/job"
+	"fluxend/internal/domain/job"
+	"time"
+)
+
+func ToJobResource(job *job.Job) jobDto.Response {
+	return jobDto.Response{
+		Uuid:        job.Uuid,
+		ProjectUuid: job.ProjectUuid,
+		Type:        job.Type,
+		Status:      job.Status,
+		Payload:     job.Payload,
+		Result:      job.Result,
+		Error:       job.Error,
+		CreatedBy:   job.CreatedBy,
+		CreatedAt:   job.CreatedAt.Format("2006-01-02 15:04:05"),
+		StartedAt:   formatOptionalTime(job.StartedAt),
+		FinishedAt:  formatOptionalTime(job.FinishedAt),
+	}
+}
+
+func ToJobResourceCollection(jobs []job.Job) []jobDto.Response {
+	resourceJobs := make([]jobDto.Response, len(jobs))
+	for i, currentJob := range jobs {
+		resourceJobs[i] = ToJobResource(&currentJob)
+	}
+
+	return resourceJobs
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToMigrationResource(migration *databaseDomain.Migration) databaseDto.MigrationResponse {
+	return databaseDto.MigrationResponse{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Up:        migration.UpSQL,
+		Down:      migration.DownSQL,
+		Source:    migration.Source,
+		Status:    migration.Status,
+		CreatedAt: migration.CreatedAt,
+		AppliedAt: migration.AppliedAt,
+	}
+}
+
+func ToMigrationResourceCollection(migrations []databaseDomain.Migration) []databaseDto.MigrationResponse {
+	resourceMigrations := make([]databaseDto.MigrationResponse, len(migrations))
+	for i, currentMigration := range migrations {
+		resourceMigrations[i] = ToMigrationResource(&currentMigration)
+	}
+
+	return resourceMigrations
+}
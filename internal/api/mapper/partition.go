@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToPartitionResource(partition *databaseDomain.Partition) databaseDto.PartitionResponse {
+	return databaseDto.PartitionResponse{
+		Schema:        partition.Schema,
+		Name:          partition.Name,
+		FullName:      partition.FullName(),
+		Bounds:        partition.Bounds,
+		Partitioned:   partition.Partitioned,
+		EstimatedRows: partition.EstimatedRows,
+		TotalSize:     partition.TotalSize,
+	}
+}
+
+func ToPartitionedTableResource(table *databaseDomain.PartitionedTable) databaseDto.PartitionedTableResponse {
+	partitions := make([]databaseDto.PartitionResponse, len(table.Partitions))
+	for i, partition := range table.Partitions {
+		partitions[i] = ToPartitionResource(&partition)
+	}
+
+	var policy *databaseDto.PartitionPolicyResponse
+	if table.Policy != nil {
+		policy = &databaseDto.PartitionPolicyResponse{
+			Interval:  table.Policy.Interval,
+			Premake:   table.Policy.Premake,
+			Retention: table.Policy.Retention,
+			NextRunAt: table.Policy.NextRunAt,
+			LastRunAt: table.Policy.LastRunAt,
+			LastError: table.Policy.LastError,
+		}
+	}
+
+	return databaseDto.PartitionedTableResponse{
+		Schema:        table.Schema,
+		Name:          table.Name,
+		Strategy:      table.Key.Strategy,
+		Columns:       table.Key.Columns,
+		KeyDefinition: table.Key.Definition,
+		Partitions:    partitions,
+		Policy:        policy,
+	}
+}
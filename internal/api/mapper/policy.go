@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToPolicyResource(policy *databaseDomain.Policy) databaseDto.PolicyResponse {
+	return databaseDto.PolicyResponse{
+		Name:        policy.Name,
+		Command:     policy.Command,
+		Restrictive: !policy.Permissive,
+		Roles:       policy.Roles,
+		Using:       policy.Using,
+		WithCheck:   policy.WithCheck,
+	}
+}
+
+func ToPolicyResourceCollection(policies []databaseDomain.Policy) []databaseDto.PolicyResponse {
+	resourcePolicies := make([]databaseDto.PolicyResponse, len(policies))
+	for i, currentPolicy := range policies {
+		resourcePolicies[i] = ToPolicyResource(&currentPolicy)
+	}
+
+	return resourcePolicies
+}
+
+func ToRowLevelSecurityResource(security *databaseDomain.RowLevelSecurity) databaseDto.RowLevelSecurityResponse {
+	return databaseDto.RowLevelSecurityResponse{
+		Enabled: security.Enabled,
+		Forced:  security.Forced,
+	}
+}
+
+func ToPolicyTestResource(result *databaseDomain.PolicyTestResult) databaseDto.PolicyTestResponse {
+	return databaseDto.PolicyTestResponse{
+		Role:      result.Role,
+		Columns:   result.Columns,
+		Rows:      result.Rows,
+		RowCount:  result.RowCount,
+		Truncated: result.Truncated,
+	}
+}
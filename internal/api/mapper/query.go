@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	queryDto "fluxend/internal/api/dto/query"
+	queryDomain "fluxend/internal/domain/query"
+)
+
+func ToQueryResource(result *queryDomain.Result) queryDto.Response {
+	return queryDto.Response{
+		Columns:    result.Columns,
+		Rows:       result.Rows,
+		RowCount:   result.RowCount,
+		Truncated:  result.Truncated,
+		ReadOnly:   result.ReadOnly,
+		DurationMs: result.DurationMs,
+	}
+}
+
+func ToPlanResource(plan *queryDomain.Plan) queryDto.PlanResponse {
+	return queryDto.PlanResponse{
+		Root:          plan.Root,
+		PlanningTime:  plan.PlanningTime,
+		ExecutionTime: plan.ExecutionTime,
+		Analyzed:      plan.Analyzed,
+		SeqScans:      plan.SeqScans,
+		Suggestions:   plan.Suggestions,
+	}
+}
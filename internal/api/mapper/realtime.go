@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	realtimeDto "fluxend/internal/api/dto/realtime"
+	"fluxend/internal/domain/realtime"
+)
+
+func ToRealtimeTableResource(table *realtime.TableState) realtimeDto.TableResponse {
+	return realtimeDto.TableResponse{
+		Schema:  table.Schema,
+		Table:   table.Table,
+		Enabled: table.Enabled,
+	}
+}
+
+func ToRealtimeTableResourceCollection(tables []realtime.TableState) []realtimeDto.TableResponse {
+	resourceTables := make([]realtimeDto.TableResponse, len(tables))
+	for i, table := range tables {
+		resourceTables[i] = ToRealtimeTableResource(&table)
+	}
+
+	return resourceTables
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToRoleResource(role *databaseDomain.Role) databaseDto.RoleResponse {
+	return databaseDto.RoleResponse{
+		Name:     role.Name,
+		Inherit:  role.Inherit,
+		MemberOf: role.MemberOf,
+	}
+}
+
+func ToRoleResourceCollection(roles []databaseDomain.Role) []databaseDto.RoleResponse {
+	resourceRoles := make([]databaseDto.RoleResponse, len(roles))
+	for i, currentRole := range roles {
+		resourceRoles[i] = ToRoleResource(&currentRole)
+	}
+
+	return resourceRoles
+}
+
+func ToRoleGrantResourceCollection(grants []databaseDomain.RoleGrant) []databaseDto.RoleGrantResponse {
+	resourceGrants := make([]databaseDto.RoleGrantResponse, len(grants))
+	for i, currentGrant := range grants {
+		resourceGrants[i] = databaseDto.RoleGrantResponse{
+			ObjectType: currentGrant.ObjectType,
+			Object:     currentGrant.Object,
+			Column:     currentGrant.Column,
+			Privileges: currentGrant.Privileges,
+		}
+	}
+
+	return resourceGrants
+}
+
+func ToRoleTokenResource(token *databaseDomain.RoleToken) databaseDto.RoleTokenResponse {
+	return databaseDto.RoleTokenResponse{
+		Role:      token.Role,
+		Token:     token.Token,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToSchemaResource(schema *databaseDomain.Schema) databaseDto.SchemaResponse {
+	return databaseDto.SchemaResponse{
+		Name:       schema.Name,
+		Owner:      schema.Owner,
+		TableCount: schema.TableCount,
+		Comment:    schema.Comment,
+		Exposed:    schema.Exposed,
+	}
+}
+
+func ToSchemaResourceCollection(schemas []databaseDomain.Schema) []databaseDto.SchemaResponse {
+	resourceSchemas := make([]databaseDto.SchemaResponse, len(schemas))
+	for i, currentSchema := range schemas {
+		resourceSchemas[i] = ToSchemaResource(&currentSchema)
+	}
+
+	return resourceSchemas
+}
+
+func ToExposedSchemasResource(schemas []string) databaseDto.ExposedSchemasResponse {
+	if schemas == nil {
+		schemas = []string{}
+	}
+
+	return databaseDto.ExposedSchemasResponse{Schemas: schemas}
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToSchemaDiffResource(diff databaseDomain.SchemaDiff) databaseDto.SchemaDiffResponse {
+	changes := make([]databaseDto.SchemaChangeResponse, len(diff.Changes))
+	for i, change := range diff.Changes {
+		changes[i] = databaseDto.SchemaChangeResponse{
+			Object: change.Object,
+			Action: change.Action,
+			Name:   change.Name,
+		}
+	}
+
+	return databaseDto.SchemaDiffResponse{
+		Changes:    changes,
+		Statements: diff.Statements,
+		Script:     diff.Script(),
+	}
+}
@@ -0,0 +1,98 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToSchemaGraphResource(graph databaseDomain.SchemaGraph) databaseDto.SchemaGraphResponse {
+	tables := make([]databaseDto.SchemaGraphTableResponse, len(graph.Tables))
+	for i, table := range graph.Tables {
+		indexes := make([]databaseDto.SchemaGraphIndexResponse, len(table.Indexes))
+		for j, index := range table.Indexes {
+			columns := make([]string, len(index.Columns))
+			for k, column := range index.Columns {
+				columns[k] = column.Name
+			}
+
+			indexes[j] = databaseDto.SchemaGraphIndexResponse{
+				Name:    index.Name,
+				Columns: columns,
+				Method:  index.Method,
+				Unique:  index.Unique,
+				Primary: index.Primary,
+			}
+		}
+
+		tables[i] = databaseDto.SchemaGraphTableResponse{
+			Schema:      table.Schema,
+			Name:        table.Name,
+			Description: table.Description,
+			Columns:     toSchemaGraphColumnResources(table.Columns),
+			PrimaryKey:  table.PrimaryKey,
+			Indexes:     indexes,
+		}
+	}
+
+	relations := make([]databaseDto.SchemaGraphRelationResponse, len(graph.Relations))
+	for i, relation := range graph.Relations {
+		relations[i] = databaseDto.SchemaGraphRelationResponse{
+			Name:        relation.Name,
+			FromTable:   relation.FromTable,
+			FromColumns: relation.FromColumns,
+			ToTable:     relation.ToTable,
+			ToColumns:   relation.ToColumns,
+			OnDelete:    relation.OnDelete,
+			OnUpdate:    relation.OnUpdate,
+			Optional:    relation.Optional,
+			OneToOne:    relation.OneToOne,
+		}
+	}
+
+	views := make([]databaseDto.SchemaGraphViewResponse, len(graph.Views))
+	for i, view := range graph.Views {
+		views[i] = databaseDto.SchemaGraphViewResponse{
+			Schema:       view.Schema,
+			Name:         view.Name,
+			Materialized: view.Materialized,
+			Columns:      toSchemaGraphColumnResources(view.Columns),
+		}
+	}
+
+	functions := make([]databaseDto.SchemaGraphFunctionResponse, len(graph.Functions))
+	for i, function := range graph.Functions {
+		functions[i] = databaseDto.SchemaGraphFunctionResponse{
+			Schema:     function.Schema,
+			Name:       function.Name,
+			Arguments:  function.Arguments,
+			ReturnType: function.ReturnType,
+			Language:   function.Language,
+		}
+	}
+
+	return databaseDto.SchemaGraphResponse{
+		Schemas:   graph.Schemas,
+		Tables:    tables,
+		Relations: relations,
+		Views:     views,
+		Functions: functions,
+	}
+}
+
+func toSchemaGraphColumnResources(columns []databaseDomain.GraphColumn) []databaseDto.SchemaGraphColumnResponse {
+	resources := make([]databaseDto.SchemaGraphColumnResponse, len(columns))
+	for i, column := range columns {
+		resources[i] = databaseDto.SchemaGraphColumnResponse{
+			Name:        column.Name,
+			Type:        column.Type,
+			NotNull:     column.NotNull,
+			Default:     column.Default,
+			Description: column.Description,
+			Primary:     column.Primary,
+			Unique:      column.Unique,
+			Foreign:     column.Foreign,
+		}
+	}
+
+	return resources
+}
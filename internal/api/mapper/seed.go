@@ -0,0 +1,14 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToSeedResource(result databaseDomain.SeedResult) databaseDto.SeedResponse {
+	return databaseDto.SeedResponse{
+		Requested: result.Requested,
+		Inserted:  result.Inserted,
+		Skipped:   result.Skipped,
+	}
+}
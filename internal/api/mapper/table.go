@@ -12,6 +12,9 @@ func ToTableResource(table *databaseDomain.Table) databaseDto.TableResponse {
 		Schema:        table.Schema,
 		EstimatedRows: table.EstimatedRows,
 		TotalSize:     table.TotalSize,
+		Partitioned:   table.Partitioned,
+		PartitionOf:   table.PartitionOf,
+		Description:   table.Description,
 	}
 }
 
@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToTriggerResource(trigger *databaseDomain.Trigger) databaseDto.TriggerResponse {
+	return databaseDto.TriggerResponse{
+		Name:          trigger.Name,
+		Timing:        trigger.Timing,
+		Level:         trigger.Level,
+		Events:        trigger.Events,
+		UpdateColumns: trigger.UpdateColumns,
+		Condition:     trigger.Condition,
+		Function:      trigger.Function,
+		Enabled:       trigger.Enabled,
+		Definition:    trigger.Definition,
+	}
+}
+
+func ToTriggerResourceCollection(triggers []databaseDomain.Trigger) []databaseDto.TriggerResponse {
+	resourceTriggers := make([]databaseDto.TriggerResponse, len(triggers))
+	for i, currentTrigger := range triggers {
+		resourceTriggers[i] = ToTriggerResource(&currentTrigger)
+	}
+
+	return resourceTriggers
+}
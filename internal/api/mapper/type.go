@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToTypeResource(fetchedType *databaseDomain.Type) databaseDto.TypeResponse {
+	attributes := make([]databaseDto.TypeAttributeResponse, len(fetchedType.Attributes))
+	for i, attribute := range fetchedType.Attributes {
+		attributes[i] = databaseDto.TypeAttributeResponse{Name: attribute.Name, Type: attribute.Type}
+	}
+
+	return databaseDto.TypeResponse{
+		Schema:     fetchedType.Schema,
+		Name:       fetchedType.Name,
+		FullName:   fetchedType.FullName(),
+		Kind:       fetchedType.Kind,
+		Values:     fetchedType.Values,
+		Attributes: attributes,
+	}
+}
+
+func ToTypeResourceCollection(types []databaseDomain.Type) []databaseDto.TypeResponse {
+	resourceTypes := make([]databaseDto.TypeResponse, len(types))
+	for i, currentType := range types {
+		resourceTypes[i] = ToTypeResource(&currentType)
+	}
+
+	return resourceTypes
+}
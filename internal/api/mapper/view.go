@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	databaseDto "fluxend/internal/api/dto/database"
+	databaseDomain "fluxend/internal/domain/database"
+)
+
+func ToViewResource(view *databaseDomain.View) databaseDto.ViewResponse {
+	var schedule *databaseDto.ViewRefreshScheduleResponse
+	if view.Schedule != nil {
+		schedule = &databaseDto.ViewRefreshScheduleResponse{
+			IntervalMinutes: view.Schedule.IntervalMinutes,
+			Concurrently:    view.Schedule.Concurrently,
+			NextRefreshAt:   view.Schedule.NextRefreshAt,
+			LastRefreshedAt: view.Schedule.LastRefreshedAt,
+			LastError:       view.Schedule.LastError,
+		}
+	}
+
+	return databaseDto.ViewResponse{
+		Schema:         view.Schema,
+		Name:           view.Name,
+		FullName:       view.FullName(),
+		Materialized:   view.Materialized,
+		Definition:     view.Definition,
+		Populated:      view.Populated,
+		HasUniqueIndex: view.HasUniqueIndex,
+		Schedule:       schedule,
+	}
+}
+
+func ToViewResourceCollection(views []databaseDomain.View) []databaseDto.ViewResponse {
+	resourceViews := make([]databaseDto.ViewResponse, len(views))
+	for i, view := range views {
+		resourceViews[i] = ToViewResource(&view)
+	}
+
+	return resourceViews
+}
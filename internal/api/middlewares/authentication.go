@@ -32,40 +32,31 @@ func Authentication(userRepo user.Repository) echo.MiddlewareFunc {
 
 			// Parse the token
 			claims := jwt.MapClaims{}
-			token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-				// Check the signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-
-				// Return the JWT_SECRET as the key
-				return []byte(os.Getenv("JWT_SECRET")), nil
-			})
+			token, err := jwt.ParseWithClaims(tokenString, claims, signingKey)
 
 			if err != nil || !token.Valid {
 				// Token is invalid or expired
 				return response.ErrorResponse(c, errors.NewUnauthorizedError("auth.error.tokenInvalid"))
 			}
 
-			userUUID, err := uuid.Parse(claims["uuid"].(string))
-			if err != nil {
+			userClaims, ok := parseUserClaims(claims)
+			if !ok {
 				return response.ErrorResponse(c, errors.NewUnauthorizedError("auth.error.tokenInvalid"))
 			}
 
-			loggedInJWTVersion := int(claims["version"].(float64))
-			latestVersion, err := userRepo.GetJWTVersion(userUUID)
+			latestVersion, err := userRepo.GetJWTVersion(userClaims.uuid)
 			if err != nil {
 				return response.ErrorResponse(c, err)
 			}
 
 			// Allow a max 5 sessions to be active at the same time
-			if (latestVersion - loggedInJWTVersion) >= constants.UserMaxLoginSessions {
+			if (latestVersion - userClaims.version) >= constants.UserMaxLoginSessions {
 				return response.UnauthorizedResponse(c, "auth.error.tokenInvalid")
 			}
 
 			c.Set("user", auth.User{
-				Uuid:   userUUID,
-				RoleID: int(claims["role_id"].(float64)),
+				Uuid:   userClaims.uuid,
+				RoleID: userClaims.roleID,
 			})
 
 			// Proceed to the next handler if everything is valid
@@ -73,3 +64,48 @@ func Authentication(userRepo user.Repository) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// signingKey only accepts HMAC signed tokens, they are signed with JWT_SECRET
+func signingKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return []byte(os.Getenv("JWT_SECRET")), nil
+}
+
+type tokenUser struct {
+	uuid    uuid.UUID
+	version int
+	roleID  int
+}
+
+// parseUserClaims only accepts tokens issued at login. Role tokens for PostgREST are signed with the
+// same secret, they carry an audience and lack the user claims
+func parseUserClaims(claims jwt.MapClaims) (tokenUser, bool) {
+	if _, ok := claims["aud"]; ok {
+		return tokenUser{}, false
+	}
+
+	rawUUID, ok := claims["uuid"].(string)
+	if !ok {
+		return tokenUser{}, false
+	}
+
+	userUUID, err := uuid.Parse(rawUUID)
+	if err != nil {
+		return tokenUser{}, false
+	}
+
+	version, ok := claims["version"].(float64)
+	if !ok {
+		return tokenUser{}, false
+	}
+
+	roleID, ok := claims["role_id"].(float64)
+	if !ok {
+		return tokenUser{}, false
+	}
+
+	return tokenUser{uuid: userUUID, version: int(version), roleID: int(roleID)}, true
+}
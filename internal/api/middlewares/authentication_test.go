@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseUserClaims_Suite(t *testing.T) {
+	userUUID := uuid.New()
+
+	t.Run("login token", func(t *testing.T) {
+		claims := jwt.MapClaims{"uuid": userUUID.String(), "version": float64(3), "role_id": float64(2)}
+
+		user, ok := parseUserClaims(claims)
+
+		assert.True(t, ok)
+		assert.Equal(t, tokenUser{uuid: userUUID, version: 3, roleID: 2}, user)
+	})
+
+	t.Run("role token is refused", func(t *testing.T) {
+		claims := jwt.MapClaims{
+			"uuid":    userUUID.String(),
+			"version": float64(3),
+			"role_id": float64(1),
+			"role":    "reporting",
+			"aud":     constants.RoleTokenAudience,
+		}
+
+		_, ok := parseUserClaims(claims)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("missing or mistyped claims", func(t *testing.T) {
+		for _, claims := range []jwt.MapClaims{
+			{"role": "reporting"},
+			{"uuid": 42, "version": float64(1), "role_id": float64(1)},
+			{"uuid": "not-a-uuid", "version": float64(1), "role_id": float64(1)},
+			{"uuid": userUUID.String(), "version": "1", "role_id": float64(1)},
+			{"uuid": userUUID.String(), "version": float64(1)},
+		} {
+			_, ok := parseUserClaims(claims)
+
+			assert.False(t, ok)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"strings"
+)
+
+// ProjectClaimsKey holds the claims of a project token on the context
+const ProjectClaimsKey = "projectClaims"
+
+// ProjectTokenOrAuthentication lets project frontends in with the token PostgREST accepts, a role
+// token carrying the PostgREST audience and a role claim. Its claims are put on the context instead
+// of a user, every other request goes through the regular authentication
+func ProjectTokenOrAuthentication(authMiddleware echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := authMiddleware(next)
+
+		return func(c echo.Context) error {
+			tokenString, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				return authenticated(c)
+			}
+
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(tokenString, claims, signingKey, jwt.WithAudience(constants.RoleTokenAudience))
+			if err != nil || !token.Valid {
+				return authenticated(c)
+			}
+
+			if role, _ := claims["role"].(string); role == "" {
+				return authenticated(c)
+			}
+
+			c.Set(ProjectClaimsKey, claims)
+
+			return next(c)
+		}
+	}
+}
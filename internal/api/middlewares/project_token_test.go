@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProjectTokenOrAuthentication_Suite(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	run := func(claims jwt.MapClaims) (echo.Context, bool) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+
+		request := httptest.NewRequest(http.MethodGet, "/realtime", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		c := echo.New().NewContext(request, httptest.NewRecorder())
+
+		authenticated := false
+		authMiddleware := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				authenticated = true
+
+				return next(c)
+			}
+		}
+
+		_ = ProjectTokenOrAuthentication(authMiddleware)(func(c echo.Context) error { return nil })(c)
+
+		return c, authenticated
+	}
+
+	t.Run("project token carries its claims", func(t *testing.T) {
+		c, authenticated := run(jwt.MapClaims{"role": "customer", "sub": "42", "aud": constants.RoleTokenAudience})
+
+		assert.False(t, authenticated)
+		assert.Equal(t, "customer", c.Get(ProjectClaimsKey).(jwt.MapClaims)["role"])
+	})
+
+	t.Run("other tokens go through authentication", func(t *testing.T) {
+		for _, claims := range []jwt.MapClaims{
+			{"uuid": "3f1c", "version": float64(1), "role_id": float64(1)},
+			{"role": "customer"},
+			{"aud": constants.RoleTokenAudience},
+		} {
+			c, authenticated := run(claims)
+
+			assert.True(t, authenticated)
+			assert.Nil(t, c.Get(ProjectClaimsKey))
+		}
+	})
+}
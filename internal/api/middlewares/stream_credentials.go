@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// StreamCredentials lets event streams pass the token and project in the query string, browsers
+// cannot set headers on an EventSource. They are moved into the usual headers and taken off the
+// URL, so the token never ends up in the request log
+func StreamCredentials() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			query := request.URL.Query()
+
+			if token := query.Get("access_token"); token != "" && request.Header.Get("Authorization") == "" {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			if project := query.Get("project"); project != "" && request.Header.Get("X-Project") == "" {
+				request.Header.Set("X-Project", project)
+			}
+
+			query.Del("access_token")
+			query.Del("project")
+			request.URL.RawQuery = query.Encode()
+
+			return next(c)
+		}
+	}
+}
@@ -15,5 +15,7 @@ func RegisterFunctionRoutes(e *echo.Echo, container *do.Injector, authMiddleware
 	functionsGroup.GET("/:schema", functionController.List)
 	functionsGroup.POST("/:schema", functionController.Store)
 	functionsGroup.GET("/:schema/:functionName", functionController.Show)
+	functionsGroup.PUT("/:schema/:functionName", functionController.Update)
+	functionsGroup.POST("/:schema/:functionName/execute", functionController.Execute)
 	functionsGroup.DELETE("/:schema/:functionName", functionController.Delete)
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"fluxend/internal/api/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+func RegisterJobRoutes(e *echo.Echo, container *do.Injector, authMiddleware echo.MiddlewareFunc) {
+	jobController := do.MustInvoke[*handlers.JobHandler](container)
+
+	jobsGroup := e.Group("jobs", authMiddleware)
+
+	jobsGroup.GET("", jobController.List)
+	jobsGroup.GET("/:jobUUID", jobController.Show)
+}
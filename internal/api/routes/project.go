@@ -9,6 +9,18 @@ import (
 func RegisterProjectRoutes(e *echo.Echo, container *do.Injector, authMiddleware echo.MiddlewareFunc, allowProjectMiddleware echo.MiddlewareFunc) {
 	projectController := do.MustInvoke[*handlers.ProjectHandler](container)
 	statHandler := do.MustInvoke[*handlers.StatHandler](container)
+	queryHandler := do.MustInvoke[*handlers.QueryHandler](container)
+	migrationHandler := do.MustInvoke[*handlers.MigrationHandler](container)
+	schemaDiffHandler := do.MustInvoke[*handlers.SchemaDiffHandler](container)
+	schemaGraphHandler := do.MustInvoke[*handlers.SchemaGraphHandler](container)
+	ddlHandler := do.MustInvoke[*handlers.DDLHandler](container)
+	typeHandler := do.MustInvoke[*handlers.TypeHandler](container)
+	viewHandler := do.MustInvoke[*handlers.ViewHandler](container)
+	roleHandler := do.MustInvoke[*handlers.RoleHandler](container)
+	extensionHandler := do.MustInvoke[*handlers.ExtensionHandler](container)
+	schemaHandler := do.MustInvoke[*handlers.SchemaHandler](container)
+	cronHandler := do.MustInvoke[*handlers.CronHandler](container)
+	trashHandler := do.MustInvoke[*handlers.TrashHandler](container)
 
 	projectsGroup := e.Group("projects", authMiddleware, allowProjectMiddleware)
 
@@ -22,6 +34,66 @@ func RegisterProjectRoutes(e *echo.Echo, container *do.Injector, authMiddleware
 
 	projectsGroup.GET("/:projectUUID/stats", statHandler.Retrieve)
 
+	projectsGroup.POST("/:projectUUID/sql", queryHandler.Execute)
+	projectsGroup.POST("/:projectUUID/sql/explain", queryHandler.Explain)
+
+	projectsGroup.GET("/:projectUUID/migrations", migrationHandler.List)
+	projectsGroup.POST("/:projectUUID/migrations", migrationHandler.Store)
+	projectsGroup.POST("/:projectUUID/migrations/apply", migrationHandler.Apply)
+	projectsGroup.POST("/:projectUUID/migrations/rollback", migrationHandler.Rollback)
+
+	projectsGroup.GET("/:projectUUID/schema-diff/:targetProjectUUID", schemaDiffHandler.Show)
+	projectsGroup.POST("/:projectUUID/schema-diff/:targetProjectUUID/apply", schemaDiffHandler.Apply)
+
+	projectsGroup.GET("/:projectUUID/schema/graph", schemaGraphHandler.Show)
+	projectsGroup.GET("/:projectUUID/schema/ddl", ddlHandler.Project)
+
+	projectsGroup.GET("/:projectUUID/types", typeHandler.List)
+	projectsGroup.POST("/:projectUUID/types", typeHandler.Store)
+	projectsGroup.GET("/:projectUUID/types/:fullTypeName", typeHandler.Show)
+	projectsGroup.PUT("/:projectUUID/types/:fullTypeName", typeHandler.Update)
+	projectsGroup.DELETE("/:projectUUID/types/:fullTypeName", typeHandler.Delete)
+
+	projectsGroup.GET("/:projectUUID/views", viewHandler.List)
+	projectsGroup.POST("/:projectUUID/views", viewHandler.Store)
+	projectsGroup.GET("/:projectUUID/views/:fullViewName", viewHandler.Show)
+	projectsGroup.PUT("/:projectUUID/views/:fullViewName", viewHandler.Update)
+	projectsGroup.DELETE("/:projectUUID/views/:fullViewName", viewHandler.Delete)
+	projectsGroup.POST("/:projectUUID/views/:fullViewName/refresh", viewHandler.Refresh)
+	projectsGroup.PUT("/:projectUUID/views/:fullViewName/schedule", viewHandler.Schedule)
+
+	projectsGroup.GET("/:projectUUID/roles", roleHandler.List)
+	projectsGroup.POST("/:projectUUID/roles", roleHandler.Store)
+	projectsGroup.GET("/:projectUUID/roles/:roleName", roleHandler.Show)
+	projectsGroup.DELETE("/:projectUUID/roles/:roleName", roleHandler.Delete)
+	projectsGroup.GET("/:projectUUID/roles/:roleName/grants", roleHandler.ListGrants)
+	projectsGroup.POST("/:projectUUID/roles/:roleName/grant", roleHandler.Grant)
+	projectsGroup.POST("/:projectUUID/roles/:roleName/revoke", roleHandler.Revoke)
+	projectsGroup.POST("/:projectUUID/roles/:roleName/tokens", roleHandler.IssueToken)
+
+	projectsGroup.GET("/:projectUUID/extensions", extensionHandler.List)
+	projectsGroup.POST("/:projectUUID/extensions/:extensionName", extensionHandler.Enable)
+	projectsGroup.DELETE("/:projectUUID/extensions/:extensionName", extensionHandler.Disable)
+
+	projectsGroup.GET("/:projectUUID/schemas", schemaHandler.List)
+	projectsGroup.POST("/:projectUUID/schemas", schemaHandler.Store)
+	projectsGroup.PUT("/:projectUUID/schemas/:schemaName", schemaHandler.Rename)
+	projectsGroup.DELETE("/:projectUUID/schemas/:schemaName", schemaHandler.Delete)
+	projectsGroup.GET("/:projectUUID/exposed-schemas", schemaHandler.ListExposed)
+	projectsGroup.PUT("/:projectUUID/exposed-schemas", schemaHandler.UpdateExposed)
+
+	projectsGroup.GET("/:projectUUID/cron-jobs", cronHandler.List)
+	projectsGroup.POST("/:projectUUID/cron-jobs", cronHandler.Store)
+	projectsGroup.DELETE("/:projectUUID/cron-jobs/:jobUUID", cronHandler.Delete)
+	projectsGroup.POST("/:projectUUID/cron-jobs/:jobUUID/pause", cronHandler.Pause)
+	projectsGroup.POST("/:projectUUID/cron-jobs/:jobUUID/resume", cronHandler.Resume)
+	projectsGroup.POST("/:projectUUID/cron-jobs/:jobUUID/run", cronHandler.Run)
+	projectsGroup.GET("/:projectUUID/cron-jobs/:jobUUID/runs", cronHandler.ListRuns)
+
+	projectsGroup.GET("/:projectUUID/trash", trashHandler.List)
+	projectsGroup.POST("/:projectUUID/trash/:snapshotUUID/restore", trashHandler.Restore)
+	projectsGroup.DELETE("/:projectUUID/trash/:snapshotUUID", trashHandler.Delete)
+
 	// track postgrest requests
 	e.GET("projects/:dbName/logs/capture", projectController.StoreLogs)
 }
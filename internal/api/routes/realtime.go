@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"fluxend/internal/api/handlers"
+	"fluxend/internal/api/middlewares"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+)
+
+func RegisterRealtimeRoutes(e *echo.Echo, container *do.Injector, authMiddleware echo.MiddlewareFunc) {
+	realtimeController := do.MustInvoke[*handlers.RealtimeHandler](container)
+
+	realtimeGroup := e.Group("realtime", middlewares.StreamCredentials())
+
+	realtimeGroup.GET("", realtimeController.Stream, middlewares.ProjectTokenOrAuthentication(authMiddleware))
+	realtimeGroup.GET("/tables", realtimeController.ListTables, authMiddleware)
+}
@@ -10,6 +10,13 @@ func RegisterTableRoutes(e *echo.Echo, container *do.Injector, authMiddleware ec
 	tableController := do.MustInvoke[*handlers.TableHandler](container)
 	columnController := do.MustInvoke[*handlers.ColumnHandler](container)
 	indexController := do.MustInvoke[*handlers.IndexHandler](container)
+	constraintController := do.MustInvoke[*handlers.ConstraintHandler](container)
+	triggerController := do.MustInvoke[*handlers.TriggerHandler](container)
+	policyController := do.MustInvoke[*handlers.PolicyHandler](container)
+	realtimeController := do.MustInvoke[*handlers.RealtimeHandler](container)
+	partitionController := do.MustInvoke[*handlers.PartitionHandler](container)
+	ddlController := do.MustInvoke[*handlers.DDLHandler](container)
+	maintenanceController := do.MustInvoke[*handlers.MaintenanceHandler](container)
 
 	tablesGroup := e.Group("tables", authMiddleware)
 
@@ -20,7 +27,12 @@ func RegisterTableRoutes(e *echo.Echo, container *do.Injector, authMiddleware ec
 	tablesGroup.GET("/:fullTableName", tableController.Show)
 	tablesGroup.PUT("/:fullTableName/duplicate", tableController.Duplicate)
 	tablesGroup.PUT("/:fullTableName/rename", tableController.Rename)
+	tablesGroup.PUT("/:fullTableName/description", tableController.UpdateDescription)
 	tablesGroup.DELETE("/:fullTableName", tableController.Delete)
+	tablesGroup.GET("/:fullTableName/export", tableController.Export)
+	tablesGroup.POST("/:fullTableName/seed", tableController.Seed)
+	tablesGroup.GET("/:fullTableName/ddl", ddlController.Table)
+	tablesGroup.POST("/:fullTableName/maintenance", maintenanceController.Run)
 
 	// column routes
 	tablesGroup.GET("/:fullTableName/columns", columnController.List)
@@ -34,4 +46,41 @@ func RegisterTableRoutes(e *echo.Echo, container *do.Injector, authMiddleware ec
 	tablesGroup.GET("/:fullTableName/indexes", indexController.List)
 	tablesGroup.GET("/:fullTableName/indexes/:indexName", indexController.Show)
 	tablesGroup.DELETE("/:fullTableName/indexes/:indexName", indexController.Delete)
+
+	// constraint routes
+	tablesGroup.POST("/:fullTableName/constraints", constraintController.Store)
+	tablesGroup.GET("/:fullTableName/constraints", constraintController.List)
+	tablesGroup.GET("/:fullTableName/constraints/:constraintName", constraintController.Show)
+	tablesGroup.POST("/:fullTableName/constraints/:constraintName/validate", constraintController.Validate)
+	tablesGroup.DELETE("/:fullTableName/constraints/:constraintName", constraintController.Delete)
+
+	// trigger routes
+	tablesGroup.POST("/:fullTableName/triggers", triggerController.Store)
+	tablesGroup.GET("/:fullTableName/triggers", triggerController.List)
+	tablesGroup.GET("/:fullTableName/triggers/:triggerName", triggerController.Show)
+	tablesGroup.POST("/:fullTableName/triggers/:triggerName/enable", triggerController.Enable)
+	tablesGroup.POST("/:fullTableName/triggers/:triggerName/disable", triggerController.Disable)
+	tablesGroup.DELETE("/:fullTableName/triggers/:triggerName", triggerController.Delete)
+
+	// row level security routes
+	tablesGroup.GET("/:fullTableName/rls", policyController.ShowRowLevelSecurity)
+	tablesGroup.PUT("/:fullTableName/rls", policyController.UpdateRowLevelSecurity)
+	tablesGroup.POST("/:fullTableName/policies", policyController.Store)
+	tablesGroup.GET("/:fullTableName/policies", policyController.List)
+	tablesGroup.POST("/:fullTableName/policies/test", policyController.Test)
+	tablesGroup.GET("/:fullTableName/policies/:policyName", policyController.Show)
+	tablesGroup.PUT("/:fullTableName/policies/:policyName", policyController.Update)
+	tablesGroup.DELETE("/:fullTableName/policies/:policyName", policyController.Delete)
+
+	// realtime routes
+	tablesGroup.GET("/:fullTableName/realtime", realtimeController.ShowTable)
+	tablesGroup.PUT("/:fullTableName/realtime", realtimeController.UpdateTable)
+
+	// partition routes
+	tablesGroup.GET("/:fullTableName/partitions", partitionController.List)
+	tablesGroup.POST("/:fullTableName/partitions", partitionController.Store)
+	tablesGroup.PUT("/:fullTableName/partitions/maintenance", partitionController.UpdatePolicy)
+	tablesGroup.DELETE("/:fullTableName/partitions/maintenance", partitionController.DeletePolicy)
+	tablesGroup.POST("/:fullTableName/partitions/:partitionName/attach", partitionController.Attach)
+	tablesGroup.POST("/:fullTableName/partitions/:partitionName/detach", partitionController.Detach)
 }
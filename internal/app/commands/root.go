@@ -24,5 +24,6 @@ func init() {
 	RootCmd.AddCommand(routesCmd)
 	RootCmd.AddCommand(udbStats)
 	RootCmd.AddCommand(udbRestart)
+	RootCmd.AddCommand(udbPrepare)
 	RootCmd.AddCommand(optimizeCmd)
 }
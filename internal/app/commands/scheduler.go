@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/cron"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/job"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+// runViewRefreshScheduler refreshes materialized views whose schedule is due. Schedules are
+// claimed with row locks, so several API instances can run the scheduler side by side
+func runViewRefreshScheduler(container *do.Injector) {
+	viewService := do.MustInvoke[database.ViewService](container)
+
+	ticker := time.NewTicker(constants.ViewRefreshSchedulerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := viewService.RefreshDue(); err != nil {
+			log.Error().
+				Str("action", constants.ActionViewRefresh).
+				Str("error", err.Error()).
+				Msg("failed to run scheduled view refreshes")
+		}
+	}
+}
+
+// runCronScheduler starts cron jobs that are due. Like view refreshes, due jobs are claimed with row
+// locks so every API instance can run this loop
+func runCronScheduler(container *do.Injector) {
+	cronService := do.MustInvoke[cron.Service](container)
+
+	ticker := time.NewTicker(constants.CronSchedulerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := cronService.RunDue(); err != nil {
+			log.Error().
+				Str("action", constants.ActionCron).
+				Str("error", err.Error()).
+				Msg("failed to run scheduled cron jobs")
+		}
+	}
+}
+
+// runPartitionMaintenanceScheduler creates and drops the partitions of tables with a maintenance
+// policy, policies are claimed with row locks like view refresh schedules
+func runPartitionMaintenanceScheduler(container *do.Injector) {
+	partitionService := do.MustInvoke[database.PartitionService](container)
+
+	ticker := time.NewTicker(constants.PartitionMaintenanceSchedulerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := partitionService.MaintainDue(); err != nil {
+			log.Error().
+				Str("action", constants.ActionPartition).
+				Str("error", err.Error()).
+				Msg("failed to run partition maintenance")
+		}
+	}
+}
+
+// runTrashPurgeScheduler drops the snapshots of dropped tables and columns once their retention is over
+func runTrashPurgeScheduler(container *do.Injector) {
+	trashService := do.MustInvoke[database.TrashService](container)
+
+	ticker := time.NewTicker(constants.TrashPurgeSchedulerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := trashService.PurgeExpired(); err != nil {
+			log.Error().
+				Str("action", constants.ActionTrash).
+				Str("error", err.Error()).
+				Msg("failed to purge expired trash")
+		}
+	}
+}
+
+// runAbandonedJobScheduler marks jobs as failed once the process running them stopped sending
+// heartbeats, their work lived in that process and is gone. Runs once at startup, then on every tick
+func runAbandonedJobScheduler(container *do.Injector) {
+	jobService := do.MustInvoke[job.Service](container)
+
+	ticker := time.NewTicker(constants.JobHeartbeatInterval)
+	defer ticker.Stop()
+
+	for ; true; <-ticker.C {
+		if _, err := jobService.FailAbandoned(); err != nil {
+			log.Error().
+				Str("action", constants.ActionJob).
+				Str("error", err.Error()).
+				Msg("failed to close abandoned jobs")
+		}
+	}
+}
@@ -30,9 +30,16 @@ var serverCmd = &cobra.Command{
 }
 
 func startServer() {
-	e := SetupServer(app.InitializeContainer())
+	container := app.InitializeContainer()
+	e := SetupServer(container)
 	validateEnvVariables()
 
+	go runAbandonedJobScheduler(container)
+	go runViewRefreshScheduler(container)
+	go runCronScheduler(container)
+	go runPartitionMaintenanceScheduler(container)
+	go runTrashPurgeScheduler(container)
+
 	e.Logger.Fatal(e.Start("0.0.0.0:8080"))
 }
 
@@ -83,6 +90,7 @@ func getCorsConfig() middleware.CORSConfig {
 			"Range-Unit",
 			"range",
 			"Prefer",
+			"Last-Event-ID",
 		},
 		ExposeHeaders: []string{
 			echo.HeaderContentLength, echo.HeaderContentType,
@@ -114,6 +122,8 @@ func registerRoutes(e *echo.Echo, container *do.Injector) {
 	routes.RegisterStorageRoutes(e, container, authMiddleware, allowStorageMiddleware)
 	routes.RegisterFunctionRoutes(e, container, authMiddleware)
 	routes.RegisterBackup(e, container, authMiddleware, allowBackupMiddleware)
+	routes.RegisterJobRoutes(e, container, authMiddleware)
+	routes.RegisterRealtimeRoutes(e, container, authMiddleware)
 
 	e.GET("/", func(c echo.Context) error {
 		response := map[string]string{
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fluxend/internal/app"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+var udbPrepare = &cobra.Command{
+	Use:   "udb.prepare",
+	Short: "Create the Fluxend schema and tables in all project databases",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return prepareProjectDatabases()
+	},
+}
+
+// prepareProjectDatabases brings databases created before a bookkeeping table was added up to date,
+// new databases are prepared when they are created
+func prepareProjectDatabases() error {
+	container := app.InitializeContainer()
+
+	projectRepository := do.MustInvoke[project.Repository](container)
+	databaseService := do.MustInvoke[shared.DatabaseService](container)
+
+	projects, err := projectRepository.List(shared.PaginationParams{Page: 1, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("error fetching projects: %w", err)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No projects found")
+
+		return nil
+	}
+
+	fmt.Printf("Found %d projects\n", len(projects))
+
+	for i, currentProject := range projects {
+		if currentProject.DBName == "" {
+			continue
+		}
+
+		fmt.Printf("Preparing database of project %s (%d/%d)\n", currentProject.DBName, i+1, len(projects))
+
+		if err := databaseService.Prepare(currentProject.DBName); err != nil {
+			fmt.Printf("Failed to prepare database %s: %v\n", currentProject.DBName, err)
+		}
+	}
+
+	return nil
+}
@@ -11,13 +11,18 @@ import (
 	"fluxend/internal/database/factories"
 	"fluxend/internal/database/repositories"
 	"fluxend/internal/domain/backup"
+	"fluxend/internal/domain/cron"
 	databaseDomain "fluxend/internal/domain/database"
 	"fluxend/internal/domain/form"
 	"fluxend/internal/domain/health"
+	"fluxend/internal/domain/job"
 	"fluxend/internal/domain/logging"
+	"fluxend/internal/domain/maintenance"
 	"fluxend/internal/domain/openapi"
 	"fluxend/internal/domain/organization"
 	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/query"
+	"fluxend/internal/domain/realtime"
 	"fluxend/internal/domain/setting"
 	"fluxend/internal/domain/shared"
 	"fluxend/internal/domain/stats"
@@ -101,6 +106,22 @@ func InitializeContainer() *do.Injector {
 	do.Provide(injector, backup.NewBackupService)
 	do.Provide(injector, handlers.NewBackupHandler)
 
+	// --- Jobs ---
+	do.Provide(injector, repositories.NewJobRepository)
+	do.Provide(injector, job.NewJobService)
+	do.Provide(injector, handlers.NewJobHandler)
+
+	// --- Cron ---
+	do.Provide(injector, repositories.NewCronJobRepository)
+	do.Provide(injector, repositories.NewCronRunRepository)
+	do.Provide(injector, cron.NewCronService)
+	do.Provide(injector, handlers.NewCronHandler)
+
+	// --- Realtime ---
+	do.Provide(injector, realtime.NewHub)
+	do.Provide(injector, realtime.NewRealtimeService)
+	do.Provide(injector, handlers.NewRealtimeHandler)
+
 	// --- Client & Stats ---
 	do.Provide(injector, client.NewClientService)
 	do.Provide(injector, stats.NewDatabaseStatsService)
@@ -108,16 +129,56 @@ func InitializeContainer() *do.Injector {
 	do.Provide(injector, handlers.NewStatHandler)
 
 	// --- Tables ---
+	do.Provide(injector, repositories.NewViewRefreshScheduleRepository)
+	do.Provide(injector, repositories.NewPartitionPolicyRepository)
+	do.Provide(injector, repositories.NewTrashSnapshotRepository)
 	do.Provide(injector, databaseDomain.NewTableService)
 	do.Provide(injector, databaseDomain.NewFileImportService)
 	do.Provide(injector, databaseDomain.NewColumnService)
 	do.Provide(injector, databaseDomain.NewIndexService)
+	do.Provide(injector, databaseDomain.NewConstraintService)
+	do.Provide(injector, databaseDomain.NewTriggerService)
+	do.Provide(injector, databaseDomain.NewPolicyService)
 	do.Provide(injector, databaseDomain.NewFunctionService)
+	do.Provide(injector, databaseDomain.NewExportService)
+	do.Provide(injector, databaseDomain.NewSeedService)
+	do.Provide(injector, databaseDomain.NewMigrationService)
+	do.Provide(injector, databaseDomain.NewSchemaDiffService)
+	do.Provide(injector, databaseDomain.NewSchemaGraphService)
+	do.Provide(injector, databaseDomain.NewDDLService)
+	do.Provide(injector, databaseDomain.NewTypeService)
+	do.Provide(injector, databaseDomain.NewViewService)
+	do.Provide(injector, databaseDomain.NewRoleService)
+	do.Provide(injector, databaseDomain.NewExtensionService)
+	do.Provide(injector, databaseDomain.NewSchemaService)
+	do.Provide(injector, databaseDomain.NewPartitionService)
+	do.Provide(injector, databaseDomain.NewDropService)
+	do.Provide(injector, databaseDomain.NewTrashService)
+	do.Provide(injector, maintenance.NewMaintenanceService)
 
 	do.Provide(injector, handlers.NewTableHandler)
 	do.Provide(injector, handlers.NewColumnHandler)
 	do.Provide(injector, handlers.NewIndexHandler)
+	do.Provide(injector, handlers.NewConstraintHandler)
+	do.Provide(injector, handlers.NewTriggerHandler)
+	do.Provide(injector, handlers.NewPolicyHandler)
 	do.Provide(injector, handlers.NewFunctionHandler)
+	do.Provide(injector, handlers.NewMigrationHandler)
+	do.Provide(injector, handlers.NewSchemaDiffHandler)
+	do.Provide(injector, handlers.NewSchemaGraphHandler)
+	do.Provide(injector, handlers.NewDDLHandler)
+	do.Provide(injector, handlers.NewTypeHandler)
+	do.Provide(injector, handlers.NewViewHandler)
+	do.Provide(injector, handlers.NewRoleHandler)
+	do.Provide(injector, handlers.NewExtensionHandler)
+	do.Provide(injector, handlers.NewSchemaHandler)
+	do.Provide(injector, handlers.NewPartitionHandler)
+	do.Provide(injector, handlers.NewTrashHandler)
+	do.Provide(injector, handlers.NewMaintenanceHandler)
+
+	// --- SQL console ---
+	do.Provide(injector, query.NewQueryService)
+	do.Provide(injector, handlers.NewQueryHandler)
 
 	// --- Health ---
 	do.Provide(injector, health.NewHealthService)
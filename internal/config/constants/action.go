@@ -1,11 +1,19 @@
 package constants
 
 const (
-	ActionAPIRequest = "api_request"
-	ActionPostgrest  = "postgrest"
-	ActionBackup     = "backup"
+	ActionAPIRequest  = "api_request"
+	ActionPostgrest   = "postgrest"
+	ActionBackup      = "backup"
+	ActionMigration   = "migration"
+	ActionViewRefresh = "view_refresh"
+	ActionJob         = "job"
+	ActionCron        = "cron"
+	ActionRealtime    = "realtime"
+	ActionPartition   = "partition_maintenance"
+	ActionTrash       = "trash_purge"
 
 	ActionClientDatabaseCreate  = "client_database_create"
 	ActionClientDatabaseConnect = "client_database_connect"
 	ActionClientDatabaseSeed    = "client_database_seed"
+	ActionClientDatabasePrepare = "client_database_prepare"
 )
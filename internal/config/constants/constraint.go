@@ -0,0 +1,25 @@
+package constants
+
+const (
+	ConstraintTypePrimaryKey = "primary_key"
+	ConstraintTypeUnique     = "unique"
+	ConstraintTypeForeignKey = "foreign_key"
+	ConstraintTypeCheck      = "check"
+	ConstraintTypeExclusion  = "exclusion"
+)
+
+// ConstraintTypes can be created through the API, exclusion constraints are only listed
+var ConstraintTypes = []interface{}{
+	ConstraintTypePrimaryKey,
+	ConstraintTypeUnique,
+	ConstraintTypeForeignKey,
+	ConstraintTypeCheck,
+}
+
+var ConstraintReferentialActions = []interface{}{
+	"NO ACTION",
+	"RESTRICT",
+	"CASCADE",
+	"SET NULL",
+	"SET DEFAULT",
+}
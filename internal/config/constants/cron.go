@@ -0,0 +1,30 @@
+package constants
+
+import "time"
+
+const (
+	CronRunStatusRunning   = "running"
+	CronRunStatusSucceeded = "succeeded"
+	CronRunStatusFailed    = "failed"
+	CronRunStatusSkipped   = "skipped"
+
+	CronTriggerSchedule = "schedule"
+	CronTriggerManual   = "manual"
+
+	DefaultCronTimeoutInMs = 60000
+	MaxCronTimeoutInMs     = 15 * 60 * 1000
+
+	// CronSchedulerInterval is how often due cron jobs are picked up, cron has a one minute resolution
+	CronSchedulerInterval = 20 * time.Second
+
+	// CronBatchSize caps the jobs claimed by one scheduler tick
+	CronBatchSize = 50
+
+	// CronStaleRunGrace is added to a job's timeout before a run still marked as running is considered lost
+	CronStaleRunGrace = time.Minute
+
+	CronRunHistoryLimit = 100
+
+	CronRunSkippedError = "Previous run is still in progress"
+	CronRunLostError    = "Run did not finish, the server running it went away"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	DDLFormatJSON = "json"
+	DDLFormatSQL  = "sql"
+)
+
+var DDLFormats = []string{DDLFormatJSON, DDLFormatSQL}
@@ -0,0 +1,17 @@
+package constants
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatSQL    = "sql"
+
+	ExportChunkSize = 1000
+)
+
+var ExportFormats = []string{
+	ExportFormatCSV,
+	ExportFormatJSON,
+	ExportFormatNDJSON,
+	ExportFormatSQL,
+}
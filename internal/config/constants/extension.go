@@ -0,0 +1,11 @@
+package constants
+
+const (
+	// ExtensionAllowlistSetting holds the comma separated extensions projects may enable
+	ExtensionAllowlistSetting = "extensionAllowlist"
+	DefaultExtensionAllowlist = "pgcrypto,uuid-ossp,pg_trgm,citext,hstore,postgis"
+)
+
+// ProtectedExtensions are never enabled or disabled through the API, even when allowlisted. Functions,
+// triggers and the realtime notify trigger are written in plpgsql, stats read pg_stat_statements
+var ProtectedExtensions = []string{"plpgsql", "pg_stat_statements"}
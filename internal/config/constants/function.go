@@ -0,0 +1,16 @@
+package constants
+
+const (
+	FunctionVolatile  = "VOLATILE"
+	FunctionStable    = "STABLE"
+	FunctionImmutable = "IMMUTABLE"
+
+	// FunctionBodyTag is the dollar quote tag function bodies are wrapped in
+	FunctionBodyTag = "function"
+)
+
+var FunctionVolatilities = []interface{}{
+	FunctionVolatile,
+	FunctionStable,
+	FunctionImmutable,
+}
@@ -0,0 +1,23 @@
+package constants
+
+const (
+	IndexMethodBtree = "btree"
+	IndexMethodHash  = "hash"
+	IndexMethodGin   = "gin"
+	IndexMethodGist  = "gist"
+	IndexMethodBrin  = "brin"
+
+	IndexOrderAsc  = "ASC"
+	IndexOrderDesc = "DESC"
+
+	IndexNullsFirst = "FIRST"
+	IndexNullsLast  = "LAST"
+)
+
+var IndexMethods = []interface{}{
+	IndexMethodBtree,
+	IndexMethodHash,
+	IndexMethodGin,
+	IndexMethodGist,
+	IndexMethodBrin,
+}
@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+
+	JobTypeIndexBuild    = "index_build"
+	JobTypeTableTruncate = "table_truncate"
+	JobTypeTableVacuum   = "table_vacuum"
+	JobTypeTableReindex  = "table_reindex"
+	JobTypeTableExport   = "table_export"
+
+	// JobInterruptedError is recorded on jobs whose server stopped sending heartbeats, it crashed or was restarted
+	JobInterruptedError = "Job was interrupted, the server running it went away"
+
+	// JobHeartbeatInterval is how often a running job tells it is alive, also how often abandoned jobs are looked for
+	JobHeartbeatInterval = 30 * time.Second
+
+	// JobHeartbeatTimeout is how long a job may go without a heartbeat before it counts as abandoned
+	JobHeartbeatTimeout = 2 * time.Minute
+)
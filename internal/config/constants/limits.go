@@ -7,6 +7,23 @@ const (
 	MinColumnNameLength           = 2
 	MaxIndexNameLength            = 60
 	MinIndexNameLength            = 3
+	MaxConstraintNameLength       = 60
+	MinConstraintNameLength       = 3
+	MaxTypeNameLength             = 60
+	MinTypeNameLength             = 3
+	MaxEnumValueLength            = 63
+	MaxViewNameLength             = 60
+	MinViewNameLength             = 3
+	MaxTriggerNameLength          = 60
+	MinTriggerNameLength          = 3
+	MaxPolicyNameLength           = 60
+	MinPolicyNameLength           = 3
+	MaxRoleNameLength             = 60
+	MinRoleNameLength             = 3
+	MaxSchemaNameLength           = 60
+	MinSchemaNameLength           = 2
+	MaxCronJobNameLength          = 60
+	MinCronJobNameLength          = 3
 	MaxOrganizationNameLength     = 100
 	MinOrganizationNameLength     = 3
 	MaxProjectNameLength          = 100
@@ -21,4 +38,11 @@ const (
 	MaxContainerDescriptionLength = 255
 	MinFileNameLength             = 3
 	MaxFileNameLength             = 63
+	MinMigrationNameLength        = 3
+	MaxMigrationNameLength        = 255
+	MaxTableDescriptionLength     = 1024
+	MaxColumnDescriptionLength    = 1024
+
+	// MaxIdentifierLength is the longest name postgres keeps, longer ones are truncated
+	MaxIdentifierLength = 63
 )
@@ -0,0 +1,20 @@
+package constants
+
+const (
+	MaintenanceActionTruncate = "truncate"
+	MaintenanceActionVacuum   = "vacuum"
+	MaintenanceActionReindex  = "reindex"
+)
+
+var MaintenanceActions = []interface{}{
+	MaintenanceActionTruncate,
+	MaintenanceActionVacuum,
+	MaintenanceActionReindex,
+}
+
+// MaintenanceJobTypes tracks each action as its own job type, so jobs can be listed per action
+var MaintenanceJobTypes = map[string]string{
+	MaintenanceActionTruncate: JobTypeTableTruncate,
+	MaintenanceActionVacuum:   JobTypeTableVacuum,
+	MaintenanceActionReindex:  JobTypeTableReindex,
+}
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	MigrationSourceAuto   = "auto"
+	MigrationSourceUpload = "upload"
+
+	MigrationStatusPending    = "pending"
+	MigrationStatusApplied    = "applied"
+	MigrationStatusRolledBack = "rolled_back"
+)
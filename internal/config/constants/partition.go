@@ -0,0 +1,43 @@
+package constants
+
+import "time"
+
+const (
+	PartitionStrategyRange = "range"
+	PartitionStrategyList  = "list"
+	PartitionStrategyHash  = "hash"
+
+	PartitionIntervalDay   = "day"
+	PartitionIntervalWeek  = "week"
+	PartitionIntervalMonth = "month"
+
+	// PartitionBoundsDefault is how postgres reports the bounds of a default partition
+	PartitionBoundsDefault = "DEFAULT"
+
+	DefaultPartitionPremake = 3
+	MaxPartitionPremake     = 100
+
+	// MaxPartitionRetention is counted in intervals, 0 keeps every partition
+	MaxPartitionRetention = 3650
+
+	// PartitionMaintenanceSchedulerInterval is how often due maintenance policies are picked up
+	PartitionMaintenanceSchedulerInterval = 5 * time.Minute
+
+	// PartitionMaintenanceRunInterval is the time between two maintenance runs of the same table
+	PartitionMaintenanceRunInterval = time.Hour
+
+	// PartitionMaintenanceBatchSize caps the policies claimed by one scheduler tick
+	PartitionMaintenanceBatchSize = 20
+)
+
+var PartitionStrategies = []interface{}{
+	PartitionStrategyRange,
+	PartitionStrategyList,
+	PartitionStrategyHash,
+}
+
+var PartitionIntervals = []interface{}{
+	PartitionIntervalDay,
+	PartitionIntervalWeek,
+	PartitionIntervalMonth,
+}
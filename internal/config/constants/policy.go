@@ -0,0 +1,22 @@
+package constants
+
+const (
+	PolicyCommandAll    = "ALL"
+	PolicyCommandSelect = "SELECT"
+	PolicyCommandInsert = "INSERT"
+	PolicyCommandUpdate = "UPDATE"
+	PolicyCommandDelete = "DELETE"
+
+	// PolicyRolePublic applies a policy to every role, it is a keyword rather than a role name
+	PolicyRolePublic = "public"
+
+	PolicyClaimsSetting = "request.jwt.claims"
+)
+
+var PolicyCommands = []interface{}{
+	PolicyCommandAll,
+	PolicyCommandSelect,
+	PolicyCommandInsert,
+	PolicyCommandUpdate,
+	PolicyCommandDelete,
+}
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	DefaultQueryTimeoutInMs = 5000
+	MaxQueryTimeoutInMs     = 60000
+	DefaultQueryMaxRows     = 500
+	MaxQueryRows            = 5000
+
+	// sequential scans on tables with at least this many live rows are flagged in query plans
+	SeqScanRowThreshold = 10000
+)
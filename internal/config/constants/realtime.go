@@ -0,0 +1,53 @@
+package constants
+
+import "time"
+
+const (
+	// RealtimeChannel is the notification channel every realtime trigger of a client database publishes to
+	RealtimeChannel = "fluxend_realtime"
+
+	// RealtimeTriggerName is the trigger created on tables that opt in, it calls RealtimeNotifyFunction
+	RealtimeTriggerName    = "fluxend_realtime"
+	RealtimeNotifyFunction = "fluxend.realtime_notify"
+
+	RealtimeEventInsert = "INSERT"
+	RealtimeEventUpdate = "UPDATE"
+	RealtimeEventDelete = "DELETE"
+
+	// Stream event types, change carries a row change and resync tells clients that changes may have been
+	// missed, so they should reload what they display
+	RealtimeStreamChange = "change"
+	RealtimeStreamResync = "resync"
+	RealtimeStreamClose  = "close"
+
+	// RealtimeSubscriberBuffer is how many changes may queue up for one subscriber before it is dropped
+	RealtimeSubscriberBuffer = 256
+
+	// RealtimeBacklogSize is how many changes per database are kept to replay after a reconnect
+	RealtimeBacklogSize = 1000
+
+	RealtimeMaxSubscribersPerDatabase = 200
+
+	RealtimeHeartbeatInterval = 15 * time.Second
+	RealtimeClientRetryInMs   = 3000
+
+	// pq reconnects a lost listener connection with a backoff between these intervals
+	RealtimeMinReconnectInterval = time.Second
+	RealtimeMaxReconnectInterval = 30 * time.Second
+
+	RealtimeListenTimeout = 10 * time.Second
+
+	// RealtimeListenerPingInterval checks an idle listener connection, a dead one is only noticed when used
+	RealtimeListenerPingInterval = 90 * time.Second
+
+	// RealtimeReadBackTimeoutInMs bounds reading a change back as the role of a project token
+	RealtimeReadBackTimeoutInMs = 2000
+
+	RealtimeSlowSubscriberError = "Subscriber could not keep up, reconnect to resume from the last event"
+)
+
+var RealtimeEvents = []string{
+	RealtimeEventInsert,
+	RealtimeEventUpdate,
+	RealtimeEventDelete,
+}
@@ -0,0 +1,64 @@
+package constants
+
+const (
+	// RoleAuthenticator is the login role PostgREST connects with, it switches to the role in the JWT claim
+	RoleAuthenticator = "authenticator"
+	RoleWebAnonymous  = "web_anon"
+
+	// RoleReader runs read-only console sessions, it reads every table and can't write or reach the server
+	RoleReader = "fluxend_reader"
+
+	// RoleProjectMarker is stored as the role comment, roles are cluster wide and this ties them to a project database
+	RoleProjectMarker = "fluxend project "
+
+	GrantObjectTable    = "table"
+	GrantObjectColumn   = "column"
+	GrantObjectSchema   = "schema"
+	GrantObjectFunction = "function"
+	GrantObjectSequence = "sequence"
+
+	PrivilegeAll = "ALL"
+
+	DefaultRoleTokenTTLInSeconds = 60 * 60
+	MaxRoleTokenTTLInSeconds     = 365 * 24 * 60 * 60
+
+	// RoleTokenAudience marks tokens issued for PostgREST, the API refuses tokens that carry an audience
+	RoleTokenAudience = "postgrest"
+)
+
+// RoleTokenServerClaims are always set by the server when a role token is issued
+var RoleTokenServerClaims = []string{"role", "exp", "iat", "aud"}
+
+// RoleTokenAllowedClaims are the only custom claims a role token takes, anything else that is
+// needed by policies goes under app_metadata or user_metadata
+var RoleTokenAllowedClaims = []string{"sub", "email", "phone", "app_metadata", "user_metadata"}
+
+var GrantObjectTypes = []interface{}{
+	GrantObjectTable,
+	GrantObjectColumn,
+	GrantObjectSchema,
+	GrantObjectFunction,
+	GrantObjectSequence,
+}
+
+// GrantPrivileges lists the privileges postgres accepts for each object type
+var GrantPrivileges = map[string][]string{
+	GrantObjectTable:    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", PrivilegeAll},
+	GrantObjectColumn:   {"SELECT", "INSERT", "UPDATE", "REFERENCES", PrivilegeAll},
+	GrantObjectSchema:   {"USAGE", "CREATE", PrivilegeAll},
+	GrantObjectFunction: {"EXECUTE", PrivilegeAll},
+	GrantObjectSequence: {"USAGE", "SELECT", "UPDATE", PrivilegeAll},
+}
+
+// ReservedRoleNames cannot be created or dropped through the roles API
+var ReservedRoleNames = []string{
+	"public",
+	"postgres",
+	"fluxend",
+	RoleAuthenticator,
+	RoleWebAnonymous,
+	RoleReader,
+}
+
+// ReservedRolePrefixes belong to postgres and to fluxend users
+var ReservedRolePrefixes = []string{"pg_", "usr_"}
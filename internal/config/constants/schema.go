@@ -0,0 +1,12 @@
+package constants
+
+// ReservedSchemaNames cannot be created, renamed or dropped through the schemas API, public can still be exposed
+var ReservedSchemaNames = []string{
+	"public",
+	"information_schema",
+	"fluxend",
+	TrashSchemaName,
+}
+
+// ReservedSchemaPrefixes belong to postgres
+var ReservedSchemaPrefixes = []string{"pg_"}
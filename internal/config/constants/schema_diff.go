@@ -0,0 +1,15 @@
+package constants
+
+const (
+	SchemaObjectSchema     = "schema"
+	SchemaObjectExtension  = "extension"
+	SchemaObjectTable      = "table"
+	SchemaObjectColumn     = "column"
+	SchemaObjectConstraint = "constraint"
+	SchemaObjectIndex      = "index"
+	SchemaObjectFunction   = "function"
+
+	SchemaChangeCreate = "create"
+	SchemaChangeAlter  = "alter"
+	SchemaChangeDrop   = "drop"
+)
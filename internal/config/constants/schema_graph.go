@@ -0,0 +1,15 @@
+package constants
+
+const (
+	SchemaGraphFormatJSON    = "json"
+	SchemaGraphFormatDBML    = "dbml"
+	SchemaGraphFormatMermaid = "mermaid"
+	SchemaGraphFormatDOT     = "dot"
+)
+
+var SchemaGraphFormats = []string{
+	SchemaGraphFormatJSON,
+	SchemaGraphFormatDBML,
+	SchemaGraphFormatMermaid,
+	SchemaGraphFormatDOT,
+}
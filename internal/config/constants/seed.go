@@ -0,0 +1,18 @@
+package constants
+
+const (
+	DefaultSeedRows = 50
+	MaxSeedRows     = 10000
+
+	// SeedParentSampleSize caps the parent rows sampled per foreign key
+	SeedParentSampleSize = 1000
+
+	// SeedBatchRows is the most rows sent in one insert, postgres allows 65535 parameters per statement
+	SeedBatchRows = 500
+
+	// SeedUniqueAttempts is how often a row is regenerated when it collides with an earlier one
+	SeedUniqueAttempts = 20
+
+	// SeedNullChance is the percentage of nullable columns left empty
+	SeedNullChance = 10
+)
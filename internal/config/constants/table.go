@@ -1,14 +1,38 @@
 package constants
 
 const (
-	ColumnTypeInteger   = "integer"
-	ColumnTypeSerial    = "serial"
-	ColumnTypeVarchar   = "varchar"
-	ColumnTypeText      = "text"
-	ColumnTypeBoolean   = "boolean"
-	ColumnTypeDate      = "date"
-	ColumnTypeTimestamp = "timestamp"
-	ColumnTypeFloat     = "float"
-	ColumnTypeUUID      = "uuid"
-	ColumnTypeJSON      = "json"
+	ColumnTypeInteger     = "integer"
+	ColumnTypeSmallint    = "smallint"
+	ColumnTypeBigint      = "bigint"
+	ColumnTypeSerial      = "serial"
+	ColumnTypeSmallSerial = "smallserial"
+	ColumnTypeBigSerial   = "bigserial"
+	ColumnTypeNumeric     = "numeric"
+	ColumnTypeDecimal     = "decimal"
+	ColumnTypeReal        = "real"
+	ColumnTypeDouble      = "double precision"
+	ColumnTypeFloat       = "float"
+	ColumnTypeVarchar     = "varchar"
+	ColumnTypeChar        = "char"
+	ColumnTypeText        = "text"
+	ColumnTypeBoolean     = "boolean"
+	ColumnTypeDate        = "date"
+	ColumnTypeTime        = "time"
+	ColumnTypeTimeTZ      = "timetz"
+	ColumnTypeTimestamp   = "timestamp"
+	ColumnTypeTimestampTZ = "timestamptz"
+	ColumnTypeInterval    = "interval"
+	ColumnTypeUUID        = "uuid"
+	ColumnTypeJSON        = "json"
+	ColumnTypeJSONB       = "jsonb"
+	ColumnTypeBytea       = "bytea"
+	ColumnTypeInet        = "inet"
+	ColumnTypeCidr        = "cidr"
+	ColumnTypeMacaddr     = "macaddr"
+)
+
+const (
+	MaxCharacterLength  = 10485760
+	MaxNumericPrecision = 1000
+	MaxTimePrecision    = 6
 )
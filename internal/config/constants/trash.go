@@ -0,0 +1,28 @@
+package constants
+
+import "time"
+
+const (
+	// TrashSchemaName holds copies of dropped tables and columns until they are restored or expire
+	TrashSchemaName = "fluxend_trash"
+
+	TrashKindTable  = "table"
+	TrashKindColumn = "column"
+
+	// snapshots are stored as pending before the drop runs and become active once it went through
+	TrashStatusPending = "pending"
+	TrashStatusActive  = "active"
+
+	// TrashPendingTimeout is how long a snapshot may stay pending before it counts as left over from a
+	// drop that never finished, such snapshots are purged like expired ones
+	TrashPendingTimeout = time.Hour
+
+	DefaultTrashRetentionDays = 7
+	MaxTrashRetentionDays     = 90
+
+	// TrashPurgeSchedulerInterval is how often expired snapshots are dropped
+	TrashPurgeSchedulerInterval = time.Hour
+
+	// TrashPurgeBatchSize caps the snapshots purged by one scheduler tick
+	TrashPurgeBatchSize = 50
+)
@@ -0,0 +1,36 @@
+package constants
+
+const (
+	TriggerTimingBefore    = "BEFORE"
+	TriggerTimingAfter     = "AFTER"
+	TriggerTimingInsteadOf = "INSTEAD OF"
+
+	TriggerLevelRow       = "ROW"
+	TriggerLevelStatement = "STATEMENT"
+
+	TriggerEventInsert   = "INSERT"
+	TriggerEventUpdate   = "UPDATE"
+	TriggerEventDelete   = "DELETE"
+	TriggerEventTruncate = "TRUNCATE"
+
+	// TriggerFunctionReturnType is the return type postgres requires for functions called by triggers
+	TriggerFunctionReturnType = "trigger"
+)
+
+var TriggerTimings = []interface{}{
+	TriggerTimingBefore,
+	TriggerTimingAfter,
+	TriggerTimingInsteadOf,
+}
+
+var TriggerLevels = []interface{}{
+	TriggerLevelRow,
+	TriggerLevelStatement,
+}
+
+var TriggerEvents = []interface{}{
+	TriggerEventInsert,
+	TriggerEventUpdate,
+	TriggerEventDelete,
+	TriggerEventTruncate,
+}
@@ -0,0 +1,20 @@
+package constants
+
+const (
+	TypeKindEnum      = "enum"
+	TypeKindComposite = "composite"
+
+	TypeActionAddValue    = "add_value"
+	TypeActionRenameValue = "rename_value"
+)
+
+var TypeKinds = []interface{}{
+	TypeKindEnum,
+	TypeKindComposite,
+}
+
+// TypeActions are the changes that can be made to an existing enum
+var TypeActions = []interface{}{
+	TypeActionAddValue,
+	TypeActionRenameValue,
+}
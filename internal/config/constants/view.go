@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const (
+	MinViewRefreshIntervalMinutes = 5
+	MaxViewRefreshIntervalMinutes = 7 * 24 * 60
+
+	// ViewRefreshSchedulerInterval is how often due materialized view refreshes are picked up
+	ViewRefreshSchedulerInterval = time.Minute
+
+	// ViewRefreshBatchSize caps the refreshes claimed by one scheduler tick
+	ViewRefreshBatchSize = 20
+)
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fluxend.view_refresh_schedules (
+     id SERIAL PRIMARY KEY,
+     project_uuid UUID NOT NULL REFERENCES fluxend.projects(uuid) ON DELETE CASCADE,
+     schema_name VARCHAR(63) NOT NULL,
+     view_name VARCHAR(63) NOT NULL,
+     interval_minutes INTEGER NOT NULL,
+     concurrently BOOLEAN NOT NULL DEFAULT FALSE,
+     next_refresh_at TIMESTAMP NOT NULL,
+     last_refreshed_at TIMESTAMP NULL,
+     last_error TEXT NOT NULL DEFAULT '',
+     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     UNIQUE (project_uuid, schema_name, view_name)
+);
+
+CREATE INDEX view_refresh_schedules_next_refresh_at_idx ON fluxend.view_refresh_schedules (next_refresh_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fluxend.view_refresh_schedules;
+-- +goose StatementEnd
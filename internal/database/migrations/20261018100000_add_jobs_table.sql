@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fluxend.jobs (
+     uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+     project_uuid UUID NOT NULL REFERENCES fluxend.projects(uuid) ON DELETE CASCADE,
+     type VARCHAR(50) NOT NULL,
+     status VARCHAR(20) NOT NULL,
+     payload JSONB NOT NULL DEFAULT '{}',
+     result JSONB NOT NULL DEFAULT '{}',
+     error TEXT NOT NULL DEFAULT '',
+     created_by UUID NOT NULL,
+     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     started_at TIMESTAMP NULL,
+     finished_at TIMESTAMP NULL
+);
+
+CREATE INDEX jobs_project_uuid_created_at_idx ON fluxend.jobs (project_uuid, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fluxend.jobs;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE fluxend.projects ADD COLUMN exposed_schemas TEXT[] NOT NULL DEFAULT '{public}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fluxend.projects DROP COLUMN exposed_schemas;
+-- +goose StatementEnd
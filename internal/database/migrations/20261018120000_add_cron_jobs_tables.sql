@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fluxend.cron_jobs (
+     uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+     project_uuid UUID NOT NULL REFERENCES fluxend.projects(uuid) ON DELETE CASCADE,
+     name VARCHAR(63) NOT NULL,
+     schedule VARCHAR(100) NOT NULL,
+     command TEXT NOT NULL,
+     timeout_in_ms INTEGER NOT NULL,
+     enabled BOOLEAN NOT NULL DEFAULT TRUE,
+     next_run_at TIMESTAMP NOT NULL,
+     last_run_at TIMESTAMP NULL,
+     created_by UUID NOT NULL,
+     updated_by UUID NOT NULL,
+     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     UNIQUE (project_uuid, name)
+);
+
+CREATE INDEX cron_jobs_next_run_at_idx ON fluxend.cron_jobs (next_run_at) WHERE enabled;
+
+CREATE TABLE fluxend.cron_job_runs (
+     uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+     job_uuid UUID NOT NULL REFERENCES fluxend.cron_jobs(uuid) ON DELETE CASCADE,
+     trigger VARCHAR(20) NOT NULL,
+     status VARCHAR(20) NOT NULL,
+     rows_affected BIGINT NOT NULL DEFAULT 0,
+     duration_ms BIGINT NOT NULL DEFAULT 0,
+     error TEXT NOT NULL DEFAULT '',
+     started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     finished_at TIMESTAMP NULL
+);
+
+CREATE INDEX cron_job_runs_job_uuid_started_at_idx ON fluxend.cron_job_runs (job_uuid, started_at DESC);
+-- at most one running run per job, a second one fails to insert and is recorded as skipped
+CREATE UNIQUE INDEX cron_job_runs_running_idx ON fluxend.cron_job_runs (job_uuid) WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fluxend.cron_job_runs;
+DROP TABLE IF EXISTS fluxend.cron_jobs;
+-- +goose StatementEnd
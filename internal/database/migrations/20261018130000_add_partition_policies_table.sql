@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fluxend.partition_policies (
+     id SERIAL PRIMARY KEY,
+     project_uuid UUID NOT NULL REFERENCES fluxend.projects(uuid) ON DELETE CASCADE,
+     schema_name VARCHAR(63) NOT NULL,
+     table_name VARCHAR(63) NOT NULL,
+     interval_unit VARCHAR(10) NOT NULL,
+     premake INTEGER NOT NULL,
+     retention INTEGER NOT NULL DEFAULT 0,
+     next_run_at TIMESTAMP NOT NULL,
+     last_run_at TIMESTAMP NULL,
+     last_error TEXT NOT NULL DEFAULT '',
+     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     UNIQUE (project_uuid, schema_name, table_name)
+);
+
+CREATE INDEX partition_policies_next_run_at_idx ON fluxend.partition_policies (next_run_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fluxend.partition_policies;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fluxend.trash_snapshots (
+     uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+     project_uuid UUID NOT NULL REFERENCES fluxend.projects(uuid) ON DELETE CASCADE,
+     kind VARCHAR(10) NOT NULL,
+     schema_name VARCHAR(63) NOT NULL,
+     table_name VARCHAR(63) NOT NULL,
+     column_name VARCHAR(63) NOT NULL DEFAULT '',
+     trash_table VARCHAR(63) NOT NULL,
+     definition TEXT[] NOT NULL,
+     restore_statements TEXT[] NOT NULL,
+     migration_sql TEXT NOT NULL,
+     created_by UUID NOT NULL,
+     expires_at TIMESTAMP NOT NULL,
+     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX trash_snapshots_project_uuid_idx ON fluxend.trash_snapshots (project_uuid);
+CREATE INDEX trash_snapshots_expires_at_idx ON fluxend.trash_snapshots (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fluxend.trash_snapshots;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE fluxend.jobs ADD COLUMN heartbeat_at TIMESTAMP NULL;
+
+UPDATE fluxend.jobs SET heartbeat_at = COALESCE(started_at, created_at) WHERE status IN ('pending', 'running');
+
+CREATE INDEX jobs_unfinished_heartbeat_at_idx ON fluxend.jobs (heartbeat_at) WHERE status IN ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS fluxend.jobs_unfinished_heartbeat_at_idx;
+ALTER TABLE fluxend.jobs DROP COLUMN IF EXISTS heartbeat_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE fluxend.trash_snapshots ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'active';
+
+CREATE INDEX trash_snapshots_pending_created_at_idx ON fluxend.trash_snapshots (created_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS fluxend.trash_snapshots_pending_created_at_idx;
+ALTER TABLE fluxend.trash_snapshots DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- read-only console sessions log in as authenticator and switch to fluxend_reader, it can read every
+-- table but holds no privilege a superuser connection would bring along
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'fluxend_reader') THEN
+            CREATE ROLE fluxend_reader NOLOGIN;
+        END IF;
+    END $$;
+
+GRANT pg_read_all_data TO fluxend_reader;
+GRANT fluxend_reader TO authenticator;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP ROLE IF EXISTS fluxend_reader;
+-- +goose StatementEnd
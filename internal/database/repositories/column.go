@@ -25,14 +25,29 @@ func (r *ColumnRepository) List(tableName string) ([]database.Column, error) {
 			a.attname AS name,
 			a.attnum AS position,
 			a.attnotnull AS not_null,
-			COALESCE(pg_catalog.format_type(a.atttypid, a.atttypmod), '') AS type,
+			-- user-defined types are reported schema qualified, the way columns are created with them
+			COALESCE(
+				CASE WHEN et.typtype IN ('e', 'c')
+					THEN quote_ident(en.nspname) || '.' || quote_ident(et.typname) || CASE WHEN t.typcategory = 'A' THEN '[]' ELSE '' END
+					ELSE pg_catalog.format_type(a.atttypid, a.atttypmod)
+				END,
+				''
+			) AS type,
 			COALESCE(pg_get_expr(ad.adbin, ad.adrelid), '') AS default_value,
+			a.attidentity <> '' OR a.attgenerated <> '' AS generated,
 			COALESCE(ct.contype = 'p', false) AS primary,
 			COALESCE(ct.contype = 'u', false) AS unique,
 			COALESCE(ct.contype = 'f', false) AS foreign,
 			ref_table.relname AS reference_table,
-			ref_col.attname AS reference_column
+			ref_col.attname AS reference_column,
+			COALESCE(col_description(a.attrelid, a.attnum), '') AS description
 		FROM pg_attribute a
+		JOIN pg_type t
+			ON t.oid = a.atttypid
+		JOIN pg_type et
+			ON et.oid = CASE WHEN t.typcategory = 'A' THEN t.typelem ELSE t.oid END
+		JOIN pg_namespace en
+			ON en.oid = et.typnamespace
 		LEFT JOIN pg_attrdef ad 
 			ON a.attrelid = ad.adrelid AND a.attnum = ad.adnum
 		LEFT JOIN pg_constraint ct 
@@ -49,8 +64,14 @@ func (r *ColumnRepository) List(tableName string) ([]database.Column, error) {
 	return columns, r.db.Select(&columns, query, tableName)
 }
 
+// Has and the other lookups resolve tableName like postgres does, so schema qualified names work
 func (r *ColumnRepository) Has(tableName, columnName string) (bool, error) {
-	return r.db.Exists("information_schema.columns", "table_name = $1 AND column_name = $2", tableName, columnName)
+	return r.db.Exists(
+		"pg_attribute",
+		"attrelid = to_regclass($1) AND attname = $2 AND attnum > 0 AND NOT attisdropped",
+		tableName,
+		columnName,
+	)
 }
 
 func (r *ColumnRepository) HasAny(tableName string, columns []database.Column) (bool, error) {
@@ -58,9 +79,11 @@ func (r *ColumnRepository) HasAny(tableName string, columns []database.Column) (
 	columnNames := r.mapColumnsToNames(columns)
 	query := `
 		SELECT COUNT(*)
-		FROM information_schema.columns
-		WHERE table_name = $1
-		AND column_name = ANY($2)
+		FROM pg_attribute
+		WHERE attrelid = to_regclass($1)
+		AND attname = ANY($2)
+		AND attnum > 0
+		AND NOT attisdropped
 	`
 
 	err := r.db.Get(&count, query, tableName, pq.Array(columnNames))
@@ -75,10 +98,12 @@ func (r *ColumnRepository) HasAll(tableName string, columns []database.Column) (
 	var count int
 	columnNames := r.mapColumnsToNames(columns)
 	query := `
-		SELECT COUNT(*) 
-		FROM information_schema.columns 
-		WHERE table_name = $1 
-		AND column_name = ANY($2)
+		SELECT COUNT(*)
+		FROM pg_attribute
+		WHERE attrelid = to_regclass($1)
+		AND attname = ANY($2)
+		AND attnum > 0
+		AND NOT attisdropped
 	`
 
 	err := r.db.Get(&count, query, tableName, pq.Array(columnNames))
@@ -104,6 +129,12 @@ func (r *ColumnRepository) CreateOne(tableName string, column database.Column) e
 			}
 		}
 
+		if commentQuery, ok := r.BuildCommentStatement(tableName, column); ok {
+			if _, err := tx.Exec(commentQuery); err != nil {
+				return fmt.Errorf("failed to add column comment: %w", err)
+			}
+		}
+
 		return nil
 	})
 }
@@ -147,6 +178,29 @@ func (r *ColumnRepository) AlterMany(tableName string, fields []database.Column)
 	return nil
 }
 
+// Alter runs the ALTER statements in one transaction, so a failing conversion leaves the table untouched
+func (r *ColumnRepository) Alter(statements []string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// HasUserDefinedType checks for an enum or composite type columns can be created with
+func (r *ColumnRepository) HasUserDefinedType(schema, typeName string) (bool, error) {
+	return r.db.Exists(
+		"pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace",
+		"t.typtype IN ('e', 'c') AND n.nspname = $1 AND t.typname = $2",
+		schema,
+		typeName,
+	)
+}
+
 func (r *ColumnRepository) Rename(tableName, oldColumnName, newColumnName string) error {
 	query := fmt.Sprintf(
 		"ALTER TABLE %s RENAME COLUMN %s TO %s",
@@ -158,10 +212,16 @@ func (r *ColumnRepository) Rename(tableName, oldColumnName, newColumnName string
 	return r.db.ExecWithErr(query)
 }
 
-func (r *ColumnRepository) Drop(tableName, columnName string) error {
-	query := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tableName, pq.QuoteIdentifier(columnName))
-	_, err := r.db.ExecWithRowsAffected(query)
-	return err
+// Comment sets the column comment, an empty description removes it
+func (r *ColumnRepository) Comment(tableName, columnName, description string) error {
+	query := fmt.Sprintf(
+		"COMMENT ON COLUMN %s.%s IS %s",
+		tableName,
+		pq.QuoteIdentifier(columnName),
+		commentLiteral(description),
+	)
+
+	return r.db.ExecWithErr(query)
 }
 
 func (r *ColumnRepository) DropMany(tableName string, columns []database.Column) error {
@@ -174,9 +234,7 @@ func (r *ColumnRepository) DropMany(tableName string, columns []database.Column)
 		drops = append(drops, fmt.Sprintf("DROP COLUMN %s", pq.QuoteIdentifier(column.Name)))
 	}
 
-	query := fmt.Sprintf("ALTER TABLE %s %s",
-		pq.QuoteIdentifier(tableName),
-		strings.Join(drops, ", "))
+	query := fmt.Sprintf("ALTER TABLE %s %s", tableName, strings.Join(drops, ", "))
 
 	_, err := r.db.ExecWithRowsAffected(query)
 
@@ -228,3 +286,26 @@ func (r *ColumnRepository) BuildForeignKeyConstraint(tableName string, column da
 		column.ReferenceColumn.String,
 	), true
 }
+
+// BuildCommentStatement returns the COMMENT ON COLUMN statement for columns with a description
+func (r *ColumnRepository) BuildCommentStatement(tableName string, column database.Column) (string, bool) {
+	if column.Description == "" {
+		return "", false
+	}
+
+	return fmt.Sprintf(
+		"COMMENT ON COLUMN %s.%s IS %s;",
+		tableName,
+		pq.QuoteIdentifier(column.Name),
+		commentLiteral(column.Description),
+	), true
+}
+
+// commentLiteral quotes a comment, postgres drops the comment when it is set to NULL
+func commentLiteral(description string) string {
+	if description == "" {
+		return "NULL"
+	}
+
+	return pq.QuoteLiteral(description)
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+const constraintColumns = `
+	con.conname AS name,
+	CASE con.contype
+		WHEN 'p' THEN 'primary_key'
+		WHEN 'u' THEN 'unique'
+		WHEN 'f' THEN 'foreign_key'
+		WHEN 'c' THEN 'check'
+		WHEN 'x' THEN 'exclusion'
+		ELSE con.contype::text
+	END AS type,
+	pg_get_constraintdef(con.oid) AS definition,
+	COALESCE((
+		SELECT array_agg(a.attname::text ORDER BY k.ord)
+		FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+	), '{}') AS columns,
+	CASE WHEN con.contype = 'f' THEN con.confrelid::regclass::text ELSE '' END AS reference_table,
+	COALESCE((
+		SELECT array_agg(a.attname::text ORDER BY k.ord)
+		FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+	), '{}') AS reference_columns,
+	CASE con.confdeltype
+		WHEN 'a' THEN 'NO ACTION'
+		WHEN 'r' THEN 'RESTRICT'
+		WHEN 'c' THEN 'CASCADE'
+		WHEN 'n' THEN 'SET NULL'
+		WHEN 'd' THEN 'SET DEFAULT'
+		ELSE ''
+	END AS on_delete,
+	CASE con.confupdtype
+		WHEN 'a' THEN 'NO ACTION'
+		WHEN 'r' THEN 'RESTRICT'
+		WHEN 'c' THEN 'CASCADE'
+		WHEN 'n' THEN 'SET NULL'
+		WHEN 'd' THEN 'SET DEFAULT'
+		ELSE ''
+	END AS on_update,
+	con.condeferrable AS deferrable,
+	con.condeferred AS initially_deferred,
+	con.convalidated AS validated
+`
+
+type ConstraintRepository struct {
+	db shared.DB
+}
+
+func NewConstraintRepository(injector *do.Injector) (database.ConstraintRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &ConstraintRepository{db: db}, nil
+}
+
+func (r *ConstraintRepository) List(fullTableName string) ([]database.Constraint, error) {
+	var constraints []database.Constraint
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_constraint con
+       WHERE con.conrelid = $1::regclass
+       ORDER BY con.conname
+    `, constraintColumns)
+
+	return constraints, r.db.Select(&constraints, query, r.quoteTableName(fullTableName))
+}
+
+func (r *ConstraintRepository) GetByName(fullTableName, constraintName string) (database.Constraint, error) {
+	var constraint database.Constraint
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_constraint con
+       WHERE con.conrelid = $1::regclass AND con.conname = $2
+    `, constraintColumns)
+
+	return constraint, r.db.GetWithNotFound(
+		&constraint,
+		"constraint.error.notFound",
+		query,
+		r.quoteTableName(fullTableName),
+		constraintName,
+	)
+}
+
+func (r *ConstraintRepository) Has(fullTableName, constraintName string) (bool, error) {
+	return r.db.Exists("pg_constraint", "conrelid = $1::regclass AND conname = $2", r.quoteTableName(fullTableName), constraintName)
+}
+
+func (r *ConstraintRepository) Create(constraintSQL string) error {
+	return r.db.ExecWithErr(constraintSQL)
+}
+
+func (r *ConstraintRepository) Drop(fullTableName, constraintName string) error {
+	query := fmt.Sprintf(
+		"ALTER TABLE %s DROP CONSTRAINT %s",
+		r.quoteTableName(fullTableName),
+		pq.QuoteIdentifier(constraintName),
+	)
+
+	return r.db.ExecWithErr(query)
+}
+
+// Validate checks existing rows against a constraint created with NOT VALID
+func (r *ConstraintRepository) Validate(fullTableName, constraintName string) error {
+	query := fmt.Sprintf(
+		"ALTER TABLE %s VALIDATE CONSTRAINT %s",
+		r.quoteTableName(fullTableName),
+		pq.QuoteIdentifier(constraintName),
+	)
+
+	return r.db.ExecWithErr(query)
+}
+
+func (r *ConstraintRepository) quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/cron"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type CronJobRepository struct {
+	db shared.DB
+}
+
+func NewCronJobRepository(injector *do.Injector) (cron.Repository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &CronJobRepository{db: db}, nil
+}
+
+func (r *CronJobRepository) ListForProject(projectUUID uuid.UUID) ([]cron.Job, error) {
+	query := "SELECT %s FROM fluxend.cron_jobs WHERE project_uuid = $1 ORDER BY name"
+	query = fmt.Sprintf(query, pkg.GetColumns[cron.Job]())
+
+	var jobs []cron.Job
+	return jobs, r.db.Select(&jobs, query, projectUUID)
+}
+
+func (r *CronJobRepository) GetByUUID(jobUUID uuid.UUID) (cron.Job, error) {
+	query := "SELECT %s FROM fluxend.cron_jobs WHERE uuid = $1"
+	query = fmt.Sprintf(query, pkg.GetColumns[cron.Job]())
+
+	var job cron.Job
+	return job, r.db.GetWithNotFound(&job, "cron.error.notFound", query, jobUUID)
+}
+
+func (r *CronJobRepository) ExistsByNameForProject(name string, projectUUID uuid.UUID) (bool, error) {
+	return r.db.Exists("fluxend.cron_jobs", "name = $1 AND project_uuid = $2", name, projectUUID)
+}
+
+func (r *CronJobRepository) Create(job *cron.Job) (*cron.Job, error) {
+	query := `
+        INSERT INTO fluxend.cron_jobs (
+            project_uuid, name, schedule, command, timeout_in_ms, enabled, next_run_at, created_by, updated_by
+        ) VALUES (
+            $1, $2, $3, $4, $5, $6, $7, $8, $9
+        )
+        RETURNING uuid, created_at, updated_at
+    `
+
+	return job, r.db.QueryRow(
+		query,
+		job.ProjectUuid,
+		job.Name,
+		job.Schedule,
+		job.Command,
+		job.TimeoutInMs,
+		job.Enabled,
+		job.NextRunAt,
+		job.CreatedBy,
+		job.UpdatedBy,
+	).Scan(&job.Uuid, &job.CreatedAt, &job.UpdatedAt)
+}
+
+func (r *CronJobRepository) SetEnabled(jobUUID uuid.UUID, enabled bool, nextRunAt time.Time, updatedBy uuid.UUID) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.cron_jobs SET enabled = $1, next_run_at = $2, updated_by = $3, updated_at = CURRENT_TIMESTAMP WHERE uuid = $4",
+		enabled,
+		nextRunAt,
+		updatedBy,
+		jobUUID,
+	)
+}
+
+func (r *CronJobRepository) MarkRan(jobUUID uuid.UUID, ranAt time.Time) error {
+	return r.db.ExecWithErr("UPDATE fluxend.cron_jobs SET last_run_at = $1 WHERE uuid = $2", ranAt, jobUUID)
+}
+
+func (r *CronJobRepository) Delete(jobUUID uuid.UUID) error {
+	return r.db.ExecWithErr("DELETE FROM fluxend.cron_jobs WHERE uuid = $1", jobUUID)
+}
+
+// ClaimDue moves the next run of due jobs forward and returns them. The rows stay locked until
+// the transaction commits and locked rows are skipped, so each run is picked up by one instance
+func (r *CronJobRepository) ClaimDue(limit int, nextRunAt func(job cron.Job) time.Time) ([]cron.Job, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM fluxend.cron_jobs
+		WHERE enabled AND next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, pkg.GetColumns[cron.Job]())
+
+	var jobs []cron.Job
+	err := r.db.WithTransaction(func(tx shared.Tx) error {
+		if err := tx.Select(&jobs, query, time.Now().UTC(), limit); err != nil {
+			return err
+		}
+
+		for i, job := range jobs {
+			jobs[i].NextRunAt = nextRunAt(job)
+
+			_, err := tx.Exec(
+				"UPDATE fluxend.cron_jobs SET next_run_at = $1 WHERE uuid = $2",
+				jobs[i].NextRunAt,
+				job.Uuid,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return jobs, err
+}
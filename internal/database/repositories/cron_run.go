@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/cron"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type CronRunRepository struct {
+	db shared.DB
+}
+
+func NewCronRunRepository(injector *do.Injector) (cron.RunRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &CronRunRepository{db: db}, nil
+}
+
+// ListForJob returns the latest runs first
+func (r *CronRunRepository) ListForJob(jobUUID uuid.UUID, limit int) ([]cron.Run, error) {
+	query := "SELECT %s FROM fluxend.cron_job_runs WHERE job_uuid = $1 ORDER BY started_at DESC LIMIT $2"
+	query = fmt.Sprintf(query, pkg.GetColumns[cron.Run]())
+
+	var runs []cron.Run
+	return runs, r.db.Select(&runs, query, jobUUID, limit)
+}
+
+// Create records a run, runs that do not start in the running state are finished right away
+func (r *CronRunRepository) Create(run *cron.Run) (*cron.Run, error) {
+	query := `
+        INSERT INTO fluxend.cron_job_runs (
+            job_uuid, trigger, status, error, finished_at
+        ) VALUES (
+            $1, $2, $3, $4, CASE WHEN $3::text = $5::text THEN NULL ELSE CURRENT_TIMESTAMP END
+        )
+        RETURNING uuid, started_at, finished_at
+    `
+
+	return run, r.db.QueryRow(
+		query,
+		run.JobUuid,
+		run.Trigger,
+		run.Status,
+		run.Error,
+		constants.CronRunStatusRunning,
+	).Scan(&run.Uuid, &run.StartedAt, &run.FinishedAt)
+}
+
+func (r *CronRunRepository) MarkFinished(run cron.Run) error {
+	return r.db.ExecWithErr(
+		`UPDATE fluxend.cron_job_runs
+		SET status = $1, rows_affected = $2, duration_ms = $3, error = $4, finished_at = CURRENT_TIMESTAMP
+		WHERE uuid = $5`,
+		run.Status,
+		run.RowsAffected,
+		run.DurationMs,
+		run.Error,
+		run.Uuid,
+	)
+}
+
+// FailStale closes runs that outlived their job's timeout by more than the grace period, the
+// instance running them went away. Runs within their timeout may still belong to another instance
+func (r *CronRunRepository) FailStale(errorMessage string, grace time.Duration) (int64, error) {
+	query := `
+		UPDATE fluxend.cron_job_runs AS run
+		SET status = $1, error = $2, finished_at = CURRENT_TIMESTAMP
+		FROM fluxend.cron_jobs AS job
+		WHERE run.job_uuid = job.uuid
+			AND run.status = $3
+			AND run.started_at < CURRENT_TIMESTAMP - make_interval(secs => (job.timeout_in_ms + $4) / 1000.0)
+	`
+
+	return r.db.ExecWithRowsAffected(
+		query,
+		constants.CronRunStatusFailed,
+		errorMessage,
+		constants.CronRunStatusRunning,
+		grace.Milliseconds(),
+	)
+}
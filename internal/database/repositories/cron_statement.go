@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"fluxend/internal/domain/cron"
+	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/samber/do"
+)
+
+type CronStatementRepository struct {
+	db shared.DB
+}
+
+func NewCronStatementRepository(injector *do.Injector) (cron.StatementRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &CronStatementRepository{db: db}, nil
+}
+
+// Run executes a job command in its own transaction and returns the rows affected by it
+func (r *CronStatementRepository) Run(ctx context.Context, statement string, timeoutInMs int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutInMs)); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, statement)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, tx.Commit()
+}
@@ -29,13 +29,21 @@ func (r *DatabaseStatsRepository) GetUnusedIndexes() ([]stats.UnusedIndex, error
 	var unusedIndexes []stats.UnusedIndex
 	err := r.db.Select(&unusedIndexes, `
 		SELECT 
-			relname AS table_name, 
-			indexrelname AS index_name, 
-			idx_scan AS index_scans,
-			pg_size_pretty(pg_relation_size(indexrelid)) AS index_size
-		FROM pg_stat_user_indexes
-		WHERE idx_scan < 50
-		ORDER BY idx_scan;
+			s.schemaname AS schema_name, 
+			s.relname AS table_name, 
+			s.indexrelname AS index_name, 
+			s.idx_scan AS index_scans,
+			pg_size_pretty(pg_relation_size(s.indexrelid)) AS index_size,
+			COALESCE((
+				SELECT array_agg(a.attname::text ORDER BY k.position)
+				FROM pg_index i
+				CROSS JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, position)
+				JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+				WHERE i.indexrelid = s.indexrelid AND k.position <= i.indnkeyatts
+			), '{}') AS columns
+		FROM pg_stat_user_indexes s
+		WHERE s.idx_scan < 50
+		ORDER BY s.idx_scan;
 	`)
 	if err != nil {
 		return nil, err
@@ -70,17 +78,32 @@ func (r *DatabaseStatsRepository) GetSizePerTable() ([]stats.TableSize, error) {
 	query := `
        SELECT 
           relname AS table_name, 
-          pg_size_pretty(pg_total_relation_size(relid)) AS total_size
+          pg_size_pretty(pg_total_relation_size(relid)) AS total_size,
+          pg_total_relation_size(relid) AS total_bytes
        FROM pg_catalog.pg_statio_user_tables
        ORDER BY pg_total_relation_size(relid) DESC;
     `
 	return tableSizes, r.db.Select(&tableSizes, query)
 }
 
+// GetTableSize adds up the partitions of a partitioned table, the parent itself holds no rows
+func (r *DatabaseStatsRepository) GetTableSize(fullTableName string) (stats.TableSize, error) {
+	var tableSize stats.TableSize
+	query := `
+       SELECT 
+          $1::text AS table_name, 
+          pg_size_pretty(COALESCE(SUM(pg_total_relation_size(relid)), 0)) AS total_size,
+          COALESCE(SUM(pg_total_relation_size(relid)), 0) AS total_bytes
+       FROM pg_partition_tree(to_regclass($1));
+    `
+	return tableSize, r.db.Get(&tableSize, query, fullTableName)
+}
+
 func (r *DatabaseStatsRepository) GetRowCountPerTable() ([]stats.TableRowCount, error) {
 	var rowCounts []stats.TableRowCount
 	query := `
        SELECT 
+          schemaname AS schema_name, 
           relname AS table_name, 
           n_live_tup AS estimated_row_count
        FROM pg_stat_user_tables
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+type DDLRepository struct {
+	db shared.DB
+}
+
+func NewDDLRepository(injector *do.Injector) (database.DDLRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &DDLRepository{db: db}, nil
+}
+
+func (r *DDLRepository) ListColumnDefinitions(fullTableName string) ([]database.ColumnDefinition, error) {
+	var columns []database.ColumnDefinition
+	query := `
+		SELECT
+			a.attname AS name,
+			-- user-defined types are reported schema qualified, the way columns are created with them
+			CASE WHEN et.typtype IN ('e', 'c')
+				THEN quote_ident(en.nspname) || '.' || quote_ident(et.typname) || CASE WHEN t.typcategory = 'A' THEN '[]' ELSE '' END
+				ELSE pg_catalog.format_type(a.atttypid, a.atttypmod)
+			END AS type,
+			a.attnotnull AS not_null,
+			CASE WHEN a.attgenerated = '' THEN COALESCE(pg_get_expr(ad.adbin, ad.adrelid), '') ELSE '' END AS default_value,
+			CASE WHEN a.attcollation <> t.typcollation THEN COALESCE(quote_ident(co.collname), '') ELSE '' END AS collation,
+			CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE '' END AS identity,
+			CASE WHEN a.attgenerated <> '' THEN COALESCE(pg_get_expr(ad.adbin, ad.adrelid), '') ELSE '' END AS generated,
+			COALESCE(col_description(a.attrelid, a.attnum), '') AS description
+		FROM pg_attribute a
+		JOIN pg_type t
+			ON t.oid = a.atttypid
+		JOIN pg_type et
+			ON et.oid = CASE WHEN t.typcategory = 'A' THEN t.typelem ELSE t.oid END
+		JOIN pg_namespace en
+			ON en.oid = et.typnamespace
+		LEFT JOIN pg_attrdef ad
+			ON a.attrelid = ad.adrelid AND a.attnum = ad.adnum
+		LEFT JOIN pg_collation co
+			ON co.oid = a.attcollation
+		WHERE a.attrelid = $1::regclass
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`
+
+	return columns, r.db.Select(&columns, query, fullTableName)
+}
+
+// ListGrants leaves out the privileges of the owner, they come with owning the table
+func (r *DDLRepository) ListGrants(fullTableName string) ([]database.TableGrant, error) {
+	var grants []database.TableGrant
+	query := `
+		SELECT grantee, column_name, array_agg(privilege ORDER BY privilege) AS privileges, grantable
+		FROM (
+			SELECT
+				CASE WHEN acl.grantee = 0 THEN 'PUBLIC' ELSE pg_get_userbyid(acl.grantee) END AS grantee,
+				'' AS column_name,
+				acl.privilege_type AS privilege,
+				acl.is_grantable AS grantable
+			FROM pg_class c
+			CROSS JOIN LATERAL aclexplode(c.relacl) acl
+			WHERE c.oid = $1::regclass AND acl.grantee <> c.relowner
+			UNION ALL
+			SELECT
+				CASE WHEN acl.grantee = 0 THEN 'PUBLIC' ELSE pg_get_userbyid(acl.grantee) END,
+				a.attname,
+				acl.privilege_type,
+				acl.is_grantable
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			CROSS JOIN LATERAL aclexplode(a.attacl) acl
+			WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped AND acl.grantee <> c.relowner
+		) grants
+		GROUP BY grantee, column_name, grantable
+		ORDER BY grantee, column_name, grantable
+	`
+
+	return grants, r.db.Select(&grants, query, fullTableName)
+}
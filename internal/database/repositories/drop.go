@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+// maxDependencyDepth stops the walk on long chains of views built on views
+const maxDependencyDepth = 10
+
+type DropRepository struct {
+	db shared.DB
+}
+
+func NewDropRepository(injector *do.Injector) (database.DropRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &DropRepository{db: db}, nil
+}
+
+// ListDependencies follows normal and automatic dependencies. Views depend through their rewrite rule,
+// the rule is swapped for the view so objects built on the view are found too. Functions taking the
+// row type of the table hang off its type, which the table owns internally
+func (r *DropRepository) ListDependencies(fullTableName, columnName string) ([]database.Dependency, error) {
+	query := `
+		WITH RECURSIVE dependents (classid, objid, objsubid, refclassid, refobjid, refobjsubid, deptype, depth) AS (
+			SELECT
+				CASE WHEN rw.oid IS NULL THEN d.classid ELSE 'pg_class'::regclass END,
+				COALESCE(rw.ev_class, d.objid),
+				CASE WHEN rw.oid IS NULL THEN d.objsubid ELSE 0 END,
+				d.refclassid,
+				d.refobjid,
+				d.refobjsubid,
+				d.deptype,
+				1
+			FROM pg_depend d
+			LEFT JOIN pg_rewrite rw ON d.classid = 'pg_rewrite'::regclass AND rw.oid = d.objid
+			WHERE d.refclassid = 'pg_class'::regclass
+				AND d.refobjid = to_regclass($1)
+				AND (
+					$2::text = ''
+					OR d.refobjsubid = (SELECT attnum FROM pg_attribute WHERE attrelid = to_regclass($1) AND attname = $2)
+				)
+				AND (d.deptype IN ('n', 'a') OR (d.classid = 'pg_type'::regclass AND d.deptype = 'i'))
+			UNION
+			SELECT
+				CASE WHEN rw.oid IS NULL THEN d.classid ELSE 'pg_class'::regclass END,
+				COALESCE(rw.ev_class, d.objid),
+				CASE WHEN rw.oid IS NULL THEN d.objsubid ELSE 0 END,
+				p.classid,
+				p.objid,
+				p.objsubid,
+				d.deptype,
+				p.depth + 1
+			FROM dependents p
+			JOIN pg_depend d ON d.refclassid = p.classid AND d.refobjid = p.objid
+			LEFT JOIN pg_rewrite rw ON d.classid = 'pg_rewrite'::regclass AND rw.oid = d.objid
+			WHERE p.depth < $3
+				AND COALESCE(rw.ev_class, d.objid) <> p.objid
+				AND (d.deptype IN ('n', 'a') OR (d.classid = 'pg_type'::regclass AND d.deptype = 'i'))
+		)
+		SELECT object_type, object_schema, object_identity, depends_on, depth, requires_cascade
+		FROM (
+			SELECT DISTINCT ON (dep.classid, dep.objid, dep.objsubid)
+				o.type AS object_type,
+				COALESCE(o.schema, '') AS object_schema,
+				o.identity AS object_identity,
+				parent.identity AS depends_on,
+				dep.depth,
+				dep.deptype = 'n' AS requires_cascade
+			FROM dependents dep
+			CROSS JOIN LATERAL pg_identify_object(dep.classid, dep.objid, dep.objsubid) o
+			CROSS JOIN LATERAL pg_identify_object(dep.refclassid, dep.refobjid, dep.refobjsubid) parent
+			WHERE dep.classid NOT IN ('pg_type'::regclass, 'pg_attrdef'::regclass)
+				AND NOT (dep.classid = 'pg_class'::regclass AND dep.objid = to_regclass($1))
+			ORDER BY dep.classid, dep.objid, dep.objsubid, dep.deptype DESC, dep.depth
+		) dependencies
+		ORDER BY depth, object_type, object_identity
+	`
+
+	var dependencies []database.Dependency
+
+	return dependencies, r.db.Select(&dependencies, query, fullTableName, columnName, maxDependencyDepth)
+}
+
+// ListReferencingFunctions matches function bodies as text and similarly named objects can still
+// match. A body counts when it names the table with its schema, or without one while the table is
+// reachable from the function: it lives in the function's schema, in public or in its search_path.
+// Functions of extensions are left out
+func (r *DropRepository) ListReferencingFunctions(schema, tableName, columnName string) ([]database.Dependency, error) {
+	query := `
+		WITH target AS (
+			SELECT
+				regexp_replace($1, '(\W)', '\\\1', 'g') AS schema_pattern,
+				regexp_replace($2, '(\W)', '\\\1', 'g') AS table_pattern,
+				regexp_replace($3, '(\W)', '\\\1', 'g') AS column_pattern
+		)
+		SELECT
+			'function' AS object_type,
+			n.nspname AS object_schema,
+			format('%I.%I(%s)', n.nspname, p.proname, pg_get_function_identity_arguments(p.oid)) AS object_identity,
+			'' AS depends_on,
+			0 AS depth,
+			false AS requires_cascade
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		CROSS JOIN target t
+		WHERE n.nspname NOT LIKE 'pg\_%'
+			AND n.nspname NOT IN ('information_schema', 'fluxend')
+			AND p.prokind IN ('f', 'p')
+			AND (
+				p.prosrc ~* ('(^|[^\w."])"?' || t.schema_pattern || '"?\s*\.\s*"?' || t.table_pattern || '"?([^\w]|$)')
+				OR (
+					p.prosrc ~* ('(^|[^\w."])"?' || t.table_pattern || '"?([^\w]|$)')
+					AND (
+						n.nspname = $1
+						OR $1 = 'public'
+						OR EXISTS (
+							SELECT 1 FROM unnest(p.proconfig) AS setting
+							WHERE setting LIKE 'search_path=%' AND strpos(lower(setting), lower($1)) > 0
+						)
+					)
+				)
+			)
+			AND ($3::text = '' OR p.prosrc ~* ('(^|[^\w"])"?' || t.column_pattern || '"?([^\w]|$)'))
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend d
+				WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+			)
+		ORDER BY object_identity
+	`
+
+	var functions []database.Dependency
+
+	return functions, r.db.Select(&functions, query, schema, tableName, columnName)
+}
+
+// Execute runs the statements in one transaction, a failing drop leaves no half made snapshot behind
+func (r *DropRepository) Execute(statements ...string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+const extensionQuery = `
+	SELECT
+		a.name,
+		COALESCE(a.default_version, '') AS default_version,
+		COALESCE(a.installed_version, '') AS installed_version,
+		COALESCE(n.nspname, '') AS schema,
+		COALESCE(a.comment, '') AS comment
+	FROM pg_available_extensions a
+	LEFT JOIN pg_extension e ON e.extname = a.name
+	LEFT JOIN pg_namespace n ON n.oid = e.extnamespace
+`
+
+type ExtensionRepository struct {
+	db shared.DB
+}
+
+func NewExtensionRepository(injector *do.Injector) (database.ExtensionRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &ExtensionRepository{db: db}, nil
+}
+
+func (r *ExtensionRepository) List() ([]database.Extension, error) {
+	var extensions []database.Extension
+
+	return extensions, r.db.Select(&extensions, extensionQuery+" ORDER BY a.name")
+}
+
+func (r *ExtensionRepository) GetByName(name string) (database.Extension, error) {
+	var extension database.Extension
+
+	return extension, r.db.GetWithNotFound(&extension, "extension.error.notAvailable", extensionQuery+" WHERE a.name = $1", name)
+}
+
+func (r *ExtensionRepository) Create(extensionSQL string) error {
+	return r.db.ExecWithErr(extensionSQL)
+}
+
+func (r *ExtensionRepository) Drop(extensionSQL string) error {
+	return r.db.ExecWithErr(extensionSQL)
+}
@@ -1,58 +1,151 @@
 package repositories
 
 import (
+	"context"
+	"database/sql"
 	"fluxend/internal/domain/database"
 	"fluxend/internal/domain/shared"
 	"fmt"
-	"github.com/lib/pq"
 	"github.com/samber/do"
+	"time"
 )
 
 type FunctionRepository struct {
 	db shared.DB
 }
 
-func NewFunctionRepository(injector *do.Injector) (*FunctionRepository, error) {
+func NewFunctionRepository(injector *do.Injector) (database.FunctionRepository, error) {
 	db := do.MustInvoke[shared.DB](injector)
 	return &FunctionRepository{db: db}, nil
 }
 
+// functionColumns takes the expression used for the definition, listings only carry the body
+const functionColumns = `
+	p.proname AS routine_name,
+	'FUNCTION' AS routine_type,
+	format_type(p.prorettype, NULL) AS data_type,
+	t.typname AS type_udt_name,
+	%s AS routine_definition,
+	l.lanname AS external_language,
+	CASE WHEN p.provolatile = 'v' THEN 'MODIFIES' ELSE 'READS' END AS sql_data_access,
+	pg_get_function_identity_arguments(p.oid) AS arguments,
+	oidvectortypes(p.proargtypes) AS argument_types,
+	CASE p.provolatile WHEN 'i' THEN 'IMMUTABLE' WHEN 's' THEN 'STABLE' ELSE 'VOLATILE' END AS volatility,
+	p.prosecdef AS security_definer,
+	COALESCE((
+		SELECT substring(setting FROM 'search_path=(.*)')
+		FROM unnest(p.proconfig) AS setting
+		WHERE setting LIKE 'search_path=%%'
+	), '') AS search_path,
+	COALESCE((
+		SELECT e.extname FROM pg_depend d JOIN pg_extension e ON e.oid = d.refobjid
+		WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+	), '') AS extension
+`
+
+const functionJoins = `
+	FROM pg_proc p
+	JOIN pg_namespace n ON n.oid = p.pronamespace
+	JOIN pg_language l ON l.oid = p.prolang
+	JOIN pg_type t ON t.oid = p.prorettype
+`
+
 func (r *FunctionRepository) List(schema string) ([]database.Function, error) {
 	var functions []database.Function
-	query := `
-       SELECT routine_name, routine_type, data_type, type_udt_name, routine_definition, external_language, sql_data_access
-       FROM information_schema.routines 
-       WHERE routine_type = 'FUNCTION' AND specific_schema = $1`
+	query := fmt.Sprintf(`
+       SELECT %s %s
+       WHERE p.prokind = 'f' AND n.nspname = $1
+       ORDER BY p.proname, argument_types`,
+		fmt.Sprintf(functionColumns, "p.prosrc"),
+		functionJoins,
+	)
 
 	return functions, r.db.Select(&functions, query, schema)
 }
 
+func (r *FunctionRepository) ListByName(schema, functionName string) ([]database.Function, error) {
+	var functions []database.Function
+	query := fmt.Sprintf(`
+       SELECT %s %s
+       WHERE p.prokind = 'f' AND n.nspname = $1 AND p.proname = $2
+       ORDER BY argument_types`,
+		fmt.Sprintf(functionColumns, "pg_get_functiondef(p.oid)"),
+		functionJoins,
+	)
+
+	return functions, r.db.Select(&functions, query, schema, functionName)
+}
+
+// GetBySignature resolves a signature such as "public"."total"(integer, text) the way postgres
+// resolves function references, so type aliases like int and int4 match as well
+func (r *FunctionRepository) GetBySignature(signature string) (database.Function, error) {
+	var function database.Function
+	query := fmt.Sprintf(`
+       SELECT %s %s
+       WHERE p.prokind = 'f' AND p.oid = to_regprocedure($1)`,
+		fmt.Sprintf(functionColumns, "pg_get_functiondef(p.oid)"),
+		functionJoins,
+	)
+
+	return function, r.db.GetWithNotFound(&function, "function.error.notFound", query, signature)
+}
+
+func (r *FunctionRepository) Has(signature string) (bool, error) {
+	return r.db.Exists("pg_proc", "oid = to_regprocedure($1)", signature)
+}
+
 func (r *FunctionRepository) Create(functionSQL string) error {
 	_, err := r.db.ExecWithRowsAffected(functionSQL)
 	return err
 }
 
-func (r *FunctionRepository) GetByName(schema, functionName string) (database.Function, error) {
-	var function database.Function
-	query := `
-       SELECT 
-          r.routine_name, 
-          r.routine_type, 
-          r.data_type, 
-          r.type_udt_name, 
-          r.external_language, 
-          r.sql_data_access,
-          pg_get_functiondef(p.oid) AS routine_definition
-       FROM information_schema.routines r
-       JOIN pg_proc p ON r.routine_name = p.proname
-       JOIN pg_namespace n ON p.pronamespace = n.oid
-       WHERE r.specific_schema = $1 AND r.routine_name = $2 AND n.nspname = $1`
-
-	return function, r.db.Get(&function, query, schema, functionName)
-}
-
-func (r *FunctionRepository) Delete(schema, functionName string) error {
-	query := fmt.Sprintf(`DROP FUNCTION IF EXISTS %s.%s CASCADE`, pq.QuoteIdentifier(schema), pq.QuoteIdentifier(functionName))
-
-	return r.db.ExecWithErr(query)
+func (r *FunctionRepository) Drop(functionSQL string) error {
+	return r.db.ExecWithErr(functionSQL)
+}
+
+// Execute calls a function inside its own transaction. Read-only transactions are always
+// rolled back, everything else is committed once the rows have been read
+func (r *FunctionRepository) Execute(ctx context.Context, statement string, args []interface{}, options database.FunctionExecuteOptions) (database.FunctionResult, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: options.ReadOnly})
+	if err != nil {
+		return database.FunctionResult{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", options.TimeoutInMs)); err != nil {
+		return database.FunctionResult{}, err
+	}
+
+	startedAt := time.Now()
+
+	rows, err := tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return database.FunctionResult{}, err
+	}
+
+	// one extra row is read to find out whether the result was cut off
+	columns, resultRows, err := scanRows(rows, options.MaxRows+1)
+	if err != nil {
+		return database.FunctionResult{}, err
+	}
+
+	truncated := len(resultRows) > options.MaxRows
+	if truncated {
+		resultRows = resultRows[:options.MaxRows]
+	}
+
+	if !options.ReadOnly {
+		if err = tx.Commit(); err != nil {
+			return database.FunctionResult{}, err
+		}
+	}
+
+	return database.FunctionResult{
+		Columns:    columns,
+		Rows:       resultRows,
+		RowCount:   len(resultRows),
+		Truncated:  truncated,
+		ReadOnly:   options.ReadOnly,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}, nil
 }
@@ -1,13 +1,60 @@
 package repositories
 
 import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
 	"fluxend/internal/domain/shared"
 	"fmt"
 	"github.com/lib/pq"
 	"github.com/samber/do"
-	"strings"
 )
 
+// indexQuery reads keys one position at a time, indoption holds the DESC (1) and NULLS FIRST (2) bits of each key
+const indexQuery = `
+	SELECT
+		ic.relname AS name,
+		pg_get_indexdef(i.indexrelid) AS definition,
+		am.amname AS method,
+		i.indisunique AS is_unique,
+		i.indisprimary AS is_primary,
+		i.indisvalid AS is_valid,
+		COALESCE(pg_get_expr(i.indpred, i.indrelid, true), '') AS predicate,
+		ARRAY(
+			SELECT pg_get_indexdef(i.indexrelid, k, true)
+			FROM generate_series(i.indnkeyatts + 1, i.indnatts) k
+			ORDER BY k
+		) AS include_columns,
+		pg_relation_size(i.indexrelid) AS size_bytes,
+		ARRAY(
+			SELECT pg_get_indexdef(i.indexrelid, k, true)
+			FROM generate_series(1, i.indnkeyatts) k
+			ORDER BY k
+		) AS key_names,
+		ARRAY(
+			SELECT i.indkey[k - 1] = 0
+			FROM generate_series(1, i.indnkeyatts) k
+			ORDER BY k
+		) AS key_expressions,
+		ARRAY(
+			SELECT i.indoption[k - 1]
+			FROM generate_series(1, i.indnkeyatts) k
+			ORDER BY k
+		) AS key_options
+	FROM pg_index i
+	JOIN pg_class ic ON ic.oid = i.indexrelid
+	JOIN pg_class t ON t.oid = i.indrelid
+	JOIN pg_namespace n ON n.oid = t.relnamespace
+	JOIN pg_am am ON am.oid = ic.relam
+	WHERE n.nspname = $1 AND t.relname = $2
+`
+
+type indexRow struct {
+	database.Index
+	KeyNames       pq.StringArray `db:"key_names"`
+	KeyExpressions pq.BoolArray   `db:"key_expressions"`
+	KeyOptions     pq.Int64Array  `db:"key_options"`
+}
+
 type IndexRepository struct {
 	db shared.DB
 }
@@ -17,53 +64,89 @@ func NewIndexRepository(injector *do.Injector) (*IndexRepository, error) {
 	return &IndexRepository{db: db}, nil
 }
 
-func (r *IndexRepository) GetByName(tableName, indexName string) (string, error) {
-	var index string
-	query := `
-       SELECT indexdef
-       FROM pg_indexes
-       WHERE tablename = $1 AND indexname = $2
-    `
-	return index, r.db.Get(&index, query, tableName, indexName)
+func (r *IndexRepository) GetByName(schema, tableName, indexName string) (database.Index, error) {
+	var row indexRow
+	err := r.db.GetWithNotFound(&row, "index.error.notFound", indexQuery+" AND ic.relname = $3", schema, tableName, indexName)
+	if err != nil {
+		return database.Index{}, err
+	}
+
+	return row.toIndex(), nil
 }
 
-func (r *IndexRepository) Has(tableName, indexName string) (bool, error) {
-	return r.db.Exists("pg_indexes", "tablename = $1 AND indexname = $2", tableName, indexName)
+// Has checks the whole schema, index names are unique per schema rather than per table
+func (r *IndexRepository) Has(schema, indexName string) (bool, error) {
+	return r.db.Exists("pg_indexes", "schemaname = $1 AND indexname = $2", schema, indexName)
 }
 
-func (r *IndexRepository) List(tableName string) ([]string, error) {
-	var indexes []string
+func (r *IndexRepository) List(schema, tableName string) ([]database.Index, error) {
+	var rows []indexRow
+	if err := r.db.Select(&rows, indexQuery+" ORDER BY ic.relname", schema, tableName); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]database.Index, len(rows))
+	for i, row := range rows {
+		indexes[i] = row.toIndex()
+	}
+
+	return indexes, nil
+}
+
+// ListDefinitions skips indexes backing primary key, unique and exclusion constraints, those are owned by the constraint
+func (r *IndexRepository) ListDefinitions(schema, tableName string) ([]database.Index, error) {
+	var indexes []database.Index
 	query := `
-       SELECT indexname
-       FROM pg_indexes
-       WHERE tablename = $1
+       SELECT i.indexname AS name, i.indexdef AS definition
+       FROM pg_indexes i
+       WHERE i.schemaname = $1 AND i.tablename = $2
+         AND NOT EXISTS (
+            SELECT 1
+            FROM pg_constraint con
+            WHERE con.conindid = format('%I.%I', i.schemaname, i.indexname)::regclass
+              AND con.contype IN ('p', 'u', 'x')
+         )
+       ORDER BY i.indexname
     `
-	return indexes, r.db.Select(&indexes, query, tableName)
+	return indexes, r.db.Select(&indexes, query, schema, tableName)
 }
 
-func (r *IndexRepository) Create(tableName string, indexName string, columns []string, isUnique bool) (bool, error) {
-	unique := ""
-	if isUnique {
-		unique = "UNIQUE"
-	}
-
-	columnsStr := strings.Join(columns, ", ")
+// Create runs outside a transaction, CREATE INDEX CONCURRENTLY refuses to run inside one
+func (r *IndexRepository) Create(indexSQL string) error {
+	return r.db.ExecWithErr(indexSQL)
+}
 
-	query := fmt.Sprintf(
-		"CREATE %s INDEX %s ON %s (%s)",
-		unique,
-		pq.QuoteIdentifier(indexName),
-		pq.QuoteIdentifier(tableName),
-		columnsStr,
-	)
+func (r *IndexRepository) DropIfExists(schema, indexName string) (bool, error) {
+	query := fmt.Sprintf("DROP INDEX IF EXISTS %s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(indexName))
 
 	_, err := r.db.ExecWithRowsAffected(query)
 	return err == nil, err
 }
 
-func (r *IndexRepository) DropIfExists(indexName string) (bool, error) {
-	query := fmt.Sprintf("DROP INDEX IF EXISTS %s", pq.QuoteIdentifier(indexName))
+func (row indexRow) toIndex() database.Index {
+	index := row.Index
+	index.Columns = make([]database.IndexColumn, len(row.KeyNames))
 
-	_, err := r.db.ExecWithRowsAffected(query)
-	return err == nil, err
+	for i, name := range row.KeyNames {
+		column := database.IndexColumn{
+			Name:         name,
+			IsExpression: i < len(row.KeyExpressions) && row.KeyExpressions[i],
+		}
+
+		if index.Method == constants.IndexMethodBtree && i < len(row.KeyOptions) {
+			column.Order = constants.IndexOrderAsc
+			if row.KeyOptions[i]&1 == 1 {
+				column.Order = constants.IndexOrderDesc
+			}
+
+			column.Nulls = constants.IndexNullsLast
+			if row.KeyOptions[i]&2 == 2 {
+				column.Nulls = constants.IndexNullsFirst
+			}
+		}
+
+		index.Columns[i] = column
+	}
+
+	return index
 }
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/job"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type JobRepository struct {
+	db shared.DB
+}
+
+func NewJobRepository(injector *do.Injector) (job.Repository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &JobRepository{db: db}, nil
+}
+
+// ListForProject returns the latest jobs first, an empty type lists every type
+func (r *JobRepository) ListForProject(projectUUID uuid.UUID, jobType string) ([]job.Job, error) {
+	query := `
+       SELECT %s FROM fluxend.jobs
+       WHERE project_uuid = $1 AND ($2::text = '' OR type = $2)
+       ORDER BY created_at DESC
+       LIMIT 100
+    `
+
+	query = fmt.Sprintf(query, pkg.GetColumns[job.Job]())
+
+	var jobs []job.Job
+	return jobs, r.db.Select(&jobs, query, projectUUID, jobType)
+}
+
+func (r *JobRepository) GetByUUID(jobUUID uuid.UUID) (job.Job, error) {
+	query := "SELECT %s FROM fluxend.jobs WHERE uuid = $1"
+	query = fmt.Sprintf(query, pkg.GetColumns[job.Job]())
+
+	var fetchedJob job.Job
+	return fetchedJob, r.db.GetWithNotFound(&fetchedJob, "job.error.notFound", query, jobUUID)
+}
+
+func (r *JobRepository) Create(job *job.Job) (*job.Job, error) {
+	query := `
+        INSERT INTO fluxend.jobs (
+            project_uuid, type, status, payload, result, created_by, heartbeat_at
+        ) VALUES (
+            $1, $2, $3, $4, $5, $6, NOW()
+        )
+        RETURNING uuid, created_at
+    `
+
+	return job, r.db.QueryRow(
+		query,
+		job.ProjectUuid, job.Type, job.Status, []byte(job.Payload), []byte(job.Result), job.CreatedBy,
+	).Scan(&job.Uuid, &job.CreatedAt)
+}
+
+func (r *JobRepository) MarkRunning(jobUUID uuid.UUID) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.jobs SET status = $1, started_at = NOW(), heartbeat_at = NOW() WHERE uuid = $2",
+		constants.JobStatusRunning, jobUUID,
+	)
+}
+
+func (r *JobRepository) MarkCompleted(jobUUID uuid.UUID, result json.RawMessage) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.jobs SET status = $1, result = $2, finished_at = NOW() WHERE uuid = $3",
+		constants.JobStatusCompleted, []byte(result), jobUUID,
+	)
+}
+
+func (r *JobRepository) MarkFailed(jobUUID uuid.UUID, errorMessage string) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.jobs SET status = $1, error = $2, finished_at = NOW() WHERE uuid = $3",
+		constants.JobStatusFailed, errorMessage, jobUUID,
+	)
+}
+
+func (r *JobRepository) Heartbeat(jobUUID uuid.UUID) error {
+	return r.db.ExecWithErr("UPDATE fluxend.jobs SET heartbeat_at = NOW() WHERE uuid = $1", jobUUID)
+}
+
+// FailAbandoned closes unfinished jobs without a heartbeat for longer than the timeout. Jobs still
+// running on other instances keep sending heartbeats and are left alone
+func (r *JobRepository) FailAbandoned(errorMessage string, timeout time.Duration) (int64, error) {
+	query := `
+        UPDATE fluxend.jobs
+        SET status = $1, error = $2, finished_at = NOW()
+        WHERE status IN ($3, $4)
+          AND COALESCE(heartbeat_at, created_at) < NOW() - make_interval(secs => $5)
+    `
+
+	return r.db.ExecWithRowsAffected(
+		query,
+		constants.JobStatusFailed, errorMessage, constants.JobStatusPending, constants.JobStatusRunning, timeout.Seconds(),
+	)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/maintenance"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+type MaintenanceRepository struct {
+	db shared.DB
+}
+
+func NewMaintenanceRepository(injector *do.Injector) (maintenance.Repository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &MaintenanceRepository{db: db}, nil
+}
+
+// Execute runs outside of a transaction, VACUUM refuses to run inside one
+func (r *MaintenanceRepository) Execute(statement string) error {
+	return r.db.ExecWithErr(statement)
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/guregu/null/v6"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"time"
+)
+
+// migrations live in a private schema of the client database, so they are never exposed through PostgREST.
+// The schema and table are created when the database is provisioned
+const migrationsTable = "fluxend.fluxend_migrations"
+
+type MigrationRepository struct {
+	db shared.DB
+}
+
+func NewMigrationRepository(injector *do.Injector) (database.MigrationRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &MigrationRepository{db: db}, nil
+}
+
+func (r *MigrationRepository) List() ([]database.Migration, error) {
+	var migrations []database.Migration
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY version", migrationsTable)
+
+	return migrations, r.db.Select(&migrations, query)
+}
+
+func (r *MigrationRepository) ListByStatus(statuses []string, descending bool) ([]database.Migration, error) {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	var migrations []database.Migration
+	query := fmt.Sprintf("SELECT * FROM %s WHERE status = ANY($1) ORDER BY version %s", migrationsTable, direction)
+
+	return migrations, r.db.Select(&migrations, query, pq.Array(statuses))
+}
+
+func (r *MigrationRepository) GetByVersion(version int) (database.Migration, error) {
+	var migration database.Migration
+	query := fmt.Sprintf("SELECT * FROM %s WHERE version = $1", migrationsTable)
+
+	return migration, r.db.GetWithNotFound(&migration, "migration.error.notFound", query, version)
+}
+
+// Create stores the migration under the next free version number
+func (r *MigrationRepository) Create(migration *database.Migration) (*database.Migration, error) {
+	err := r.db.WithTransaction(func(tx shared.Tx) error {
+		return r.insert(tx, migration)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// Execute runs the up SQL and stores the migration as applied in a single transaction
+func (r *MigrationRepository) Execute(migration *database.Migration) (*database.Migration, error) {
+	err := r.db.WithTransaction(func(tx shared.Tx) error {
+		if _, err := tx.Exec(migration.UpSQL); err != nil {
+			return fmt.Errorf("failed to execute migration: %w", err)
+		}
+
+		migration.Status = constants.MigrationStatusApplied
+		migration.AppliedAt = null.TimeFrom(time.Now())
+
+		return r.insert(tx, migration)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+func (r *MigrationRepository) Apply(migration database.Migration) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		if _, err := tx.Exec(migration.UpSQL); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET status = $1, applied_at = NOW() WHERE version = $2", migrationsTable)
+		_, err := tx.Exec(query, constants.MigrationStatusApplied, migration.Version)
+
+		return err
+	})
+}
+
+func (r *MigrationRepository) Rollback(migration database.Migration) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		if _, err := tx.Exec(migration.DownSQL); err != nil {
+			return fmt.Errorf("failed to roll back migration %d: %w", migration.Version, err)
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET status = $1, applied_at = NULL WHERE version = $2", migrationsTable)
+		_, err := tx.Exec(query, constants.MigrationStatusRolledBack, migration.Version)
+
+		return err
+	})
+}
+
+func (r *MigrationRepository) insert(tx shared.Tx, migration *database.Migration) error {
+	// serializes concurrent writers so version numbers stay gapless
+	if _, err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", migrationsTable)); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (version, name, up_sql, down_sql, source, status, applied_at)
+		SELECT COALESCE(MAX(version), 0) + 1, $1, $2, $3, $4, $5, $6 FROM %[1]s
+		RETURNING *
+	`, migrationsTable)
+
+	return tx.Get(
+		migration,
+		query,
+		migration.Name,
+		migration.UpSQL,
+		migration.DownSQL,
+		migration.Source,
+		migration.Status,
+		migration.AppliedAt,
+	)
+}
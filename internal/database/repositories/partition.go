@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+type PartitionRepository struct {
+	db shared.DB
+}
+
+func NewPartitionRepository(injector *do.Injector) (database.PartitionRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &PartitionRepository{db: db}, nil
+}
+
+// GetKey reports expression parts of the key as empty column names
+func (r *PartitionRepository) GetKey(fullTableName string) (database.PartitionKey, error) {
+	query := `
+		SELECT
+			CASE pt.partstrat WHEN 'r' THEN 'range' WHEN 'l' THEN 'list' ELSE 'hash' END AS strategy,
+			ARRAY(
+				SELECT COALESCE(a.attname, '')
+				FROM unnest(pt.partattrs::int2[]) WITH ORDINALITY AS k(attnum, position)
+				LEFT JOIN pg_attribute a ON a.attrelid = pt.partrelid AND a.attnum = k.attnum
+				ORDER BY k.position
+			) AS columns,
+			ARRAY(
+				SELECT COALESCE(format_type(a.atttypid, a.atttypmod), '')
+				FROM unnest(pt.partattrs::int2[]) WITH ORDINALITY AS k(attnum, position)
+				LEFT JOIN pg_attribute a ON a.attrelid = pt.partrelid AND a.attnum = k.attnum
+				ORDER BY k.position
+			) AS column_types,
+			pg_get_partkeydef(pt.partrelid) AS definition
+		FROM pg_partitioned_table pt
+		WHERE pt.partrelid = to_regclass($1)
+	`
+
+	var key database.PartitionKey
+
+	return key, r.db.GetWithNotFound(&key, "partition.error.notPartitioned", query, fullTableName)
+}
+
+func (r *PartitionRepository) List(fullTableName string) ([]database.Partition, error) {
+	query := `
+		SELECT
+			n.nspname AS schema,
+			c.relname AS name,
+			pg_get_expr(c.relpartbound, c.oid) AS bounds,
+			c.relkind = 'p' AS partitioned,
+			GREATEST(c.reltuples, 0)::bigint AS estimated_rows,
+			pg_size_pretty(pg_total_relation_size(c.oid)) AS total_size
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE i.inhparent = to_regclass($1)
+		ORDER BY c.relname
+	`
+
+	partitions := make([]database.Partition, 0)
+
+	return partitions, r.db.Select(&partitions, query, fullTableName)
+}
+
+// Execute runs several statements in one transaction. A single statement runs on its own,
+// DETACH PARTITION CONCURRENTLY is rejected inside a transaction block
+func (r *PartitionRepository) Execute(statements ...string) error {
+	if len(statements) == 1 {
+		return r.db.ExecWithErr(statements[0])
+	}
+
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type PartitionPolicyRepository struct {
+	db shared.DB
+}
+
+func NewPartitionPolicyRepository(injector *do.Injector) (database.PartitionPolicyRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &PartitionPolicyRepository{db: db}, nil
+}
+
+// GetByTable returns nil when the table has no policy
+func (r *PartitionPolicyRepository) GetByTable(projectUUID uuid.UUID, schema, tableName string) (*database.PartitionPolicy, error) {
+	query := fmt.Sprintf(
+		"SELECT %s FROM fluxend.partition_policies WHERE project_uuid = $1 AND schema_name = $2 AND table_name = $3",
+		pkg.GetColumns[database.PartitionPolicy](),
+	)
+
+	var policy database.PartitionPolicy
+	if err := r.db.Get(&policy, query, projectUUID, schema, tableName); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *PartitionPolicyRepository) Upsert(policy database.PartitionPolicy) (database.PartitionPolicy, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO fluxend.partition_policies (
+			project_uuid, schema_name, table_name, interval_unit, premake, retention, next_run_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		ON CONFLICT (project_uuid, schema_name, table_name) DO UPDATE SET
+			interval_unit = EXCLUDED.interval_unit,
+			premake = EXCLUDED.premake,
+			retention = EXCLUDED.retention,
+			next_run_at = EXCLUDED.next_run_at,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING %s
+	`, pkg.GetColumns[database.PartitionPolicy]())
+
+	var storedPolicy database.PartitionPolicy
+	err := r.db.Get(
+		&storedPolicy,
+		query,
+		policy.ProjectUuid,
+		policy.Schema,
+		policy.TableName,
+		policy.Interval,
+		policy.Premake,
+		policy.Retention,
+		policy.NextRunAt,
+	)
+
+	return storedPolicy, err
+}
+
+func (r *PartitionPolicyRepository) Delete(projectUUID uuid.UUID, schema, tableName string) error {
+	return r.db.ExecWithErr(
+		"DELETE FROM fluxend.partition_policies WHERE project_uuid = $1 AND schema_name = $2 AND table_name = $3",
+		projectUUID,
+		schema,
+		tableName,
+	)
+}
+
+// ClaimDue moves the next run of due policies forward and returns them, rows locked by
+// another instance are skipped so each table is maintained once
+func (r *PartitionPolicyRepository) ClaimDue(limit int) ([]database.PartitionPolicy, error) {
+	query := fmt.Sprintf(`
+		UPDATE fluxend.partition_policies
+		SET next_run_at = CURRENT_TIMESTAMP + make_interval(secs => $2),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id
+			FROM fluxend.partition_policies
+			WHERE next_run_at <= CURRENT_TIMESTAMP
+			ORDER BY next_run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, pkg.GetColumns[database.PartitionPolicy]())
+
+	var policies []database.PartitionPolicy
+
+	return policies, r.db.Select(&policies, query, limit, constants.PartitionMaintenanceRunInterval.Seconds())
+}
+
+func (r *PartitionPolicyRepository) MarkRun(id int, ranAt time.Time, lastError string) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.partition_policies SET last_run_at = $1, last_error = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3",
+		ranAt,
+		lastError,
+		id,
+	)
+}
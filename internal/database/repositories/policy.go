@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type PolicyRepository struct {
+	db shared.DB
+}
+
+func NewPolicyRepository(injector *do.Injector) (database.PolicyRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &PolicyRepository{db: db}, nil
+}
+
+const policyColumns = `
+	policyname AS name,
+	cmd AS command,
+	permissive = 'PERMISSIVE' AS permissive,
+	roles::text[] AS roles,
+	COALESCE(qual, '') AS "using",
+	COALESCE(with_check, '') AS with_check
+`
+
+func (r *PolicyRepository) List(fullTableName string) ([]database.Policy, error) {
+	var policies []database.Policy
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_policies
+       WHERE schemaname = $1 AND tablename = $2
+       ORDER BY policyname
+    `, policyColumns)
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return policies, r.db.Select(&policies, query, schema, tableName)
+}
+
+func (r *PolicyRepository) GetByName(fullTableName, policyName string) (database.Policy, error) {
+	var policy database.Policy
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_policies
+       WHERE schemaname = $1 AND tablename = $2 AND policyname = $3
+    `, policyColumns)
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return policy, r.db.GetWithNotFound(&policy, "policy.error.notFound", query, schema, tableName, policyName)
+}
+
+func (r *PolicyRepository) Has(fullTableName, policyName string) (bool, error) {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return r.db.Exists("pg_policies", "schemaname = $1 AND tablename = $2 AND policyname = $3", schema, tableName, policyName)
+}
+
+func (r *PolicyRepository) Create(policySQL string) error {
+	return r.db.ExecWithErr(policySQL)
+}
+
+// Replace swaps a policy in one transaction, so the table is never left without it
+func (r *PolicyRepository) Replace(dropSQL, createSQL string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		if _, err := tx.Exec(dropSQL); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(createSQL)
+
+		return err
+	})
+}
+
+func (r *PolicyRepository) Drop(policySQL string) error {
+	return r.db.ExecWithErr(policySQL)
+}
+
+func (r *PolicyRepository) GetRowLevelSecurity(fullTableName string) (database.RowLevelSecurity, error) {
+	var security database.RowLevelSecurity
+	query := `
+       SELECT c.relrowsecurity AS enabled, c.relforcerowsecurity AS forced
+       FROM pg_class c
+       JOIN pg_namespace n ON n.oid = c.relnamespace
+       WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p')
+    `
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return security, r.db.GetWithNotFound(&security, "table.error.notFound", query, schema, tableName)
+}
+
+func (r *PolicyRepository) SetRowLevelSecurity(statements []string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PolicyRepository) HasRole(role string) (bool, error) {
+	return r.db.Exists("pg_roles", "rolname = $1", role)
+}
+
+// IsTestableRole accepts the anonymous role and the roles of the project, as long as neither they
+// nor any role they are a member of is a superuser, bypasses row level security or is predefined
+func (r *PolicyRepository) IsTestableRole(role, marker string) (bool, error) {
+	return r.db.Exists("pg_roles r", projectRoleCondition, role, constants.RoleWebAnonymous, marker)
+}
+
+// Test runs a statement the way PostgREST would for a request: as the given role with the JWT
+// claims available through current_setting. The repository has to sit on an authenticator
+// connection, the role can then only be switched to roles PostgREST could switch to as well.
+// The transaction is always rolled back, the statement is prepared so it can't be followed by
+// another one that commits
+func (r *PolicyRepository) Test(ctx context.Context, statement string, options database.PolicyTestOptions) (database.PolicyTestResult, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: options.ReadOnly})
+	if err != nil {
+		return database.PolicyTestResult{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", options.TimeoutInMs)); err != nil {
+		return database.PolicyTestResult{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", constants.PolicyClaimsSetting, options.Claims); err != nil {
+		return database.PolicyTestResult{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "SET LOCAL ROLE "+pq.QuoteIdentifier(options.Role)); err != nil {
+		return database.PolicyTestResult{}, err
+	}
+
+	prepared, err := tx.PrepareContext(ctx, statement)
+	if err != nil {
+		return database.PolicyTestResult{}, err
+	}
+	defer prepared.Close()
+
+	rows, err := prepared.QueryContext(ctx)
+	if err != nil {
+		return database.PolicyTestResult{}, err
+	}
+
+	// one extra row is read to find out whether the result was cut off
+	columns, resultRows, err := scanRows(rows, options.MaxRows+1)
+	if err != nil {
+		return database.PolicyTestResult{}, err
+	}
+
+	truncated := len(resultRows) > options.MaxRows
+	if truncated {
+		resultRows = resultRows[:options.MaxRows]
+	}
+
+	return database.PolicyTestResult{
+		Role:      options.Role,
+		Columns:   columns,
+		Rows:      resultRows,
+		RowCount:  len(resultRows),
+		Truncated: truncated,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fluxend/pkg"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/samber/do"
 )
 
@@ -138,6 +139,18 @@ func (r *ProjectRepository) Update(projectInput *project.Project) (*project.Proj
 	return projectInput, err
 }
 
+func (r *ProjectRepository) UpdateExposedSchemas(projectUUID uuid.UUID, schemas []string) (bool, error) {
+	rowsAffected, err := r.db.ExecWithRowsAffected(
+		"UPDATE fluxend.projects SET exposed_schemas = $1, updated_at = NOW() WHERE uuid = $2",
+		pq.Array(schemas),
+		projectUUID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
 func (r *ProjectRepository) UpdateStatusByDatabaseName(databaseName, status string) (bool, error) {
 	rowsAffected, err := r.db.ExecWithRowsAffected("UPDATE fluxend.projects SET status = $1 WHERE db_name = $2", status, databaseName)
 	if err != nil {
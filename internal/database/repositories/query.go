@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fluxend/internal/domain/query"
+	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/samber/do"
+	"time"
+)
+
+type QueryRepository struct {
+	db shared.DB
+}
+
+func NewQueryRepository(injector *do.Injector) (query.Repository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &QueryRepository{db: db}, nil
+}
+
+// Execute runs an arbitrary statement inside its own transaction. Read-only transactions
+// are always rolled back, everything else is committed once the rows have been read.
+// The statement is prepared first, the extended protocol refuses more than one statement
+func (r *QueryRepository) Execute(ctx context.Context, statement string, options query.ExecuteOptions) (query.Result, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: options.ReadOnly})
+	if err != nil {
+		return query.Result{}, err
+	}
+	defer tx.Rollback()
+
+	if err = r.setStatementTimeout(ctx, tx, options.TimeoutInMs); err != nil {
+		return query.Result{}, err
+	}
+
+	startedAt := time.Now()
+
+	prepared, err := tx.PrepareContext(ctx, statement)
+	if err != nil {
+		return query.Result{}, err
+	}
+	defer prepared.Close()
+
+	rows, err := prepared.QueryContext(ctx)
+	if err != nil {
+		return query.Result{}, err
+	}
+
+	// one extra row is read to find out whether the result was cut off
+	columns, resultRows, err := scanRows(rows, options.MaxRows+1)
+	if err != nil {
+		return query.Result{}, err
+	}
+
+	truncated := len(resultRows) > options.MaxRows
+	if truncated {
+		resultRows = resultRows[:options.MaxRows]
+	}
+
+	if !options.ReadOnly {
+		if err = tx.Commit(); err != nil {
+			return query.Result{}, err
+		}
+	}
+
+	return query.Result{
+		Columns:    columns,
+		Rows:       resultRows,
+		RowCount:   len(resultRows),
+		Truncated:  truncated,
+		ReadOnly:   options.ReadOnly,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}, nil
+}
+
+func (r *QueryRepository) setStatementTimeout(ctx context.Context, tx shared.Tx, timeoutInMs int) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutInMs))
+
+	return err
+}
+
+// Explain returns the plan as produced by EXPLAIN (FORMAT JSON, VERBOSE), verbose plans name the
+// schema of every relation. The transaction is always rolled back and the statement is prepared,
+// a second statement such as COMMIT can't sneak in, so ANALYZE never leaves side effects behind
+func (r *QueryRepository) Explain(ctx context.Context, statement string, options query.ExplainOptions) ([]byte, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: options.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = r.setStatementTimeout(ctx, tx, options.TimeoutInMs); err != nil {
+		return nil, err
+	}
+
+	explainOptions := "FORMAT JSON, VERBOSE"
+	if options.Analyze {
+		explainOptions += ", ANALYZE, BUFFERS"
+	}
+
+	prepared, err := tx.PrepareContext(ctx, fmt.Sprintf("EXPLAIN (%s) %s", explainOptions, statement))
+	if err != nil {
+		return nil, err
+	}
+	defer prepared.Close()
+
+	var plan []byte
+	if err = prepared.QueryRowContext(ctx).Scan(&plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/realtime"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type RealtimeRepository struct {
+	db shared.DB
+}
+
+func NewRealtimeRepository(injector *do.Injector) (realtime.Repository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &RealtimeRepository{db: db}, nil
+}
+
+const realtimeTableStateSelect = `
+	SELECT
+		n.nspname AS schema_name,
+		c.relname AS table_name,
+		EXISTS (
+			SELECT 1 FROM pg_trigger t WHERE t.tgrelid = c.oid AND t.tgname = $1
+		) AS enabled
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+`
+
+func (r *RealtimeRepository) ListEnabled() ([]realtime.TableState, error) {
+	var tables []realtime.TableState
+	query := realtimeTableStateSelect + `
+		WHERE c.relkind IN ('r', 'p')
+		AND EXISTS (SELECT 1 FROM pg_trigger t WHERE t.tgrelid = c.oid AND t.tgname = $1)
+		ORDER BY n.nspname, c.relname
+	`
+
+	return tables, r.db.Select(&tables, query, constants.RealtimeTriggerName)
+}
+
+func (r *RealtimeRepository) GetTableState(fullTableName string) (realtime.TableState, error) {
+	var table realtime.TableState
+	query := realtimeTableStateSelect + `
+		WHERE n.nspname = $2 AND c.relname = $3 AND c.relkind IN ('r', 'p')
+	`
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return table, r.db.GetWithNotFound(&table, "table.error.notFound", query, constants.RealtimeTriggerName, schema, tableName)
+}
+
+func (r *RealtimeRepository) GetPrimaryKey(fullTableName string) ([]string, error) {
+	query := `
+		SELECT a.attname
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = (quote_ident($1) || '.' || quote_ident($2))::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::int2[], a.attnum)
+	`
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	var columns []string
+	return columns, r.db.Select(&columns, query, schema, tableName)
+}
+
+func (r *RealtimeRepository) IsSubscribableRole(role, marker, fullTableName string) (bool, error) {
+	condition := projectRoleCondition + " AND has_table_privilege(r.oid, quote_ident($4) || '.' || quote_ident($5), 'SELECT')"
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return r.db.Exists("pg_roles r", condition, role, constants.RoleWebAnonymous, marker, schema, tableName)
+}
+
+// ReadAs switches to the role the way PostgREST does for a request. The transaction is read-only and
+// always rolled back
+func (r *RealtimeRepository) ReadAs(access realtime.Access, statement string, record []byte) (map[string]interface{}, bool, error) {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", constants.RealtimeReadBackTimeoutInMs)); err != nil {
+		return nil, false, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", constants.PolicyClaimsSetting, access.Claims); err != nil {
+		return nil, false, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "SET LOCAL ROLE "+pq.QuoteIdentifier(access.Role)); err != nil {
+		return nil, false, err
+	}
+
+	var row []byte
+	if err = tx.QueryRowContext(ctx, statement, string(record)).Scan(&row); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var decoded map[string]interface{}
+	if err = json.Unmarshal(row, &decoded); err != nil {
+		return nil, false, err
+	}
+
+	return decoded, true, nil
+}
+
+func (r *RealtimeRepository) Apply(statements []string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+// roles are cluster wide, the comment written on create marks the ones a project owns
+const roleQuery = `
+	SELECT
+		r.rolname AS name,
+		r.rolinherit AS inherit,
+		ARRAY(
+			SELECT g.rolname
+			FROM pg_auth_members m
+			JOIN pg_roles g ON g.oid = m.roleid
+			WHERE m.member = r.oid
+			ORDER BY g.rolname
+		) AS member_of
+	FROM pg_roles r
+	WHERE shobj_description(r.oid, 'pg_authid') = $1
+`
+
+// projectRoleCondition matches the role $1 in pg_roles r when it is the anonymous role $2 or marked
+// for the project with $3, and neither it nor a role it is a member of is a superuser, bypasses row
+// level security or is predefined
+const projectRoleCondition = `
+	r.rolname = $1
+	AND (r.rolname = $2 OR shobj_description(r.oid, 'pg_authid') = $3)
+	AND NOT EXISTS (
+		SELECT 1 FROM pg_roles g
+		WHERE (g.rolsuper OR g.rolbypassrls OR g.rolname LIKE 'pg\_%')
+			AND pg_has_role(r.oid, g.oid, 'MEMBER')
+	)
+`
+
+// roleGrantQuery explodes the ACLs of the current database, privileges granted by any role are included
+const roleGrantQuery = `
+	WITH grantee AS (
+		SELECT oid FROM pg_roles WHERE rolname = $1
+	)
+	SELECT
+		CASE WHEN c.relkind = 'S' THEN 'sequence' ELSE 'table' END AS object_type,
+		n.nspname || '.' || c.relname AS object,
+		'' AS column_name,
+		array_agg(DISTINCT a.privilege_type) AS privileges
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL aclexplode(c.relacl) a
+	WHERE a.grantee = (SELECT oid FROM grantee) AND c.relkind IN ('r', 'p', 'v', 'm', 'f', 'S')
+	GROUP BY 1, 2
+	UNION ALL
+	SELECT
+		'column',
+		n.nspname || '.' || c.relname,
+		at.attname,
+		array_agg(DISTINCT a.privilege_type)
+	FROM pg_attribute at
+	JOIN pg_class c ON c.oid = at.attrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL aclexplode(at.attacl) a
+	WHERE a.grantee = (SELECT oid FROM grantee) AND at.attnum > 0 AND NOT at.attisdropped
+	GROUP BY 1, 2, 3
+	UNION ALL
+	SELECT
+		'schema',
+		n.nspname,
+		'',
+		array_agg(DISTINCT a.privilege_type)
+	FROM pg_namespace n
+	CROSS JOIN LATERAL aclexplode(n.nspacl) a
+	WHERE a.grantee = (SELECT oid FROM grantee)
+	GROUP BY 1, 2
+	UNION ALL
+	SELECT
+		'function',
+		n.nspname || '.' || p.proname,
+		'',
+		array_agg(DISTINCT a.privilege_type)
+	FROM pg_proc p
+	JOIN pg_namespace n ON n.oid = p.pronamespace
+	CROSS JOIN LATERAL aclexplode(p.proacl) a
+	WHERE a.grantee = (SELECT oid FROM grantee)
+	GROUP BY 1, 2
+	ORDER BY 1, 2, 3
+`
+
+type RoleRepository struct {
+	db shared.DB
+}
+
+func NewRoleRepository(injector *do.Injector) (database.RoleRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &RoleRepository{db: db}, nil
+}
+
+func (r *RoleRepository) List(marker string) ([]database.Role, error) {
+	var roles []database.Role
+
+	return roles, r.db.Select(&roles, roleQuery+" ORDER BY r.rolname", marker)
+}
+
+func (r *RoleRepository) GetByName(roleName, marker string) (database.Role, error) {
+	var role database.Role
+
+	return role, r.db.GetWithNotFound(&role, "role.error.notFound", roleQuery+" AND r.rolname = $2", marker, roleName)
+}
+
+func (r *RoleRepository) Exists(roleName string) (bool, error) {
+	return r.db.Exists("pg_roles", "rolname = $1", roleName)
+}
+
+func (r *RoleRepository) Create(statements []string) error {
+	return r.execInTransaction(statements)
+}
+
+func (r *RoleRepository) Drop(statements []string) error {
+	return r.execInTransaction(statements)
+}
+
+func (r *RoleRepository) ListGrants(roleName string) ([]database.RoleGrant, error) {
+	var grants []database.RoleGrant
+
+	return grants, r.db.Select(&grants, roleGrantQuery, roleName)
+}
+
+func (r *RoleRepository) Grant(grantSQL string) error {
+	return r.db.ExecWithErr(grantSQL)
+}
+
+func (r *RoleRepository) execInTransaction(statements []string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -1,11 +1,20 @@
 package repositories
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fluxend/internal/config/constants"
 	"fluxend/internal/domain/database"
 	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/lib/pq"
 	"github.com/samber/do"
+	"strings"
 )
 
+const streamCursorName = "fluxend_row_stream"
+
 type RowRepository struct {
 	db shared.DB
 }
@@ -43,3 +52,283 @@ func (r *RowRepository) CreateMany(tableName string, columns []database.Column,
 
 	return r.db.ExecWithErr(query)
 }
+
+// Stream reads the table through a server side cursor, so only chunkSize rows are held in memory at once
+func (r *RowRepository) Stream(
+	ctx context.Context,
+	schema, tableName string,
+	options database.RowQueryOptions,
+	chunkSize int,
+	handler database.RowChunkHandler,
+) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	declareQuery := fmt.Sprintf(
+		"DECLARE %s NO SCROLL CURSOR FOR %s",
+		streamCursorName,
+		r.buildSelectQuery(schema, tableName, options),
+	)
+
+	if _, err = tx.ExecContext(ctx, declareQuery); err != nil {
+		return fmt.Errorf("failed to declare cursor: %w", err)
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM %s", chunkSize, streamCursorName)
+	for isFirstChunk := true; ; isFirstChunk = false {
+		rows, err := tx.QueryContext(ctx, fetchQuery)
+		if err != nil {
+			return fmt.Errorf("failed to fetch rows: %w", err)
+		}
+
+		columns, chunk, err := scanRows(rows, 0)
+		if err != nil {
+			return err
+		}
+
+		// header is always sent, even when the table has no rows
+		if len(chunk) > 0 || isFirstChunk {
+			if err = handler(columns, chunk); err != nil {
+				return err
+			}
+		}
+
+		if len(chunk) < chunkSize {
+			break
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "CLOSE "+streamCursorName)
+
+	return err
+}
+
+// Sample returns up to limit random rows of the given columns as text, skipping rows where any of them is null
+func (r *RowRepository) Sample(quotedTableName string, columns []string, limit int) ([][]string, error) {
+	selectList := make([]string, len(columns))
+	conditions := make([]string, len(columns))
+	for i, column := range columns {
+		selectList[i] = pq.QuoteIdentifier(column) + "::text"
+		conditions[i] = pq.QuoteIdentifier(column) + " IS NOT NULL"
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s ORDER BY random() LIMIT $1",
+		strings.Join(selectList, ", "),
+		quotedTableName,
+		strings.Join(conditions, " AND "),
+	)
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([][]string, 0)
+	for rows.Next() {
+		values := make([]string, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, values)
+	}
+
+	return result, rows.Err()
+}
+
+// InsertMany inserts rows with bind parameters in batches within a single transaction.
+// Rows conflicting with existing data are skipped, so the returned count may be lower than len(rows)
+func (r *RowRepository) InsertMany(quotedTableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = pq.QuoteIdentifier(column)
+	}
+
+	// postgres caps a statement at 65535 bind parameters
+	batchSize := constants.SeedBatchRows
+	if len(columns) == 0 {
+		// every column has a default, so each row is its own DEFAULT VALUES insert
+		batchSize = 1
+	} else if batchSize*len(columns) > 65535 {
+		batchSize = 65535 / len(columns)
+	}
+
+	var inserted int64
+	err := r.db.WithTransaction(func(tx shared.Tx) error {
+		for start := 0; start < len(rows); start += batchSize {
+			end := min(start+batchSize, len(rows))
+			query, args := r.buildInsertQuery(quotedTableName, quotedColumns, rows[start:end])
+
+			result, err := tx.Exec(query, args...)
+			if err != nil {
+				return err
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			inserted += affected
+		}
+
+		return nil
+	})
+
+	return inserted, err
+}
+
+func (r *RowRepository) buildInsertQuery(quotedTableName string, quotedColumns []string, rows [][]interface{}) (string, []interface{}) {
+	if len(quotedColumns) == 0 {
+		return fmt.Sprintf("INSERT INTO %s DEFAULT VALUES ON CONFLICT DO NOTHING", quotedTableName), nil
+	}
+
+	args := make([]interface{}, 0, len(rows)*len(quotedColumns))
+	tuples := make([]string, len(rows))
+	for i, row := range rows {
+		placeholders := make([]string, len(row))
+		for j, value := range row {
+			args = append(args, value)
+			placeholders[j] = fmt.Sprintf("$%d", len(args))
+		}
+
+		tuples[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s ON CONFLICT DO NOTHING",
+		quotedTableName,
+		strings.Join(quotedColumns, ", "),
+		strings.Join(tuples, ", "),
+	)
+
+	return query, args
+}
+
+func (r *RowRepository) buildSelectQuery(schema, tableName string, options database.RowQueryOptions) string {
+	selectList := "*"
+	if len(options.Columns) > 0 {
+		quotedColumns := make([]string, len(options.Columns))
+		for i, column := range options.Columns {
+			quotedColumns[i] = pq.QuoteIdentifier(column)
+		}
+
+		selectList = strings.Join(quotedColumns, ", ")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s.%s", selectList, pq.QuoteIdentifier(schema), pq.QuoteIdentifier(tableName))
+
+	if len(options.Filters) > 0 {
+		conditions := make([]string, len(options.Filters))
+		for i, filter := range options.Filters {
+			conditions[i] = r.buildFilterCondition(filter)
+		}
+
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if len(options.Order) > 0 {
+		orderings := make([]string, len(options.Order))
+		for i, order := range options.Order {
+			direction := "ASC"
+			if order.Descending {
+				direction = "DESC"
+			}
+
+			orderings[i] = fmt.Sprintf("%s %s", pq.QuoteIdentifier(order.Column), direction)
+		}
+
+		query += " ORDER BY " + strings.Join(orderings, ", ")
+	}
+
+	return query
+}
+
+// buildFilterCondition inlines the value as a quoted literal as DECLARE CURSOR doesn't accept bind parameters
+func (r *RowRepository) buildFilterCondition(filter database.RowFilter) string {
+	operator := database.RowFilterOperators[filter.Operator]
+	column := pq.QuoteIdentifier(filter.Column)
+
+	// is values are checked against RowFilterIsValues when the request is validated
+	if filter.Operator == "is" {
+		return column + " IS " + database.RowFilterIsValues[strings.ToLower(filter.Value)]
+	}
+
+	return fmt.Sprintf("%s %s %s", column, operator, pq.QuoteLiteral(filter.Value))
+}
+
+// scanRows reads all rows from the result set into generic values, stopping after limit rows when limit > 0
+func scanRows(rows *sql.Rows, limit int) ([]database.ResultColumn, [][]interface{}, error) {
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make([]database.ResultColumn, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = database.ResultColumn{
+			Name: columnType.Name(),
+			Type: strings.ToLower(columnType.DatabaseTypeName()),
+		}
+	}
+
+	result := make([][]interface{}, 0)
+	for rows.Next() {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+
+		for i, value := range values {
+			values[i] = normalizeValue(value, columns[i].Type)
+		}
+
+		result = append(result, values)
+	}
+
+	return columns, result, rows.Err()
+}
+
+// normalizeValue converts raw driver values into types that serialize cleanly
+func normalizeValue(value interface{}, databaseType string) interface{} {
+	raw, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	switch databaseType {
+	case "bytea":
+		return fmt.Sprintf("\\x%x", raw)
+	case "json", "jsonb":
+		return json.RawMessage(raw)
+	case "numeric":
+		return json.Number(raw)
+	default:
+		return string(raw)
+	}
+}
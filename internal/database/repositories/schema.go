@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+// schemaQuery leaves out the schemas of postgres, the fluxend bookkeeping schema and the trash of dropped objects
+const schemaQuery = `
+	SELECT
+		n.nspname AS name,
+		pg_get_userbyid(n.nspowner) AS owner,
+		COUNT(c.oid) FILTER (WHERE c.relkind IN ('r', 'p')) AS table_count,
+		COALESCE(obj_description(n.oid, 'pg_namespace'), '') AS comment
+	FROM pg_namespace n
+	LEFT JOIN pg_class c ON c.relnamespace = n.oid
+	WHERE n.nspname NOT LIKE 'pg\_%' AND n.nspname NOT IN ('information_schema', 'fluxend', 'fluxend_trash')
+`
+
+type SchemaRepository struct {
+	db shared.DB
+}
+
+func NewSchemaRepository(injector *do.Injector) (database.SchemaRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &SchemaRepository{db: db}, nil
+}
+
+func (r *SchemaRepository) List() ([]database.Schema, error) {
+	var schemas []database.Schema
+
+	return schemas, r.db.Select(&schemas, schemaQuery+" GROUP BY n.oid, n.nspname, n.nspowner ORDER BY n.nspname")
+}
+
+func (r *SchemaRepository) GetByName(name string) (database.Schema, error) {
+	var schema database.Schema
+	query := schemaQuery + " AND n.nspname = $1 GROUP BY n.oid, n.nspname, n.nspowner"
+
+	return schema, r.db.GetWithNotFound(&schema, "schema.error.notFound", query, name)
+}
+
+func (r *SchemaRepository) Exists(name string) (bool, error) {
+	return r.db.Exists("pg_namespace", "nspname = $1", name)
+}
+
+func (r *SchemaRepository) Create(schemaSQL string) error {
+	return r.db.ExecWithErr(schemaSQL)
+}
+
+func (r *SchemaRepository) Rename(schemaSQL string) error {
+	return r.db.ExecWithErr(schemaSQL)
+}
+
+func (r *SchemaRepository) Drop(schemaSQL string) error {
+	return r.db.ExecWithErr(schemaSQL)
+}
@@ -29,11 +29,11 @@ func NewTableRepository(injector *do.Injector) (database.TableRepository, error)
 	}, nil
 }
 
-func (r *TableRepository) Exists(name string) (bool, error) {
-	return r.db.Exists("information_schema.tables", "table_schema = 'public' AND table_name = $1", name)
+func (r *TableRepository) Exists(schema, name string) (bool, error) {
+	return r.db.Exists("information_schema.tables", "table_schema = $1 AND table_name = $2", schema, name)
 }
 
-func (r *TableRepository) Create(name string, columns []database.Column) error {
+func (r *TableRepository) Create(fullTableName string, columns []database.Column) error {
 	return r.db.WithTransaction(func(tx shared.Tx) error {
 		var defs []string
 		var foreignConstraints []string
@@ -41,12 +41,12 @@ func (r *TableRepository) Create(name string, columns []database.Column) error {
 		for _, currentColumn := range columns {
 			defs = append(defs, r.columnRepository.BuildColumnDefinition(currentColumn))
 
-			if fkQuery, ok := r.columnRepository.BuildForeignKeyConstraint(name, currentColumn); ok {
+			if fkQuery, ok := r.columnRepository.BuildForeignKeyConstraint(r.quoteTableName(fullTableName), currentColumn); ok {
 				foreignConstraints = append(foreignConstraints, fkQuery)
 			}
 		}
 
-		createQuery := fmt.Sprintf("CREATE TABLE %s (\n%s\n);", pq.QuoteIdentifier(name), strings.Join(defs, ",\n"))
+		createQuery := fmt.Sprintf("CREATE TABLE %s (\n%s\n);", r.quoteTableName(fullTableName), strings.Join(defs, ",\n"))
 
 		if _, err := tx.Exec(createQuery); err != nil {
 			return fmt.Errorf("failed to create table: %w", err)
@@ -58,17 +58,25 @@ func (r *TableRepository) Create(name string, columns []database.Column) error {
 			}
 		}
 
+		for _, currentColumn := range columns {
+			if commentQuery, ok := r.columnRepository.BuildCommentStatement(r.quoteTableName(fullTableName), currentColumn); ok {
+				if _, err := tx.Exec(commentQuery); err != nil {
+					return fmt.Errorf("failed to add column comment: %w", err)
+				}
+			}
+		}
+
 		return nil
 	})
 }
 
 func (r *TableRepository) Duplicate(existingTable string, newTable string) error {
-	query := fmt.Sprintf("CREATE TABLE %s AS TABLE %s", pq.QuoteIdentifier(newTable), pq.QuoteIdentifier(existingTable))
+	query := fmt.Sprintf("CREATE TABLE %s AS TABLE %s", r.quoteTableName(newTable), r.quoteTableName(existingTable))
 
 	return r.db.ExecWithErr(query)
 }
 
-func (r *TableRepository) List() ([]database.Table, error) {
+func (r *TableRepository) List(schema string) ([]database.Table, error) {
 	var tables []database.Table
 	query := `
        SELECT
@@ -76,14 +84,21 @@ func (r *TableRepository) List() ([]database.Table, error) {
           c.relname AS name,
           n.nspname AS schema,
           c.reltuples AS estimated_rows,  -- Approximate row count
-          pg_size_pretty(pg_total_relation_size(c.oid)) AS total_size -- Table size (including indexes)
+          pg_size_pretty(pg_total_relation_size(c.oid)) AS total_size, -- Table size (including indexes)
+          c.relkind = 'p' AS partitioned,
+          COALESCE(obj_description(c.oid, 'pg_class'), '') AS description,
+          COALESCE((SELECT i.inhparent::regclass::text FROM pg_inherits i WHERE i.inhrelid = c.oid AND c.relispartition), '') AS partition_of,
+          COALESCE((
+             SELECT e.extname FROM pg_depend d JOIN pg_extension e ON e.oid = d.refobjid
+             WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'e'
+          ), '') AS extension
        FROM pg_class c
               JOIN pg_namespace n ON c.relnamespace = n.oid
-       WHERE n.nspname = 'public'  -- Only list tables in the "public" schema
-         AND c.relkind = 'r'  -- 'r' means regular table (excludes views, indexes, etc.)
+       WHERE n.nspname = $1  -- Filter by schema
+         AND c.relkind IN ('r', 'p')  -- regular and partitioned tables (excludes views, indexes, etc.)
        ORDER BY c.relname;
     `
-	return tables, r.db.Select(&tables, query)
+	return tables, r.db.Select(&tables, query, schema)
 }
 
 func (r *TableRepository) GetByNameInSchema(schema, name string) (database.Table, error) {
@@ -94,7 +109,14 @@ func (r *TableRepository) GetByNameInSchema(schema, name string) (database.Table
           c.relname AS name,
           n.nspname AS schema,
           c.reltuples AS estimated_rows,  -- Approximate row count
-          pg_size_pretty(pg_total_relation_size(c.oid)) AS total_size -- Table size (including indexes)
+          pg_size_pretty(pg_total_relation_size(c.oid)) AS total_size, -- Table size (including indexes)
+          c.relkind = 'p' AS partitioned,
+          COALESCE(obj_description(c.oid, 'pg_class'), '') AS description,
+          COALESCE((SELECT i.inhparent::regclass::text FROM pg_inherits i WHERE i.inhrelid = c.oid AND c.relispartition), '') AS partition_of,
+          COALESCE((
+             SELECT e.extname FROM pg_depend d JOIN pg_extension e ON e.oid = d.refobjid
+             WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'e'
+          ), '') AS extension
        FROM pg_class c
        JOIN pg_namespace n ON c.relnamespace = n.oid
        WHERE n.nspname = $1  -- Filter by schema
@@ -105,14 +127,20 @@ func (r *TableRepository) GetByNameInSchema(schema, name string) (database.Table
 	return fetchedTable, r.db.GetWithNotFound(&fetchedTable, "table.error.notFound", query, schema, name)
 }
 
-func (r *TableRepository) DropIfExists(name string) error {
-	schema, name := pkg.ParseTableName(name)
+// Rename keeps the table in its schema, ALTER TABLE cannot move it with RENAME
+func (r *TableRepository) Rename(fullTableName string, newName string) error {
+	query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", r.quoteTableName(fullTableName), pq.QuoteIdentifier(newName))
 
-	return r.db.ExecWithErr(fmt.Sprintf(`DROP TABLE IF EXISTS %s."%s"`, schema, name))
+	return r.db.ExecWithErr(query)
+}
+
+// Comment sets the table comment, an empty description removes it
+func (r *TableRepository) Comment(fullTableName, description string) error {
+	return r.db.ExecWithErr(fmt.Sprintf("COMMENT ON TABLE %s IS %s", r.quoteTableName(fullTableName), commentLiteral(description)))
 }
 
-func (r *TableRepository) Rename(oldName string, newName string) error {
-	query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", pq.QuoteIdentifier(oldName), pq.QuoteIdentifier(newName))
+func (r *TableRepository) quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
 
-	return r.db.ExecWithErr(query)
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
 }
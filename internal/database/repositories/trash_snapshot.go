@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type TrashSnapshotRepository struct {
+	db shared.DB
+}
+
+func NewTrashSnapshotRepository(injector *do.Injector) (database.TrashSnapshotRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &TrashSnapshotRepository{db: db}, nil
+}
+
+func (r *TrashSnapshotRepository) ListForProject(projectUUID uuid.UUID) ([]database.TrashSnapshot, error) {
+	query := "SELECT %s FROM fluxend.trash_snapshots WHERE project_uuid = $1 AND status = $2 ORDER BY created_at DESC"
+	query = fmt.Sprintf(query, pkg.GetColumns[database.TrashSnapshot]())
+
+	var snapshots []database.TrashSnapshot
+	return snapshots, r.db.Select(&snapshots, query, projectUUID, constants.TrashStatusActive)
+}
+
+func (r *TrashSnapshotRepository) GetByUUID(snapshotUUID uuid.UUID) (database.TrashSnapshot, error) {
+	query := "SELECT %s FROM fluxend.trash_snapshots WHERE uuid = $1 AND status = $2"
+	query = fmt.Sprintf(query, pkg.GetColumns[database.TrashSnapshot]())
+
+	var snapshot database.TrashSnapshot
+	return snapshot, r.db.GetWithNotFound(&snapshot, "trash.error.notFound", query, snapshotUUID, constants.TrashStatusActive)
+}
+
+// Create keeps the uuid of the snapshot, the trash table is already named after it
+func (r *TrashSnapshotRepository) Create(snapshot *database.TrashSnapshot) (*database.TrashSnapshot, error) {
+	query := `
+        INSERT INTO fluxend.trash_snapshots (
+            uuid, project_uuid, kind, schema_name, table_name, column_name, trash_table,
+            definition, restore_statements, migration_sql, status, created_by, expires_at
+        ) VALUES (
+            $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+        )
+        RETURNING created_at, updated_at
+    `
+
+	return snapshot, r.db.QueryRow(
+		query,
+		snapshot.Uuid,
+		snapshot.ProjectUuid,
+		snapshot.Kind,
+		snapshot.Schema,
+		snapshot.TableName,
+		snapshot.ColumnName,
+		snapshot.TrashTable,
+		snapshot.Definition,
+		snapshot.RestoreStatements,
+		snapshot.MigrationSQL,
+		snapshot.Status,
+		snapshot.CreatedBy,
+		snapshot.ExpiresAt,
+	).Scan(&snapshot.CreatedAt, &snapshot.UpdatedAt)
+}
+
+// Activate only turns a pending snapshot active, it returns false when the snapshot was purged meanwhile
+func (r *TrashSnapshotRepository) Activate(snapshotUUID uuid.UUID) (bool, error) {
+	rowsAffected, err := r.db.ExecWithRowsAffected(
+		"UPDATE fluxend.trash_snapshots SET status = $1, updated_at = NOW() WHERE uuid = $2 AND status = $3",
+		constants.TrashStatusActive,
+		snapshotUUID,
+		constants.TrashStatusPending,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *TrashSnapshotRepository) Delete(snapshotUUID uuid.UUID) error {
+	return r.db.ExecWithErr("DELETE FROM fluxend.trash_snapshots WHERE uuid = $1", snapshotUUID)
+}
+
+func (r *TrashSnapshotRepository) ListExpired(before, pendingBefore time.Time, limit int) ([]database.TrashSnapshot, error) {
+	query := `
+        SELECT %s FROM fluxend.trash_snapshots
+        WHERE expires_at <= $1 OR (status = $2 AND created_at <= $3)
+        ORDER BY expires_at
+        LIMIT $4
+    `
+	query = fmt.Sprintf(query, pkg.GetColumns[database.TrashSnapshot]())
+
+	var snapshots []database.TrashSnapshot
+	return snapshots, r.db.Select(&snapshots, query, before, constants.TrashStatusPending, pendingBefore, limit)
+}
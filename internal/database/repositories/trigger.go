@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+// triggerColumns decodes the tgtype bitmask: 1 row level, 2 before, 4 insert, 8 delete,
+// 16 update, 32 truncate and 64 instead of
+const triggerColumns = `
+	t.tgname AS name,
+	CASE
+		WHEN t.tgtype::int & 2 = 2 THEN 'BEFORE'
+		WHEN t.tgtype::int & 64 = 64 THEN 'INSTEAD OF'
+		ELSE 'AFTER'
+	END AS timing,
+	CASE WHEN t.tgtype::int & 1 = 1 THEN 'ROW' ELSE 'STATEMENT' END AS level,
+	array_remove(ARRAY[
+		CASE WHEN t.tgtype::int & 4 = 4 THEN 'INSERT' END,
+		CASE WHEN t.tgtype::int & 16 = 16 THEN 'UPDATE' END,
+		CASE WHEN t.tgtype::int & 8 = 8 THEN 'DELETE' END,
+		CASE WHEN t.tgtype::int & 32 = 32 THEN 'TRUNCATE' END
+	], NULL) AS events,
+	COALESCE((
+		SELECT array_agg(a.attname::text ORDER BY k.ord)
+		FROM unnest(t.tgattr::int2[]) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = t.tgrelid AND a.attnum = k.attnum
+	), '{}') AS update_columns,
+	COALESCE(substring(pg_get_triggerdef(t.oid) from 'WHEN \((.*)\) EXECUTE (?:FUNCTION|PROCEDURE)'), '') AS condition,
+	n.nspname || '.' || p.proname AS function,
+	t.tgenabled <> 'D' AS enabled,
+	pg_get_triggerdef(t.oid) AS definition
+`
+
+type TriggerRepository struct {
+	db shared.DB
+}
+
+func NewTriggerRepository(injector *do.Injector) (database.TriggerRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &TriggerRepository{db: db}, nil
+}
+
+func (r *TriggerRepository) List(fullTableName string) ([]database.Trigger, error) {
+	var triggers []database.Trigger
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_trigger t
+       JOIN pg_proc p ON p.oid = t.tgfoid
+       JOIN pg_namespace n ON n.oid = p.pronamespace
+       WHERE t.tgrelid = $1::regclass AND NOT t.tgisinternal
+       ORDER BY t.tgname
+    `, triggerColumns)
+
+	return triggers, r.db.Select(&triggers, query, r.quoteTableName(fullTableName))
+}
+
+func (r *TriggerRepository) GetByName(fullTableName, triggerName string) (database.Trigger, error) {
+	var trigger database.Trigger
+	query := fmt.Sprintf(`
+       SELECT %s
+       FROM pg_trigger t
+       JOIN pg_proc p ON p.oid = t.tgfoid
+       JOIN pg_namespace n ON n.oid = p.pronamespace
+       WHERE t.tgrelid = $1::regclass AND t.tgname = $2 AND NOT t.tgisinternal
+    `, triggerColumns)
+
+	return trigger, r.db.GetWithNotFound(
+		&trigger,
+		"trigger.error.notFound",
+		query,
+		r.quoteTableName(fullTableName),
+		triggerName,
+	)
+}
+
+func (r *TriggerRepository) Has(fullTableName, triggerName string) (bool, error) {
+	return r.db.Exists("pg_trigger", "tgrelid = $1::regclass AND tgname = $2", r.quoteTableName(fullTableName), triggerName)
+}
+
+func (r *TriggerRepository) Create(triggerSQL string) error {
+	return r.db.ExecWithErr(triggerSQL)
+}
+
+func (r *TriggerRepository) Drop(triggerSQL string) error {
+	return r.db.ExecWithErr(triggerSQL)
+}
+
+func (r *TriggerRepository) SetEnabled(triggerSQL string) error {
+	return r.db.ExecWithErr(triggerSQL)
+}
+
+// GetFunctionReturnType returns the return type of the function without arguments, the only
+// kind a trigger can call, or an empty string when there is no such function
+func (r *TriggerRepository) GetFunctionReturnType(schema, functionName string) (string, error) {
+	var returnTypes []string
+	query := `
+       SELECT format_type(p.prorettype, NULL)
+       FROM pg_proc p
+       JOIN pg_namespace n ON n.oid = p.pronamespace
+       WHERE n.nspname = $1 AND p.proname = $2 AND p.pronargs = 0
+    `
+
+	if err := r.db.Select(&returnTypes, query, schema, functionName); err != nil {
+		return "", err
+	}
+
+	if len(returnTypes) == 0 {
+		return "", nil
+	}
+
+	return returnTypes[0], nil
+}
+
+func (r *TriggerRepository) quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+// composite types are backed by a pg_class entry of kind 'c', table row types are left out
+const typeQuery = `
+	SELECT
+		n.nspname AS schema,
+		t.typname AS name,
+		CASE t.typtype WHEN 'e' THEN 'enum' ELSE 'composite' END AS kind,
+		COALESCE((
+			SELECT array_agg(e.enumlabel::text ORDER BY e.enumsortorder)
+			FROM pg_enum e
+			WHERE e.enumtypid = t.oid
+		), '{}') AS "values",
+		COALESCE((
+			SELECT e.extname FROM pg_depend d JOIN pg_extension e ON e.oid = d.refobjid
+			WHERE d.classid = 'pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e'
+		), '') AS extension
+	FROM pg_type t
+	JOIN pg_namespace n ON n.oid = t.typnamespace
+	LEFT JOIN pg_class c ON c.oid = t.typrelid
+	WHERE n.nspname = $1
+	  AND (t.typtype = 'e' OR (t.typtype = 'c' AND c.relkind = 'c'))
+`
+
+const typeAttributeQuery = `
+	SELECT
+		n.nspname AS type_schema,
+		t.typname AS type_name,
+		a.attname AS name,
+		pg_catalog.format_type(a.atttypid, a.atttypmod) AS type
+	FROM pg_type t
+	JOIN pg_namespace n ON n.oid = t.typnamespace
+	JOIN pg_class c ON c.oid = t.typrelid AND c.relkind = 'c'
+	JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+	WHERE n.nspname = $1
+`
+
+type TypeRepository struct {
+	db shared.DB
+}
+
+func NewTypeRepository(injector *do.Injector) (database.TypeRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &TypeRepository{db: db}, nil
+}
+
+func (r *TypeRepository) List(schema string) ([]database.Type, error) {
+	var types []database.Type
+	if err := r.db.Select(&types, typeQuery+" ORDER BY t.typname", schema); err != nil {
+		return nil, err
+	}
+
+	var attributes []database.TypeAttribute
+	if err := r.db.Select(&attributes, typeAttributeQuery+" ORDER BY t.typname, a.attnum", schema); err != nil {
+		return nil, err
+	}
+
+	attributesByType := make(map[string][]database.TypeAttribute)
+	for _, attribute := range attributes {
+		attributesByType[attribute.TypeName] = append(attributesByType[attribute.TypeName], attribute)
+	}
+
+	for i := range types {
+		types[i].Attributes = attributesByType[types[i].Name]
+	}
+
+	return types, nil
+}
+
+func (r *TypeRepository) GetByName(schema, typeName string) (database.Type, error) {
+	var fetchedType database.Type
+	err := r.db.GetWithNotFound(&fetchedType, "type.error.notFound", typeQuery+" AND t.typname = $2", schema, typeName)
+	if err != nil {
+		return database.Type{}, err
+	}
+
+	err = r.db.Select(&fetchedType.Attributes, typeAttributeQuery+" AND t.typname = $2 ORDER BY a.attnum", schema, typeName)
+	if err != nil {
+		return database.Type{}, err
+	}
+
+	return fetchedType, nil
+}
+
+func (r *TypeRepository) Has(schema, typeName string) (bool, error) {
+	return r.db.Exists(
+		"pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace",
+		"n.nspname = $1 AND t.typname = $2",
+		schema,
+		typeName,
+	)
+}
+
+func (r *TypeRepository) Create(typeSQL string) error {
+	return r.db.ExecWithErr(typeSQL)
+}
+
+func (r *TypeRepository) Alter(typeSQL string) error {
+	return r.db.ExecWithErr(typeSQL)
+}
+
+func (r *TypeRepository) Drop(schema, typeName string) error {
+	query := fmt.Sprintf("DROP TYPE %s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(typeName))
+
+	return r.db.ExecWithErr(query)
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"github.com/samber/do"
+)
+
+// a concurrent refresh needs a unique index on plain columns without a WHERE clause
+const viewQuery = `
+	SELECT
+		n.nspname AS schema,
+		c.relname AS name,
+		c.relkind = 'm' AS materialized,
+		pg_get_viewdef(c.oid, true) AS definition,
+		c.relispopulated AS populated,
+		EXISTS (
+			SELECT 1
+			FROM pg_index i
+			WHERE i.indrelid = c.oid AND i.indisunique AND i.indpred IS NULL AND i.indexprs IS NULL
+		) AS has_unique_index,
+		COALESCE((
+			SELECT e.extname FROM pg_depend d JOIN pg_extension e ON e.oid = d.refobjid
+			WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'e'
+		), '') AS extension
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = $1 AND c.relkind IN ('v', 'm')
+`
+
+type ViewRepository struct {
+	db shared.DB
+}
+
+func NewViewRepository(injector *do.Injector) (database.ViewRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &ViewRepository{db: db}, nil
+}
+
+func (r *ViewRepository) List(schema string) ([]database.View, error) {
+	var views []database.View
+
+	return views, r.db.Select(&views, viewQuery+" ORDER BY c.relname", schema)
+}
+
+func (r *ViewRepository) GetByName(schema, viewName string) (database.View, error) {
+	var view database.View
+
+	return view, r.db.GetWithNotFound(&view, "view.error.notFound", viewQuery+" AND c.relname = $2", schema, viewName)
+}
+
+func (r *ViewRepository) Has(schema, viewName string) (bool, error) {
+	return r.db.Exists(
+		"pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace",
+		"n.nspname = $1 AND c.relname = $2",
+		schema,
+		viewName,
+	)
+}
+
+func (r *ViewRepository) Create(viewSQL string) error {
+	return r.db.ExecWithErr(viewSQL)
+}
+
+// Replace swaps the definition in one transaction, readers never see the view missing
+func (r *ViewRepository) Replace(dropSQL, createSQL string) error {
+	return r.db.WithTransaction(func(tx shared.Tx) error {
+		if dropSQL != "" {
+			if _, err := tx.Exec(dropSQL); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(createSQL)
+
+		return err
+	})
+}
+
+func (r *ViewRepository) Drop(viewSQL string) error {
+	return r.db.ExecWithErr(viewSQL)
+}
+
+func (r *ViewRepository) Refresh(refreshSQL string) error {
+	return r.db.ExecWithErr(refreshSQL)
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"time"
+)
+
+type ViewRefreshScheduleRepository struct {
+	db shared.DB
+}
+
+func NewViewRefreshScheduleRepository(injector *do.Injector) (database.ViewRefreshScheduleRepository, error) {
+	db := do.MustInvoke[shared.DB](injector)
+	return &ViewRefreshScheduleRepository{db: db}, nil
+}
+
+// GetByView returns nil when the view has no schedule
+func (r *ViewRefreshScheduleRepository) GetByView(projectUUID uuid.UUID, schema, viewName string) (*database.ViewRefreshSchedule, error) {
+	query := fmt.Sprintf(
+		"SELECT %s FROM fluxend.view_refresh_schedules WHERE project_uuid = $1 AND schema_name = $2 AND view_name = $3",
+		pkg.GetColumns[database.ViewRefreshSchedule](),
+	)
+
+	var schedule database.ViewRefreshSchedule
+	if err := r.db.Get(&schedule, query, projectUUID, schema, viewName); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *ViewRefreshScheduleRepository) Upsert(schedule database.ViewRefreshSchedule) error {
+	query := `
+		INSERT INTO fluxend.view_refresh_schedules (
+			project_uuid, schema_name, view_name, interval_minutes, concurrently, next_refresh_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		ON CONFLICT (project_uuid, schema_name, view_name) DO UPDATE SET
+			interval_minutes = EXCLUDED.interval_minutes,
+			concurrently = EXCLUDED.concurrently,
+			next_refresh_at = EXCLUDED.next_refresh_at,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	return r.db.ExecWithErr(
+		query,
+		schedule.ProjectUuid,
+		schedule.Schema,
+		schedule.ViewName,
+		schedule.IntervalMinutes,
+		schedule.Concurrently,
+		schedule.NextRefreshAt,
+	)
+}
+
+func (r *ViewRefreshScheduleRepository) Delete(projectUUID uuid.UUID, schema, viewName string) error {
+	return r.db.ExecWithErr(
+		"DELETE FROM fluxend.view_refresh_schedules WHERE project_uuid = $1 AND schema_name = $2 AND view_name = $3",
+		projectUUID,
+		schema,
+		viewName,
+	)
+}
+
+// ClaimDue moves the next run of due schedules forward and returns them, rows locked by
+// another instance are skipped so each refresh is picked up once
+func (r *ViewRefreshScheduleRepository) ClaimDue(limit int) ([]database.ViewRefreshSchedule, error) {
+	query := fmt.Sprintf(`
+		UPDATE fluxend.view_refresh_schedules
+		SET next_refresh_at = CURRENT_TIMESTAMP + make_interval(mins => interval_minutes),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id
+			FROM fluxend.view_refresh_schedules
+			WHERE next_refresh_at <= CURRENT_TIMESTAMP
+			ORDER BY next_refresh_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, pkg.GetColumns[database.ViewRefreshSchedule]())
+
+	var schedules []database.ViewRefreshSchedule
+
+	return schedules, r.db.Select(&schedules, query, limit)
+}
+
+func (r *ViewRefreshScheduleRepository) MarkRefreshed(id int, refreshedAt time.Time, lastError string) error {
+	return r.db.ExecWithErr(
+		"UPDATE fluxend.view_refresh_schedules SET last_refreshed_at = $1, last_error = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3",
+		refreshedAt,
+		lastError,
+		id,
+	)
+}
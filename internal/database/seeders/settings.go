@@ -21,9 +21,9 @@ func Settings(container *do.Injector) {
 		return
 	}
 
-	if len(existingSettings) > 0 {
-		log.Info().Msg("Settings already exist, skipping seeding")
-		return
+	existingNames := make(map[string]bool, len(existingSettings))
+	for _, existingSetting := range existingSettings {
+		existingNames[existingSetting.Name] = true
 	}
 
 	settings := []setting.Setting{
@@ -45,6 +45,13 @@ func Settings(container *do.Injector) {
 		{Name: "storageMaxFileSizeInKB", Value: "1024", DefaultValue: "1024"},
 		{Name: "storageAllowedMimes", Value: "jpg,png,pdf", DefaultValue: "jpg,png,pdf"},
 
+		// SQL console settings
+		{Name: "sqlConsoleMaxTimeoutInMs", Value: "60000", DefaultValue: "60000"},
+		{Name: "sqlConsoleMaxRows", Value: "5000", DefaultValue: "5000"},
+
+		// Database settings
+		{Name: constants.ExtensionAllowlistSetting, Value: constants.DefaultExtensionAllowlist, DefaultValue: constants.DefaultExtensionAllowlist},
+
 		// API throttle settings
 		{Name: "apiThrottleLimit", Value: "100", DefaultValue: "100"},
 		{Name: "apiThrottleInterval", Value: "60", DefaultValue: "60"},
@@ -66,7 +73,20 @@ func Settings(container *do.Injector) {
 		{Name: "mailgunRegion", Value: os.Getenv("MAILGUN_REGION"), DefaultValue: "us"},
 	}
 
-	_, err = settingsService.CreateMany(settings)
+	// only seed settings added since the last run, existing values are left untouched
+	var missingSettings []setting.Setting
+	for _, currentSetting := range settings {
+		if !existingNames[currentSetting.Name] {
+			missingSettings = append(missingSettings, currentSetting)
+		}
+	}
+
+	if len(missingSettings) == 0 {
+		log.Info().Msg("Settings already exist, skipping seeding")
+		return
+	}
+
+	_, err = settingsService.CreateMany(missingSettings)
 	if err != nil {
 		log.Error().
 			Str("error", err.Error()).
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Job runs a SQL statement on the project database on a cron schedule, evaluated in UTC
+type Job struct {
+	Uuid        uuid.UUID  `db:"uuid" json:"uuid"`
+	ProjectUuid uuid.UUID  `db:"project_uuid" json:"projectUuid"`
+	Name        string     `db:"name" json:"name"`
+	Schedule    string     `db:"schedule" json:"schedule"`
+	Command     string     `db:"command" json:"command"`
+	TimeoutInMs int        `db:"timeout_in_ms" json:"timeoutInMs"`
+	Enabled     bool       `db:"enabled" json:"enabled"`
+	NextRunAt   time.Time  `db:"next_run_at" json:"nextRunAt"`
+	LastRunAt   *time.Time `db:"last_run_at" json:"lastRunAt"`
+	CreatedBy   uuid.UUID  `db:"created_by" json:"createdBy"`
+	UpdatedBy   uuid.UUID  `db:"updated_by" json:"updatedBy"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+// Run is one execution of a job, started by the scheduler or by hand
+type Run struct {
+	Uuid         uuid.UUID  `db:"uuid" json:"uuid"`
+	JobUuid      uuid.UUID  `db:"job_uuid" json:"jobUuid"`
+	Trigger      string     `db:"trigger" json:"trigger"`
+	Status       string     `db:"status" json:"status"`
+	RowsAffected int64      `db:"rows_affected" json:"rowsAffected"`
+	DurationMs   int64      `db:"duration_ms" json:"durationMs"`
+	Error        string     `db:"error" json:"error"`
+	StartedAt    time.Time  `db:"started_at" json:"startedAt"`
+	FinishedAt   *time.Time `db:"finished_at" json:"finishedAt"`
+}
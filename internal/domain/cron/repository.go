@@ -0,0 +1,30 @@
+package cron
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	ListForProject(projectUUID uuid.UUID) ([]Job, error)
+	GetByUUID(jobUUID uuid.UUID) (Job, error)
+	ExistsByNameForProject(name string, projectUUID uuid.UUID) (bool, error)
+	Create(job *Job) (*Job, error)
+	SetEnabled(jobUUID uuid.UUID, enabled bool, nextRunAt time.Time, updatedBy uuid.UUID) error
+	MarkRan(jobUUID uuid.UUID, ranAt time.Time) error
+	Delete(jobUUID uuid.UUID) error
+	ClaimDue(limit int, nextRunAt func(job Job) time.Time) ([]Job, error)
+}
+
+type RunRepository interface {
+	ListForJob(jobUUID uuid.UUID, limit int) ([]Run, error)
+	Create(run *Run) (*Run, error)
+	MarkFinished(run Run) error
+	FailStale(errorMessage string, grace time.Duration) (int64, error)
+}
+
+// StatementRepository runs job commands on the project database
+type StatementRepository interface {
+	Run(ctx context.Context, statement string, timeoutInMs int) (int64, error)
+}
@@ -0,0 +1,231 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five field cron expression: minute, hour, day of month, month and day of week
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// when both day fields are restricted a day matches either of them, like in vixie cron
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+
+	// 7 is accepted as sunday as well and folded onto 0 after parsing
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxSearchYears bounds Next for expressions that never match, such as the 30th of February
+const maxSearchYears = 5
+
+// ParseSchedule parses expressions such as "*/15 * * * *", "0 3 * * mon-fri" or "@daily"
+func ParseSchedule(expression string) (Schedule, error) {
+	expression = strings.ToLower(strings.TrimSpace(expression))
+	if macro, ok := macros[expression]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	var schedule Schedule
+	var err error
+
+	if schedule.minutes, err = parseField(fields[0], minuteField); err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.hours, err = parseField(fields[1], hourField); err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.daysOfMonth, err = parseField(fields[2], dayOfMonthField); err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.months, err = parseField(fields[3], monthField); err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.daysOfWeek, err = parseField(fields[4], dayOfWeekField); err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek = schedule.daysOfWeek&^(1<<7) | 1
+	}
+
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// Next returns the first matching minute after the given time in its location, or the zero time
+// when nothing matches within the next few years
+func (s Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Year() + maxSearchYears
+
+	for t.Year() <= limit {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+// parseField turns a comma separated list of values, ranges and steps into a bit set
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(value, ",") {
+		itemBits, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+
+		bits |= itemBits
+	}
+
+	return bits, nil
+}
+
+func parseItem(item string, f field) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+	step := 1
+	if hasStep {
+		parsedStep, err := strconv.Atoi(stepPart)
+		if err != nil || parsedStep <= 0 {
+			return 0, fmt.Errorf("invalid step '%s' in %s field", stepPart, f.name)
+		}
+
+		step = parsedStep
+	}
+
+	start, end := f.min, f.max
+
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		from, to, _ := strings.Cut(rangePart, "-")
+
+		var err error
+		if start, err = parseValue(from, f); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseValue(to, f); err != nil {
+			return 0, err
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range '%s' in %s field", rangePart, f.name)
+		}
+	default:
+		value, err := parseValue(rangePart, f)
+		if err != nil {
+			return 0, err
+		}
+
+		// a single value with a step runs from that value to the end of the range, e.g. 5/15
+		start = value
+		if !hasStep {
+			end = value
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	if number, ok := f.names[value]; ok {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s' in %s field", value, f.name)
+	}
+
+	if number < f.min || number > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d in %s field", number, f.min, f.max, f.name)
+	}
+
+	return number, nil
+}
@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func at(value string) time.Time {
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+func TestParseSchedule_Suite(t *testing.T) {
+	t.Run("ParseSchedule: valid expressions", func(t *testing.T) {
+		expressions := []string{
+			"* * * * *",
+			"*/15 * * * *",
+			"0 3 * * *",
+			"0 0 1,15 * *",
+			"30 8-18/2 * * mon-fri",
+			"0 0 * JAN,Jul sun",
+			"5/10 * * * 7",
+			"@daily",
+			"@Hourly",
+		}
+
+		for _, expression := range expressions {
+			_, err := ParseSchedule(expression)
+			assert.NoError(t, err, expression)
+		}
+	})
+
+	t.Run("ParseSchedule: invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   string
+		}{
+			{expression: "", expected: "cron expression must have 5 fields, got 0"},
+			{expression: "* * * *", expected: "cron expression must have 5 fields, got 4"},
+			{expression: "60 * * * *", expected: "value 60 out of range 0-59 in minute field"},
+			{expression: "* 24 * * *", expected: "value 24 out of range 0-23 in hour field"},
+			{expression: "* * 0 * *", expected: "value 0 out of range 1-31 in day of month field"},
+			{expression: "* * * 13 *", expected: "value 13 out of range 1-12 in month field"},
+			{expression: "* * * * 8", expected: "value 8 out of range 0-7 in day of week field"},
+			{expression: "*/0 * * * *", expected: "invalid step '0' in minute field"},
+			{expression: "10-5 * * * *", expected: "invalid range '10-5' in minute field"},
+			{expression: "* * * foo *", expected: "invalid value 'foo' in month field"},
+			{expression: "@sometimes", expected: "cron expression must have 5 fields, got 1"},
+		}
+
+		for _, tt := range tests {
+			_, err := ParseSchedule(tt.expression)
+			assert.EqualError(t, err, tt.expected, tt.expression)
+		}
+	})
+}
+
+func TestScheduleNext_Suite(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		after      string
+		expected   string
+	}{
+		{name: "every minute", expression: "* * * * *", after: "2026-10-18 10:15", expected: "2026-10-18 10:16"},
+		{name: "every 15 minutes", expression: "*/15 * * * *", after: "2026-10-18 10:15", expected: "2026-10-18 10:30"},
+		{name: "nightly rolls over to the next day", expression: "0 3 * * *", after: "2026-10-18 03:00", expected: "2026-10-19 03:00"},
+		{name: "hour range with step", expression: "30 8-18/4 * * *", after: "2026-10-18 12:45", expected: "2026-10-18 16:30"},
+		{name: "weekdays only", expression: "0 9 * * mon-fri", after: "2026-10-16 10:00", expected: "2026-10-19 09:00"},
+		{name: "sunday as 7", expression: "0 0 * * 7", after: "2026-10-18 10:00", expected: "2026-10-25 00:00"},
+		{name: "month rolls over to the next year", expression: "0 0 1 jan *", after: "2026-10-18 10:00", expected: "2027-01-01 00:00"},
+		{name: "31st skips shorter months", expression: "0 0 31 * *", after: "2026-10-31 00:00", expected: "2026-12-31 00:00"},
+		{name: "leap day", expression: "0 12 29 2 *", after: "2026-03-01 00:00", expected: "2028-02-29 12:00"},
+		{name: "day of month or day of week", expression: "0 0 1 * mon", after: "2026-10-18 10:00", expected: "2026-10-19 00:00"},
+		{name: "day of month and any day of week", expression: "0 0 1 * *", after: "2026-10-18 10:00", expected: "2026-11-01 00:00"},
+		{name: "macro", expression: "@monthly", after: "2026-12-15 08:00", expected: "2027-01-01 00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+			assert.NoError(t, err)
+
+			assert.Equal(t, at(tt.expected), schedule.Next(at(tt.after)))
+		})
+	}
+
+	t.Run("Next: seconds of the given time are ignored", func(t *testing.T) {
+		schedule, _ := ParseSchedule("* * * * *")
+
+		next := schedule.Next(time.Date(2026, 10, 18, 10, 15, 42, 500, time.UTC))
+
+		assert.Equal(t, at("2026-10-18 10:16"), next)
+	})
+
+	t.Run("Next: keeps the location of the given time", func(t *testing.T) {
+		schedule, _ := ParseSchedule("0 9 * * *")
+		location := time.FixedZone("UTC+2", 2*60*60)
+
+		next := schedule.Next(time.Date(2026, 10, 18, 10, 0, 0, 0, location))
+
+		assert.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, location), next)
+	})
+
+	t.Run("Next: never matching expression", func(t *testing.T) {
+		schedule, err := ParseSchedule("0 0 30 2 *")
+		assert.NoError(t, err)
+
+		assert.True(t, schedule.Next(at("2026-10-18 10:00")).IsZero())
+	})
+}
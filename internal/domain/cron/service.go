@@ -0,0 +1,363 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/project"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+type Service interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]Job, error)
+	Create(input CreateJobInput, authUser auth.User) (Job, error)
+	SetEnabled(jobUUID uuid.UUID, enabled bool, projectUUID uuid.UUID, authUser auth.User) (Job, error)
+	Trigger(jobUUID, projectUUID uuid.UUID, authUser auth.User) (Run, error)
+	ListRuns(jobUUID, projectUUID uuid.UUID, authUser auth.User) ([]Run, error)
+	Delete(jobUUID, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	RunDue() (int, error)
+}
+
+type ServiceImpl struct {
+	connectionService database.ConnectionService
+	projectPolicy     *project.Policy
+	jobRepo           Repository
+	runRepo           RunRepository
+	projectRepo       project.Repository
+}
+
+func NewCronService(injector *do.Injector) (Service, error) {
+	connectionService := do.MustInvoke[database.ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	jobRepo := do.MustInvoke[Repository](injector)
+	runRepo := do.MustInvoke[RunRepository](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ServiceImpl{
+		connectionService: connectionService,
+		projectPolicy:     policy,
+		jobRepo:           jobRepo,
+		runRepo:           runRepo,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *ServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Job, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return []Job{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return []Job{}, flxErrors.NewForbiddenError("cron.error.listForbidden")
+	}
+
+	return s.jobRepo.ListForProject(projectUUID)
+}
+
+func (s *ServiceImpl) Create(input CreateJobInput, authUser auth.User) (Job, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Job{}, flxErrors.NewForbiddenError("cron.error.createForbidden")
+	}
+
+	exists, err := s.jobRepo.ExistsByNameForProject(input.Name, input.ProjectUUID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if exists {
+		return Job{}, flxErrors.NewUnprocessableError("cron.error.alreadyExists")
+	}
+
+	nextRunAt, err := s.nextRunAt(input.Schedule, time.Now())
+	if err != nil {
+		return Job{}, err
+	}
+
+	timeout := input.TimeoutInMs
+	if timeout <= 0 {
+		timeout = constants.DefaultCronTimeoutInMs
+	}
+
+	job := Job{
+		ProjectUuid: input.ProjectUUID,
+		Name:        input.Name,
+		Schedule:    input.Schedule,
+		Command:     input.Command,
+		TimeoutInMs: timeout,
+		Enabled:     input.Enabled,
+		NextRunAt:   nextRunAt,
+		CreatedBy:   authUser.Uuid,
+		UpdatedBy:   authUser.Uuid,
+	}
+
+	createdJob, err := s.jobRepo.Create(&job)
+	if err != nil {
+		return Job{}, err
+	}
+
+	return *createdJob, nil
+}
+
+// SetEnabled pauses or resumes a job, resuming schedules the next run from now so missed runs are not caught up
+func (s *ServiceImpl) SetEnabled(jobUUID uuid.UUID, enabled bool, projectUUID uuid.UUID, authUser auth.User) (Job, error) {
+	job, err := s.getJobForUpdate(jobUUID, projectUUID, authUser)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if job.Enabled == enabled {
+		return job, nil
+	}
+
+	nextRunAt, err := s.nextRunAt(job.Schedule, time.Now())
+	if err != nil {
+		return Job{}, err
+	}
+
+	if err = s.jobRepo.SetEnabled(job.Uuid, enabled, nextRunAt, authUser.Uuid); err != nil {
+		return Job{}, err
+	}
+
+	return s.jobRepo.GetByUUID(job.Uuid)
+}
+
+// Trigger starts a run right away, paused jobs can be triggered as well. The run is returned
+// while it is still going, its outcome shows up in the history
+func (s *ServiceImpl) Trigger(jobUUID, projectUUID uuid.UUID, authUser auth.User) (Run, error) {
+	job, err := s.getJobForUpdate(jobUUID, projectUUID, authUser)
+	if err != nil {
+		return Run{}, err
+	}
+
+	return s.start(job, constants.CronTriggerManual)
+}
+
+func (s *ServiceImpl) ListRuns(jobUUID, projectUUID uuid.UUID, authUser auth.User) ([]Run, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return []Run{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return []Run{}, flxErrors.NewForbiddenError("cron.error.listForbidden")
+	}
+
+	job, err := s.getJob(jobUUID, projectUUID)
+	if err != nil {
+		return []Run{}, err
+	}
+
+	return s.runRepo.ListForJob(job.Uuid, constants.CronRunHistoryLimit)
+}
+
+func (s *ServiceImpl) Delete(jobUUID, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	job, err := s.getJobForUpdate(jobUUID, projectUUID, authUser)
+	if err != nil {
+		return false, err
+	}
+
+	if err = s.jobRepo.Delete(job.Uuid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RunDue starts the jobs that are due and returns how many were claimed. Jobs are claimed with
+// row locks, so several API instances can run the scheduler side by side without double runs
+func (s *ServiceImpl) RunDue() (int, error) {
+	if _, err := s.runRepo.FailStale(constants.CronRunLostError, constants.CronStaleRunGrace); err != nil {
+		return 0, err
+	}
+
+	jobs, err := s.jobRepo.ClaimDue(constants.CronBatchSize, func(job Job) time.Time {
+		// schedules are validated on create, an unparsable one is pushed far out instead of failing every tick
+		nextRunAt, err := s.nextRunAt(job.Schedule, time.Now())
+		if err != nil {
+			return time.Now().UTC().AddDate(1, 0, 0)
+		}
+
+		return nextRunAt
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		if _, err := s.start(job, constants.CronTriggerSchedule); err != nil {
+			s.logError(job, err, "failed to start scheduled cron job")
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// start records a run and executes the job in the background. A job never overlaps with
+// itself, a run started while the previous one is going is recorded as skipped. Only one
+// running run per job can be inserted, so concurrent starts cannot both get through
+func (s *ServiceImpl) start(job Job, trigger string) (Run, error) {
+	if err := s.jobRepo.MarkRan(job.Uuid, time.Now().UTC()); err != nil {
+		return Run{}, err
+	}
+
+	run := Run{
+		JobUuid: job.Uuid,
+		Trigger: trigger,
+		Status:  constants.CronRunStatusRunning,
+	}
+
+	createdRun, err := s.runRepo.Create(&run)
+	if isUniqueViolation(err) {
+		run.Status = constants.CronRunStatusSkipped
+		run.Error = constants.CronRunSkippedError
+
+		createdRun, err = s.runRepo.Create(&run)
+	}
+
+	if err != nil {
+		return Run{}, err
+	}
+
+	if createdRun.Status == constants.CronRunStatusRunning {
+		go s.execute(job, *createdRun)
+	}
+
+	return *createdRun, nil
+}
+
+func (s *ServiceImpl) execute(job Job, run Run) {
+	startedAt := time.Now()
+
+	rowsAffected, err := s.runStatement(job)
+
+	run.DurationMs = time.Since(startedAt).Milliseconds()
+	run.RowsAffected = rowsAffected
+	run.Status = constants.CronRunStatusSucceeded
+
+	if err != nil {
+		run.Status = constants.CronRunStatusFailed
+		run.Error = s.toRunError(err)
+
+		s.logError(job, err, "cron job run failed")
+	}
+
+	if err = s.runRepo.MarkFinished(run); err != nil {
+		s.logError(job, err, "failed to record cron job run")
+	}
+}
+
+func (s *ServiceImpl) runStatement(job Job) (int64, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(job.ProjectUuid)
+	if err != nil {
+		return 0, err
+	}
+
+	clientStatementRepo, connection, err := s.getClientStatementRepo(fetchedProject.DBName)
+	if err != nil {
+		return 0, err
+	}
+	defer connection.Close()
+
+	// the statement timeout ends the query on the server, the context also covers connection problems
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(job.TimeoutInMs)*time.Millisecond+constants.CronStaleRunGrace)
+	defer cancel()
+
+	return clientStatementRepo.Run(ctx, job.Command, job.TimeoutInMs)
+}
+
+func (s *ServiceImpl) getJob(jobUUID, projectUUID uuid.UUID) (Job, error) {
+	job, err := s.jobRepo.GetByUUID(jobUUID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if job.ProjectUuid != projectUUID {
+		return Job{}, flxErrors.NewNotFoundError("cron.error.notFound")
+	}
+
+	return job, nil
+}
+
+func (s *ServiceImpl) getJobForUpdate(jobUUID, projectUUID uuid.UUID, authUser auth.User) (Job, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Job{}, flxErrors.NewForbiddenError("cron.error.updateForbidden")
+	}
+
+	return s.getJob(jobUUID, projectUUID)
+}
+
+// nextRunAt evaluates schedules in UTC, the timestamps are stored without a time zone
+func (s *ServiceImpl) nextRunAt(expression string, after time.Time) (time.Time, error) {
+	schedule, err := ParseSchedule(expression)
+	if err != nil {
+		return time.Time{}, flxErrors.NewBadRequestError(err.Error())
+	}
+
+	nextRunAt := schedule.Next(after.UTC())
+	if nextRunAt.IsZero() {
+		return time.Time{}, flxErrors.NewBadRequestError("cron.error.neverRuns")
+	}
+
+	return nextRunAt, nil
+}
+
+// toRunError keeps the postgres message without the driver prefix
+func (s *ServiceImpl) toRunError(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Message
+	}
+
+	return err.Error()
+}
+
+// isUniqueViolation tells a run refused by the one running run per job index apart from other errors
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation"
+}
+
+func (s *ServiceImpl) logError(job Job, err error, message string) {
+	log.Error().
+		Str("action", constants.ActionCron).
+		Str("project", job.ProjectUuid.String()).
+		Str("job", job.Name).
+		Str("error", err.Error()).
+		Msg(message)
+}
+
+func (s *ServiceImpl) getClientStatementRepo(dbName string) (StatementRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetCronStatementRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(StatementRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientCronStatementRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
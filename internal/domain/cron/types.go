@@ -0,0 +1,14 @@
+package cron
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateJobInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Name        string    `json:"name"`
+	Schedule    string    `json:"schedule"`
+	Command     string    `json:"command"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+	Enabled     bool      `json:"enabled"`
+}
@@ -16,6 +16,12 @@ type Column struct {
 	Foreign  bool   `db:"foreign" json:"foreign"`
 	Default  string `db:"default_value" json:"defaultValue"`
 
+	// Description is stored as the column comment
+	Description string `db:"description" json:"description"`
+
+	// identity and generated columns get their values from postgres, they cannot be written
+	Generated bool `db:"generated" json:"-"`
+
 	// only required when constraint is FOREIGN KEY
 	ReferenceTable  null.String `db:"reference_table" json:"referenceTable,omitempty" swaggertype:"string"`
 	ReferenceColumn null.String `db:"reference_column" json:"referenceColumn,omitempty" swaggertype:"string"`
@@ -9,9 +9,12 @@ type ColumnRepository interface {
 	CreateMany(tableName string, fields []Column) error
 	AlterOne(tableName string, columns []Column) error
 	AlterMany(tableName string, fields []Column) error
+	Alter(statements []string) error
+	HasUserDefinedType(schema, typeName string) (bool, error)
 	Rename(tableName, oldColumnName, newColumnName string) error
-	Drop(tableName, columnName string) error
 	DropMany(tableName string, columns []Column) error
 	BuildColumnDefinition(column Column) string
 	BuildForeignKeyConstraint(tableName string, column Column) (string, bool)
+	BuildCommentStatement(tableName string, column Column) (string, bool)
+	Comment(tableName, columnName, description string) error
 }
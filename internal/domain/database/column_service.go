@@ -15,23 +15,25 @@ type ColumnService interface {
 	CreateMany(fullTableName string, request CreateColumnInput, authUser auth.User) ([]Column, error)
 	Update(fullTableName string, request CreateColumnInput, authUser auth.User) ([]Column, error)
 	Rename(columnName, fullTableName string, request RenameColumnInput, authUser auth.User) ([]Column, error)
-	Delete(columnName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
 }
 
 type ColumnServiceImpl struct {
 	connectionService ConnectionService
+	migrationService  MigrationService
 	projectPolicy     *project.Policy
 	projectRepo       project.Repository
 }
 
 func NewColumnService(injector *do.Injector) (ColumnService, error) {
 	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
 	policy := do.MustInvoke[*project.Policy](injector)
 	projectRepo := do.MustInvoke[project.Repository](injector)
 
 	return &ColumnServiceImpl{
 		projectPolicy:     policy,
 		connectionService: connectionService,
+		migrationService:  migrationService,
 		projectRepo:       projectRepo,
 	}, nil
 }
@@ -62,7 +64,7 @@ func (s *ColumnServiceImpl) List(fullTableName string, projectUUID uuid.UUID, au
 		return []Column{}, err
 	}
 
-	columns, err := clientColumnRepo.List(table.Name)
+	columns, err := clientColumnRepo.List(quoteTableName(table.FullName()))
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +98,7 @@ func (s *ColumnServiceImpl) CreateMany(fullTableName string, request CreateColum
 		return []Column{}, err
 	}
 
-	anyColumnExists, err := clientColumnRepo.HasAny(table.Name, request.Columns)
+	anyColumnExists, err := clientColumnRepo.HasAny(quoteTableName(table.FullName()), request.Columns)
 	if err != nil {
 		return []Column{}, err
 	}
@@ -105,11 +107,21 @@ func (s *ColumnServiceImpl) CreateMany(fullTableName string, request CreateColum
 		return []Column{}, errors.NewUnprocessableError("column.error.someAlreadyExist")
 	}
 
-	if err = clientColumnRepo.CreateMany(table.Name, request.Columns); err != nil {
+	if err = validateUserDefinedTypes(clientColumnRepo, request.Columns); err != nil {
 		return []Column{}, err
 	}
 
-	return clientColumnRepo.List(table.Name)
+	qualifiedTableName := table.FullName()
+	definitions, foreignKeys := buildColumnStatements(clientColumnRepo, qualifiedTableName, request.Columns)
+	_, err = s.migrationService.Execute(
+		connection,
+		buildAddColumnsMigration(qualifiedTableName, mapColumnsToNames(request.Columns), definitions, foreignKeys),
+	)
+	if err != nil {
+		return []Column{}, err
+	}
+
+	return clientColumnRepo.List(quoteTableName(table.FullName()))
 }
 
 func (s *ColumnServiceImpl) Update(fullTableName string, request CreateColumnInput, authUser auth.User) ([]Column, error) {
@@ -138,7 +150,7 @@ func (s *ColumnServiceImpl) Update(fullTableName string, request CreateColumnInp
 		return []Column{}, err
 	}
 
-	allColumnsExist, err := clientColumnRepo.HasAll(table.Name, request.Columns)
+	allColumnsExist, err := clientColumnRepo.HasAll(quoteTableName(table.FullName()), request.Columns)
 	if err != nil {
 		return []Column{}, err
 	}
@@ -147,34 +159,67 @@ func (s *ColumnServiceImpl) Update(fullTableName string, request CreateColumnInp
 		return []Column{}, errors.NewNotFoundError("column.error.someNotFound")
 	}
 
-	existingColumns, err := clientColumnRepo.List(table.Name)
+	existingColumns, err := clientColumnRepo.List(quoteTableName(table.FullName()))
 	if err != nil {
 		return []Column{}, err
 	}
 
+	if err = validateUserDefinedTypes(clientColumnRepo, request.Columns); err != nil {
+		return []Column{}, err
+	}
+
 	requestColumnsMap := make(map[string]Column)
 	columnsToDelete := make([]Column, 0, len(existingColumns))
+	currentColumns := make(map[string]Column, len(existingColumns))
+	previousDescriptions := make(map[string]string, len(existingColumns))
 	for _, column := range request.Columns {
 		requestColumnsMap[column.Name] = column
 	}
 
 	for _, existingColumn := range existingColumns {
+		// columns with several constraints are listed once per constraint
+		if seenColumn, seen := currentColumns[existingColumn.Name]; seen {
+			seenColumn.Primary = seenColumn.Primary || existingColumn.Primary
+			currentColumns[existingColumn.Name] = seenColumn
+
+			continue
+		}
+
+		currentColumns[existingColumn.Name] = existingColumn
+		previousDescriptions[existingColumn.Name] = existingColumn.Description
 		if _, exists := requestColumnsMap[existingColumn.Name]; !exists {
 			columnsToDelete = append(columnsToDelete, existingColumn)
 		}
 	}
 
-	if err = clientColumnRepo.AlterMany(table.Name, request.Columns); err != nil {
+	alterations, err := planColumnAlterations(request.Columns, currentColumns)
+	if err != nil {
 		return []Column{}, err
 	}
 
-	if len(columnsToDelete) > 0 {
-		if err = clientColumnRepo.DropMany(table.Name, columnsToDelete); err != nil {
+	// the migration runs exactly the statements it records, dropped columns included
+	if len(buildUpdateColumnsStatements(table.FullName(), alterations, columnsToDelete)) > 0 {
+		_, err = s.migrationService.Execute(connection, buildAlterColumnsMigration(table.FullName(), alterations, columnsToDelete))
+		if err != nil {
+			return []Column{}, err
+		}
+	}
+
+	var describedColumns []Column
+	for _, column := range request.Columns {
+		if column.Description != previousDescriptions[column.Name] {
+			describedColumns = append(describedColumns, column)
+		}
+	}
+
+	if len(describedColumns) > 0 {
+		_, err = s.migrationService.Execute(connection, buildCommentColumnsMigration(table.FullName(), describedColumns, previousDescriptions))
+		if err != nil {
 			return []Column{}, err
 		}
 	}
 
-	return clientColumnRepo.List(table.Name)
+	return clientColumnRepo.List(quoteTableName(table.FullName()))
 }
 
 func (s *ColumnServiceImpl) Rename(columnName string, fullTableName string, request RenameColumnInput, authUser auth.User) ([]Column, error) {
@@ -203,7 +248,7 @@ func (s *ColumnServiceImpl) Rename(columnName string, fullTableName string, requ
 		return []Column{}, err
 	}
 
-	columnExists, err := clientColumnRepo.Has(table.Name, columnName)
+	columnExists, err := clientColumnRepo.Has(quoteTableName(table.FullName()), columnName)
 	if err != nil {
 		return []Column{}, err
 	}
@@ -212,43 +257,12 @@ func (s *ColumnServiceImpl) Rename(columnName string, fullTableName string, requ
 		return []Column{}, errors.NewNotFoundError("column.error.notFound")
 	}
 
-	if err = clientColumnRepo.Rename(table.Name, columnName, request.Name); err != nil {
-		return []Column{}, err
-	}
-
-	return clientColumnRepo.List(table.Name)
-}
-
-func (s *ColumnServiceImpl) Delete(columnName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
-	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
-	if err != nil {
-		return false, err
-	}
-
-	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
-		return false, errors.NewForbiddenError("project.error.updateForbidden")
-	}
-	clientColumnRepo, connection, err := s.getClientColumnRepo(fetchedProject.DBName, nil)
-	if err != nil {
-		return false, err
-	}
-	defer connection.Close()
-
-	_, tableName := pkg.ParseTableName(fullTableName)
-	columnExists, err := clientColumnRepo.Has(tableName, columnName)
+	_, err = s.migrationService.Execute(connection, buildRenameColumnMigration(table.FullName(), columnName, request.Name))
 	if err != nil {
-		return false, err
-	}
-
-	if !columnExists {
-		return false, errors.NewNotFoundError("column.error.notFound")
-	}
-
-	if err = clientColumnRepo.Drop(fullTableName, columnName); err != nil {
-		return false, err
+		return []Column{}, err
 	}
 
-	return true, err
+	return clientColumnRepo.List(quoteTableName(table.FullName()))
 }
 
 func (s *ColumnServiceImpl) getClientTableRepo(dbName string) (TableRepository, *sqlx.DB, error) {
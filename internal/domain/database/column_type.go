@@ -0,0 +1,513 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/lib/pq"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ColumnType is a parsed column type such as numeric(10,2), varchar(255)[] or public.order_status
+type ColumnType struct {
+	Name       string
+	Params     []int
+	Dimensions int
+
+	// user-defined types such as enums are always schema qualified
+	UserDefined bool
+}
+
+var columnTypePattern = regexp.MustCompile(
+	`^([a-z_][a-z0-9_]*\.[a-z_][a-z0-9_]*|[a-z_][a-z0-9_]*(?: [a-z_][a-z0-9_]*)*?)` + // name
+		` ?(?:\( ?(\d+) ?(?:, ?(\d+) ?)?\))?` + // parameters
+		`( with(?:out)? time zone)?` + // timestamp and time suffix
+		`((?: ?\[\d*\])*)$`, // array dimensions
+)
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// columnTypeAliases maps alternative spellings, including the ones reported by format_type, to the names used by the API
+var columnTypeAliases = map[string]string{
+	"int":                         constants.ColumnTypeInteger,
+	"int4":                        constants.ColumnTypeInteger,
+	"int2":                        constants.ColumnTypeSmallint,
+	"int8":                        constants.ColumnTypeBigint,
+	"serial4":                     constants.ColumnTypeSerial,
+	"serial2":                     constants.ColumnTypeSmallSerial,
+	"serial8":                     constants.ColumnTypeBigSerial,
+	"bool":                        constants.ColumnTypeBoolean,
+	"float4":                      constants.ColumnTypeReal,
+	"float8":                      constants.ColumnTypeDouble,
+	"character varying":           constants.ColumnTypeVarchar,
+	"character":                   constants.ColumnTypeChar,
+	"bpchar":                      constants.ColumnTypeChar,
+	"timestamp without time zone": constants.ColumnTypeTimestamp,
+	"timestamp with time zone":    constants.ColumnTypeTimestampTZ,
+	"time without time zone":      constants.ColumnTypeTime,
+	"time with time zone":         constants.ColumnTypeTimeTZ,
+}
+
+// ParseColumnType normalises a raw column type, resolving aliases so equal types compare equal
+func ParseColumnType(raw string) (ColumnType, error) {
+	normalized := strings.ToLower(whitespacePattern.ReplaceAllString(strings.TrimSpace(raw), " "))
+
+	matches := columnTypePattern.FindStringSubmatch(normalized)
+	if matches == nil {
+		return ColumnType{}, fmt.Errorf("column type '%s' is invalid", raw)
+	}
+
+	columnType := ColumnType{
+		Name:        matches[1] + matches[4],
+		Dimensions:  strings.Count(matches[5], "["),
+		UserDefined: strings.Contains(matches[1], "."),
+	}
+
+	if alias, ok := columnTypeAliases[columnType.Name]; ok {
+		columnType.Name = alias
+	}
+
+	for _, param := range matches[2:4] {
+		if param == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return ColumnType{}, fmt.Errorf("column type '%s' is invalid", raw)
+		}
+
+		columnType.Params = append(columnType.Params, value)
+	}
+
+	return columnType, nil
+}
+
+// Validate checks the parameters of built-in types, user-defined types are checked against the database
+func (t ColumnType) Validate() error {
+	switch t.Name {
+	case constants.ColumnTypeVarchar, constants.ColumnTypeChar:
+		if len(t.Params) > 1 || (len(t.Params) == 1 && (t.Params[0] < 1 || t.Params[0] > constants.MaxCharacterLength)) {
+			return fmt.Errorf("column type '%s' must have a length between 1 and %d", t, constants.MaxCharacterLength)
+		}
+	case constants.ColumnTypeNumeric, constants.ColumnTypeDecimal:
+		if len(t.Params) > 0 && (t.Params[0] < 1 || t.Params[0] > constants.MaxNumericPrecision) {
+			return fmt.Errorf("column type '%s' must have a precision between 1 and %d", t, constants.MaxNumericPrecision)
+		}
+
+		if len(t.Params) == 2 && t.Params[1] > t.Params[0] {
+			return fmt.Errorf("column type '%s' cannot have a scale larger than its precision", t)
+		}
+	case constants.ColumnTypeTime,
+		constants.ColumnTypeTimeTZ,
+		constants.ColumnTypeTimestamp,
+		constants.ColumnTypeTimestampTZ,
+		constants.ColumnTypeInterval:
+		if len(t.Params) > 1 || (len(t.Params) == 1 && t.Params[0] > constants.MaxTimePrecision) {
+			return fmt.Errorf("column type '%s' must have a precision between 0 and %d", t, constants.MaxTimePrecision)
+		}
+	default:
+		if len(t.Params) > 0 {
+			return fmt.Errorf("column type '%s' does not take parameters", t.Name)
+		}
+	}
+
+	if t.Dimensions > 0 && t.isSerial() {
+		return fmt.Errorf("column type '%s' cannot be an array", t.Name)
+	}
+
+	return nil
+}
+
+func (t ColumnType) String() string {
+	var builder strings.Builder
+	builder.WriteString(t.Name)
+
+	if len(t.Params) > 0 {
+		params := make([]string, len(t.Params))
+		for i, param := range t.Params {
+			params[i] = strconv.Itoa(param)
+		}
+
+		builder.WriteString("(" + strings.Join(params, ",") + ")")
+	}
+
+	builder.WriteString(strings.Repeat("[]", t.Dimensions))
+
+	return builder.String()
+}
+
+// SchemaAndName splits a user-defined type into its schema and name
+func (t ColumnType) SchemaAndName() (string, string) {
+	parts := strings.SplitN(t.Name, ".", 2)
+	if len(parts) != 2 {
+		return "", t.Name
+	}
+
+	return parts[0], parts[1]
+}
+
+func (t ColumnType) element() ColumnType {
+	element := t
+	element.Dimensions = 0
+
+	return element
+}
+
+func (t ColumnType) isSerial() bool {
+	return t.Name == constants.ColumnTypeSerial ||
+		t.Name == constants.ColumnTypeSmallSerial ||
+		t.Name == constants.ColumnTypeBigSerial
+}
+
+// columnTypeFamilies groups types postgres converts between with assignment casts, which fail
+// loudly on overflow instead of truncating like explicit casts do
+var columnTypeFamilies = map[string]string{
+	constants.ColumnTypeSmallint:    "number",
+	constants.ColumnTypeInteger:     "number",
+	constants.ColumnTypeBigint:      "number",
+	constants.ColumnTypeSerial:      "number",
+	constants.ColumnTypeSmallSerial: "number",
+	constants.ColumnTypeBigSerial:   "number",
+	constants.ColumnTypeNumeric:     "number",
+	constants.ColumnTypeDecimal:     "number",
+	constants.ColumnTypeReal:        "number",
+	constants.ColumnTypeDouble:      "number",
+	constants.ColumnTypeFloat:       "number",
+	constants.ColumnTypeVarchar:     "string",
+	constants.ColumnTypeChar:        "string",
+	constants.ColumnTypeText:        "string",
+	constants.ColumnTypeDate:        "datetime",
+	constants.ColumnTypeTimestamp:   "datetime",
+	constants.ColumnTypeTimestampTZ: "datetime",
+	constants.ColumnTypeTime:        "time",
+	constants.ColumnTypeTimeTZ:      "time",
+	constants.ColumnTypeJSON:        "json",
+	constants.ColumnTypeJSONB:       "json",
+	constants.ColumnTypeInet:        "network",
+	constants.ColumnTypeCidr:        "network",
+}
+
+// integerBits and floatMantissaBits tell which numeric types hold every value of an integer type
+var integerBits = map[string]int{
+	constants.ColumnTypeSmallint:    16,
+	constants.ColumnTypeSmallSerial: 16,
+	constants.ColumnTypeInteger:     32,
+	constants.ColumnTypeSerial:      32,
+	constants.ColumnTypeBigint:      64,
+	constants.ColumnTypeBigSerial:   64,
+}
+
+var integerDigits = map[int]int{16: 5, 32: 10, 64: 19}
+
+var floatMantissaBits = map[string]int{
+	constants.ColumnTypeReal:   24,
+	constants.ColumnTypeDouble: 53,
+	constants.ColumnTypeFloat:  53,
+}
+
+// datetimeRanks orders the types of the datetime and time families, a type holds every value of the lower ranks
+var datetimeRanks = map[string]int{
+	constants.ColumnTypeDate:        1,
+	constants.ColumnTypeTimestamp:   2,
+	constants.ColumnTypeTimestampTZ: 3,
+	constants.ColumnTypeTime:        1,
+	constants.ColumnTypeTimeTZ:      2,
+}
+
+// PlanTypeChange returns the USING expression that converts a column between two types. The expression
+// is empty when postgres can convert on its own, an error is returned for conversions that narrow the
+// type, could silently lose data or have no cast at all
+func PlanTypeChange(columnName string, from, to ColumnType) (string, error) {
+	return planTypeChange(columnName, from, to, false)
+}
+
+// planTypeChange accepts narrowing within a family when allowNarrowing is set, the other conversions
+// are refused all the same
+func planTypeChange(columnName string, from, to ColumnType, allowNarrowing bool) (string, error) {
+	column := pq.QuoteIdentifier(columnName)
+
+	unsafeErr := fmt.Errorf("column '%s' cannot be safely converted from %s to %s", columnName, from, to)
+
+	switch {
+	case from.Dimensions == to.Dimensions:
+		chain, ok := castChain(from.element(), to.element(), allowNarrowing)
+		if !ok {
+			return "", unsafeErr
+		}
+
+		if len(chain) == 0 {
+			return "", nil
+		}
+
+		var builder strings.Builder
+		builder.WriteString(column)
+		for _, cast := range chain {
+			cast.Dimensions = to.Dimensions
+			builder.WriteString("::" + cast.String())
+		}
+
+		return builder.String(), nil
+	case from.Dimensions == 0:
+		// a scalar becomes the single element of the array
+		chain, ok := castChain(from, to.element(), allowNarrowing)
+		if !ok {
+			return "", unsafeErr
+		}
+
+		element := column
+		for _, cast := range chain {
+			element += "::" + cast.String()
+		}
+
+		return fmt.Sprintf("ARRAY[%s]::%s", element, to), nil
+	default:
+		return "", unsafeErr
+	}
+}
+
+// castChain lists the explicit casts needed between two element types, nil means an assignment cast suffices
+func castChain(from, to ColumnType, allowNarrowing bool) ([]ColumnType, bool) {
+	if from.String() == to.String() {
+		return nil, true
+	}
+
+	fromFamily, fromKnown := columnTypeFamilies[from.Name]
+	toFamily, toKnown := columnTypeFamilies[to.Name]
+	integer := ColumnType{Name: constants.ColumnTypeInteger}
+	text := ColumnType{Name: constants.ColumnTypeText}
+
+	switch {
+	case fromKnown && toKnown && fromFamily == toFamily && fromFamily != "json" && fromFamily != "network":
+		return nil, allowNarrowing || widens(fromFamily, from, to)
+	case toFamily == "string":
+		// every type has a text representation
+		return nil, true
+	case fromFamily == "string":
+		return []ColumnType{to}, true
+	case fromFamily == "json" && toFamily == "json", fromFamily == "network" && toFamily == "network":
+		return []ColumnType{to}, true
+	case fromFamily == "number" && to.Name == constants.ColumnTypeBoolean:
+		return []ColumnType{integer, to}, true
+	case from.Name == constants.ColumnTypeBoolean && toFamily == "number":
+		return []ColumnType{integer}, true
+	case from.UserDefined && to.UserDefined:
+		// enums have no casts between each other, but both convert through their labels
+		return []ColumnType{text, to}, true
+	}
+
+	return nil, false
+}
+
+// widens reports whether every value of from fits into to unchanged, both types belong to family
+func widens(family string, from, to ColumnType) bool {
+	switch family {
+	case "number":
+		return widensNumber(from, to)
+	case "string":
+		return widensString(from, to)
+	case "datetime", "time":
+		if datetimeRanks[to.Name] < datetimeRanks[from.Name] {
+			return false
+		}
+
+		// a date has no fractional seconds to keep
+		return from.Name == constants.ColumnTypeDate || timePrecision(to) >= timePrecision(from)
+	}
+
+	return false
+}
+
+func widensNumber(from, to ColumnType) bool {
+	if bits, ok := integerBits[from.Name]; ok {
+		if toBits, ok := integerBits[to.Name]; ok {
+			return toBits >= bits
+		}
+
+		if mantissa, ok := floatMantissaBits[to.Name]; ok {
+			return mantissa >= bits
+		}
+
+		return numericHolds(to, integerDigits[bits], 0)
+	}
+
+	if mantissa, ok := floatMantissaBits[from.Name]; ok {
+		toMantissa, ok := floatMantissaBits[to.Name]
+
+		return ok && toMantissa >= mantissa
+	}
+
+	// an unconstrained numeric only fits into another unconstrained one
+	if len(from.Params) == 0 {
+		return numericHolds(to, -1, -1)
+	}
+
+	precision, scale := numericPrecisionAndScale(from)
+
+	return numericHolds(to, precision-scale, scale)
+}
+
+// numericHolds reports whether a numeric type keeps the given digits before and after the decimal
+// point, negative digits stand for an unconstrained numeric
+func numericHolds(t ColumnType, integerDigits, scale int) bool {
+	if t.Name != constants.ColumnTypeNumeric && t.Name != constants.ColumnTypeDecimal {
+		return false
+	}
+
+	if len(t.Params) == 0 {
+		return true
+	}
+
+	if integerDigits < 0 {
+		return false
+	}
+
+	precision, toScale := numericPrecisionAndScale(t)
+
+	return toScale >= scale && precision-toScale >= integerDigits
+}
+
+func numericPrecisionAndScale(t ColumnType) (int, int) {
+	if len(t.Params) == 2 {
+		return t.Params[0], t.Params[1]
+	}
+
+	return t.Params[0], 0
+}
+
+// widensString refuses shorter lengths, postgres would reject or cut the values that no longer fit
+func widensString(from, to ColumnType) bool {
+	toLength, toBounded := characterLength(to)
+	if !toBounded {
+		return true
+	}
+
+	fromLength, fromBounded := characterLength(from)
+
+	return fromBounded && fromLength <= toLength
+}
+
+// characterLength returns the maximum length of a string type, text and varchar without a length are unbounded
+func characterLength(t ColumnType) (int, bool) {
+	switch {
+	case t.Name == constants.ColumnTypeText:
+		return 0, false
+	case len(t.Params) > 0:
+		return t.Params[0], true
+	case t.Name == constants.ColumnTypeChar:
+		return 1, true
+	}
+
+	return 0, false
+}
+
+// timePrecision returns the fractional second digits of a time or timestamp, postgres keeps six by default
+func timePrecision(t ColumnType) int {
+	if len(t.Params) > 0 {
+		return t.Params[0]
+	}
+
+	return constants.MaxTimePrecision
+}
+
+// validateUserDefinedTypes makes sure every enum or composite type the columns refer to exists in the client database
+func validateUserDefinedTypes(columnRepo ColumnRepository, columns []Column) error {
+	for _, column := range columns {
+		columnType, err := ParseColumnType(column.Type)
+		if err != nil {
+			return flxErrors.NewBadRequestError(err.Error())
+		}
+
+		if !columnType.UserDefined {
+			continue
+		}
+
+		exists, err := columnRepo.HasUserDefinedType(columnType.SchemaAndName())
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return flxErrors.NewBadRequestError(fmt.Sprintf("column type '%s' does not exist", column.Type))
+		}
+	}
+
+	return nil
+}
+
+// planColumnAlterations compares requested columns with the current ones and skips unchanged columns.
+// Primary keys stay not null, generated columns keep their expression and sequence defaults of serial
+// columns are kept unless another default is given
+func planColumnAlterations(columns []Column, currentColumns map[string]Column) ([]ColumnAlteration, error) {
+	currentTypes := make(map[string]string, len(currentColumns))
+	for name, column := range currentColumns {
+		currentTypes[name] = column.Type
+	}
+
+	typeChanges, err := planTypeChanges(columns, currentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	changesByColumn := make(map[string]ColumnTypeChange, len(typeChanges))
+	for _, change := range typeChanges {
+		changesByColumn[change.Name] = change
+	}
+
+	alterations := make([]ColumnAlteration, 0, len(columns))
+	for _, column := range columns {
+		previous := currentColumns[column.Name]
+
+		if previous.Primary {
+			column.NotNull = true
+		}
+
+		if previous.Generated || (column.Default == "" && strings.HasPrefix(previous.Default, "nextval(")) {
+			column.Default = previous.Default
+		}
+
+		alteration := ColumnAlteration{Previous: previous, Column: column}
+		if change, ok := changesByColumn[column.Name]; ok {
+			alteration.TypeChange = &change
+		}
+
+		if alteration.TypeChange == nil && !alteration.defaultChanged() && !alteration.notNullChanged() {
+			continue
+		}
+
+		alterations = append(alterations, alteration)
+	}
+
+	return alterations, nil
+}
+
+// planTypeChanges compares requested column types with the current ones and skips unchanged columns
+func planTypeChanges(columns []Column, currentTypes map[string]string) ([]ColumnTypeChange, error) {
+	changes := make([]ColumnTypeChange, 0, len(columns))
+	for _, column := range columns {
+		to, err := ParseColumnType(column.Type)
+		if err != nil {
+			return nil, flxErrors.NewBadRequestError(err.Error())
+		}
+
+		from, err := ParseColumnType(currentTypes[column.Name])
+		if err != nil {
+			return nil, flxErrors.NewBadRequestError(err.Error())
+		}
+
+		if from.String() == to.String() {
+			continue
+		}
+
+		using, err := PlanTypeChange(column.Name, from, to)
+		if err != nil {
+			return nil, flxErrors.NewBadRequestError(err.Error())
+		}
+
+		changes = append(changes, ColumnTypeChange{Name: column.Name, Type: to.String(), Using: using})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseColumnType(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+		userType bool
+	}{
+		{raw: "integer", expected: "integer"},
+		{raw: "int8", expected: "bigint"},
+		{raw: "NUMERIC(10, 2)", expected: "numeric(10,2)"},
+		{raw: "character varying(255)", expected: "varchar(255)"},
+		{raw: "varchar(255)[]", expected: "varchar(255)[]"},
+		{raw: "integer[][]", expected: "integer[][]"},
+		{raw: "timestamp(3) with time zone", expected: "timestamptz(3)"},
+		{raw: "time without time zone", expected: "time"},
+		{raw: "double precision", expected: "double precision"},
+		{raw: "public.order_status", expected: "public.order_status", userType: true},
+		{raw: "public.order_status[]", expected: "public.order_status[]", userType: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.raw, func(t *testing.T) {
+			columnType, err := ParseColumnType(tc.raw)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, columnType.String())
+			assert.Equal(t, tc.userType, columnType.UserDefined)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, raw := range []string{"", "integer;drop table users", "varchar(", "public.status.extra"} {
+			_, err := ParseColumnType(raw)
+			assert.Error(t, err, raw)
+		}
+	})
+}
+
+func TestColumnType_Validate(t *testing.T) {
+	valid := []string{"varchar(255)", "numeric(10,2)", "numeric", "timestamptz(6)", "text[]", "public.order_status"}
+	for _, raw := range valid {
+		columnType, err := ParseColumnType(raw)
+		assert.NoError(t, err)
+		assert.NoError(t, columnType.Validate(), raw)
+	}
+
+	invalid := []string{"varchar(0)", "numeric(2000)", "numeric(4,6)", "timestamp(7)", "integer(4)", "bigserial[]"}
+	for _, raw := range invalid {
+		columnType, err := ParseColumnType(raw)
+		assert.NoError(t, err)
+		assert.Error(t, columnType.Validate(), raw)
+	}
+}
+
+func TestPlanTypeChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+		unsafe   bool
+	}{
+		{name: "widening integer", from: "integer", to: "bigint", expected: ""},
+		{name: "integer to double", from: "integer", to: "double precision", expected: ""},
+		{name: "integer to wide enough numeric", from: "integer", to: "numeric(12,2)", expected: ""},
+		{name: "numeric to larger numeric", from: "numeric(10,2)", to: "numeric(12,4)", expected: ""},
+		{name: "date to timestamp", from: "date", to: "timestamp", expected: ""},
+		{name: "timestamp to timestamptz", from: "timestamp", to: "timestamptz", expected: ""},
+		{name: "longer varchar", from: "varchar(50)", to: "varchar(255)", expected: ""},
+		{name: "varchar to text", from: "varchar(50)", to: "text", expected: ""},
+		{name: "anything to text", from: "jsonb", to: "text", expected: ""},
+		{name: "text to integer", from: "text", to: "integer", expected: `"col"::integer`},
+		{name: "json to jsonb", from: "json", to: "jsonb", expected: `"col"::jsonb`},
+		{name: "integer to boolean", from: "integer", to: "boolean", expected: `"col"::integer::boolean`},
+		{name: "boolean to integer", from: "boolean", to: "integer", expected: `"col"::integer`},
+		{name: "enum to enum", from: "public.old_status", to: "public.new_status", expected: `"col"::text::public.new_status`},
+		{name: "array elements", from: "text[]", to: "integer[]", expected: `"col"::integer[]`},
+		{name: "scalar to array", from: "text", to: "text[]", expected: `ARRAY["col"]::text[]`},
+		{name: "array to scalar", from: "text[]", to: "text", unsafe: true},
+		{name: "timestamp to integer", from: "timestamp", to: "integer", unsafe: true},
+		{name: "uuid to integer", from: "uuid", to: "integer", unsafe: true},
+		{name: "numeric to integer", from: "numeric(10,2)", to: "integer", unsafe: true},
+		{name: "double to integer", from: "double precision", to: "integer", unsafe: true},
+		{name: "bigint to smallint", from: "bigint", to: "smallint", unsafe: true},
+		{name: "bigint to double", from: "bigint", to: "double precision", unsafe: true},
+		{name: "integer to narrow numeric", from: "integer", to: "numeric(6,2)", unsafe: true},
+		{name: "numeric to smaller scale", from: "numeric(10,2)", to: "numeric(10,1)", unsafe: true},
+		{name: "timestamptz to timestamp", from: "timestamptz", to: "timestamp", unsafe: true},
+		{name: "timestamp to date", from: "timestamp", to: "date", unsafe: true},
+		{name: "timestamp to lower precision", from: "timestamp(6)", to: "timestamp(3)", unsafe: true},
+		{name: "shorter varchar", from: "varchar(255)", to: "varchar(50)", unsafe: true},
+		{name: "text to varchar", from: "text", to: "varchar(255)", unsafe: true},
+		{name: "narrowing array elements", from: "bigint[]", to: "integer[]", unsafe: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from, err := ParseColumnType(tc.from)
+			assert.NoError(t, err)
+
+			to, err := ParseColumnType(tc.to)
+			assert.NoError(t, err)
+
+			using, err := PlanTypeChange("col", from, to)
+			if tc.unsafe {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, using)
+		})
+	}
+}
+
+func TestPlanColumnAlterations(t *testing.T) {
+	current := map[string]Column{
+		"id":    {Name: "id", Type: "integer", NotNull: true, Primary: true, Default: "nextval('users_id_seq'::regclass)"},
+		"email": {Name: "email", Type: "text"},
+		"score": {Name: "score", Type: "integer", Default: "0"},
+	}
+
+	alterations, err := planColumnAlterations([]Column{
+		{Name: "id", Type: "integer"},
+		{Name: "email", Type: "text", NotNull: true},
+		{Name: "score", Type: "bigint", Default: "0"},
+	}, current)
+
+	assert.NoError(t, err)
+	assert.Len(t, alterations, 2)
+
+	assert.Equal(t, "email", alterations[0].Column.Name)
+	assert.Nil(t, alterations[0].TypeChange)
+	assert.True(t, alterations[0].notNullChanged())
+
+	assert.Equal(t, "score", alterations[1].Column.Name)
+	assert.Equal(t, "bigint", alterations[1].TypeChange.Type)
+	assert.False(t, alterations[1].defaultChanged())
+}
@@ -13,3 +13,25 @@ type RenameColumnInput struct {
 	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
 	Name        string    `json:"name"`
 }
+
+// ColumnTypeChange describes an ALTER COLUMN ... TYPE, Using is empty when postgres converts on its own
+type ColumnTypeChange struct {
+	Name  string
+	Type  string
+	Using string
+}
+
+// ColumnAlteration is what an update changes on an existing column, TypeChange is nil when the type stays
+type ColumnAlteration struct {
+	Previous   Column
+	Column     Column
+	TypeChange *ColumnTypeChange
+}
+
+func (a ColumnAlteration) defaultChanged() bool {
+	return a.Previous.Default != a.Column.Default
+}
+
+func (a ColumnAlteration) notNullChanged() bool {
+	return a.Previous.NotNull != a.Column.NotNull
+}
@@ -6,10 +6,28 @@ import (
 
 type ConnectionService interface {
 	ConnectByDatabaseName(name string) (*sqlx.DB, error)
+	ConnectReadOnlyByDatabaseName(name string) (*sqlx.DB, error)
+	ConnectAuthenticatorByDatabaseName(name string) (*sqlx.DB, error)
 	GetDatabaseStatsRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 	GetTableRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 	GetFunctionRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 	GetColumnRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 	GetIndexRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetConstraintRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetTriggerRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetPolicyRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetTypeRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetViewRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetRoleRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetExtensionRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetSchemaRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 	GetRowRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetQueryRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetCronStatementRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetRealtimeRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetMigrationRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetPartitionRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetDDLRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetDropRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
+	GetMaintenanceRepo(databaseName string, connection *sqlx.DB) (interface{}, *sqlx.DB, error)
 }
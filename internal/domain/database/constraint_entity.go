@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+type Constraint struct {
+	Name       string `db:"name" json:"name"`
+	Type       string `db:"type" json:"type"`
+	Definition string `db:"definition" json:"definition"`
+
+	Columns pq.StringArray `db:"columns" json:"columns" swaggertype:"array,string"`
+
+	// only set when type is foreign_key
+	ReferenceTable   string         `db:"reference_table" json:"referenceTable"`
+	ReferenceColumns pq.StringArray `db:"reference_columns" json:"referenceColumns" swaggertype:"array,string"`
+	OnDelete         string         `db:"on_delete" json:"onDelete"`
+	OnUpdate         string         `db:"on_update" json:"onUpdate"`
+
+	Deferrable        bool `db:"deferrable" json:"deferrable"`
+	InitiallyDeferred bool `db:"initially_deferred" json:"initiallyDeferred"`
+	Validated         bool `db:"validated" json:"validated"`
+}
@@ -0,0 +1,10 @@
+package database
+
+type ConstraintRepository interface {
+	List(fullTableName string) ([]Constraint, error)
+	GetByName(fullTableName, constraintName string) (Constraint, error)
+	Has(fullTableName, constraintName string) (bool, error)
+	Create(constraintSQL string) error
+	Drop(fullTableName, constraintName string) error
+	Validate(fullTableName, constraintName string) error
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type ConstraintService interface {
+	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Constraint, error)
+	GetByName(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Constraint, error)
+	Create(fullTableName string, request CreateConstraintInput, authUser auth.User) (Constraint, error)
+	Validate(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Constraint, error)
+	Delete(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+}
+
+type ConstraintServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewConstraintService(injector *do.Injector) (ConstraintService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ConstraintServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *ConstraintServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Constraint, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientConstraintRepo, connection, err := s.getClientConstraintRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	constraints, err := clientConstraintRepo.List(fullTableName)
+	if err != nil {
+		return nil, s.toConstraintError(err)
+	}
+
+	return constraints, nil
+}
+
+func (s *ConstraintServiceImpl) GetByName(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Constraint, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Constraint{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientConstraintRepo, connection, err := s.getClientConstraintRepo(fetchedProject.DBName)
+	if err != nil {
+		return Constraint{}, err
+	}
+	defer connection.Close()
+
+	constraint, err := clientConstraintRepo.GetByName(fullTableName, constraintName)
+	if err != nil {
+		return Constraint{}, s.toConstraintError(err)
+	}
+
+	return constraint, nil
+}
+
+// Create adds a named constraint. Unless NotValid is set, existing rows are checked and
+// the request fails with the first violation reported by the database
+func (s *ConstraintServiceImpl) Create(fullTableName string, request CreateConstraintInput, authUser auth.User) (Constraint, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Constraint{}, flxErrors.NewForbiddenError("table.error.createForbidden")
+	}
+
+	clientConstraintRepo, connection, err := s.getClientConstraintRepo(fetchedProject.DBName)
+	if err != nil {
+		return Constraint{}, err
+	}
+	defer connection.Close()
+
+	hasConstraint, err := clientConstraintRepo.Has(fullTableName, request.Name)
+	if err != nil {
+		return Constraint{}, s.toConstraintError(err)
+	}
+
+	if hasConstraint {
+		return Constraint{}, flxErrors.NewUnprocessableError("constraint.error.alreadyExists")
+	}
+
+	body := buildConstraintBody(request)
+	if err = clientConstraintRepo.Create(buildAddConstraintStatement(fullTableName, request.Name, body)); err != nil {
+		return Constraint{}, s.toConstraintError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateConstraintMigration(fullTableName, request.Name, body))
+
+	return clientConstraintRepo.GetByName(fullTableName, request.Name)
+}
+
+// Validate checks existing rows against a constraint that was created with NotValid
+func (s *ConstraintServiceImpl) Validate(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Constraint, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Constraint{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientConstraintRepo, connection, err := s.getClientConstraintRepo(fetchedProject.DBName)
+	if err != nil {
+		return Constraint{}, err
+	}
+	defer connection.Close()
+
+	constraint, err := clientConstraintRepo.GetByName(fullTableName, constraintName)
+	if err != nil {
+		return Constraint{}, s.toConstraintError(err)
+	}
+
+	if constraint.Validated {
+		return constraint, nil
+	}
+
+	if err = clientConstraintRepo.Validate(fullTableName, constraintName); err != nil {
+		return Constraint{}, s.toConstraintError(err)
+	}
+
+	constraint.Validated = true
+
+	return constraint, nil
+}
+
+func (s *ConstraintServiceImpl) Delete(constraintName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientConstraintRepo, connection, err := s.getClientConstraintRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	constraint, err := clientConstraintRepo.GetByName(fullTableName, constraintName)
+	if err != nil {
+		return false, s.toConstraintError(err)
+	}
+
+	if err = clientConstraintRepo.Drop(fullTableName, constraintName); err != nil {
+		return false, s.toConstraintError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropConstraintMigration(fullTableName, constraint))
+
+	return true, nil
+}
+
+// toConstraintError surfaces database errors such as violations by existing rows or
+// unknown referenced columns as bad requests
+func (s *ConstraintServiceImpl) toConstraintError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *ConstraintServiceImpl) getClientConstraintRepo(dbName string) (ConstraintRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetConstraintRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(ConstraintRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// buildConstraintBody renders everything after ADD CONSTRAINT <name>
+func buildConstraintBody(input CreateConstraintInput) string {
+	var body string
+	switch input.Type {
+	case constants.ConstraintTypePrimaryKey:
+		body = fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifiers(input.Columns))
+	case constants.ConstraintTypeUnique:
+		body = fmt.Sprintf("UNIQUE (%s)", quoteIdentifiers(input.Columns))
+	case constants.ConstraintTypeForeignKey:
+		body = fmt.Sprintf(
+			"FOREIGN KEY (%s) REFERENCES %s (%s)",
+			quoteIdentifiers(input.Columns),
+			quoteTableName(input.ReferenceTable),
+			quoteIdentifiers(input.ReferenceColumns),
+		)
+
+		if input.OnDelete != "" {
+			body += " ON DELETE " + input.OnDelete
+		}
+
+		if input.OnUpdate != "" {
+			body += " ON UPDATE " + input.OnUpdate
+		}
+	case constants.ConstraintTypeCheck:
+		body = fmt.Sprintf("CHECK (%s)", input.Expression)
+	}
+
+	if input.Deferrable {
+		body += " DEFERRABLE"
+
+		if input.InitiallyDeferred {
+			body += " INITIALLY DEFERRED"
+		}
+	}
+
+	if input.NotValid {
+		body += " NOT VALID"
+	}
+
+	return body
+}
+
+func buildAddConstraintStatement(fullTableName, constraintName, body string) string {
+	return fmt.Sprintf(
+		"ALTER TABLE %s ADD CONSTRAINT %s %s;",
+		quoteTableName(fullTableName),
+		pq.QuoteIdentifier(constraintName),
+		body,
+	)
+}
+
+func buildDropConstraintStatement(fullTableName, constraintName string) string {
+	return fmt.Sprintf(
+		"ALTER TABLE %s DROP CONSTRAINT %s;",
+		quoteTableName(fullTableName),
+		pq.QuoteIdentifier(constraintName),
+	)
+}
+
+func buildCreateConstraintMigration(fullTableName, constraintName, body string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "add_constraint_" + constraintName,
+		UpSQL:   buildAddConstraintStatement(fullTableName, constraintName, body),
+		DownSQL: buildDropConstraintStatement(fullTableName, constraintName),
+	}
+}
+
+// buildDropConstraintMigration restores the constraint from its catalog definition, which
+// keeps the referential actions, deferrability and NOT VALID flag
+func buildDropConstraintMigration(fullTableName string, constraint Constraint) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_constraint_" + constraint.Name,
+		UpSQL:   buildDropConstraintStatement(fullTableName, constraint.Name),
+		DownSQL: buildAddConstraintStatement(fullTableName, constraint.Name, constraint.Definition),
+	}
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = pq.QuoteIdentifier(identifier)
+	}
+
+	return strings.Join(quoted, ", ")
+}
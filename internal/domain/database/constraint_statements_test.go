@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstraintStatements_Suite(t *testing.T) {
+	t.Run("composite primary key", func(t *testing.T) {
+		body := buildConstraintBody(CreateConstraintInput{
+			Type:    constants.ConstraintTypePrimaryKey,
+			Columns: []string{"order_id", "product_id"},
+		})
+
+		assert.Equal(t, `PRIMARY KEY ("order_id", "product_id")`, body)
+	})
+
+	t.Run("deferrable unique", func(t *testing.T) {
+		body := buildConstraintBody(CreateConstraintInput{
+			Type:              constants.ConstraintTypeUnique,
+			Columns:           []string{"email"},
+			Deferrable:        true,
+			InitiallyDeferred: true,
+		})
+
+		assert.Equal(t, `UNIQUE ("email") DEFERRABLE INITIALLY DEFERRED`, body)
+	})
+
+	t.Run("foreign key with referential actions", func(t *testing.T) {
+		body := buildConstraintBody(CreateConstraintInput{
+			Type:             constants.ConstraintTypeForeignKey,
+			Columns:          []string{"customer_id"},
+			ReferenceTable:   "sales.customers",
+			ReferenceColumns: []string{"id"},
+			OnDelete:         "CASCADE",
+			OnUpdate:         "SET NULL",
+			NotValid:         true,
+		})
+
+		assert.Equal(
+			t,
+			`FOREIGN KEY ("customer_id") REFERENCES "sales"."customers" ("id") ON DELETE CASCADE ON UPDATE SET NULL NOT VALID`,
+			body,
+		)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		body := buildConstraintBody(CreateConstraintInput{
+			Type:       constants.ConstraintTypeCheck,
+			Expression: "total >= 0",
+		})
+
+		assert.Equal(t, "CHECK (total >= 0)", body)
+	})
+
+	t.Run("create and drop migrations are inverse", func(t *testing.T) {
+		created := buildCreateConstraintMigration("orders", "orders_total_check", "CHECK (total >= 0)")
+		dropped := buildDropConstraintMigration("orders", Constraint{
+			Name:       "orders_total_check",
+			Definition: "CHECK (total >= 0)",
+		})
+
+		assert.Equal(t, `ALTER TABLE "public"."orders" ADD CONSTRAINT "orders_total_check" CHECK (total >= 0);`, created.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."orders" DROP CONSTRAINT "orders_total_check";`, created.DownSQL)
+		assert.Equal(t, created.UpSQL, dropped.DownSQL)
+		assert.Equal(t, created.DownSQL, dropped.UpSQL)
+	})
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateConstraintInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Columns     []string  `json:"columns"`
+
+	// only used when type is foreign_key
+	ReferenceTable   string   `json:"referenceTable"`
+	ReferenceColumns []string `json:"referenceColumns"`
+	OnDelete         string   `json:"onDelete"`
+	OnUpdate         string   `json:"onUpdate"`
+
+	// only used when type is check
+	Expression string `json:"expression"`
+
+	Deferrable        bool `json:"deferrable"`
+	InitiallyDeferred bool `json:"initiallyDeferred"`
+
+	// skips checking existing rows, they can be checked later through the validate endpoint
+	NotValid bool `json:"notValid"`
+}
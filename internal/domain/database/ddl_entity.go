@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+// ColumnDefinition carries what a CREATE TABLE needs beyond Column, identity and generated columns included
+type ColumnDefinition struct {
+	Name      string `db:"name"`
+	Type      string `db:"type"`
+	NotNull   bool   `db:"not_null"`
+	Default   string `db:"default_value"`
+	Collation string `db:"collation"`
+	// Identity is ALWAYS or BY DEFAULT for identity columns
+	Identity string `db:"identity"`
+	// Generated holds the expression of a stored generated column
+	Generated   string `db:"generated"`
+	Description string `db:"description"`
+}
+
+// TableGrant groups the privileges a grantee holds on a table, column grants carry the column name
+type TableGrant struct {
+	Grantee    string         `db:"grantee"`
+	Column     string         `db:"column_name"`
+	Privileges pq.StringArray `db:"privileges"`
+	Grantable  bool           `db:"grantable"`
+}
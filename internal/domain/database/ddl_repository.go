@@ -0,0 +1,6 @@
+package database
+
+type DDLRepository interface {
+	ListColumnDefinitions(fullTableName string) ([]ColumnDefinition, error)
+	ListGrants(fullTableName string) ([]TableGrant, error)
+}
@@ -0,0 +1,392 @@
+package database
+
+import (
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+	"slices"
+)
+
+type DDLService interface {
+	Table(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (DDL, error)
+	Project(request ProjectDDLInput, authUser auth.User) (DDL, error)
+}
+
+type DDLServiceImpl struct {
+	connectionService ConnectionService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+type ddlRepos struct {
+	ddlRepo        DDLRepository
+	schemaRepo     SchemaRepository
+	extensionRepo  ExtensionRepository
+	typeRepo       TypeRepository
+	functionRepo   FunctionRepository
+	tableRepo      TableRepository
+	constraintRepo ConstraintRepository
+	indexRepo      IndexRepository
+	triggerRepo    TriggerRepository
+	policyRepo     PolicyRepository
+	partitionRepo  PartitionRepository
+	viewRepo       ViewRepository
+}
+
+func NewDDLService(injector *do.Injector) (DDLService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &DDLServiceImpl{
+		connectionService: connectionService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// Table returns the DDL of one table, a partitioned table comes with its partitions
+func (s *DDLServiceImpl) Table(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (DDL, error) {
+	repos, connection, err := s.connect(projectUUID, authUser)
+	if err != nil {
+		return DDL{}, err
+	}
+	defer connection.Close()
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	table, err := repos.tableRepo.GetByNameInSchema(schema, tableName)
+	if err != nil {
+		return DDL{}, err
+	}
+
+	builder := &ddlBuilder{}
+	if err := s.addTableWithPartitions(builder, repos, table); err != nil {
+		return DDL{}, err
+	}
+
+	return builder.build(), nil
+}
+
+// Project dumps schemas, extensions, types, functions, tables and views without any rows
+func (s *DDLServiceImpl) Project(request ProjectDDLInput, authUser auth.User) (DDL, error) {
+	repos, connection, err := s.connect(request.ProjectUUID, authUser)
+	if err != nil {
+		return DDL{}, err
+	}
+	defer connection.Close()
+
+	schemas, err := repos.schemaRepo.List()
+	if err != nil {
+		return DDL{}, err
+	}
+
+	names := make([]string, len(schemas))
+	for i, schema := range schemas {
+		names[i] = schema.Name
+	}
+
+	for _, schema := range request.Schemas {
+		if !slices.Contains(names, schema) {
+			return DDL{}, flxErrors.NewNotFoundError("schema.error.notFound")
+		}
+	}
+
+	if len(request.Schemas) > 0 {
+		names = request.Schemas
+	}
+
+	builder := &ddlBuilder{}
+	builder.skipFunctionBodyChecks()
+
+	for _, schema := range names {
+		if schema != pkg.DefaultSchema {
+			builder.addSchema(schema)
+		}
+	}
+
+	extensions, err := repos.extensionRepo.List()
+	if err != nil {
+		return DDL{}, err
+	}
+
+	// plpgsql comes with every database
+	for _, extension := range extensions {
+		if extension.Installed() && extension.Name != "plpgsql" && slices.Contains(names, extension.Schema) {
+			builder.addExtension(extension)
+		}
+	}
+
+	for _, schema := range names {
+		if err := s.addSchemaObjects(builder, repos, schema); err != nil {
+			return DDL{}, err
+		}
+	}
+
+	return builder.build(), nil
+}
+
+func (s *DDLServiceImpl) addSchemaObjects(builder *ddlBuilder, repos ddlRepos, schema string) error {
+	types, err := repos.typeRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	// objects of an extension are left to its CREATE EXTENSION
+	for _, typ := range types {
+		if typ.Extension == "" {
+			builder.addType(typ)
+		}
+	}
+
+	functions, err := repos.functionRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	// the listing only carries the function body, the dump needs the complete statement
+	for _, function := range functions {
+		if function.Extension != "" {
+			continue
+		}
+
+		fetchedFunction, err := repos.functionRepo.GetBySignature(
+			buildFunctionSignature(schema, function.Name, function.argumentTypeList()),
+		)
+		if err != nil {
+			return err
+		}
+
+		builder.addFunction(fetchedFunction)
+	}
+
+	tables, err := repos.tableRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table.Extension != "" {
+			continue
+		}
+
+		definition, err := s.getTableDefinition(repos, table)
+		if err != nil {
+			return err
+		}
+
+		builder.addTable(definition)
+	}
+
+	views, err := repos.viewRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, view := range views {
+		if view.Extension == "" {
+			builder.addView(view)
+		}
+	}
+
+	return nil
+}
+
+func (s *DDLServiceImpl) addTableWithPartitions(builder *ddlBuilder, repos ddlRepos, table Table) error {
+	definition, err := s.getTableDefinition(repos, table)
+	if err != nil {
+		return err
+	}
+
+	builder.addTable(definition)
+	if !table.Partitioned {
+		return nil
+	}
+
+	partitions, err := repos.partitionRepo.List(table.FullName())
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range partitions {
+		partitionTable, err := repos.tableRepo.GetByNameInSchema(partition.Schema, partition.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := s.addTableWithPartitions(builder, repos, partitionTable); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *DDLServiceImpl) getTableDefinition(repos ddlRepos, table Table) (TableDefinition, error) {
+	fullTableName := table.FullName()
+	definition := TableDefinition{Table: table}
+
+	var err error
+	if definition.Columns, err = repos.ddlRepo.ListColumnDefinitions(quoteTableName(fullTableName)); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if definition.Grants, err = repos.ddlRepo.ListGrants(quoteTableName(fullTableName)); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if table.Partitioned {
+		key, err := repos.partitionRepo.GetKey(quoteTableName(fullTableName))
+		if err != nil {
+			return TableDefinition{}, err
+		}
+
+		definition.PartitionKey = key.Definition
+	}
+
+	if table.PartitionOf != "" {
+		partitions, err := repos.partitionRepo.List(quoteTableName(normalizeRegclassName(table.PartitionOf)))
+		if err != nil {
+			return TableDefinition{}, err
+		}
+
+		for _, partition := range partitions {
+			if partition.Name == table.Name && partition.Schema == table.Schema {
+				definition.PartitionBounds = partition.Bounds
+			}
+		}
+
+		return definition, nil
+	}
+
+	if definition.Constraints, err = repos.constraintRepo.List(fullTableName); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if definition.Indexes, err = repos.indexRepo.ListDefinitions(table.Schema, table.Name); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if definition.Triggers, err = repos.triggerRepo.List(fullTableName); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if definition.Security, err = repos.policyRepo.GetRowLevelSecurity(fullTableName); err != nil {
+		return TableDefinition{}, err
+	}
+
+	if definition.Policies, err = repos.policyRepo.List(fullTableName); err != nil {
+		return TableDefinition{}, err
+	}
+
+	return definition, nil
+}
+
+func (s *DDLServiceImpl) connect(projectUUID uuid.UUID, authUser auth.User) (ddlRepos, *sqlx.DB, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return ddlRepos{}, nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return ddlRepos{}, nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	connection, err := s.connectionService.ConnectByDatabaseName(fetchedProject.DBName)
+	if err != nil {
+		return ddlRepos{}, nil, err
+	}
+
+	repos, err := s.getClientRepos(connection)
+	if err != nil {
+		connection.Close()
+
+		return ddlRepos{}, nil, err
+	}
+
+	return repos, connection, nil
+}
+
+func (s *DDLServiceImpl) getClientRepos(connection *sqlx.DB) (ddlRepos, error) {
+	getters := []func(string, *sqlx.DB) (interface{}, *sqlx.DB, error){
+		s.connectionService.GetDDLRepo,
+		s.connectionService.GetSchemaRepo,
+		s.connectionService.GetExtensionRepo,
+		s.connectionService.GetTypeRepo,
+		s.connectionService.GetFunctionRepo,
+		s.connectionService.GetTableRepo,
+		s.connectionService.GetConstraintRepo,
+		s.connectionService.GetIndexRepo,
+		s.connectionService.GetTriggerRepo,
+		s.connectionService.GetPolicyRepo,
+		s.connectionService.GetPartitionRepo,
+		s.connectionService.GetViewRepo,
+	}
+
+	clientRepos := make([]interface{}, len(getters))
+	for i, getRepo := range getters {
+		repo, _, err := getRepo("", connection)
+		if err != nil {
+			return ddlRepos{}, err
+		}
+
+		clientRepos[i] = repo
+	}
+
+	var repos ddlRepos
+	var ok bool
+
+	if repos.ddlRepo, ok = clientRepos[0].(DDLRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientDDLRepo is invalid")
+	}
+
+	if repos.schemaRepo, ok = clientRepos[1].(SchemaRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientSchemaRepo is invalid")
+	}
+
+	if repos.extensionRepo, ok = clientRepos[2].(ExtensionRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientExtensionRepo is invalid")
+	}
+
+	if repos.typeRepo, ok = clientRepos[3].(TypeRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientTypeRepo is invalid")
+	}
+
+	if repos.functionRepo, ok = clientRepos[4].(FunctionRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientFunctionRepo is invalid")
+	}
+
+	if repos.tableRepo, ok = clientRepos[5].(TableRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	if repos.constraintRepo, ok = clientRepos[6].(ConstraintRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	if repos.indexRepo, ok = clientRepos[7].(IndexRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientIndexRepo is invalid")
+	}
+
+	if repos.triggerRepo, ok = clientRepos[8].(TriggerRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientTriggerRepo is invalid")
+	}
+
+	if repos.policyRepo, ok = clientRepos[9].(PolicyRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientPolicyRepo is invalid")
+	}
+
+	if repos.partitionRepo, ok = clientRepos[10].(PartitionRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientPartitionRepo is invalid")
+	}
+
+	if repos.viewRepo, ok = clientRepos[11].(ViewRepository); !ok {
+		return ddlRepos{}, flxErrors.NewUnprocessableError("clientViewRepo is invalid")
+	}
+
+	return repos, nil
+}
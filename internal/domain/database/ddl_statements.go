@@ -0,0 +1,268 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// TableDefinition is what the DDL of one table is built from. Partitions only keep their
+// bounds, constraints, indexes and triggers come to them from the parent table
+type TableDefinition struct {
+	Table       Table
+	Columns     []ColumnDefinition
+	Constraints []Constraint
+	// Indexes leaves out the indexes backing constraints, the constraints create them
+	Indexes  []Index
+	Triggers []Trigger
+	Security RowLevelSecurity
+	Policies []Policy
+	Grants   []TableGrant
+
+	// PartitionKey is set for partitioned tables, PartitionBounds for partitions
+	PartitionKey    string
+	PartitionBounds string
+}
+
+// DDL is a schema only dump, the statements are in an order that can be replayed on an empty database
+type DDL struct {
+	Statements []string `json:"statements"`
+}
+
+func (d DDL) Script() string {
+	if len(d.Statements) == 0 {
+		return ""
+	}
+
+	return strings.Join(d.Statements, "\n\n") + "\n"
+}
+
+// ddlBuilder collects statements per phase, so foreign keys, views and triggers of a project
+// dump only run once every table and function they can refer to exists
+type ddlBuilder struct {
+	settings    []string
+	schemas     []string
+	extensions  []string
+	types       []string
+	functions   []string
+	tables      []string
+	partitions  []string
+	foreignKeys []string
+	indexes     []string
+	views       []string
+	triggers    []string
+	security    []string
+	comments    []string
+	grants      []string
+}
+
+func (b *ddlBuilder) build() DDL {
+	statements := make([]string, 0)
+	for _, phase := range [][]string{
+		b.settings,
+		b.schemas,
+		b.extensions,
+		b.types,
+		b.functions,
+		b.tables,
+		b.partitions,
+		b.foreignKeys,
+		b.indexes,
+		b.views,
+		b.triggers,
+		b.security,
+		b.comments,
+		b.grants,
+	} {
+		statements = append(statements, phase...)
+	}
+
+	return DDL{Statements: statements}
+}
+
+// skipFunctionBodyChecks lets sql functions be created before the tables their bodies refer to
+func (b *ddlBuilder) skipFunctionBodyChecks() {
+	b.settings = append(b.settings, "SET check_function_bodies = false;")
+}
+
+func (b *ddlBuilder) addSchema(name string) {
+	b.schemas = append(b.schemas, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", pq.QuoteIdentifier(name)))
+}
+
+func (b *ddlBuilder) addExtension(extension Extension) {
+	b.extensions = append(b.extensions, fmt.Sprintf(
+		"CREATE EXTENSION IF NOT EXISTS %s WITH SCHEMA %s;",
+		pq.QuoteIdentifier(extension.Name),
+		pq.QuoteIdentifier(extension.Schema),
+	))
+}
+
+func (b *ddlBuilder) addType(typ Type) {
+	b.types = append(b.types, buildCreateTypeStatement(CreateTypeInput{
+		Schema:     typ.Schema,
+		Name:       typ.Name,
+		Kind:       typ.Kind,
+		Values:     typ.Values,
+		Attributes: typ.Attributes,
+	}))
+}
+
+// addFunction takes a function fetched by signature, the listing leaves out the complete statement
+func (b *ddlBuilder) addFunction(function Function) {
+	b.functions = append(b.functions, strings.TrimSpace(function.Definition)+";")
+}
+
+// addView creates materialized views without data, a schema dump carries no rows to fill them with
+func (b *ddlBuilder) addView(view View) {
+	b.views = append(b.views, buildCreateViewStatement(CreateViewInput{
+		Schema:       view.Schema,
+		Name:         view.Name,
+		Definition:   view.Definition,
+		Materialized: view.Materialized,
+		SkipData:     view.Materialized,
+	}, false))
+}
+
+func (b *ddlBuilder) addTable(definition TableDefinition) {
+	table := quoteTableName(definition.Table.FullName())
+
+	if definition.Table.PartitionOf != "" {
+		statement := fmt.Sprintf(
+			"CREATE TABLE %s PARTITION OF %s %s",
+			table,
+			quoteTableName(normalizeRegclassName(definition.Table.PartitionOf)),
+			definition.PartitionBounds,
+		)
+		if definition.PartitionKey != "" {
+			statement += " PARTITION BY " + definition.PartitionKey
+		}
+
+		b.partitions = append(b.partitions, statement+";")
+	} else {
+		b.tables = append(b.tables, buildCreateTableDDL(definition))
+		b.addTableDependents(definition)
+	}
+
+	if definition.Table.Description != "" {
+		b.comments = append(b.comments, buildCommentStatement("TABLE", table, definition.Table.Description))
+	}
+
+	for _, column := range definition.Columns {
+		if column.Description != "" {
+			b.comments = append(b.comments, buildCommentStatement(
+				"COLUMN",
+				table+"."+pq.QuoteIdentifier(column.Name),
+				column.Description,
+			))
+		}
+	}
+
+	for _, grant := range definition.Grants {
+		b.grants = append(b.grants, buildTableGrantStatement(table, grant))
+	}
+}
+
+// addTableDependents adds what partitions inherit from their parent table
+func (b *ddlBuilder) addTableDependents(definition TableDefinition) {
+	fullTableName := definition.Table.FullName()
+	table := quoteTableName(fullTableName)
+
+	for _, constraint := range definition.Constraints {
+		if constraint.Type == constants.ConstraintTypeForeignKey {
+			b.foreignKeys = append(b.foreignKeys, fmt.Sprintf(
+				"ALTER TABLE %s ADD CONSTRAINT %s %s;",
+				table,
+				pq.QuoteIdentifier(constraint.Name),
+				constraint.Definition,
+			))
+		}
+	}
+
+	// indexes of partitioned tables are listed ON ONLY the parent, the partitions exist
+	// by the time indexes are created and should get the index as well
+	for _, index := range definition.Indexes {
+		b.indexes = append(b.indexes, strings.Replace(index.Definition, " ON ONLY ", " ON ", 1)+";")
+	}
+
+	for _, trigger := range definition.Triggers {
+		b.triggers = append(b.triggers, trigger.Definition+";")
+	}
+
+	b.security = append(b.security, buildRowLevelSecurityStatements(fullTableName, RowLevelSecurity{}, definition.Security)...)
+	for _, policy := range definition.Policies {
+		b.security = append(b.security, buildCreatePolicyStatement(fullTableName, policyToInput(policy)))
+	}
+}
+
+// buildCreateTableDDL keeps every constraint but foreign keys inline, foreign keys
+// are added once the referenced tables exist
+func buildCreateTableDDL(definition TableDefinition) string {
+	lines := make([]string, 0, len(definition.Columns)+len(definition.Constraints))
+	for _, column := range definition.Columns {
+		lines = append(lines, "    "+buildDDLColumnDefinition(column))
+	}
+
+	for _, constraint := range definition.Constraints {
+		switch constraint.Type {
+		case constants.ConstraintTypePrimaryKey, constants.ConstraintTypeUnique, constants.ConstraintTypeCheck, constants.ConstraintTypeExclusion:
+			lines = append(lines, fmt.Sprintf("    CONSTRAINT %s %s", pq.QuoteIdentifier(constraint.Name), constraint.Definition))
+		}
+	}
+
+	statement := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", quoteTableName(definition.Table.FullName()), strings.Join(lines, ",\n"))
+	if definition.PartitionKey != "" {
+		statement += " PARTITION BY " + definition.PartitionKey
+	}
+
+	return statement + ";"
+}
+
+func buildDDLColumnDefinition(column ColumnDefinition) string {
+	columnType, columnDefault := serialColumnType(column.Type, column.Default)
+
+	definition := pq.QuoteIdentifier(column.Name) + " " + columnType
+	if column.Collation != "" {
+		definition += " COLLATE " + column.Collation
+	}
+
+	switch {
+	case column.Generated != "":
+		definition += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", column.Generated)
+	case column.Identity != "":
+		definition += fmt.Sprintf(" GENERATED %s AS IDENTITY", column.Identity)
+	}
+
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+
+	if columnDefault != "" {
+		definition += " DEFAULT " + columnDefault
+	}
+
+	return definition
+}
+
+// buildTableGrantStatement keeps PUBLIC as the keyword that grants to every role
+func buildTableGrantStatement(table string, grant TableGrant) string {
+	grantee := "PUBLIC"
+	if grant.Grantee != "PUBLIC" {
+		grantee = pq.QuoteIdentifier(grant.Grantee)
+	}
+
+	privileges := make([]string, len(grant.Privileges))
+	for i, privilege := range grant.Privileges {
+		privileges[i] = privilege
+		if grant.Column != "" {
+			privileges[i] += " (" + pq.QuoteIdentifier(grant.Column) + ")"
+		}
+	}
+
+	statement := fmt.Sprintf("GRANT %s ON TABLE %s TO %s", strings.Join(privileges, ", "), table, grantee)
+	if grant.Grantable {
+		statement += " WITH GRANT OPTION"
+	}
+
+	return statement + ";"
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func ordersDefinition() TableDefinition {
+	return TableDefinition{
+		Table: Table{Schema: "public", Name: "orders", Description: "Placed orders"},
+		Columns: []ColumnDefinition{
+			{Name: "id", Type: "integer", NotNull: true, Default: "nextval('orders_id_seq'::regclass)"},
+			{Name: "user_id", Type: "bigint"},
+			{Name: "code", Type: "text", Collation: `"C"`, NotNull: true, Description: "Public order code"},
+			{Name: "total", Type: "numeric(10,2)", NotNull: true, Default: "0"},
+			{Name: "total_cents", Type: "bigint", Generated: "((total * (100)::numeric))::bigint"},
+		},
+		Constraints: []Constraint{
+			{Name: "orders_pkey", Type: constants.ConstraintTypePrimaryKey, Definition: "PRIMARY KEY (id)"},
+			{Name: "orders_total_check", Type: constants.ConstraintTypeCheck, Definition: "CHECK ((total >= (0)::numeric))"},
+			{Name: "orders_user_id_fkey", Type: constants.ConstraintTypeForeignKey, Definition: "FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE"},
+		},
+		Indexes: []Index{
+			{Name: "orders_code_idx", Definition: "CREATE INDEX orders_code_idx ON public.orders USING btree (code)"},
+		},
+		Triggers: []Trigger{
+			{Name: "orders_touch", Definition: "CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch()"},
+		},
+		Security: RowLevelSecurity{Enabled: true},
+		Policies: []Policy{
+			{Name: "own orders", Command: "SELECT", Permissive: true, Roles: []string{"authenticated"}, Using: "(user_id = 1)"},
+		},
+		Grants: []TableGrant{
+			{Grantee: "PUBLIC", Privileges: []string{"SELECT"}},
+			{Grantee: "web_anon", Column: "code", Privileges: []string{"SELECT", "UPDATE"}, Grantable: true},
+		},
+	}
+}
+
+func TestDDLStatements_Suite(t *testing.T) {
+	t.Run("table ddl in dependency order", func(t *testing.T) {
+		builder := &ddlBuilder{}
+		builder.addTable(ordersDefinition())
+
+		assert.Equal(t, []string{
+			"CREATE TABLE \"public\".\"orders\" (\n" +
+				"    \"id\" serial NOT NULL,\n" +
+				"    \"user_id\" bigint,\n" +
+				"    \"code\" text COLLATE \"C\" NOT NULL,\n" +
+				"    \"total\" numeric(10,2) NOT NULL DEFAULT 0,\n" +
+				"    \"total_cents\" bigint GENERATED ALWAYS AS (((total * (100)::numeric))::bigint) STORED,\n" +
+				"    CONSTRAINT \"orders_pkey\" PRIMARY KEY (id),\n" +
+				"    CONSTRAINT \"orders_total_check\" CHECK ((total >= (0)::numeric))\n" +
+				");",
+			`ALTER TABLE "public"."orders" ADD CONSTRAINT "orders_user_id_fkey" FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;`,
+			"CREATE INDEX orders_code_idx ON public.orders USING btree (code);",
+			"CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch();",
+			`ALTER TABLE "public"."orders" ENABLE ROW LEVEL SECURITY;`,
+			`CREATE POLICY "own orders" ON "public"."orders" AS PERMISSIVE FOR SELECT TO "authenticated" USING ((user_id = 1));`,
+			`COMMENT ON TABLE "public"."orders" IS 'Placed orders';`,
+			`COMMENT ON COLUMN "public"."orders"."code" IS 'Public order code';`,
+			`GRANT SELECT ON TABLE "public"."orders" TO PUBLIC;`,
+			`GRANT SELECT ("code"), UPDATE ("code") ON TABLE "public"."orders" TO "web_anon" WITH GRANT OPTION;`,
+		}, builder.build().Statements)
+	})
+
+	t.Run("identity columns", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`"id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL`,
+			buildDDLColumnDefinition(ColumnDefinition{Name: "id", Type: "bigint", NotNull: true, Identity: "BY DEFAULT"}),
+		)
+	})
+
+	t.Run("partitioned tables and partitions", func(t *testing.T) {
+		builder := &ddlBuilder{}
+		builder.addTable(TableDefinition{
+			Table:        Table{Schema: "public", Name: "events", Partitioned: true},
+			Columns:      []ColumnDefinition{{Name: "created_at", Type: "date", NotNull: true}},
+			PartitionKey: "RANGE (created_at)",
+			Indexes: []Index{
+				{Name: "events_created_at_idx", Definition: "CREATE INDEX events_created_at_idx ON ONLY public.events USING btree (created_at)"},
+			},
+		})
+		builder.addTable(TableDefinition{
+			Table:           Table{Schema: "public", Name: "events_p202501", PartitionOf: "events"},
+			Columns:         []ColumnDefinition{{Name: "created_at", Type: "date", NotNull: true}},
+			PartitionBounds: "FOR VALUES FROM ('2025-01-01') TO ('2025-02-01')",
+		})
+
+		assert.Equal(t, []string{
+			"CREATE TABLE \"public\".\"events\" (\n    \"created_at\" date NOT NULL\n) PARTITION BY RANGE (created_at);",
+			`CREATE TABLE "public"."events_p202501" PARTITION OF "public"."events" FOR VALUES FROM ('2025-01-01') TO ('2025-02-01');`,
+			"CREATE INDEX events_created_at_idx ON public.events USING btree (created_at);",
+		}, builder.build().Statements)
+	})
+
+	t.Run("project objects come before tables", func(t *testing.T) {
+		builder := &ddlBuilder{}
+		builder.addView(View{Schema: "billing", Name: "totals", Materialized: true, Definition: "SELECT 1 AS one"})
+		builder.addTable(TableDefinition{
+			Table:   Table{Schema: "billing", Name: "invoices"},
+			Columns: []ColumnDefinition{{Name: "status", Type: "billing.status"}},
+		})
+		builder.addType(Type{Schema: "billing", Name: "status", Kind: constants.TypeKindEnum, Values: []string{"open", "paid"}})
+		builder.addExtension(Extension{Name: "pgcrypto", Schema: "public"})
+		builder.addSchema("billing")
+		builder.skipFunctionBodyChecks()
+
+		ddl := builder.build()
+
+		assert.Equal(t, []string{
+			"SET check_function_bodies = false;",
+			`CREATE SCHEMA IF NOT EXISTS "billing";`,
+			`CREATE EXTENSION IF NOT EXISTS "pgcrypto" WITH SCHEMA "public";`,
+			`CREATE TYPE "billing"."status" AS ENUM ('open', 'paid');`,
+			"CREATE TABLE \"billing\".\"invoices\" (\n    \"status\" billing.status\n);",
+		}, ddl.Statements[:5])
+		assert.Contains(t, ddl.Statements[5], `CREATE MATERIALIZED VIEW "billing"."totals"`)
+		assert.Contains(t, ddl.Statements[5], "WITH NO DATA")
+		assert.Equal(t, "", DDL{}.Script())
+	})
+}
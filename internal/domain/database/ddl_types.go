@@ -0,0 +1,11 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+// ProjectDDLInput dumps the given schemas, all user schemas when empty
+type ProjectDDLInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Schemas     []string  `json:"schemas"`
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// Dependency is an object that goes away or breaks when a table or column is dropped. DependsOn
+// names the object it hangs off, which makes the list a graph rooted at the dropped object
+type Dependency struct {
+	Type      string `db:"object_type" json:"type"`
+	Schema    string `db:"object_schema" json:"schema"`
+	Identity  string `db:"object_identity" json:"identity"`
+	DependsOn string `db:"depends_on" json:"dependsOn"`
+	Depth     int    `db:"depth" json:"depth"`
+	// RequiresCascade is false for objects postgres drops along on its own, such as indexes
+	RequiresCascade bool `db:"requires_cascade" json:"requiresCascade"`
+}
+
+// DropPlan lists what a drop touches and the exact statements it runs. ReferencingFunctions is a plain
+// text match on function bodies, postgres doesn't track those and they only fail once called
+type DropPlan struct {
+	Object               string         `json:"object"`
+	DryRun               bool           `json:"dryRun"`
+	Cascade              bool           `json:"cascade"`
+	Dependencies         []Dependency   `json:"dependencies"`
+	ReferencingFunctions []Dependency   `json:"referencingFunctions"`
+	Statements           []string       `json:"statements"`
+	Snapshot             *TrashSnapshot `json:"snapshot"`
+	// Warning is set when the drop went through but its snapshot could not be kept
+	Warning string `json:"warning,omitempty"`
+}
+
+// TrashSnapshot is a copy of a dropped table or column kept in the trash schema of the project
+// database. Definition recreates the structure, RestoreStatements copy the rows back. MigrationSQL is
+// the structure as it was before the drop, a column is added back without NOT NULL until its rows are copied
+type TrashSnapshot struct {
+	Uuid              uuid.UUID      `db:"uuid" json:"uuid"`
+	ProjectUuid       uuid.UUID      `db:"project_uuid" json:"projectUuid"`
+	Kind              string         `db:"kind" json:"kind"`
+	Schema            string         `db:"schema_name" json:"schema"`
+	TableName         string         `db:"table_name" json:"tableName"`
+	ColumnName        string         `db:"column_name" json:"columnName"`
+	TrashTable        string         `db:"trash_table" json:"trashTable"`
+	Definition        pq.StringArray `db:"definition" json:"definition"`
+	RestoreStatements pq.StringArray `db:"restore_statements" json:"restoreStatements"`
+	MigrationSQL      string         `db:"migration_sql" json:"-"`
+	Status            string         `db:"status" json:"status"`
+	CreatedBy         uuid.UUID      `db:"created_by" json:"createdBy"`
+	ExpiresAt         time.Time      `db:"expires_at" json:"expiresAt"`
+	CreatedAt         time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time      `db:"updated_at" json:"updatedAt"`
+}
+
+func (p DropPlan) RequiresCascade() bool {
+	for _, dependency := range p.Dependencies {
+		if dependency.RequiresCascade {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s TrashSnapshot) FullTableName() string {
+	return s.Schema + "." + s.TableName
+}
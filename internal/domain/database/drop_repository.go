@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type DropRepository interface {
+	// ListDependencies walks pg_depend from a table, or one of its columns when columnName is set
+	ListDependencies(fullTableName, columnName string) ([]Dependency, error)
+	ListReferencingFunctions(schema, tableName, columnName string) ([]Dependency, error)
+	Execute(statements ...string) error
+}
+
+type TrashSnapshotRepository interface {
+	ListForProject(projectUUID uuid.UUID) ([]TrashSnapshot, error)
+	GetByUUID(snapshotUUID uuid.UUID) (TrashSnapshot, error)
+	Create(snapshot *TrashSnapshot) (*TrashSnapshot, error)
+	Activate(snapshotUUID uuid.UUID) (bool, error)
+	Delete(snapshotUUID uuid.UUID) error
+	// ListExpired also returns snapshots still pending since before pendingBefore
+	ListExpired(before, pendingBefore time.Time, limit int) ([]TrashSnapshot, error)
+}
@@ -0,0 +1,365 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"slices"
+	"time"
+)
+
+const snapshotNotKeptWarning = "The drop went through but its snapshot could not be kept, it cannot be restored"
+
+// DropService drops tables and columns after checking what depends on them. A dry run only returns
+// the plan, without cascade a drop that would take other objects along is refused
+type DropService interface {
+	Table(fullTableName string, request DropInput, authUser auth.User) (DropPlan, error)
+	Column(columnName, fullTableName string, request DropInput, authUser auth.User) (DropPlan, error)
+}
+
+type DropServiceImpl struct {
+	connectionService ConnectionService
+	ddlService        DDLService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	trashRepo         TrashSnapshotRepository
+}
+
+type dropRepos struct {
+	dropRepo       DropRepository
+	tableRepo      TableRepository
+	ddlRepo        DDLRepository
+	constraintRepo ConstraintRepository
+}
+
+func NewDropService(injector *do.Injector) (DropService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	ddlService := do.MustInvoke[DDLService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	trashRepo := do.MustInvoke[TrashSnapshotRepository](injector)
+
+	return &DropServiceImpl{
+		connectionService: connectionService,
+		ddlService:        ddlService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		trashRepo:         trashRepo,
+	}, nil
+}
+
+func (s *DropServiceImpl) Table(fullTableName string, request DropInput, authUser auth.User) (DropPlan, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return DropPlan{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	repos, connection, err := s.connect(fetchedProject.DBName)
+	if err != nil {
+		return DropPlan{}, err
+	}
+	defer connection.Close()
+
+	table, err := repos.tableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	plan, err := s.plan(repos, table, "", request)
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	if request.Snapshot {
+		if plan.Snapshot, err = s.buildTableSnapshot(repos, table, request, authUser); err != nil {
+			return DropPlan{}, err
+		}
+
+		plan.Statements = buildTableSnapshotStatements(table.FullName(), plan.Snapshot.TrashTable)
+	}
+
+	plan.Statements = append(plan.Statements, buildDropTableStatement(table.FullName(), request.Cascade))
+
+	return s.execute(fetchedProject.DBName, connection, repos, plan, buildDropTableMigration(table.FullName(), request.Cascade))
+}
+
+func (s *DropServiceImpl) Column(columnName, fullTableName string, request DropInput, authUser auth.User) (DropPlan, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return DropPlan{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	repos, connection, err := s.connect(fetchedProject.DBName)
+	if err != nil {
+		return DropPlan{}, err
+	}
+	defer connection.Close()
+
+	table, err := repos.tableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	columns, err := repos.ddlRepo.ListColumnDefinitions(quoteTableName(table.FullName()))
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	index := slices.IndexFunc(columns, func(column ColumnDefinition) bool { return column.Name == columnName })
+	if index == -1 {
+		return DropPlan{}, flxErrors.NewNotFoundError("column.error.notFound")
+	}
+
+	plan, err := s.plan(repos, table, columnName, request)
+	if err != nil {
+		return DropPlan{}, err
+	}
+
+	if request.Snapshot {
+		primaryKey, err := s.getPrimaryKey(repos, table)
+		if err != nil {
+			return DropPlan{}, err
+		}
+
+		// rows are matched on the primary key when restored, the key has to outlive the column
+		if len(primaryKey) == 0 || slices.Contains(primaryKey, columnName) {
+			return DropPlan{}, flxErrors.NewBadRequestError("trash.error.primaryKeyRequired")
+		}
+
+		plan.Snapshot = newTrashSnapshot(table, constants.TrashKindColumn, request, authUser)
+		plan.Snapshot.ColumnName = columnName
+		plan.Snapshot.MigrationSQL = buildAddColumnStatement(table.FullName(), columns[index])
+		plan.Snapshot.Definition, plan.Snapshot.RestoreStatements = buildColumnRestore(
+			table.FullName(),
+			columns[index],
+			primaryKey,
+			plan.Snapshot.TrashTable,
+		)
+
+		plan.Statements = buildColumnSnapshotStatements(table.FullName(), columnName, primaryKey, plan.Snapshot.TrashTable)
+	}
+
+	plan.Statements = append(plan.Statements, buildDropColumnStatement(table.FullName(), columnName, request.Cascade))
+
+	migration := buildDropColumnMigration(table.FullName(), columnName, buildDDLColumnDefinition(columns[index]), request.Cascade)
+
+	return s.execute(fetchedProject.DBName, connection, repos, plan, migration)
+}
+
+// activateSnapshot runs after the drop committed, a failure can no longer undo the drop. The trash
+// table is dropped right away and the pending row is purged later if it cannot be deleted either.
+// A snapshot purged while the drop was still running is gone as well and is reported the same way
+func (s *DropServiceImpl) activateSnapshot(repos dropRepos, plan *DropPlan) {
+	if activated, err := s.trashRepo.Activate(plan.Snapshot.Uuid); err == nil && activated {
+		plan.Snapshot.Status = constants.TrashStatusActive
+
+		return
+	}
+
+	if err := repos.dropRepo.Execute(buildDropTrashTableStatement(plan.Snapshot.TrashTable)); err == nil {
+		_ = s.trashRepo.Delete(plan.Snapshot.Uuid)
+	}
+
+	plan.Snapshot = nil
+	plan.Warning = snapshotNotKeptWarning
+}
+
+// plan collects what depends on the table, or on one of its columns when columnName is set
+func (s *DropServiceImpl) plan(repos dropRepos, table Table, columnName string, request DropInput) (DropPlan, error) {
+	plan := DropPlan{
+		Object:     table.FullName(),
+		DryRun:     request.DryRun,
+		Cascade:    request.Cascade,
+		Statements: make([]string, 0),
+	}
+
+	if columnName != "" {
+		plan.Object += "." + columnName
+	}
+
+	var err error
+	if plan.Dependencies, err = repos.dropRepo.ListDependencies(quoteTableName(table.FullName()), columnName); err != nil {
+		return DropPlan{}, err
+	}
+
+	if plan.ReferencingFunctions, err = repos.dropRepo.ListReferencingFunctions(table.Schema, table.Name, columnName); err != nil {
+		return DropPlan{}, err
+	}
+
+	return plan, nil
+}
+
+// execute runs the snapshot and the drop in one transaction. The snapshot is stored as pending first
+// and only becomes active once the drop went through, a failed drop leaves nothing in the trash
+func (s *DropServiceImpl) execute(dbName string, connection *sqlx.DB, repos dropRepos, plan DropPlan, migration RecordMigrationInput) (DropPlan, error) {
+	if plan.DryRun {
+		return plan, nil
+	}
+
+	if plan.RequiresCascade() && !plan.Cascade {
+		return DropPlan{}, flxErrors.NewBadRequestError("drop.error.dependentObjects")
+	}
+
+	if plan.Snapshot != nil {
+		if _, err := s.trashRepo.Create(plan.Snapshot); err != nil {
+			return DropPlan{}, err
+		}
+	}
+
+	if err := repos.dropRepo.Execute(plan.Statements...); err != nil {
+		if plan.Snapshot != nil {
+			_ = s.trashRepo.Delete(plan.Snapshot.Uuid)
+		}
+
+		return DropPlan{}, toDropError(err)
+	}
+
+	if plan.Snapshot != nil {
+		s.activateSnapshot(repos, &plan)
+	}
+
+	s.migrationService.Record(connection, migration)
+	s.postgrestService.RefreshSchemaCache(dbName)
+
+	return plan, nil
+}
+
+// buildTableSnapshot recreates the table from its DDL, partitions, indexes, triggers and grants included
+func (s *DropServiceImpl) buildTableSnapshot(repos dropRepos, table Table, request DropInput, authUser auth.User) (*TrashSnapshot, error) {
+	ddl, err := s.ddlService.Table(table.FullName(), request.ProjectUUID, authUser)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := repos.ddlRepo.ListColumnDefinitions(quoteTableName(table.FullName()))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := newTrashSnapshot(table, constants.TrashKindTable, request, authUser)
+	snapshot.Definition = ddl.Statements
+	snapshot.RestoreStatements = buildTableRestoreStatements(table.FullName(), columns, snapshot.TrashTable)
+	snapshot.MigrationSQL = ddl.Script()
+
+	return snapshot, nil
+}
+
+func (s *DropServiceImpl) getPrimaryKey(repos dropRepos, table Table) ([]string, error) {
+	constraints, err := repos.constraintRepo.List(table.FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, constraint := range constraints {
+		if constraint.Type == constants.ConstraintTypePrimaryKey {
+			return constraint.Columns, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *DropServiceImpl) connect(dbName string) (dropRepos, *sqlx.DB, error) {
+	connection, err := s.connectionService.ConnectByDatabaseName(dbName)
+	if err != nil {
+		return dropRepos{}, nil, err
+	}
+
+	repos, err := s.getClientRepos(connection)
+	if err != nil {
+		connection.Close()
+
+		return dropRepos{}, nil, err
+	}
+
+	return repos, connection, nil
+}
+
+func (s *DropServiceImpl) getClientRepos(connection *sqlx.DB) (dropRepos, error) {
+	getters := []func(string, *sqlx.DB) (interface{}, *sqlx.DB, error){
+		s.connectionService.GetDropRepo,
+		s.connectionService.GetTableRepo,
+		s.connectionService.GetDDLRepo,
+		s.connectionService.GetConstraintRepo,
+	}
+
+	clientRepos := make([]interface{}, len(getters))
+	for i, getRepo := range getters {
+		repo, _, err := getRepo("", connection)
+		if err != nil {
+			return dropRepos{}, err
+		}
+
+		clientRepos[i] = repo
+	}
+
+	var repos dropRepos
+	var ok bool
+
+	if repos.dropRepo, ok = clientRepos[0].(DropRepository); !ok {
+		return dropRepos{}, flxErrors.NewUnprocessableError("clientDropRepo is invalid")
+	}
+
+	if repos.tableRepo, ok = clientRepos[1].(TableRepository); !ok {
+		return dropRepos{}, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	if repos.ddlRepo, ok = clientRepos[2].(DDLRepository); !ok {
+		return dropRepos{}, flxErrors.NewUnprocessableError("clientDDLRepo is invalid")
+	}
+
+	if repos.constraintRepo, ok = clientRepos[3].(ConstraintRepository); !ok {
+		return dropRepos{}, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	return repos, nil
+}
+
+// newTrashSnapshot names the trash table after the snapshot, so drops of tables with the same name never collide
+func newTrashSnapshot(table Table, kind string, request DropInput, authUser auth.User) *TrashSnapshot {
+	snapshotUUID := uuid.New()
+
+	return &TrashSnapshot{
+		Uuid:        snapshotUUID,
+		ProjectUuid: request.ProjectUUID,
+		Kind:        kind,
+		Schema:      table.Schema,
+		TableName:   table.Name,
+		TrashTable:  snapshotUUID.String(),
+		Status:      constants.TrashStatusPending,
+		CreatedBy:   authUser.Uuid,
+		ExpiresAt:   time.Now().AddDate(0, 0, request.RetentionDays),
+	}
+}
+
+// toDropError surfaces database errors, such as a restored table clashing with a new one, as bad requests
+func toDropError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
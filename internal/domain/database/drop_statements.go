@@ -0,0 +1,183 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+func buildDropTableStatement(fullTableName string, cascade bool) string {
+	statement := "DROP TABLE IF EXISTS " + quoteTableName(fullTableName)
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return statement + ";"
+}
+
+func buildDropColumnStatement(fullTableName, columnName string, cascade bool) string {
+	statement := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", quoteTableName(fullTableName), pq.QuoteIdentifier(columnName))
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return statement + ";"
+}
+
+func quoteTrashTable(trashTable string) string {
+	return pq.QuoteIdentifier(constants.TrashSchemaName) + "." + pq.QuoteIdentifier(trashTable)
+}
+
+func buildDropTrashTableStatement(trashTable string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", quoteTrashTable(trashTable))
+}
+
+// buildTableSnapshotStatements copies every row, rows of partitions included, into the trash
+func buildTableSnapshotStatements(fullTableName, trashTable string) []string {
+	return []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", pq.QuoteIdentifier(constants.TrashSchemaName)),
+		fmt.Sprintf("CREATE TABLE %s AS TABLE %s;", quoteTrashTable(trashTable), quoteTableName(fullTableName)),
+	}
+}
+
+// buildColumnSnapshotStatements copies the column along with the primary key its rows are matched on when restored
+func buildColumnSnapshotStatements(fullTableName, columnName string, primaryKey []string, trashTable string) []string {
+	columns := make([]string, 0, len(primaryKey)+1)
+	for _, column := range slices.Concat(primaryKey, []string{columnName}) {
+		columns = append(columns, pq.QuoteIdentifier(column))
+	}
+
+	return []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", pq.QuoteIdentifier(constants.TrashSchemaName)),
+		fmt.Sprintf(
+			"CREATE TABLE %s AS SELECT %s FROM %s;",
+			quoteTrashTable(trashTable),
+			strings.Join(columns, ", "),
+			quoteTableName(fullTableName),
+		),
+	}
+}
+
+// buildTableRestoreStatements copies the rows back into a table recreated from its DDL. Triggers are
+// held off so restored rows aren't treated as new ones, generated columns are computed again
+func buildTableRestoreStatements(fullTableName string, columns []ColumnDefinition, trashTable string) []string {
+	table := quoteTableName(fullTableName)
+
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column.Generated == "" {
+			names = append(names, pq.QuoteIdentifier(column.Name))
+		}
+	}
+
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s DISABLE TRIGGER USER;", table),
+		fmt.Sprintf(
+			"INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %s;",
+			table,
+			strings.Join(names, ", "),
+			strings.Join(names, ", "),
+			quoteTrashTable(trashTable),
+		),
+		fmt.Sprintf("ALTER TABLE %s ENABLE TRIGGER USER;", table),
+	}
+
+	for _, column := range columns {
+		if isSequenceBacked(column) {
+			statements = append(statements, buildSetSequenceStatement(fullTableName, column.Name))
+		}
+	}
+
+	return append(statements, buildDropTrashTableStatement(trashTable))
+}
+
+// buildColumnRestore adds the column back without its NOT NULL and identity, existing rows only get
+// their values once they are copied from the trash. Generated columns are computed instead of copied
+func buildColumnRestore(fullTableName string, column ColumnDefinition, primaryKey []string, trashTable string) ([]string, []string) {
+	table := quoteTableName(fullTableName)
+
+	if column.Generated != "" {
+		return []string{buildAddColumnStatement(fullTableName, column)}, []string{buildDropTrashTableStatement(trashTable)}
+	}
+
+	relaxed := column
+	relaxed.NotNull = false
+	relaxed.Identity = ""
+
+	definition := []string{buildAddColumnStatement(fullTableName, relaxed)}
+
+	matches := make([]string, len(primaryKey))
+	for i, key := range primaryKey {
+		matches[i] = fmt.Sprintf("target.%s = snapshot.%s", pq.QuoteIdentifier(key), pq.QuoteIdentifier(key))
+	}
+
+	restore := []string{fmt.Sprintf(
+		"UPDATE %s AS target SET %s = snapshot.%s FROM %s AS snapshot WHERE %s;",
+		table,
+		pq.QuoteIdentifier(column.Name),
+		pq.QuoteIdentifier(column.Name),
+		quoteTrashTable(trashTable),
+		strings.Join(matches, " AND "),
+	)}
+
+	if column.NotNull {
+		restore = append(restore, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, pq.QuoteIdentifier(column.Name)))
+	}
+
+	if column.Identity != "" {
+		restore = append(restore, fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY;",
+			table,
+			pq.QuoteIdentifier(column.Name),
+			column.Identity,
+		))
+	}
+
+	if isSequenceBacked(column) {
+		restore = append(restore, buildSetSequenceStatement(fullTableName, column.Name))
+	}
+
+	return definition, append(restore, buildDropTrashTableStatement(trashTable))
+}
+
+func buildAddColumnStatement(fullTableName string, column ColumnDefinition) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quoteTableName(fullTableName), buildDDLColumnDefinition(column))
+}
+
+// buildSetSequenceStatement moves the sequence of a serial or identity column past the restored values
+func buildSetSequenceStatement(fullTableName, columnName string) string {
+	return fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence(%s, %s), COALESCE(MAX(%s), 0) + 1, false) FROM %s;",
+		pq.QuoteLiteral(quoteTableName(fullTableName)),
+		pq.QuoteLiteral(columnName),
+		pq.QuoteIdentifier(columnName),
+		quoteTableName(fullTableName),
+	)
+}
+
+func isSequenceBacked(column ColumnDefinition) bool {
+	columnType, _ := serialColumnType(column.Type, column.Default)
+
+	return column.Identity != "" || columnType != column.Type
+}
+
+// buildRestoreMigration records the structure a restore brings back, the copied rows aren't part of the schema history
+func buildRestoreMigration(snapshot TrashSnapshot) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(snapshot.FullTableName())
+	if snapshot.Kind == constants.TrashKindColumn {
+		return RecordMigrationInput{
+			Name:    fmt.Sprintf("restore_column_%s_to_%s", snapshot.ColumnName, tableName),
+			UpSQL:   snapshot.MigrationSQL,
+			DownSQL: buildDropColumnStatement(snapshot.FullTableName(), snapshot.ColumnName, false),
+		}
+	}
+
+	return RecordMigrationInput{
+		Name:    "restore_table_" + tableName,
+		UpSQL:   snapshot.MigrationSQL,
+		DownSQL: buildDropTableStatement(snapshot.FullTableName(), false),
+	}
+}
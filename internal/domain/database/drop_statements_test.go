@@ -0,0 +1,100 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const dummyTrashTable = "0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f"
+
+func TestDropStatements_Suite(t *testing.T) {
+	t.Run("drop statements", func(t *testing.T) {
+		assert.Equal(t, `DROP TABLE IF EXISTS "public"."orders";`, buildDropTableStatement("public.orders", false))
+		assert.Equal(t, `DROP TABLE IF EXISTS "public"."orders" CASCADE;`, buildDropTableStatement("public.orders", true))
+		assert.Equal(t, `ALTER TABLE "public"."orders" DROP COLUMN "total" CASCADE;`, buildDropColumnStatement("public.orders", "total", true))
+	})
+
+	t.Run("snapshots copy into the trash schema", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`CREATE SCHEMA IF NOT EXISTS "fluxend_trash";`,
+			`CREATE TABLE "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f" AS TABLE "public"."orders";`,
+		}, buildTableSnapshotStatements("public.orders", dummyTrashTable))
+
+		primaryKey := []string{"id"}
+		statements := buildColumnSnapshotStatements("public.orders", "total", primaryKey, dummyTrashTable)
+
+		assert.Equal(t, `CREATE TABLE "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f" AS SELECT "id", "total" FROM "public"."orders";`, statements[1])
+		assert.Equal(t, []string{"id"}, primaryKey)
+	})
+
+	t.Run("table restore skips generated columns and moves sequences", func(t *testing.T) {
+		columns := []ColumnDefinition{
+			{Name: "id", Type: "integer", NotNull: true, Default: "nextval('orders_id_seq'::regclass)"},
+			{Name: "total", Type: "numeric"},
+			{Name: "total_with_tax", Type: "numeric", Generated: "(total * 1.2)"},
+		}
+
+		assert.Equal(t, []string{
+			`ALTER TABLE "public"."orders" DISABLE TRIGGER USER;`,
+			`INSERT INTO "public"."orders" ("id", "total") OVERRIDING SYSTEM VALUE SELECT "id", "total" FROM "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f";`,
+			`ALTER TABLE "public"."orders" ENABLE TRIGGER USER;`,
+			`SELECT setval(pg_get_serial_sequence('"public"."orders"', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "public"."orders";`,
+			`DROP TABLE IF EXISTS "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f";`,
+		}, buildTableRestoreStatements("public.orders", columns, dummyTrashTable))
+	})
+
+	t.Run("column restore adds the column before copying its values", func(t *testing.T) {
+		column := ColumnDefinition{Name: "code", Type: "bigint", NotNull: true, Identity: "BY DEFAULT"}
+
+		definition, restore := buildColumnRestore("public.orders", column, []string{"tenant_id", "id"}, dummyTrashTable)
+
+		assert.Equal(t, []string{`ALTER TABLE "public"."orders" ADD COLUMN "code" bigint;`}, definition)
+		assert.Equal(t, []string{
+			`UPDATE "public"."orders" AS target SET "code" = snapshot."code" FROM "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f" AS snapshot WHERE target."tenant_id" = snapshot."tenant_id" AND target."id" = snapshot."id";`,
+			`ALTER TABLE "public"."orders" ALTER COLUMN "code" SET NOT NULL;`,
+			`ALTER TABLE "public"."orders" ALTER COLUMN "code" ADD GENERATED BY DEFAULT AS IDENTITY;`,
+			`SELECT setval(pg_get_serial_sequence('"public"."orders"', 'code'), COALESCE(MAX("code"), 0) + 1, false) FROM "public"."orders";`,
+			`DROP TABLE IF EXISTS "fluxend_trash"."0b5c7e52-8f4e-4f0a-9d1c-3b2a1c0d9e8f";`,
+		}, restore)
+	})
+
+	t.Run("generated columns are not copied back", func(t *testing.T) {
+		column := ColumnDefinition{Name: "total_with_tax", Type: "numeric", Generated: "(total * 1.2)"}
+
+		definition, restore := buildColumnRestore("public.orders", column, []string{"id"}, dummyTrashTable)
+
+		assert.Equal(t, []string{`ALTER TABLE "public"."orders" ADD COLUMN "total_with_tax" numeric GENERATED ALWAYS AS ((total * 1.2)) STORED;`}, definition)
+		assert.Len(t, restore, 1)
+	})
+
+	t.Run("restore migrations", func(t *testing.T) {
+		snapshot := TrashSnapshot{
+			Kind:         constants.TrashKindColumn,
+			Schema:       "public",
+			TableName:    "orders",
+			ColumnName:   "total",
+			MigrationSQL: `ALTER TABLE "public"."orders" ADD COLUMN "total" numeric NOT NULL;`,
+		}
+
+		migration := buildRestoreMigration(snapshot)
+		assert.Equal(t, "restore_column_total_to_orders", migration.Name)
+		assert.Equal(t, snapshot.MigrationSQL, migration.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."orders" DROP COLUMN "total";`, migration.DownSQL)
+
+		snapshot.Kind = constants.TrashKindTable
+		migration = buildRestoreMigration(snapshot)
+		assert.Equal(t, "restore_table_orders", migration.Name)
+		assert.Equal(t, `DROP TABLE IF EXISTS "public"."orders";`, migration.DownSQL)
+	})
+}
+
+func TestDropPlan_RequiresCascade(t *testing.T) {
+	plan := DropPlan{Dependencies: []Dependency{
+		{Type: "index", Identity: "public.orders_total_idx"},
+	}}
+	assert.False(t, plan.RequiresCascade())
+
+	plan.Dependencies = append(plan.Dependencies, Dependency{Type: "view", Identity: "public.order_totals", RequiresCascade: true})
+	assert.True(t, plan.RequiresCascade())
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type DropInput struct {
+	ProjectUUID uuid.UUID
+
+	// DryRun returns the plan without dropping anything
+	DryRun bool
+
+	// Cascade also drops the views, foreign keys and other objects that depend on the dropped one
+	Cascade bool
+
+	// Snapshot copies the dropped table or column into the trash, it can be restored for RetentionDays
+	Snapshot      bool
+	RetentionDays int
+}
@@ -0,0 +1,327 @@
+package database
+
+import (
+	"context"
+	"fluxend/internal/adapters/storage"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/job"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/setting"
+	"fluxend/internal/domain/storage/container"
+	"fluxend/internal/domain/storage/file"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+	"io"
+	"os"
+	"time"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, fullTableName string, input ExportTableInput, writer io.Writer, authUser auth.User) error
+	ExportToContainer(fullTableName string, input ExportTableInput, authUser auth.User) (job.Job, error)
+}
+
+type ExportServiceImpl struct {
+	connectionService ConnectionService
+	settingService    setting.Service
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	containerRepo     container.Repository
+	fileRepo          file.Repository
+	jobService        job.Service
+	storageFactory    *storage.Factory
+}
+
+func NewExportService(injector *do.Injector) (ExportService, error) {
+	settingService, err := setting.NewSettingService(injector)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	containerRepo := do.MustInvoke[container.Repository](injector)
+	fileRepo := do.MustInvoke[file.Repository](injector)
+	jobService := do.MustInvoke[job.Service](injector)
+	storageFactory := do.MustInvoke[*storage.Factory](injector)
+
+	return &ExportServiceImpl{
+		connectionService: connectionService,
+		settingService:    settingService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		containerRepo:     containerRepo,
+		fileRepo:          fileRepo,
+		jobService:        jobService,
+		storageFactory:    storageFactory,
+	}, nil
+}
+
+// Export streams the table straight into the writer, one chunk at a time
+func (s *ExportServiceImpl) Export(ctx context.Context, fullTableName string, input ExportTableInput, writer io.Writer, authUser auth.User) error {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return flxErrors.NewForbiddenError("export.error.forbidden")
+	}
+
+	return s.export(ctx, fetchedProject.DBName, fullTableName, input, writer)
+}
+
+// ExportToContainer validates the request and writes the export into a storage container in a background job
+func (s *ExportServiceImpl) ExportToContainer(fullTableName string, input ExportTableInput, authUser auth.User) (job.Job, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return job.Job{}, flxErrors.NewForbiddenError("export.error.forbidden")
+	}
+
+	fetchedContainer, err := s.containerRepo.GetByUUID(input.ContainerUUID)
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	if fetchedContainer.ProjectUuid != fetchedProject.Uuid {
+		return job.Job{}, flxErrors.NewBadRequestError("export.error.containerMismatch")
+	}
+
+	if err = s.validateTable(fetchedProject.DBName, fullTableName, input); err != nil {
+		return job.Job{}, err
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	fileName := fmt.Sprintf("%s_%s_%d.%s", schema, tableName, time.Now().Unix(), input.Format)
+
+	payload := map[string]string{
+		"table":         fullTableName,
+		"format":        input.Format,
+		"containerUuid": fetchedContainer.Uuid.String(),
+		"fileName":      fileName,
+	}
+
+	return s.jobService.Dispatch(input.ProjectUUID, constants.JobTypeTableExport, payload, authUser, func() (interface{}, error) {
+		return s.exportToContainer(fetchedProject.DBName, fullTableName, fileName, input, fetchedContainer, authUser)
+	})
+}
+
+// exportToContainer spools the export to a temporary file, storage providers need the size before the
+// upload starts, and streams the file from disk so tables larger than memory can be exported
+func (s *ExportServiceImpl) exportToContainer(
+	dbName, fullTableName, fileName string,
+	input ExportTableInput,
+	fetchedContainer container.Container,
+	authUser auth.User,
+) (ExportResult, error) {
+	tempFile, err := os.CreateTemp("", "fluxend-export-*")
+	if err != nil {
+		return ExportResult{}, err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if err = s.export(context.Background(), dbName, fullTableName, input, tempFile); err != nil {
+		return ExportResult{}, err
+	}
+
+	size, err := tempFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	if _, err = tempFile.Seek(0, io.SeekStart); err != nil {
+		return ExportResult{}, err
+	}
+
+	storageService, err := s.storageFactory.CreateProvider(s.settingService.GetStorageDriver())
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	err = storageService.UploadStream(storage.UploadStreamInput{
+		ContainerName: fetchedContainer.NameKey,
+		FileName:      fileName,
+		Reader:        tempFile,
+		Size:          size,
+	})
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	createdFile, err := s.fileRepo.Create(&file.File{
+		ContainerUuid: fetchedContainer.Uuid,
+		FullFileName:  fileName,
+		Size:          pkg.ConvertBytesToKiloBytes(int(size)),
+		MimeType:      ExportContentType(input.Format),
+		CreatedBy:     authUser.Uuid,
+		UpdatedBy:     authUser.Uuid,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	})
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	if err = s.containerRepo.IncrementTotalFiles(fetchedContainer.Uuid); err != nil {
+		return ExportResult{}, err
+	}
+
+	return ExportResult{
+		ContainerUUID: fetchedContainer.Uuid,
+		FileUUID:      createdFile.Uuid,
+		FileName:      fileName,
+		Format:        input.Format,
+		Size:          createdFile.Size,
+	}, nil
+}
+
+func (s *ExportServiceImpl) export(ctx context.Context, dbName, fullTableName string, input ExportTableInput, writer io.Writer) error {
+	clientRowRepo, connection, err := s.getClientRowRepo(dbName)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	if err = s.validateColumns(fullTableName, input, connection); err != nil {
+		return err
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	exportWriter, err := NewExportWriter(input.Format, writer, schema, tableName)
+	if err != nil {
+		return flxErrors.NewBadRequestError("export.error.invalidFormat")
+	}
+
+	options := RowQueryOptions{
+		Columns: input.Columns,
+		Filters: input.Filters,
+		Order:   input.Order,
+	}
+
+	headerWritten := false
+	err = clientRowRepo.Stream(ctx, schema, tableName, options, constants.ExportChunkSize, func(columns []ResultColumn, rows [][]interface{}) error {
+		if !headerWritten {
+			if err := exportWriter.WriteHeader(columns); err != nil {
+				return err
+			}
+
+			headerWritten = true
+		}
+
+		return exportWriter.WriteRows(rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	return exportWriter.Close()
+}
+
+func (s *ExportServiceImpl) validateTable(dbName, fullTableName string, input ExportTableInput) error {
+	connection, err := s.connectionService.ConnectByDatabaseName(dbName)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	return s.validateColumns(fullTableName, input, connection)
+}
+
+// validateColumns makes sure the table exists and every column referenced by the export is part of it
+func (s *ExportServiceImpl) validateColumns(fullTableName string, input ExportTableInput, connection *sqlx.DB) error {
+	clientTableRepo, _, err := s.getClientTableRepo(connection)
+	if err != nil {
+		return err
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	if _, err = clientTableRepo.GetByNameInSchema(schema, tableName); err != nil {
+		return err
+	}
+
+	clientColumnRepo, _, err := s.getClientColumnRepo(connection)
+	if err != nil {
+		return err
+	}
+
+	columns, err := clientColumnRepo.List(schema + "." + tableName)
+	if err != nil {
+		return err
+	}
+
+	existingColumns := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		existingColumns[column.Name] = true
+	}
+
+	referencedColumns := append([]string{}, input.Columns...)
+	for _, filter := range input.Filters {
+		referencedColumns = append(referencedColumns, filter.Column)
+	}
+
+	for _, order := range input.Order {
+		referencedColumns = append(referencedColumns, order.Column)
+	}
+
+	for _, column := range referencedColumns {
+		if !existingColumns[column] {
+			return flxErrors.NewBadRequestError("export.error.unknownColumn")
+		}
+	}
+
+	return nil
+}
+
+func (s *ExportServiceImpl) getClientRowRepo(dbName string) (RowRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetRowRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(RowRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientRowRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
+
+func (s *ExportServiceImpl) getClientTableRepo(connection *sqlx.DB) (TableRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetTableRepo("", connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(TableRepository)
+	if !ok {
+		return nil, nil, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
+
+func (s *ExportServiceImpl) getClientColumnRepo(connection *sqlx.DB) (ColumnRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetColumnRepo("", connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(ColumnRepository)
+	if !ok {
+		return nil, nil, flxErrors.NewUnprocessableError("clientColumnRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
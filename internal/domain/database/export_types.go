@@ -0,0 +1,24 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type ExportTableInput struct {
+	ProjectUUID   uuid.UUID   `json:"projectUUID,omitempty"`
+	Format        string      `json:"format"`
+	Columns       []string    `json:"columns"`
+	Filters       []RowFilter `json:"filters"`
+	Order         []RowOrder  `json:"order"`
+	Async         bool        `json:"async"`
+	ContainerUUID uuid.UUID   `json:"containerUUID"`
+}
+
+// ExportResult is stored as the result of the export job, Size is in KB like file sizes
+type ExportResult struct {
+	ContainerUUID uuid.UUID `json:"containerUuid"`
+	FileUUID      uuid.UUID `json:"fileUuid"`
+	FileName      string    `json:"fileName"`
+	Format        string    `json:"format"`
+	Size          int       `json:"size"`
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportWriter serializes streamed rows into one of the supported export formats
+type ExportWriter interface {
+	WriteHeader(columns []ResultColumn) error
+	WriteRows(rows [][]interface{}) error
+	Close() error
+}
+
+func NewExportWriter(format string, writer io.Writer, schema, tableName string) (ExportWriter, error) {
+	switch format {
+	case constants.ExportFormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(writer)}, nil
+	case constants.ExportFormatJSON:
+		return &jsonExportWriter{writer: writer}, nil
+	case constants.ExportFormatNDJSON:
+		return &jsonExportWriter{writer: writer, lineDelimited: true}, nil
+	case constants.ExportFormatSQL:
+		return &sqlExportWriter{writer: writer, tableName: pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func ExportContentType(format string) string {
+	switch format {
+	case constants.ExportFormatCSV:
+		return "text/csv"
+	case constants.ExportFormatJSON:
+		return "application/json"
+	case constants.ExportFormatNDJSON:
+		return "application/x-ndjson"
+	case constants.ExportFormatSQL:
+		return "application/sql"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvExportWriter) WriteHeader(columns []ResultColumn) error {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+
+	return w.writer.Write(header)
+}
+
+func (w *csvExportWriter) WriteRows(rows [][]interface{}) error {
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = formatTextValue(value)
+		}
+
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+type jsonExportWriter struct {
+	writer        io.Writer
+	lineDelimited bool
+	columns       []ResultColumn
+	rowsWritten   int
+}
+
+func (w *jsonExportWriter) WriteHeader(columns []ResultColumn) error {
+	w.columns = columns
+	if w.lineDelimited {
+		return nil
+	}
+
+	_, err := io.WriteString(w.writer, "[")
+
+	return err
+}
+
+func (w *jsonExportWriter) WriteRows(rows [][]interface{}) error {
+	var builder strings.Builder
+
+	for _, row := range rows {
+		if !w.lineDelimited && w.rowsWritten > 0 {
+			builder.WriteString(",")
+		}
+
+		// objects are built by hand so that keys keep the column order
+		builder.WriteString("{")
+		for i, value := range row {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+
+			key, err := json.Marshal(w.columns[i].Name)
+			if err != nil {
+				return err
+			}
+
+			encodedValue, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+
+			builder.Write(key)
+			builder.WriteString(":")
+			builder.Write(encodedValue)
+		}
+		builder.WriteString("}")
+
+		if w.lineDelimited {
+			builder.WriteString("\n")
+		}
+
+		w.rowsWritten++
+	}
+
+	_, err := io.WriteString(w.writer, builder.String())
+
+	return err
+}
+
+func (w *jsonExportWriter) Close() error {
+	if w.lineDelimited {
+		return nil
+	}
+
+	_, err := io.WriteString(w.writer, "]\n")
+
+	return err
+}
+
+type sqlExportWriter struct {
+	writer    io.Writer
+	tableName string
+	columnSQL string
+}
+
+func (w *sqlExportWriter) WriteHeader(columns []ResultColumn) error {
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = pq.QuoteIdentifier(column.Name)
+	}
+
+	w.columnSQL = strings.Join(quotedColumns, ", ")
+
+	return nil
+}
+
+// WriteRows emits a single multi-row INSERT per chunk
+func (w *sqlExportWriter) WriteRows(rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tuples := make([]string, len(rows))
+	for i, row := range rows {
+		literals := make([]string, len(row))
+		for j, value := range row {
+			literals[j] = formatSQLLiteral(value)
+		}
+
+		tuples[i] = "(" + strings.Join(literals, ", ") + ")"
+	}
+
+	statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES\n%s;\n", w.tableName, w.columnSQL, strings.Join(tuples, ",\n"))
+	_, err := io.WriteString(w.writer, statement)
+
+	return err
+}
+
+func (w *sqlExportWriter) Close() error {
+	return nil
+}
+
+func formatTextValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case json.RawMessage:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatSQLLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+
+		return "FALSE"
+	case int64, float64, json.Number:
+		return fmt.Sprint(v)
+	default:
+		return pq.QuoteLiteral(formatTextValue(v))
+	}
+}
@@ -0,0 +1,16 @@
+package database
+
+type Extension struct {
+	Name             string `db:"name" json:"name"`
+	DefaultVersion   string `db:"default_version" json:"defaultVersion"`
+	InstalledVersion string `db:"installed_version" json:"installedVersion"`
+	Schema           string `db:"schema" json:"schema"`
+	Comment          string `db:"comment" json:"comment"`
+
+	// Allowed is not stored in postgres, it comes from the extension allowlist setting
+	Allowed bool `db:"-" json:"allowed"`
+}
+
+func (e Extension) Installed() bool {
+	return e.InstalledVersion != ""
+}
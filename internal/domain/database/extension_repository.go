@@ -0,0 +1,8 @@
+package database
+
+type ExtensionRepository interface {
+	List() ([]Extension, error)
+	GetByName(name string) (Extension, error)
+	Create(extensionSQL string) error
+	Drop(extensionSQL string) error
+}
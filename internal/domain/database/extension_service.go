@@ -0,0 +1,206 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/setting"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"slices"
+)
+
+type ExtensionService interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]Extension, error)
+	Enable(name string, request EnableExtensionInput, authUser auth.User) (Extension, error)
+	Disable(name string, request DisableExtensionInput, authUser auth.User) (bool, error)
+}
+
+type ExtensionServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	settingService    setting.Service
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewExtensionService(injector *do.Injector) (ExtensionService, error) {
+	settingService, err := setting.NewSettingService(injector)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ExtensionServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		settingService:    settingService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// List returns every extension the server could install, flagged with whether the allowlist permits it
+func (s *ExtensionServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Extension, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientExtensionRepo, connection, err := s.getClientExtensionRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	extensions, err := clientExtensionRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := s.getAllowlist()
+	for i := range extensions {
+		extensions[i].Allowed = allowed[extensions[i].Name]
+	}
+
+	return extensions, nil
+}
+
+func (s *ExtensionServiceImpl) Enable(name string, request EnableExtensionInput, authUser auth.User) (Extension, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Extension{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	if !s.getAllowlist()[name] {
+		return Extension{}, flxErrors.NewForbiddenError("extension.error.notAllowed")
+	}
+
+	clientExtensionRepo, connection, err := s.getClientExtensionRepo(fetchedProject.DBName)
+	if err != nil {
+		return Extension{}, err
+	}
+	defer connection.Close()
+
+	extension, err := clientExtensionRepo.GetByName(name)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	if extension.Installed() {
+		return Extension{}, flxErrors.NewUnprocessableError("extension.error.alreadyEnabled")
+	}
+
+	if err = clientExtensionRepo.Create(buildCreateExtensionStatement(name, request.Schema)); err != nil {
+		return Extension{}, s.toExtensionError(err)
+	}
+
+	s.migrationService.Record(connection, buildEnableExtensionMigration(name, request.Schema))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	extension, err = clientExtensionRepo.GetByName(name)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	extension.Allowed = true
+
+	return extension, nil
+}
+
+// Disable is limited by the allowlist like Enable, extensions Fluxend depends on are never dropped
+func (s *ExtensionServiceImpl) Disable(name string, request DisableExtensionInput, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	if slices.Contains(constants.ProtectedExtensions, name) {
+		return false, flxErrors.NewForbiddenError("extension.error.protected")
+	}
+
+	if !s.getAllowlist()[name] {
+		return false, flxErrors.NewForbiddenError("extension.error.notAllowed")
+	}
+
+	clientExtensionRepo, connection, err := s.getClientExtensionRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	extension, err := clientExtensionRepo.GetByName(name)
+	if err != nil {
+		return false, err
+	}
+
+	if !extension.Installed() {
+		return false, flxErrors.NewUnprocessableError("extension.error.notEnabled")
+	}
+
+	if err = clientExtensionRepo.Drop(buildDropExtensionStatement(name, request.Cascade)); err != nil {
+		return false, s.toExtensionError(err)
+	}
+
+	s.migrationService.Record(connection, buildDisableExtensionMigration(extension, request.Cascade))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return true, nil
+}
+
+func (s *ExtensionServiceImpl) getAllowlist() map[string]bool {
+	return parseExtensionAllowlist(
+		s.settingService.GetValue(constants.ExtensionAllowlistSetting),
+		constants.DefaultExtensionAllowlist,
+	)
+}
+
+// toExtensionError surfaces database errors such as objects depending on the extension as bad requests
+func (s *ExtensionServiceImpl) toExtensionError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *ExtensionServiceImpl) getClientExtensionRepo(dbName string) (ExtensionRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetExtensionRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(ExtensionRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientExtensionRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
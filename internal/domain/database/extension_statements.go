@@ -0,0 +1,60 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+func buildCreateExtensionStatement(name, schema string) string {
+	statement := "CREATE EXTENSION " + pq.QuoteIdentifier(name)
+	if schema != "" {
+		statement += " WITH SCHEMA " + pq.QuoteIdentifier(schema)
+	}
+
+	return statement + ";"
+}
+
+func buildDropExtensionStatement(name string, cascade bool) string {
+	statement := "DROP EXTENSION " + pq.QuoteIdentifier(name)
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return statement + ";"
+}
+
+func buildEnableExtensionMigration(name, schema string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "enable_extension_" + name,
+		UpSQL:   buildCreateExtensionStatement(name, schema),
+		DownSQL: buildDropExtensionStatement(name, false),
+	}
+}
+
+// buildDisableExtensionMigration cannot bring back objects a cascading drop removed
+func buildDisableExtensionMigration(extension Extension, cascade bool) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "disable_extension_" + extension.Name,
+		UpSQL:   buildDropExtensionStatement(extension.Name, cascade),
+		DownSQL: buildCreateExtensionStatement(extension.Name, extension.Schema),
+	}
+}
+
+// parseExtensionAllowlist reads the comma separated setting, the default applies when it is empty and
+// protected extensions are left out
+func parseExtensionAllowlist(value, fallback string) map[string]bool {
+	if strings.TrimSpace(value) == "" {
+		value = fallback
+	}
+
+	allowed := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(constants.ProtectedExtensions, name) {
+			allowed[name] = true
+		}
+	}
+
+	return allowed
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExtensionStatements_Suite(t *testing.T) {
+	t.Run("enable into a schema", func(t *testing.T) {
+		migration := buildEnableExtensionMigration("uuid-ossp", "extensions")
+
+		assert.Equal(t, "enable_extension_uuid-ossp", migration.Name)
+		assert.Equal(t, `CREATE EXTENSION "uuid-ossp" WITH SCHEMA "extensions";`, migration.UpSQL)
+		assert.Equal(t, `DROP EXTENSION "uuid-ossp";`, migration.DownSQL)
+	})
+
+	t.Run("cascading disable recreates the extension in its schema", func(t *testing.T) {
+		migration := buildDisableExtensionMigration(Extension{Name: "citext", Schema: "public", InstalledVersion: "1.6"}, true)
+
+		assert.Equal(t, `DROP EXTENSION "citext" CASCADE;`, migration.UpSQL)
+		assert.Equal(t, `CREATE EXTENSION "citext" WITH SCHEMA "public";`, migration.DownSQL)
+	})
+
+	t.Run("allowlist trims names and falls back to the default", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{"pg_trgm": true, "pgcrypto": true}, parseExtensionAllowlist(" pg_trgm, ,pgcrypto ", ""))
+
+		allowed := parseExtensionAllowlist("  ", constants.DefaultExtensionAllowlist)
+		assert.True(t, allowed["uuid-ossp"])
+		assert.True(t, allowed["postgis"])
+		assert.False(t, allowed["plpython3u"])
+	})
+
+	t.Run("allowlist never includes protected extensions", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{"citext": true}, parseExtensionAllowlist("plpgsql,citext,pg_stat_statements", ""))
+	})
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type EnableExtensionInput struct {
+	ProjectUUID uuid.UUID
+
+	// empty installs into the default schema of the extension
+	Schema string
+}
+
+type DisableExtensionInput struct {
+	ProjectUUID uuid.UUID
+
+	// Cascade also drops the objects that use the extension, such as citext columns
+	Cascade bool
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"mime/multipart"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,6 +19,27 @@ import (
 
 type Row map[string]interface{}
 
+type timeFormat struct {
+	layout   string
+	dateOnly bool
+	zoned    bool
+}
+
+// timeFormats lists the date and time layouts recognised in CSV files, in the order they are tried
+var timeFormats = []timeFormat{
+	{layout: time.RFC3339, zoned: true},
+	{layout: "2006-01-02 15:04:05Z07:00", zoned: true},
+	{layout: "2006-01-02 15:04:05-07", zoned: true},
+	{layout: "2006-01-02T15:04:05"},
+	{layout: "2006-01-02 15:04:05"},
+	{layout: "2006-01-02", dateOnly: true},
+	{layout: "01/02/2006", dateOnly: true},
+	{layout: "02/01/2006", dateOnly: true},
+	{layout: "2006/01/02", dateOnly: true},
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 type FileImportService interface {
 	ImportCSV(file multipart.File) ([]Column, [][]string, error)
 }
@@ -126,9 +148,14 @@ func (s *FileImportServiceImpl) detectColumnType(rows [][]string, colIndex int)
 	// Try to determine type from data
 	isBoolean := true
 	isInteger := true
+	isBigint := false
 	isFloat := true
 	isJSON := true
 	isTimestamp := true
+	isDate := true
+	isZoned := false
+	isUUID := true
+	isInet := true
 	maxLength := 0
 	maxPrecision := 0
 	maxScale := 0
@@ -156,9 +183,11 @@ func (s *FileImportServiceImpl) detectColumnType(rows [][]string, colIndex int)
 
 		// Check if value is integer
 		if isInteger {
-			_, err := strconv.ParseInt(value, 10, 64)
+			intVal, err := strconv.ParseInt(value, 10, 64)
 			if err != nil {
 				isInteger = false
+			} else if intVal > math.MaxInt32 || intVal < math.MinInt32 {
+				isBigint = true
 			}
 		}
 
@@ -213,32 +242,25 @@ func (s *FileImportServiceImpl) detectColumnType(rows [][]string, colIndex int)
 			}
 		}
 
-		// Check if value is timestamp
+		// Check if value is timestamp, and whether it carries a time or a zone
 		if isTimestamp {
-			isValidDate := false
-
-			// Try common date formats
-			formats := []string{
-				time.RFC3339,
-				"2006-01-02T15:04:05",
-				"2006-01-02 15:04:05",
-				"2006-01-02",
-				"01/02/2006",
-				"02/01/2006",
-				"2006/01/02",
+			format, _, ok := s.matchTimeFormat(value)
+			if !ok {
+				isTimestamp = false
+			} else {
+				isDate = isDate && format.dateOnly
+				isZoned = isZoned || format.zoned
 			}
+		}
 
-			for _, format := range formats {
-				_, err := time.Parse(format, value)
-				if err == nil {
-					isValidDate = true
-					break
-				}
-			}
+		// Check if value is uuid
+		if isUUID && !uuidPattern.MatchString(value) {
+			isUUID = false
+		}
 
-			if !isValidDate {
-				isTimestamp = false
-			}
+		// Check if value is an ip address
+		if isInet && net.ParseIP(value) == nil {
+			isInet = false
 		}
 	}
 
@@ -256,6 +278,10 @@ func (s *FileImportServiceImpl) detectColumnType(rows [][]string, colIndex int)
 		return "boolean", !nullable
 	}
 
+	if isInteger && isBigint {
+		return "bigint", !nullable
+	}
+
 	if isInteger {
 		return "integer", !nullable
 	}
@@ -282,13 +308,29 @@ func (s *FileImportServiceImpl) detectColumnType(rows [][]string, colIndex int)
 	}
 
 	if isJSON {
-		return "json", !nullable
+		return "jsonb", !nullable
+	}
+
+	if isTimestamp && isDate {
+		return "date", !nullable
+	}
+
+	if isTimestamp && isZoned {
+		return "timestamptz", !nullable
 	}
 
 	if isTimestamp {
 		return "timestamp", !nullable
 	}
 
+	if isUUID {
+		return "uuid", !nullable
+	}
+
+	if isInet {
+		return "inet", !nullable
+	}
+
 	// Default to varchar for short strings, text for longer ones
 	if maxLength <= 255 {
 		return fmt.Sprintf("varchar(%d)", maxLength), !nullable
@@ -337,14 +379,24 @@ func (s *FileImportServiceImpl) parseTypeHint(typeHint string) string {
 		return "boolean"
 	case "int", "integer":
 		return "integer"
+	case "bigint":
+		return "bigint"
 	case "float", "real":
 		return "float"
 	case "text":
 		return "text"
 	case "json":
 		return "json"
+	case "jsonb":
+		return "jsonb"
 	case "timestamp", "datetime":
 		return "timestamp"
+	case "timestamptz":
+		return "timestamptz"
+	case "date":
+		return "date"
+	case "uuid":
+		return "uuid"
 	default:
 		return typeHint
 	}
@@ -398,6 +450,10 @@ func (s *FileImportServiceImpl) convertValueToType(value string, colType string)
 		return strconv.Atoi(value)
 	}
 
+	if colType == "bigint" {
+		return strconv.ParseInt(value, 10, 64)
+	}
+
 	if colType == "float" {
 		return strconv.ParseFloat(value, 64)
 	}
@@ -408,29 +464,15 @@ func (s *FileImportServiceImpl) convertValueToType(value string, colType string)
 		return value, nil
 	}
 
-	if colType == "json" {
+	if colType == "json" || colType == "jsonb" {
 		var parsedJSON interface{}
 		err := json.Unmarshal([]byte(value), &parsedJSON)
 		return parsedJSON, err
 	}
 
-	if colType == "timestamp" {
-		// Try common date formats
-		formats := []string{
-			time.RFC3339,
-			"2006-01-02T15:04:05",
-			"2006-01-02 15:04:05",
-			"2006-01-02",
-			"01/02/2006",
-			"02/01/2006",
-			"2006/01/02",
-		}
-
-		for _, format := range formats {
-			parsedTime, err := time.Parse(format, value)
-			if err == nil {
-				return parsedTime, nil
-			}
+	if colType == "timestamp" || colType == "timestamptz" || colType == "date" {
+		if _, parsedTime, ok := s.matchTimeFormat(value); ok {
+			return parsedTime, nil
 		}
 
 		return nil, fmt.Errorf("could not parse timestamp: %s", value)
@@ -439,3 +481,13 @@ func (s *FileImportServiceImpl) convertValueToType(value string, colType string)
 	// Default: return as string for unknown types
 	return value, nil
 }
+
+func (s *FileImportServiceImpl) matchTimeFormat(value string) (timeFormat, time.Time, bool) {
+	for _, format := range timeFormats {
+		if parsedTime, err := time.Parse(format.layout, value); err == nil {
+			return format, parsedTime, true
+		}
+	}
+
+	return timeFormat{}, time.Time{}, false
+}
@@ -104,11 +104,11 @@ func TestImportCSV_CompleteData(t *testing.T) {
 	assert.True(t, columns[2].NotNull)
 
 	assert.Equal(t, "salary", columns[3].Name)
-	assert.Equal(t, "numeric(7,2)", columns[3].Type) // TODO: handle column types with precision and scale etc in requests
+	assert.Equal(t, "numeric(7,2)", columns[3].Type)
 	assert.True(t, columns[3].NotNull)
 
 	assert.Equal(t, "details", columns[4].Name)
-	assert.Equal(t, constants.ColumnTypeJSON, columns[4].Type)
+	assert.Equal(t, constants.ColumnTypeJSONB, columns[4].Type)
 	assert.False(t, columns[4].NotNull) // the second row contains empty value so not null is false
 }
 
@@ -465,15 +465,45 @@ func TestDetectColumnType(t *testing.T) {
 		{
 			name:         "JSON Column",
 			values:       []string{`{"name":"John"}`, `{"name":"Jane"}`},
-			expectedType: "json",
+			expectedType: "jsonb",
 			notNull:      true,
 		},
 		{
-			name:         "Timestamp Column",
+			name:         "Bigint Column",
+			values:       []string{"1", "3000000000"},
+			expectedType: "bigint",
+			notNull:      true,
+		},
+		{
+			name:         "Date Column",
 			values:       []string{"2023-01-01", "2023-02-01"},
+			expectedType: "date",
+			notNull:      true,
+		},
+		{
+			name:         "Timestamp Column",
+			values:       []string{"2023-01-01 10:00:00", "2023-02-01"},
 			expectedType: "timestamp",
 			notNull:      true,
 		},
+		{
+			name:         "Timestamptz Column",
+			values:       []string{"2023-01-01T10:00:00Z", "2023-02-01 10:00:00+02"},
+			expectedType: "timestamptz",
+			notNull:      true,
+		},
+		{
+			name:         "UUID Column",
+			values:       []string{"123e4567-e89b-12d3-a456-426614174000", "00000000-0000-0000-0000-000000000000"},
+			expectedType: "uuid",
+			notNull:      true,
+		},
+		{
+			name:         "Inet Column",
+			values:       []string{"192.168.1.1", "::1"},
+			expectedType: "inet",
+			notNull:      true,
+		},
 		{
 			name:         "Nullable Column",
 			values:       []string{"1", "", "3"},
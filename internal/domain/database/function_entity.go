@@ -8,4 +8,34 @@ type Function struct {
 	Definition    string `db:"routine_definition" json:"definition"`
 	Language      string `db:"external_language" json:"language"`
 	SqlDataAccess string `db:"sql_data_access" json:"sqlDataAccess"`
+
+	// Arguments carries names and types, ArgumentTypes only the types that identify an overload
+	Arguments     string `db:"arguments" json:"arguments"`
+	ArgumentTypes string `db:"argument_types" json:"argumentTypes"`
+
+	Volatility      string `db:"volatility" json:"volatility"`
+	SecurityDefiner bool   `db:"security_definer" json:"securityDefiner"`
+	SearchPath      string `db:"search_path" json:"searchPath"`
+
+	// Extension names the extension the function belongs to, it comes and goes with the extension
+	Extension string `db:"extension" json:"extension"`
+}
+
+// argumentTypeList keeps the catalog formatting as is, it is already a comma separated list
+func (f Function) argumentTypeList() []string {
+	if f.ArgumentTypes == "" {
+		return nil
+	}
+
+	return []string{f.ArgumentTypes}
+}
+
+// FunctionResult holds the rows returned by a function call
+type FunctionResult struct {
+	Columns    []ResultColumn  `json:"columns"`
+	Rows       [][]interface{} `json:"rows"`
+	RowCount   int             `json:"rowCount"`
+	Truncated  bool            `json:"truncated"`
+	ReadOnly   bool            `json:"readOnly"`
+	DurationMs int64           `json:"durationMs"`
 }
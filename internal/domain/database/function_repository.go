@@ -1,8 +1,15 @@
 package database
 
+import (
+	"context"
+)
+
 type FunctionRepository interface {
 	List(schema string) ([]Function, error)
+	ListByName(schema, functionName string) ([]Function, error)
+	GetBySignature(signature string) (Function, error)
+	Has(signature string) (bool, error)
 	Create(functionSQL string) error
-	GetByName(schema, functionName string) (Function, error)
-	Delete(schema, functionName string) error
+	Drop(functionSQL string) error
+	Execute(ctx context.Context, statement string, args []interface{}, options FunctionExecuteOptions) (FunctionResult, error)
 }
@@ -1,10 +1,13 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fluxend/internal/config/constants"
 	"fluxend/internal/domain/auth"
 	"fluxend/internal/domain/project"
 	"fluxend/internal/domain/shared"
-	"fluxend/pkg/errors"
+	flxErrors "fluxend/pkg/errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -16,13 +19,16 @@ import (
 
 type FunctionService interface {
 	List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Function, error)
-	GetByName(name, schema string, projectUUID uuid.UUID, authUser auth.User) (Function, error)
+	GetByName(name, schema string, argumentTypes []string, projectUUID uuid.UUID, authUser auth.User) (Function, error)
 	Create(schema string, request CreateFunctionInput, authUser auth.User) (Function, error)
-	Delete(name, schema string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	Replace(schema string, request CreateFunctionInput, authUser auth.User) (Function, error)
+	Execute(ctx context.Context, name, schema string, request ExecuteFunctionInput, authUser auth.User) (FunctionResult, error)
+	Delete(name, schema string, argumentTypes []string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
 }
 
 type FunctionServiceImpl struct {
 	connectionService ConnectionService
+	migrationService  MigrationService
 	projectPolicy     *project.Policy
 	databaseRepo      shared.DatabaseService
 	projectRepo       project.Repository
@@ -30,12 +36,14 @@ type FunctionServiceImpl struct {
 
 func NewFunctionService(injector *do.Injector) (FunctionService, error) {
 	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
 	policy := do.MustInvoke[*project.Policy](injector)
 	databaseRepo := do.MustInvoke[shared.DatabaseService](injector)
 	projectRepo := do.MustInvoke[project.Repository](injector)
 
 	return &FunctionServiceImpl{
 		connectionService: connectionService,
+		migrationService:  migrationService,
 		projectPolicy:     policy,
 		databaseRepo:      databaseRepo,
 		projectRepo:       projectRepo,
@@ -43,21 +51,16 @@ func NewFunctionService(injector *do.Injector) (FunctionService, error) {
 }
 
 func (s *FunctionServiceImpl) List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Function, error) {
-	dbName, err := s.projectRepo.GetDatabaseNameByUUID(projectUUID)
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
 		return []Function{}, err
 	}
 
-	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(projectUUID)
-	if err != nil {
-		return []Function{}, err
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return []Function{}, flxErrors.NewForbiddenError("function.error.listForbidden")
 	}
 
-	if !s.projectPolicy.CanAccess(organizationUUID, authUser) {
-		return []Function{}, errors.NewForbiddenError("function.error.listForbidden")
-	}
-
-	clientFunctionRepo, connection, err := s.getClientFunctionRepo(dbName)
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
 	if err != nil {
 		return []Function{}, err
 	}
@@ -66,109 +69,235 @@ func (s *FunctionServiceImpl) List(schema string, projectUUID uuid.UUID, authUse
 	return clientFunctionRepo.List(schema)
 }
 
-func (s *FunctionServiceImpl) GetByName(name, schema string, projectUUID uuid.UUID, authUser auth.User) (Function, error) {
-	dbName, err := s.projectRepo.GetDatabaseNameByUUID(projectUUID)
+// GetByName returns a function by its argument types, without them the name must not be overloaded
+func (s *FunctionServiceImpl) GetByName(name, schema string, argumentTypes []string, projectUUID uuid.UUID, authUser auth.User) (Function, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
 		return Function{}, err
 	}
 
-	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(projectUUID)
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Function{}, flxErrors.NewForbiddenError("function.error.listForbidden")
+	}
+
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
 	if err != nil {
 		return Function{}, err
 	}
+	defer connection.Close()
+
+	return s.resolveFunction(clientFunctionRepo, schema, name, argumentTypes)
+}
 
-	if !s.projectPolicy.CanAccess(organizationUUID, authUser) {
-		return Function{}, errors.NewForbiddenError("function.error.listForbidden")
+func (s *FunctionServiceImpl) Create(schema string, request CreateFunctionInput, authUser auth.User) (Function, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Function{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Function{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
 	}
 
-	clientFunctionRepo, connection, err := s.getClientFunctionRepo(dbName)
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
 	if err != nil {
 		return Function{}, err
 	}
 	defer connection.Close()
 
-	return clientFunctionRepo.GetByName(schema, name)
-}
+	signature := buildFunctionSignature(schema, request.Name, request.ParameterTypes())
 
-func (s *FunctionServiceImpl) Create(schema string, request CreateFunctionInput, authUser auth.User) (Function, error) {
-	dbName, err := s.projectRepo.GetDatabaseNameByUUID(request.ProjectUUID)
+	hasFunction, err := clientFunctionRepo.Has(signature)
 	if err != nil {
-		return Function{}, err
+		return Function{}, s.toFunctionError(err)
 	}
 
-	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(request.ProjectUUID)
+	if hasFunction {
+		return Function{}, flxErrors.NewUnprocessableError("function.error.alreadyExists")
+	}
+
+	if err = clientFunctionRepo.Create(buildCreateFunctionStatement(schema, request, false)); err != nil {
+		return Function{}, s.toFunctionError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateFunctionMigration(schema, request))
+
+	return clientFunctionRepo.GetBySignature(signature)
+}
+
+// Replace runs CREATE OR REPLACE for the overload matching the parameter types, other overloads
+// of the same name are left untouched. Postgres rejects replacements that change the return type
+func (s *FunctionServiceImpl) Replace(schema string, request CreateFunctionInput, authUser auth.User) (Function, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
 	if err != nil {
 		return Function{}, err
 	}
 
-	if !s.projectPolicy.CanCreate(organizationUUID, authUser) {
-		return Function{}, errors.NewForbiddenError("function.error.listForbidden")
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Function{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
 	}
 
-	clientFunctionRepo, connection, err := s.getClientFunctionRepo(dbName)
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
 	if err != nil {
 		return Function{}, err
 	}
 	defer connection.Close()
 
-	definitionQuery, err := s.buildDefinition(schema, request)
+	signature := buildFunctionSignature(schema, request.Name, request.ParameterTypes())
+
+	hasFunction, err := clientFunctionRepo.Has(signature)
 	if err != nil {
-		return Function{}, err
+		return Function{}, s.toFunctionError(err)
 	}
 
-	if err = clientFunctionRepo.Create(definitionQuery); err != nil {
-		return Function{}, err
+	migration := buildCreateFunctionMigration(schema, request)
+	if hasFunction {
+		existing, err := clientFunctionRepo.GetBySignature(signature)
+		if err != nil {
+			return Function{}, s.toFunctionError(err)
+		}
+
+		migration = buildReplaceFunctionMigration(schema, request, existing)
+	}
+
+	if err = clientFunctionRepo.Create(buildCreateFunctionStatement(schema, request, true)); err != nil {
+		return Function{}, s.toFunctionError(err)
 	}
 
-	return clientFunctionRepo.GetByName(schema, request.Name)
+	s.migrationService.Record(connection, migration)
+
+	return clientFunctionRepo.GetBySignature(signature)
 }
 
-func (s *FunctionServiceImpl) Delete(schema, name string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
-	dbName, err := s.projectRepo.GetDatabaseNameByUUID(projectUUID)
+// Execute calls a function with the given arguments and returns its result set. Users below the
+// developer role can only call functions in read-only transactions, which are always rolled back
+func (s *FunctionServiceImpl) Execute(ctx context.Context, name, schema string, request ExecuteFunctionInput, authUser auth.User) (FunctionResult, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
 	if err != nil {
-		return false, err
+		return FunctionResult{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return FunctionResult{}, flxErrors.NewForbiddenError("function.error.listForbidden")
 	}
 
-	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(projectUUID)
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
+	if err != nil {
+		return FunctionResult{}, err
+	}
+	defer connection.Close()
+
+	argumentTypes := request.ArgumentTypes()
+
+	function, err := s.resolveFunction(clientFunctionRepo, schema, name, argumentTypes)
+	if err != nil {
+		return FunctionResult{}, err
+	}
+
+	if function.DataType == constants.TriggerFunctionReturnType {
+		return FunctionResult{}, flxErrors.NewBadRequestError("function.error.notExecutable")
+	}
+
+	args := make([]interface{}, len(request.Arguments))
+	for i, argument := range request.Arguments {
+		if args[i], err = toFunctionArgumentValue(argument.Value); err != nil {
+			return FunctionResult{}, flxErrors.NewBadRequestError(fmt.Sprintf("argument %d is not valid JSON", i+1))
+		}
+	}
+
+	maxRows := request.MaxRows
+	if maxRows <= 0 {
+		maxRows = constants.DefaultQueryMaxRows
+	}
+
+	result, err := clientFunctionRepo.Execute(ctx, buildExecuteFunctionStatement(schema, name, argumentTypes), args, FunctionExecuteOptions{
+		ReadOnly:    request.ReadOnly || !authUser.IsDeveloperOrMore(),
+		TimeoutInMs: constants.DefaultQueryTimeoutInMs,
+		MaxRows:     min(maxRows, constants.MaxQueryRows),
+	})
+	if err != nil {
+		return FunctionResult{}, s.toFunctionError(err)
+	}
+
+	return result, nil
+}
+
+func (s *FunctionServiceImpl) Delete(name, schema string, argumentTypes []string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
 		return false, err
 	}
 
-	if !s.projectPolicy.CanUpdate(organizationUUID, authUser) {
-		return false, errors.NewForbiddenError("function.error.listForbidden")
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
 	}
 
-	clientFunctionRepo, connection, err := s.getClientFunctionRepo(dbName)
+	clientFunctionRepo, connection, err := s.getClientFunctionRepo(fetchedProject.DBName)
 	if err != nil {
 		return false, err
 	}
 	defer connection.Close()
 
-	if err = clientFunctionRepo.Delete(schema, name); err != nil {
+	function, err := s.resolveFunction(clientFunctionRepo, schema, name, argumentTypes)
+	if err != nil {
 		return false, err
 	}
 
+	if err = clientFunctionRepo.Drop(buildDropFunctionStatement(schema, name, function.argumentTypeList())); err != nil {
+		return false, s.toFunctionError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropFunctionMigration(schema, function))
+
 	return true, nil
 }
 
-func (s *FunctionServiceImpl) buildDefinition(schema string, request CreateFunctionInput) (string, error) {
-	var params []string
-	for _, param := range request.Parameters {
-		params = append(params, fmt.Sprintf("%s %s", pq.QuoteIdentifier(param.Name), pq.QuoteIdentifier(param.Type)))
+// resolveFunction looks a function up by its signature. Without argument types the name alone
+// is used, which only works as long as the function is not overloaded
+func (s *FunctionServiceImpl) resolveFunction(clientFunctionRepo FunctionRepository, schema, name string, argumentTypes []string) (Function, error) {
+	if argumentTypes != nil {
+		function, err := clientFunctionRepo.GetBySignature(buildFunctionSignature(schema, name, argumentTypes))
+		if err != nil {
+			return Function{}, s.toFunctionError(err)
+		}
+
+		return function, nil
 	}
-	paramList := strings.Join(params, ", ")
 
-	sql := fmt.Sprintf(
-		`CREATE OR REPLACE FUNCTION %s.%s(%s) RETURNS %s AS $$ %s; $$ LANGUAGE %s;`,
-		schema,
-		pq.QuoteIdentifier(request.Name),
-		paramList,
-		pq.QuoteIdentifier(request.ReturnType),
-		pq.QuoteIdentifier(request.Definition),
-		pq.QuoteIdentifier(request.Language),
-	)
+	functions, err := clientFunctionRepo.ListByName(schema, name)
+	if err != nil {
+		return Function{}, s.toFunctionError(err)
+	}
+
+	if len(functions) == 0 {
+		return Function{}, flxErrors.NewNotFoundError("function.error.notFound")
+	}
+
+	if len(functions) > 1 {
+		signatures := make([]string, len(functions))
+		for i, function := range functions {
+			signatures[i] = fmt.Sprintf("%s(%s)", function.Name, function.ArgumentTypes)
+		}
+
+		return Function{}, flxErrors.NewBadRequestError(fmt.Sprintf(
+			"function '%s.%s' is overloaded, pass the argument types of one of: %s",
+			schema,
+			name,
+			strings.Join(signatures, ", "),
+		))
+	}
+
+	return functions[0], nil
+}
+
+// toFunctionError surfaces database errors such as invalid bodies or unknown types as bad requests
+func (s *FunctionServiceImpl) toFunctionError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
 
-	return strings.ReplaceAll(sql, ";;", ";"), nil
+	return err
 }
 
 func (s *FunctionServiceImpl) getClientFunctionRepo(dbName string) (FunctionRepository, *sqlx.DB, error) {
@@ -181,7 +310,7 @@ func (s *FunctionServiceImpl) getClientFunctionRepo(dbName string) (FunctionRepo
 	if !ok {
 		connection.Close()
 
-		return nil, nil, errors.NewUnprocessableError("clientFunctionRepo is invalid")
+		return nil, nil, flxErrors.NewUnprocessableError("clientFunctionRepo is invalid")
 	}
 
 	return clientRepo, connection, nil
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// buildFunctionSignature identifies one overload of a function, postgres resolves it with to_regprocedure
+func buildFunctionSignature(schema, functionName string, argumentTypes []string) string {
+	return fmt.Sprintf(
+		"%s.%s(%s)",
+		pq.QuoteIdentifier(schema),
+		pq.QuoteIdentifier(functionName),
+		strings.Join(argumentTypes, ", "),
+	)
+}
+
+func buildCreateFunctionStatement(schema string, input CreateFunctionInput, replace bool) string {
+	parameters := make([]string, len(input.Parameters))
+	for i, parameter := range input.Parameters {
+		parameters[i] = fmt.Sprintf("%s %s", pq.QuoteIdentifier(parameter.Name), parameter.Type)
+	}
+
+	command := "CREATE FUNCTION"
+	if replace {
+		command = "CREATE OR REPLACE FUNCTION"
+	}
+
+	volatility := input.Volatility
+	if volatility == "" {
+		volatility = constants.FunctionVolatile
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(
+		"%s %s.%s(%s) RETURNS %s LANGUAGE %s %s",
+		command,
+		pq.QuoteIdentifier(schema),
+		pq.QuoteIdentifier(input.Name),
+		strings.Join(parameters, ", "),
+		input.ReturnType,
+		input.Language,
+		volatility,
+	))
+
+	if input.SecurityDefiner {
+		builder.WriteString(" SECURITY DEFINER")
+	}
+
+	if len(input.SearchPath) > 0 {
+		builder.WriteString(" SET search_path = " + quoteIdentifiers(input.SearchPath))
+	}
+
+	body := strings.TrimSuffix(strings.TrimSpace(input.Definition), ";") + ";"
+	builder.WriteString(" AS " + dollarQuote(body, constants.FunctionBodyTag) + ";")
+
+	return builder.String()
+}
+
+func buildDropFunctionStatement(schema, functionName string, argumentTypes []string) string {
+	return fmt.Sprintf("DROP FUNCTION %s;", buildFunctionSignature(schema, functionName, argumentTypes))
+}
+
+// buildExecuteFunctionStatement casts every placeholder so postgres calls the same overload it resolved
+func buildExecuteFunctionStatement(schema, functionName string, argumentTypes []string) string {
+	placeholders := make([]string, len(argumentTypes))
+	for i, argumentType := range argumentTypes {
+		placeholders[i] = fmt.Sprintf("$%d::%s", i+1, argumentType)
+	}
+
+	return fmt.Sprintf(
+		"SELECT * FROM %s.%s(%s)",
+		pq.QuoteIdentifier(schema),
+		pq.QuoteIdentifier(functionName),
+		strings.Join(placeholders, ", "),
+	)
+}
+
+func buildCreateFunctionMigration(schema string, input CreateFunctionInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_function_" + input.Name,
+		UpSQL:   buildCreateFunctionStatement(schema, input, false),
+		DownSQL: buildDropFunctionStatement(schema, input.Name, input.ParameterTypes()),
+	}
+}
+
+// buildReplaceFunctionMigration restores the previous version from its catalog definition
+func buildReplaceFunctionMigration(schema string, input CreateFunctionInput, existing Function) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "replace_function_" + input.Name,
+		UpSQL:   buildCreateFunctionStatement(schema, input, true),
+		DownSQL: strings.TrimSpace(existing.Definition) + ";",
+	}
+}
+
+func buildDropFunctionMigration(schema string, function Function) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_function_" + function.Name,
+		UpSQL:   buildDropFunctionStatement(schema, function.Name, function.argumentTypeList()),
+		DownSQL: strings.TrimSpace(function.Definition) + ";",
+	}
+}
+
+// dollarQuote wraps a body in a dollar quote whose tag does not appear in the body itself
+func dollarQuote(body, tag string) string {
+	delimiter := "$" + tag + "$"
+	for i := 1; strings.Contains(body, delimiter); i++ {
+		delimiter = fmt.Sprintf("$%s%d$", tag, i)
+	}
+
+	return delimiter + " " + body + " " + delimiter
+}
+
+// toFunctionArgumentValue passes JSON values to postgres in their text form, the statement casts them
+// to the argument type. Strings are unquoted, objects and arrays are kept as JSON for json parameters
+func toFunctionArgumentValue(value json.RawMessage) (interface{}, error) {
+	trimmed := strings.TrimSpace(string(value))
+	if trimmed == "" || trimmed == "null" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(trimmed, `"`) {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, err
+		}
+
+		return text, nil
+	}
+
+	return trimmed, nil
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFunctionStatements_Suite(t *testing.T) {
+	input := CreateFunctionInput{
+		Name: "add_points",
+		Parameters: []FunctionParameter{
+			{Name: "user_id", Type: "uuid"},
+			{Name: "points", Type: "double precision"},
+		},
+		Definition: "BEGIN RETURN points * 2; END;",
+		Language:   "plpgsql",
+		ReturnType: "double precision",
+	}
+
+	t.Run("signature identifies the overload by its types", func(t *testing.T) {
+		assert.Equal(t, `"public"."add_points"(uuid, double precision)`, buildFunctionSignature("public", "add_points", input.ParameterTypes()))
+		assert.Equal(t, `"public"."now_utc"()`, buildFunctionSignature("public", "now_utc", nil))
+	})
+
+	t.Run("create statement leaves types, body and language unquoted", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`CREATE FUNCTION "public"."add_points"("user_id" uuid, "points" double precision) RETURNS double precision `+
+				`LANGUAGE plpgsql VOLATILE AS $function$ BEGIN RETURN points * 2; END; $function$;`,
+			buildCreateFunctionStatement("public", input, false),
+		)
+	})
+
+	t.Run("replace statement carries the options", func(t *testing.T) {
+		options := input
+		options.Volatility = "STABLE"
+		options.SecurityDefiner = true
+		options.SearchPath = []string{"public", "pg_temp"}
+		options.Definition = "BEGIN RETURN points; END"
+
+		assert.Equal(
+			t,
+			`CREATE OR REPLACE FUNCTION "public"."add_points"("user_id" uuid, "points" double precision) RETURNS double precision `+
+				`LANGUAGE plpgsql STABLE SECURITY DEFINER SET search_path = "public", "pg_temp" `+
+				`AS $function$ BEGIN RETURN points; END; $function$;`,
+			buildCreateFunctionStatement("public", options, true),
+		)
+	})
+
+	t.Run("body containing the dollar tag gets another one", func(t *testing.T) {
+		assert.Equal(t, "$function$ SELECT 1; $function$", dollarQuote("SELECT 1;", "function"))
+		assert.Equal(t, "$function1$ SELECT '$function$'; $function1$", dollarQuote("SELECT '$function$';", "function"))
+	})
+
+	t.Run("migrations drop and restore the exact overload", func(t *testing.T) {
+		create := buildCreateFunctionMigration("public", input)
+		assert.Equal(t, "create_function_add_points", create.Name)
+		assert.Equal(t, `DROP FUNCTION "public"."add_points"(uuid, double precision);`, create.DownSQL)
+
+		existing := Function{
+			Name:          "add_points",
+			ArgumentTypes: "uuid, double precision",
+			Definition:    "CREATE OR REPLACE FUNCTION public.add_points(user_id uuid, points double precision)\n ...\n",
+		}
+
+		replace := buildReplaceFunctionMigration("public", input, existing)
+		assert.Equal(t, "replace_function_add_points", replace.Name)
+		assert.Equal(t, "CREATE OR REPLACE FUNCTION public.add_points(user_id uuid, points double precision)\n ...;", replace.DownSQL)
+
+		drop := buildDropFunctionMigration("public", existing)
+		assert.Equal(t, `DROP FUNCTION "public"."add_points"(uuid, double precision);`, drop.UpSQL)
+		assert.Equal(t, replace.DownSQL, drop.DownSQL)
+
+		assert.Equal(t, `DROP FUNCTION "public"."now_utc"();`, buildDropFunctionMigration("public", Function{Name: "now_utc"}).UpSQL)
+	})
+
+	t.Run("execute statement casts every argument", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`SELECT * FROM "public"."add_points"($1::uuid, $2::double precision)`,
+			buildExecuteFunctionStatement("public", "add_points", []string{"uuid", "double precision"}),
+		)
+		assert.Equal(t, `SELECT * FROM "api"."now_utc"()`, buildExecuteFunctionStatement("api", "now_utc", nil))
+	})
+
+	t.Run("arguments are passed in their text form", func(t *testing.T) {
+		tests := []struct {
+			value    string
+			expected interface{}
+		}{
+			{value: `"hello"`, expected: "hello"},
+			{value: `12345678901234567890`, expected: "12345678901234567890"},
+			{value: `true`, expected: "true"},
+			{value: `{"a": [1, 2]}`, expected: `{"a": [1, 2]}`},
+			{value: `null`, expected: nil},
+			{value: ``, expected: nil},
+		}
+
+		for _, tt := range tests {
+			value, err := toFunctionArgumentValue(json.RawMessage(tt.value))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 )
 
@@ -10,10 +11,53 @@ type FunctionParameter struct {
 }
 
 type CreateFunctionInput struct {
-	ProjectUUID uuid.UUID           `json:"projectUUID,omitempty"`
-	Name        string              `json:"name"`
-	Parameters  []FunctionParameter `json:"parameters"`
-	Definition  string              `json:"definition"`
-	Language    string              `json:"language"`
-	ReturnType  string              `json:"return_type"`
+	ProjectUUID     uuid.UUID           `json:"projectUUID,omitempty"`
+	Name            string              `json:"name"`
+	Parameters      []FunctionParameter `json:"parameters"`
+	Definition      string              `json:"definition"`
+	Language        string              `json:"language"`
+	ReturnType      string              `json:"return_type"`
+	Volatility      string              `json:"volatility"`
+	SecurityDefiner bool                `json:"securityDefiner"`
+
+	// empty leaves search_path to the caller, which security definer functions should not do
+	SearchPath []string `json:"searchPath"`
+}
+
+// ParameterTypes returns the types that identify the function among its overloads
+func (i CreateFunctionInput) ParameterTypes() []string {
+	types := make([]string, len(i.Parameters))
+	for index, parameter := range i.Parameters {
+		types[index] = parameter.Type
+	}
+
+	return types
+}
+
+type FunctionArgument struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value" swaggertype:"object"`
+}
+
+type ExecuteFunctionInput struct {
+	ProjectUUID uuid.UUID          `json:"projectUUID,omitempty"`
+	Arguments   []FunctionArgument `json:"arguments"`
+	ReadOnly    bool               `json:"readOnly"`
+	MaxRows     int                `json:"maxRows"`
+}
+
+// ArgumentTypes returns the types used to pick the overload being called
+func (i ExecuteFunctionInput) ArgumentTypes() []string {
+	types := make([]string, len(i.Arguments))
+	for index, argument := range i.Arguments {
+		types[index] = argument.Type
+	}
+
+	return types
+}
+
+type FunctionExecuteOptions struct {
+	ReadOnly    bool
+	TimeoutInMs int
+	MaxRows     int
 }
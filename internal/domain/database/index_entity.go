@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+// Index describes an index of a table, schema diffs only rely on Name and Definition
+type Index struct {
+	Name       string         `db:"name" json:"name"`
+	Definition string         `db:"definition" json:"definition"`
+	Method     string         `db:"method" json:"method"`
+	IsUnique   bool           `db:"is_unique" json:"isUnique"`
+	IsPrimary  bool           `db:"is_primary" json:"isPrimary"`
+	IsValid    bool           `db:"is_valid" json:"isValid"`
+	Predicate  string         `db:"predicate" json:"predicate"`
+	Include    pq.StringArray `db:"include_columns" json:"include" swaggertype:"array,string"`
+	SizeBytes  int64          `db:"size_bytes" json:"sizeBytes"`
+	Columns    []IndexColumn  `db:"-" json:"columns"`
+}
+
+// IndexColumn is a key of an index, either a plain column or an expression
+type IndexColumn struct {
+	Name         string `json:"name"`
+	IsExpression bool   `json:"isExpression"`
+
+	// Order and Nulls are only reported for btree indexes, the other methods do not sort
+	Order string `json:"order,omitempty"`
+	Nulls string `json:"nulls,omitempty"`
+}
@@ -1,9 +1,10 @@
 package database
 
 type IndexRepository interface {
-	GetByName(tableName, indexName string) (string, error)
-	Has(tableName, indexName string) (bool, error)
-	List(tableName string) ([]string, error)
-	Create(tableName string, indexName string, columns []string, isUnique bool) (bool, error)
-	DropIfExists(indexName string) (bool, error)
+	GetByName(schema, tableName, indexName string) (Index, error)
+	Has(schema, indexName string) (bool, error)
+	List(schema, tableName string) ([]Index, error)
+	ListDefinitions(schema, tableName string) ([]Index, error)
+	Create(indexSQL string) error
+	DropIfExists(schema, indexName string) (bool, error)
 }
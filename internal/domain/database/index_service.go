@@ -1,41 +1,51 @@
 package database
 
 import (
+	stdErrors "errors"
+	"fluxend/internal/config/constants"
 	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/job"
 	"fluxend/internal/domain/project"
 	"fluxend/pkg"
 	"fluxend/pkg/errors"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/samber/do"
 )
 
 type IndexService interface {
-	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]string, error)
-	GetByName(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (string, error)
-	Create(fullTableName string, request CreateIndexInput, authUser auth.User) (string, error)
+	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Index, error)
+	GetByName(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Index, error)
+	Create(fullTableName string, request CreateIndexInput, authUser auth.User) (Index, *job.Job, error)
 	Delete(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
 }
 
 type IndexServiceImpl struct {
 	connectionService ConnectionService
+	migrationService  MigrationService
+	jobService        job.Service
 	projectPolicy     *project.Policy
 	projectRepo       project.Repository
 }
 
 func NewIndexService(injector *do.Injector) (IndexService, error) {
 	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	jobService := do.MustInvoke[job.Service](injector)
 	policy := do.MustInvoke[*project.Policy](injector)
 	projectRepo := do.MustInvoke[project.Repository](injector)
 
 	return &IndexServiceImpl{
 		projectPolicy:     policy,
 		connectionService: connectionService,
+		migrationService:  migrationService,
+		jobService:        jobService,
 		projectRepo:       projectRepo,
 	}, nil
 }
 
-func (s *IndexServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]string, error) {
+func (s *IndexServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Index, error) {
 	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
 		return nil, err
@@ -51,63 +61,78 @@ func (s *IndexServiceImpl) List(fullTableName string, projectUUID uuid.UUID, aut
 	}
 	defer connection.Close()
 
-	_, tableName := pkg.ParseTableName(fullTableName)
-	return clientIndexRepo.List(tableName)
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	return clientIndexRepo.List(schema, tableName)
 }
 
-func (s *IndexServiceImpl) GetByName(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (string, error) {
+func (s *IndexServiceImpl) GetByName(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Index, error) {
 	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
-		return "", err
+		return Index{}, err
 	}
 
 	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
-		return "", errors.NewForbiddenError("project.error.viewForbidden")
+		return Index{}, errors.NewForbiddenError("project.error.viewForbidden")
 	}
 
 	clientIndexRepo, connection, err := s.getClientIndexRepo(fetchedProject.DBName)
 	if err != nil {
-		return "", err
+		return Index{}, err
 	}
 	defer connection.Close()
 
-	_, tableName := pkg.ParseTableName(fullTableName)
-	return clientIndexRepo.GetByName(tableName, indexName)
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	return clientIndexRepo.GetByName(schema, tableName, indexName)
 }
 
-func (s *IndexServiceImpl) Create(fullTableName string, request CreateIndexInput, authUser auth.User) (string, error) {
+// Create builds the index right away, unless it is built concurrently. Those builds can take long on big
+// tables, so they run as a job and the job is returned instead of the index
+func (s *IndexServiceImpl) Create(fullTableName string, request CreateIndexInput, authUser auth.User) (Index, *job.Job, error) {
 	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
 	if err != nil {
-		return "", err
+		return Index{}, nil, err
 	}
 
 	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
-		return "", errors.NewForbiddenError("table.error.createForbidden")
+		return Index{}, nil, errors.NewForbiddenError("table.error.createForbidden")
 	}
 
 	clientIndexRepo, connection, err := s.getClientIndexRepo(fetchedProject.DBName)
 	if err != nil {
-		return "", err
+		return Index{}, nil, err
 	}
 	defer connection.Close()
 
-	hasIndex, err := clientIndexRepo.Has(fullTableName, request.Name)
+	schema, _ := pkg.ParseTableName(fullTableName)
+	hasIndex, err := clientIndexRepo.Has(schema, request.Name)
 	if err != nil {
-		return "", err
+		return Index{}, nil, err
 	}
 
 	if hasIndex {
-		return "", errors.NewUnprocessableError("index.error.alreadyExists")
+		return Index{}, nil, errors.NewUnprocessableError("index.error.alreadyExists")
 	}
 
-	_, err = clientIndexRepo.Create(fullTableName, request.Name, request.Columns, request.IsUnique)
-	if err != nil {
-		return "", err
+	if !request.Concurrently {
+		index, err := s.build(clientIndexRepo, connection, fullTableName, request)
+
+		return index, nil, err
 	}
 
-	_, tableName := pkg.ParseTableName(fullTableName)
+	payload := map[string]string{
+		"table":     fullTableName,
+		"index":     request.Name,
+		"statement": buildCreateIndexStatement(fullTableName, request),
+	}
+
+	createdJob, err := s.jobService.Dispatch(request.ProjectUUID, constants.JobTypeIndexBuild, payload, authUser, func() (interface{}, error) {
+		return s.buildConcurrently(fetchedProject.DBName, fullTableName, request)
+	})
+	if err != nil {
+		return Index{}, nil, err
+	}
 
-	return clientIndexRepo.GetByName(tableName, request.Name)
+	return Index{}, &createdJob, nil
 }
 
 func (s *IndexServiceImpl) Delete(indexName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
@@ -126,17 +151,81 @@ func (s *IndexServiceImpl) Delete(indexName, fullTableName string, projectUUID u
 	}
 	defer connection.Close()
 
-	_, tableName := pkg.ParseTableName(fullTableName)
-	hasIndex, err := clientIndexRepo.Has(tableName, indexName)
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	index, err := clientIndexRepo.GetByName(schema, tableName, indexName)
+	if err != nil {
+		return false, err
+	}
+
+	dropped, err := clientIndexRepo.DropIfExists(schema, indexName)
 	if err != nil {
 		return false, err
 	}
 
-	if !hasIndex {
-		return false, errors.NewNotFoundError("index.error.notFound")
+	s.migrationService.Record(connection, buildDropIndexMigration(schema, indexName, index.Definition))
+
+	return dropped, nil
+}
+
+func (s *IndexServiceImpl) build(clientIndexRepo IndexRepository, connection *sqlx.DB, fullTableName string, request CreateIndexInput) (Index, error) {
+	if err := clientIndexRepo.Create(buildCreateIndexStatement(fullTableName, request)); err != nil {
+		return Index{}, s.toIndexError(err)
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	index, err := clientIndexRepo.GetByName(schema, tableName, request.Name)
+	if err != nil {
+		return Index{}, err
+	}
+
+	// the definition postgres reports has no CONCURRENTLY, so replaying the migration also works in a transaction
+	s.migrationService.Record(connection, buildCreateIndexMigration(schema, request.Name, index.Definition))
+
+	return index, nil
+}
+
+// buildConcurrently runs in the background with its own connection. A failed concurrent build leaves an
+// invalid index behind which is dropped so the name can be used again, an index of the same name that
+// was created in the meantime is valid or belongs to another table and is left alone
+func (s *IndexServiceImpl) buildConcurrently(dbName, fullTableName string, request CreateIndexInput) (Index, error) {
+	clientIndexRepo, connection, err := s.getClientIndexRepo(dbName)
+	if err != nil {
+		return Index{}, err
+	}
+	defer connection.Close()
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	hasIndex, err := clientIndexRepo.Has(schema, request.Name)
+	if err != nil {
+		return Index{}, err
+	}
+
+	if hasIndex {
+		return Index{}, errors.NewUnprocessableError("index.error.alreadyExists")
+	}
+
+	index, err := s.build(clientIndexRepo, connection, fullTableName, request)
+	if err != nil {
+		leftover, getErr := clientIndexRepo.GetByName(schema, tableName, request.Name)
+		if getErr == nil && !leftover.IsValid {
+			_, _ = clientIndexRepo.DropIfExists(schema, request.Name)
+		}
+
+		return Index{}, err
+	}
+
+	return index, nil
+}
+
+// toIndexError surfaces database errors such as unknown columns or duplicate values as bad requests
+func (s *IndexServiceImpl) toIndexError(err error) error {
+	var pqErr *pq.Error
+	if stdErrors.As(err, &pqErr) {
+		return errors.NewBadRequestError(pqErr.Message)
 	}
 
-	return clientIndexRepo.DropIfExists(indexName)
+	return err
 }
 
 func (s *IndexServiceImpl) getClientIndexRepo(dbName string) (IndexRepository, *sqlx.DB, error) {
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func buildCreateIndexStatement(fullTableName string, input CreateIndexInput) string {
+	var builder strings.Builder
+
+	builder.WriteString("CREATE ")
+	if input.IsUnique {
+		builder.WriteString("UNIQUE ")
+	}
+
+	builder.WriteString("INDEX ")
+	if input.Concurrently {
+		builder.WriteString("CONCURRENTLY ")
+	}
+
+	builder.WriteString(fmt.Sprintf(
+		"%s ON %s USING %s (%s)",
+		pq.QuoteIdentifier(input.Name),
+		quoteTableName(fullTableName),
+		input.Method,
+		buildIndexKeys(input.Keys),
+	))
+
+	if len(input.Include) > 0 {
+		builder.WriteString(fmt.Sprintf(" INCLUDE (%s)", quoteIdentifiers(input.Include)))
+	}
+
+	if input.Where != "" {
+		builder.WriteString(fmt.Sprintf(" WHERE %s", input.Where))
+	}
+
+	builder.WriteString(";")
+
+	return builder.String()
+}
+
+// buildIndexKeys wraps expressions in parentheses, postgres requires it for anything but a function call
+func buildIndexKeys(keys []IndexKeyInput) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		part := pq.QuoteIdentifier(key.Column)
+		if key.Expression != "" {
+			part = "(" + key.Expression + ")"
+		}
+
+		if key.Order != "" {
+			part += " " + key.Order
+		}
+
+		if key.Nulls != "" {
+			part += " NULLS " + key.Nulls
+		}
+
+		parts[i] = part
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIndexStatements_Suite(t *testing.T) {
+	t.Run("unique btree index on columns", func(t *testing.T) {
+		statement := buildCreateIndexStatement("public.users", CreateIndexInput{
+			Name:     "users_email_idx",
+			Method:   constants.IndexMethodBtree,
+			Keys:     []IndexKeyInput{{Column: "email"}, {Column: "created_at", Order: "DESC", Nulls: "LAST"}},
+			IsUnique: true,
+		})
+
+		assert.Equal(
+			t,
+			`CREATE UNIQUE INDEX "users_email_idx" ON "public"."users" USING btree ("email", "created_at" DESC NULLS LAST);`,
+			statement,
+		)
+	})
+
+	t.Run("partial expression index with include columns", func(t *testing.T) {
+		statement := buildCreateIndexStatement("public.users", CreateIndexInput{
+			Name:    "users_lower_email_idx",
+			Method:  constants.IndexMethodBtree,
+			Keys:    []IndexKeyInput{{Expression: "lower(email)"}},
+			Include: []string{"id", "name"},
+			Where:   "deleted_at IS NULL",
+		})
+
+		assert.Equal(
+			t,
+			`CREATE INDEX "users_lower_email_idx" ON "public"."users" USING btree ((lower(email))) INCLUDE ("id", "name") WHERE deleted_at IS NULL;`,
+			statement,
+		)
+	})
+
+	t.Run("concurrent gin index", func(t *testing.T) {
+		statement := buildCreateIndexStatement("public.posts", CreateIndexInput{
+			Name:         "posts_tags_idx",
+			Method:       constants.IndexMethodGin,
+			Keys:         []IndexKeyInput{{Column: "tags"}},
+			Concurrently: true,
+		})
+
+		assert.Equal(t, `CREATE INDEX CONCURRENTLY "posts_tags_idx" ON "public"."posts" USING gin ("tags");`, statement)
+	})
+}
@@ -5,8 +5,20 @@ import (
 )
 
 type CreateIndexInput struct {
-	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
-	Name        string    `json:"name"`
-	Columns     []string  `json:"columns"`
-	IsUnique    bool      `json:"is_unique"`
+	ProjectUUID  uuid.UUID       `json:"projectUUID,omitempty"`
+	Name         string          `json:"name"`
+	Method       string          `json:"method"`
+	Keys         []IndexKeyInput `json:"keys"`
+	Include      []string        `json:"include"`
+	Where        string          `json:"where"`
+	IsUnique     bool            `json:"is_unique"`
+	Concurrently bool            `json:"concurrently"`
+}
+
+// IndexKeyInput holds either a column or an expression
+type IndexKeyInput struct {
+	Column     string `json:"column"`
+	Expression string `json:"expression"`
+	Order      string `json:"order"`
+	Nulls      string `json:"nulls"`
 }
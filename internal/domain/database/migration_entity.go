@@ -0,0 +1,22 @@
+package database
+
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
+
+type Migration struct {
+	Id        int       `db:"id" json:"id"`
+	Version   int       `db:"version" json:"version"`
+	Name      string    `db:"name" json:"name"`
+	UpSQL     string    `db:"up_sql" json:"upSql"`
+	DownSQL   string    `db:"down_sql" json:"downSql"`
+	Source    string    `db:"source" json:"source"`
+	Status    string    `db:"status" json:"status"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	AppliedAt null.Time `db:"applied_at" json:"appliedAt" swaggertype:"string"`
+}
+
+func (m Migration) IsReversible() bool {
+	return m.DownSQL != ""
+}
@@ -0,0 +1,11 @@
+package database
+
+type MigrationRepository interface {
+	List() ([]Migration, error)
+	ListByStatus(statuses []string, descending bool) ([]Migration, error)
+	GetByVersion(version int) (Migration, error)
+	Create(migration *Migration) (*Migration, error)
+	Execute(migration *Migration) (*Migration, error)
+	Apply(migration Migration) error
+	Rollback(migration Migration) error
+}
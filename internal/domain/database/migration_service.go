@@ -0,0 +1,271 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+type MigrationService interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]Migration, error)
+	Create(request CreateMigrationInput, authUser auth.User) (Migration, error)
+	Apply(request ApplyMigrationInput, authUser auth.User) ([]Migration, error)
+	Rollback(request RollbackMigrationInput, authUser auth.User) ([]Migration, error)
+	Record(connection *sqlx.DB, input RecordMigrationInput)
+	Execute(connection *sqlx.DB, input RecordMigrationInput) (Migration, error)
+}
+
+type MigrationServiceImpl struct {
+	connectionService ConnectionService
+	projectPolicy     *project.Policy
+	postgrestService  shared.PostgrestService
+	projectRepo       project.Repository
+}
+
+func NewMigrationService(injector *do.Injector) (MigrationService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &MigrationServiceImpl{
+		connectionService: connectionService,
+		projectPolicy:     policy,
+		postgrestService:  postgrestService,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *MigrationServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Migration, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return []Migration{}, flxErrors.NewForbiddenError("migration.error.listForbidden")
+	}
+
+	clientMigrationRepo, connection, err := s.getClientMigrationRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return []Migration{}, err
+	}
+	defer connection.Close()
+
+	return clientMigrationRepo.List()
+}
+
+// Create stores a hand-written migration as pending, it is executed by Apply
+func (s *MigrationServiceImpl) Create(request CreateMigrationInput, authUser auth.User) (Migration, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Migration{}, flxErrors.NewForbiddenError("migration.error.createForbidden")
+	}
+
+	clientMigrationRepo, connection, err := s.getClientMigrationRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return Migration{}, err
+	}
+	defer connection.Close()
+
+	createdMigration, err := clientMigrationRepo.Create(&Migration{
+		Name:    request.Name,
+		UpSQL:   request.UpSQL,
+		DownSQL: request.DownSQL,
+		Source:  constants.MigrationSourceUpload,
+		Status:  constants.MigrationStatusPending,
+	})
+	if err != nil {
+		return Migration{}, err
+	}
+
+	return *createdMigration, nil
+}
+
+// Apply runs pending and rolled back migrations in version order, up to and including
+// request.Version when it is set. It stops at the first migration that fails
+func (s *MigrationServiceImpl) Apply(request ApplyMigrationInput, authUser auth.User) ([]Migration, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return []Migration{}, flxErrors.NewForbiddenError("migration.error.applyForbidden")
+	}
+
+	clientMigrationRepo, connection, err := s.getClientMigrationRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return []Migration{}, err
+	}
+	defer connection.Close()
+
+	if request.Version > 0 {
+		if _, err = clientMigrationRepo.GetByVersion(request.Version); err != nil {
+			return []Migration{}, err
+		}
+	}
+
+	migrations, err := clientMigrationRepo.ListByStatus(
+		[]string{constants.MigrationStatusPending, constants.MigrationStatusRolledBack},
+		false,
+	)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	appliedMigrations := make([]Migration, 0)
+	for _, migration := range migrations {
+		if request.Version > 0 && migration.Version > request.Version {
+			break
+		}
+
+		if err = clientMigrationRepo.Apply(migration); err != nil {
+			return appliedMigrations, flxErrors.NewBadRequestError(err.Error())
+		}
+
+		migration.Status = constants.MigrationStatusApplied
+		migration.AppliedAt = null.TimeFrom(time.Now())
+		appliedMigrations = append(appliedMigrations, migration)
+	}
+
+	if len(appliedMigrations) > 0 {
+		s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+	}
+
+	return appliedMigrations, nil
+}
+
+// Rollback reverts the latest applied migrations, newest first
+func (s *MigrationServiceImpl) Rollback(request RollbackMigrationInput, authUser auth.User) ([]Migration, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return []Migration{}, flxErrors.NewForbiddenError("migration.error.rollbackForbidden")
+	}
+
+	clientMigrationRepo, connection, err := s.getClientMigrationRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return []Migration{}, err
+	}
+	defer connection.Close()
+
+	migrations, err := clientMigrationRepo.ListByStatus([]string{constants.MigrationStatusApplied}, true)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	steps := max(request.Steps, 1)
+	if len(migrations) > steps {
+		migrations = migrations[:steps]
+	}
+
+	// refuse up front instead of stopping halfway through the requested steps
+	for _, migration := range migrations {
+		if !migration.IsReversible() {
+			return []Migration{}, flxErrors.NewBadRequestError("migration.error.irreversible")
+		}
+	}
+
+	rolledBackMigrations := make([]Migration, 0)
+	for _, migration := range migrations {
+		if err = clientMigrationRepo.Rollback(migration); err != nil {
+			return rolledBackMigrations, flxErrors.NewBadRequestError(err.Error())
+		}
+
+		migration.Status = constants.MigrationStatusRolledBack
+		migration.AppliedAt = null.Time{}
+		rolledBackMigrations = append(rolledBackMigrations, migration)
+	}
+
+	if len(rolledBackMigrations) > 0 {
+		s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+	}
+
+	return rolledBackMigrations, nil
+}
+
+// Record adds an already applied change to the migration history. The change itself has
+// succeeded at this point, so a failure here is logged rather than returned
+func (s *MigrationServiceImpl) Record(connection *sqlx.DB, input RecordMigrationInput) {
+	clientMigrationRepo, _, err := s.getClientMigrationRepo("", connection)
+	if err == nil {
+		_, err = clientMigrationRepo.Create(&Migration{
+			Name:      input.Name,
+			UpSQL:     input.UpSQL,
+			DownSQL:   input.DownSQL,
+			Source:    constants.MigrationSourceAuto,
+			Status:    constants.MigrationStatusApplied,
+			AppliedAt: null.TimeFrom(time.Now()),
+		})
+	}
+
+	if err != nil {
+		log.Error().
+			Str("action", constants.ActionMigration).
+			Str("migration", input.Name).
+			Str("error", err.Error()).
+			Msg("failed to record migration")
+	}
+}
+
+// Execute runs a generated change and adds it to the migration history in the same transaction, so
+// the history never misses a change that went through
+func (s *MigrationServiceImpl) Execute(connection *sqlx.DB, input RecordMigrationInput) (Migration, error) {
+	clientMigrationRepo, _, err := s.getClientMigrationRepo("", connection)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	executedMigration, err := clientMigrationRepo.Execute(&Migration{
+		Name:    input.Name,
+		UpSQL:   input.UpSQL,
+		DownSQL: input.DownSQL,
+		Source:  constants.MigrationSourceAuto,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			return Migration{}, flxErrors.NewBadRequestError(pqErr.Message)
+		}
+
+		return Migration{}, flxErrors.NewBadRequestError(err.Error())
+	}
+
+	return *executedMigration, nil
+}
+
+func (s *MigrationServiceImpl) getClientMigrationRepo(dbName string, connection *sqlx.DB) (MigrationRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetMigrationRepo(dbName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(MigrationRepository)
+	if !ok {
+		if dbName != "" {
+			connection.Close()
+		}
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientMigrationRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
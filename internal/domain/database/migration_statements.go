@@ -0,0 +1,307 @@
+package database
+
+import (
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// The builders below describe changes made through the table, column and index services as
+// replayable up/down SQL. An empty down statement marks the change as irreversible.
+
+func quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
+}
+
+// buildColumnStatements renders column definitions exactly as the column repository applies them, foreign
+// keys and column comments follow in the second slice because they run after the columns exist
+func buildColumnStatements(columnRepo ColumnRepository, fullTableName string, columns []Column) ([]string, []string) {
+	var definitions, foreignKeys, comments []string
+	for _, column := range columns {
+		definitions = append(definitions, columnRepo.BuildColumnDefinition(column))
+
+		if foreignKey, ok := columnRepo.BuildForeignKeyConstraint(quoteTableName(fullTableName), column); ok {
+			foreignKeys = append(foreignKeys, foreignKey)
+		}
+
+		if comment, ok := columnRepo.BuildCommentStatement(quoteTableName(fullTableName), column); ok {
+			comments = append(comments, comment)
+		}
+	}
+
+	return definitions, append(foreignKeys, comments...)
+}
+
+func buildCreateTableMigration(fullTableName string, definitions, foreignKeys []string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+	up := fmt.Sprintf("CREATE TABLE %s (\n%s\n);", quoteTableName(fullTableName), strings.Join(definitions, ",\n"))
+	if len(foreignKeys) > 0 {
+		up += "\n" + strings.Join(foreignKeys, "\n")
+	}
+
+	return RecordMigrationInput{
+		Name:    "create_table_" + tableName,
+		UpSQL:   up,
+		DownSQL: fmt.Sprintf("DROP TABLE %s;", quoteTableName(fullTableName)),
+	}
+}
+
+func buildDuplicateTableMigration(sourceTableName, targetTableName string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(targetTableName)
+
+	return RecordMigrationInput{
+		Name:    "duplicate_table_" + tableName,
+		UpSQL:   fmt.Sprintf("CREATE TABLE %s AS TABLE %s;", quoteTableName(targetTableName), quoteTableName(sourceTableName)),
+		DownSQL: fmt.Sprintf("DROP TABLE %s;", quoteTableName(targetTableName)),
+	}
+}
+
+func buildRenameTableMigration(schema, oldName, newName string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name: fmt.Sprintf("rename_table_%s_to_%s", oldName, newName),
+		UpSQL: fmt.Sprintf(
+			"ALTER TABLE %s.%s RENAME TO %s;",
+			pq.QuoteIdentifier(schema), pq.QuoteIdentifier(oldName), pq.QuoteIdentifier(newName),
+		),
+		DownSQL: fmt.Sprintf(
+			"ALTER TABLE %s.%s RENAME TO %s;",
+			pq.QuoteIdentifier(schema), pq.QuoteIdentifier(newName), pq.QuoteIdentifier(oldName),
+		),
+	}
+}
+
+func buildDropTableMigration(fullTableName string, cascade bool) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	return RecordMigrationInput{
+		Name:  "drop_table_" + tableName,
+		UpSQL: buildDropTableStatement(fullTableName, cascade),
+	}
+}
+
+func buildAddColumnsMigration(fullTableName string, columnNames, definitions, foreignKeys []string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	var up, down []string
+	for i, definition := range definitions {
+		up = append(up, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quoteTableName(fullTableName), definition))
+		down = append(down, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", quoteTableName(fullTableName), pq.QuoteIdentifier(columnNames[i])))
+	}
+
+	up = append(up, foreignKeys...)
+
+	return RecordMigrationInput{
+		Name:    "add_columns_to_" + tableName,
+		UpSQL:   strings.Join(up, "\n"),
+		DownSQL: strings.Join(down, "\n"),
+	}
+}
+
+// buildAlterColumnsMigration reverts type, default and nullability changes on the way down, unless
+// columns were dropped as well or one of the type changes cannot be safely reversed
+func buildAlterColumnsMigration(fullTableName string, alterations []ColumnAlteration, dropped []Column) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	var down []string
+	reversible := len(dropped) == 0
+	for _, alteration := range alterations {
+		reverse, ok := reverseColumnAlteration(alteration)
+		if !ok {
+			reversible = false
+			continue
+		}
+
+		down = append(down, buildAlterColumnStatements(fullTableName, reverse)...)
+	}
+
+	downSQL := strings.Join(down, "\n")
+	if !reversible {
+		downSQL = ""
+	}
+
+	return RecordMigrationInput{
+		Name:    "alter_columns_in_" + tableName,
+		UpSQL:   strings.Join(buildUpdateColumnsStatements(fullTableName, alterations, dropped), "\n"),
+		DownSQL: downSQL,
+	}
+}
+
+// buildUpdateColumnsStatements returns every statement a column update runs, the same list is recorded
+func buildUpdateColumnsStatements(fullTableName string, alterations []ColumnAlteration, dropped []Column) []string {
+	var statements []string
+	for _, alteration := range alterations {
+		statements = append(statements, buildAlterColumnStatements(fullTableName, alteration)...)
+	}
+
+	for _, column := range dropped {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", quoteTableName(fullTableName), pq.QuoteIdentifier(column.Name)))
+	}
+
+	return statements
+}
+
+// buildAlterColumnStatements drops the old default before a type change, it may not be castable to the
+// new type, and sets the new default afterwards
+func buildAlterColumnStatements(fullTableName string, alteration ColumnAlteration) []string {
+	tableName := quoteTableName(fullTableName)
+	column := pq.QuoteIdentifier(alteration.Column.Name)
+	resetDefault := alteration.TypeChange != nil || alteration.defaultChanged()
+
+	var statements []string
+	if resetDefault && alteration.Previous.Default != "" {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", tableName, column))
+	}
+
+	if alteration.TypeChange != nil {
+		statements = append(statements, buildAlterColumnTypeStatement(fullTableName, *alteration.TypeChange))
+	}
+
+	if resetDefault && alteration.Column.Default != "" {
+		statements = append(statements, fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", tableName, column, alteration.Column.Default,
+		))
+	}
+
+	if alteration.notNullChanged() {
+		action := "DROP NOT NULL"
+		if alteration.Column.NotNull {
+			action = "SET NOT NULL"
+		}
+
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", tableName, column, action))
+	}
+
+	return statements
+}
+
+// reverseColumnAlteration swaps both sides of the alteration, it fails when the type has no conversion back
+func reverseColumnAlteration(alteration ColumnAlteration) (ColumnAlteration, bool) {
+	reverse := ColumnAlteration{Previous: alteration.Column, Column: alteration.Previous}
+	if alteration.TypeChange == nil {
+		return reverse, true
+	}
+
+	from, fromErr := ParseColumnType(alteration.TypeChange.Type)
+	to, toErr := ParseColumnType(alteration.Previous.Type)
+	if fromErr != nil || toErr != nil {
+		return ColumnAlteration{}, false
+	}
+
+	// the way down returns the column to the type its values came from, so narrowing is accepted here
+	using, err := planTypeChange(alteration.Column.Name, from, to, true)
+	if err != nil {
+		return ColumnAlteration{}, false
+	}
+
+	reverse.Column.Type = to.String()
+	reverse.TypeChange = &ColumnTypeChange{Name: alteration.Column.Name, Type: to.String(), Using: using}
+
+	return reverse, true
+}
+
+func buildAlterColumnTypeStatement(fullTableName string, change ColumnTypeChange) string {
+	statement := fmt.Sprintf(
+		"ALTER TABLE %s ALTER COLUMN %s TYPE %s",
+		quoteTableName(fullTableName), pq.QuoteIdentifier(change.Name), change.Type,
+	)
+
+	if change.Using != "" {
+		statement += " USING " + change.Using
+	}
+
+	return statement + ";"
+}
+
+func buildRenameColumnMigration(fullTableName, oldName, newName string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	return RecordMigrationInput{
+		Name: fmt.Sprintf("rename_column_%s_in_%s", oldName, tableName),
+		UpSQL: fmt.Sprintf(
+			"ALTER TABLE %s RENAME COLUMN %s TO %s;",
+			quoteTableName(fullTableName), pq.QuoteIdentifier(oldName), pq.QuoteIdentifier(newName),
+		),
+		DownSQL: fmt.Sprintf(
+			"ALTER TABLE %s RENAME COLUMN %s TO %s;",
+			quoteTableName(fullTableName), pq.QuoteIdentifier(newName), pq.QuoteIdentifier(oldName),
+		),
+	}
+}
+
+// buildDropColumnMigration restores the column definition on the way down, its data is not restored
+func buildDropColumnMigration(fullTableName, columnName, definition string, cascade bool) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	return RecordMigrationInput{
+		Name:    fmt.Sprintf("drop_column_%s_from_%s", columnName, tableName),
+		UpSQL:   buildDropColumnStatement(fullTableName, columnName, cascade),
+		DownSQL: fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quoteTableName(fullTableName), definition),
+	}
+}
+
+// buildCommentStatement sets the comment of a table or column, an empty description removes it
+func buildCommentStatement(objectType, object, description string) string {
+	literal := "NULL"
+	if description != "" {
+		literal = pq.QuoteLiteral(description)
+	}
+
+	return fmt.Sprintf("COMMENT ON %s %s IS %s;", objectType, object, literal)
+}
+
+func buildCommentTableMigration(fullTableName, previous, description string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	return RecordMigrationInput{
+		Name:    "comment_on_table_" + tableName,
+		UpSQL:   buildCommentStatement("TABLE", quoteTableName(fullTableName), description),
+		DownSQL: buildCommentStatement("TABLE", quoteTableName(fullTableName), previous),
+	}
+}
+
+// buildCommentColumnsMigration restores the previous column comments on the way down
+func buildCommentColumnsMigration(fullTableName string, columns []Column, previous map[string]string) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	var up, down []string
+	for _, column := range columns {
+		object := quoteTableName(fullTableName) + "." + pq.QuoteIdentifier(column.Name)
+
+		up = append(up, buildCommentStatement("COLUMN", object, column.Description))
+		down = append(down, buildCommentStatement("COLUMN", object, previous[column.Name]))
+	}
+
+	return RecordMigrationInput{
+		Name:    "comment_on_columns_in_" + tableName,
+		UpSQL:   strings.Join(up, "\n"),
+		DownSQL: strings.Join(down, "\n"),
+	}
+}
+
+func buildCreateIndexMigration(schema, indexName, indexDefinition string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_index_" + indexName,
+		UpSQL:   indexDefinition + ";",
+		DownSQL: fmt.Sprintf("DROP INDEX %s.%s;", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(indexName)),
+	}
+}
+
+func buildDropIndexMigration(schema, indexName, indexDefinition string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_index_" + indexName,
+		UpSQL:   fmt.Sprintf("DROP INDEX %s.%s;", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(indexName)),
+		DownSQL: indexDefinition + ";",
+	}
+}
+
+func mapColumnsToNames(columns []Column) []string {
+	columnNames := make([]string, len(columns))
+	for i, column := range columns {
+		columnNames[i] = column.Name
+	}
+
+	return columnNames
+}
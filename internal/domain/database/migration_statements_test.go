@@ -0,0 +1,171 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMigrationStatements_Suite(t *testing.T) {
+	t.Run("CreateTable: drops the table on the way down", func(t *testing.T) {
+		migration := buildCreateTableMigration(
+			"users",
+			[]string{`"id" serial PRIMARY KEY`, `"email" varchar(255) NOT NULL`},
+			[]string{`ALTER TABLE "public"."users" ADD CONSTRAINT fk_"org_id" FOREIGN KEY ("org_id") REFERENCES orgs(id);`},
+		)
+
+		assert.Equal(t, "create_table_users", migration.Name)
+		assert.Equal(t, "CREATE TABLE \"public\".\"users\" (\n\"id\" serial PRIMARY KEY,\n\"email\" varchar(255) NOT NULL\n);\n"+
+			`ALTER TABLE "public"."users" ADD CONSTRAINT fk_"org_id" FOREIGN KEY ("org_id") REFERENCES orgs(id);`, migration.UpSQL)
+		assert.Equal(t, `DROP TABLE "public"."users";`, migration.DownSQL)
+	})
+
+	t.Run("RenameTable: swaps names on the way down", func(t *testing.T) {
+		migration := buildRenameTableMigration("public", "users", "members")
+
+		assert.Equal(t, `ALTER TABLE "public"."users" RENAME TO "members";`, migration.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."members" RENAME TO "users";`, migration.DownSQL)
+	})
+
+	t.Run("DropTable: is irreversible", func(t *testing.T) {
+		migration := buildDropTableMigration("sales.orders", false)
+
+		assert.Equal(t, `DROP TABLE IF EXISTS "sales"."orders";`, migration.UpSQL)
+		assert.Equal(t, "", migration.DownSQL)
+	})
+
+	t.Run("AddColumns: drops each column on the way down", func(t *testing.T) {
+		migration := buildAddColumnsMigration("public.users", []string{"age", "bio"}, []string{`"age" integer`, `"bio" text`}, nil)
+
+		assert.Equal(t, "ALTER TABLE \"public\".\"users\" ADD COLUMN \"age\" integer;\nALTER TABLE \"public\".\"users\" ADD COLUMN \"bio\" text;", migration.UpSQL)
+		assert.Equal(t, "ALTER TABLE \"public\".\"users\" DROP COLUMN \"age\";\nALTER TABLE \"public\".\"users\" DROP COLUMN \"bio\";", migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: restores previous types", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.users",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "age", Type: "integer"},
+				Column:     Column{Name: "age", Type: "bigint"},
+				TypeChange: &ColumnTypeChange{Name: "age", Type: "bigint"},
+			}},
+			nil,
+		)
+
+		assert.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "age" TYPE bigint;`, migration.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "age" TYPE integer;`, migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: narrows back to the previous type on the way down", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.orders",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "placed_at", Type: "timestamp"},
+				Column:     Column{Name: "placed_at", Type: "timestamptz"},
+				TypeChange: &ColumnTypeChange{Name: "placed_at", Type: "timestamptz"},
+			}},
+			nil,
+		)
+
+		assert.Equal(t, `ALTER TABLE "public"."orders" ALTER COLUMN "placed_at" TYPE timestamp;`, migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: records default and nullability changes", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.users",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "score", Type: "integer", Default: "0"},
+				Column:     Column{Name: "score", Type: "bigint", Default: "1", NotNull: true},
+				TypeChange: &ColumnTypeChange{Name: "score", Type: "bigint"},
+			}},
+			nil,
+		)
+
+		assert.Equal(t, strings.Join([]string{
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" DROP DEFAULT;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" TYPE bigint;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" SET DEFAULT 1;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" SET NOT NULL;`,
+		}, "\n"), migration.UpSQL)
+		assert.Equal(t, strings.Join([]string{
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" DROP DEFAULT;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" TYPE integer;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" SET DEFAULT 0;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" DROP NOT NULL;`,
+		}, "\n"), migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: dropping columns makes it irreversible", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.users",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "age", Type: "integer"},
+				Column:     Column{Name: "age", Type: "bigint"},
+				TypeChange: &ColumnTypeChange{Name: "age", Type: "bigint"},
+			}},
+			[]Column{{Name: "bio", Type: "text"}},
+		)
+
+		assert.Contains(t, migration.UpSQL, `ALTER TABLE "public"."users" DROP COLUMN "bio";`)
+		assert.Equal(t, "", migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: converts with USING in both directions", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.users",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "active", Type: "integer"},
+				Column:     Column{Name: "active", Type: "boolean"},
+				TypeChange: &ColumnTypeChange{Name: "active", Type: "boolean", Using: `"active"::integer::boolean`},
+			}},
+			nil,
+		)
+
+		assert.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "active" TYPE boolean USING "active"::integer::boolean;`, migration.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "active" TYPE integer USING "active"::integer;`, migration.DownSQL)
+	})
+
+	t.Run("AlterColumns: unsafe reverse conversion makes it irreversible", func(t *testing.T) {
+		migration := buildAlterColumnsMigration(
+			"public.users",
+			[]ColumnAlteration{{
+				Previous:   Column{Name: "tags", Type: "text"},
+				Column:     Column{Name: "tags", Type: "text[]"},
+				TypeChange: &ColumnTypeChange{Name: "tags", Type: "text[]", Using: `ARRAY["tags"]::text[]`},
+			}},
+			nil,
+		)
+
+		assert.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "tags" TYPE text[] USING ARRAY["tags"]::text[];`, migration.UpSQL)
+		assert.Equal(t, "", migration.DownSQL)
+	})
+
+	t.Run("CommentTable: restores the previous description", func(t *testing.T) {
+		migration := buildCommentTableMigration("public.users", "", "People who can sign in")
+
+		assert.Equal(t, "comment_on_table_users", migration.Name)
+		assert.Equal(t, `COMMENT ON TABLE "public"."users" IS 'People who can sign in';`, migration.UpSQL)
+		assert.Equal(t, `COMMENT ON TABLE "public"."users" IS NULL;`, migration.DownSQL)
+	})
+
+	t.Run("CommentColumns: restores each previous description", func(t *testing.T) {
+		migration := buildCommentColumnsMigration(
+			"public.users",
+			[]Column{{Name: "email", Description: "Owner's address"}, {Name: "bio"}},
+			map[string]string{"bio": "Shown on the profile"},
+		)
+
+		assert.Equal(t, "COMMENT ON COLUMN \"public\".\"users\".\"email\" IS 'Owner''s address';\n"+
+			`COMMENT ON COLUMN "public"."users"."bio" IS NULL;`, migration.UpSQL)
+		assert.Equal(t, "COMMENT ON COLUMN \"public\".\"users\".\"email\" IS NULL;\n"+
+			`COMMENT ON COLUMN "public"."users"."bio" IS 'Shown on the profile';`, migration.DownSQL)
+	})
+
+	t.Run("DropIndex: recreates the index on the way down", func(t *testing.T) {
+		definition := "CREATE INDEX users_email_idx ON public.users USING btree (email)"
+		migration := buildDropIndexMigration("public", "users_email_idx", definition)
+
+		assert.Equal(t, `DROP INDEX "public"."users_email_idx";`, migration.UpSQL)
+		assert.Equal(t, definition+";", migration.DownSQL)
+	})
+}
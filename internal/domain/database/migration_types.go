@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateMigrationInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Name        string    `json:"name"`
+	UpSQL       string    `json:"up"`
+	DownSQL     string    `json:"down"`
+}
+
+type ApplyMigrationInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Version     int       `json:"version"`
+}
+
+type RollbackMigrationInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Steps       int       `json:"steps"`
+}
+
+// RecordMigrationInput describes a change that has already been applied through one of the services
+type RecordMigrationInput struct {
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
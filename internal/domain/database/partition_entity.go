@@ -0,0 +1,58 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// PartitionKey describes how a partitioned table splits its rows, expression keys only show up in Definition
+type PartitionKey struct {
+	Strategy    string         `db:"strategy" json:"strategy"`
+	Columns     pq.StringArray `db:"columns" json:"columns" swaggertype:"array,string"`
+	ColumnTypes pq.StringArray `db:"column_types" json:"columnTypes" swaggertype:"array,string"`
+	Definition  string         `db:"definition" json:"definition"`
+}
+
+type Partition struct {
+	Schema string `db:"schema" json:"schema"`
+	Name   string `db:"name" json:"name"`
+
+	// Bounds is the FOR VALUES clause, or DEFAULT for the default partition
+	Bounds        string `db:"bounds" json:"bounds"`
+	Partitioned   bool   `db:"partitioned" json:"partitioned"`
+	EstimatedRows int64  `db:"estimated_rows" json:"estimatedRows"`
+	TotalSize     string `db:"total_size" json:"totalSize"`
+}
+
+type PartitionedTable struct {
+	Schema     string           `json:"schema"`
+	Name       string           `json:"name"`
+	Key        PartitionKey     `json:"key"`
+	Partitions []Partition      `json:"partitions"`
+	Policy     *PartitionPolicy `json:"policy"`
+}
+
+// PartitionPolicy lives in the main database so one scheduler can maintain the tables of every project
+type PartitionPolicy struct {
+	ID          int        `db:"id" json:"id"`
+	ProjectUuid uuid.UUID  `db:"project_uuid" json:"projectUuid"`
+	Schema      string     `db:"schema_name" json:"schema"`
+	TableName   string     `db:"table_name" json:"tableName"`
+	Interval    string     `db:"interval_unit" json:"interval"`
+	Premake     int        `db:"premake" json:"premake"`
+	Retention   int        `db:"retention" json:"retention"`
+	NextRunAt   time.Time  `db:"next_run_at" json:"nextRunAt"`
+	LastRunAt   *time.Time `db:"last_run_at" json:"lastRunAt"`
+	LastError   string     `db:"last_error" json:"lastError"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+func (p Partition) FullName() string {
+	return p.Schema + "." + p.Name
+}
+
+func (p PartitionPolicy) FullTableName() string {
+	return p.Schema + "." + p.TableName
+}
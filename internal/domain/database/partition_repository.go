@@ -0,0 +1,20 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PartitionRepository interface {
+	GetKey(fullTableName string) (PartitionKey, error)
+	List(fullTableName string) ([]Partition, error)
+	Execute(statements ...string) error
+}
+
+type PartitionPolicyRepository interface {
+	GetByTable(projectUUID uuid.UUID, schema, tableName string) (*PartitionPolicy, error)
+	Upsert(policy PartitionPolicy) (PartitionPolicy, error)
+	Delete(projectUUID uuid.UUID, schema, tableName string) error
+	ClaimDue(limit int) ([]PartitionPolicy, error)
+	MarkRun(id int, ranAt time.Time, lastError string) error
+}
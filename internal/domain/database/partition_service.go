@@ -0,0 +1,440 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+type PartitionService interface {
+	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (PartitionedTable, error)
+	Create(fullTableName string, request CreatePartitionInput, authUser auth.User) (Partition, error)
+	Attach(fullTableName, partitionName string, request AttachPartitionInput, authUser auth.User) (Partition, error)
+	Detach(fullTableName, partitionName string, request DetachPartitionInput, authUser auth.User) (bool, error)
+	UpdatePolicy(fullTableName string, request UpdatePartitionPolicyInput, authUser auth.User) (PartitionedTable, error)
+	DeletePolicy(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	MaintainDue() (int, error)
+}
+
+type PartitionServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	policyRepo        PartitionPolicyRepository
+}
+
+func NewPartitionService(injector *do.Injector) (PartitionService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	policyRepo := do.MustInvoke[PartitionPolicyRepository](injector)
+
+	return &PartitionServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		policyRepo:        policyRepo,
+	}, nil
+}
+
+func (s *PartitionServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (PartitionedTable, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return PartitionedTable{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+	defer connection.Close()
+
+	return s.getPartitionedTable(clientPartitionRepo, projectUUID, fullTableName)
+}
+
+// Create adds a partition next to its parent table, the parent decides which bounds apply
+func (s *PartitionServiceImpl) Create(fullTableName string, request CreatePartitionInput, authUser auth.User) (Partition, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Partition{}, flxErrors.NewForbiddenError("table.error.createForbidden")
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return Partition{}, err
+	}
+	defer connection.Close()
+
+	key, err := clientPartitionRepo.GetKey(fullTableName)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	if !validatePartitionBounds(key.Strategy, request.Bounds) {
+		return Partition{}, flxErrors.NewBadRequestError("partition.error.invalidBounds")
+	}
+
+	schema, _ := pkg.ParseTableName(fullTableName)
+	partitionName := schema + "." + request.Name
+
+	clientTableRepo, err := s.getClientTableRepo(connection)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	exists, err := clientTableRepo.Exists(schema, request.Name)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	if exists {
+		return Partition{}, flxErrors.NewUnprocessableError("table.error.alreadyExists")
+	}
+
+	bounds := buildPartitionBoundsClause(key.Strategy, request.Bounds)
+	if err = clientPartitionRepo.Execute(buildCreatePartitionStatement(fullTableName, partitionName, bounds)); err != nil {
+		return Partition{}, s.toPartitionError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreatePartitionMigration(fullTableName, partitionName, bounds))
+
+	return s.getPartition(clientPartitionRepo, fullTableName, request.Name)
+}
+
+// Attach turns an existing table of the same schema into a partition. Postgres scans the table
+// to check its rows fit the bounds, unless a matching check constraint proves it
+func (s *PartitionServiceImpl) Attach(fullTableName, partitionName string, request AttachPartitionInput, authUser auth.User) (Partition, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Partition{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return Partition{}, err
+	}
+	defer connection.Close()
+
+	key, err := clientPartitionRepo.GetKey(fullTableName)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	if !validatePartitionBounds(key.Strategy, request.Bounds) {
+		return Partition{}, flxErrors.NewBadRequestError("partition.error.invalidBounds")
+	}
+
+	clientTableRepo, err := s.getClientTableRepo(connection)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	schema, _ := pkg.ParseTableName(fullTableName)
+	if _, err = clientTableRepo.GetByNameInSchema(schema, partitionName); err != nil {
+		return Partition{}, err
+	}
+
+	bounds := buildPartitionBoundsClause(key.Strategy, request.Bounds)
+	fullPartitionName := schema + "." + partitionName
+
+	if err = clientPartitionRepo.Execute(buildAttachPartitionStatement(fullTableName, fullPartitionName, bounds)); err != nil {
+		return Partition{}, s.toPartitionError(err)
+	}
+
+	s.migrationService.Record(connection, buildAttachPartitionMigration(fullTableName, fullPartitionName, bounds))
+
+	return s.getPartition(clientPartitionRepo, fullTableName, partitionName)
+}
+
+// Detach keeps the partition as a regular table. Concurrently avoids blocking queries on the
+// parent, but isn't possible while the table has a default partition
+func (s *PartitionServiceImpl) Detach(fullTableName, partitionName string, request DetachPartitionInput, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	partition, err := s.getPartition(clientPartitionRepo, fullTableName, partitionName)
+	if err != nil {
+		return false, err
+	}
+
+	statement := buildDetachPartitionStatement(fullTableName, partition.FullName(), request.Concurrently)
+	if err = clientPartitionRepo.Execute(statement); err != nil {
+		return false, s.toPartitionError(err)
+	}
+
+	s.migrationService.Record(connection, buildDetachPartitionMigration(fullTableName, partition.FullName(), partition.Bounds))
+
+	return true, nil
+}
+
+// UpdatePolicy stores the maintenance policy and runs it right away, so missing partitions exist
+// and configuration problems surface before the scheduler picks the table up
+func (s *PartitionServiceImpl) UpdatePolicy(fullTableName string, request UpdatePartitionPolicyInput, authUser auth.User) (PartitionedTable, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return PartitionedTable{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+	defer connection.Close()
+
+	key, err := clientPartitionRepo.GetKey(fullTableName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	if _, ok := partitionMaintenanceKeyType(key); !ok {
+		return PartitionedTable{}, flxErrors.NewBadRequestError("partition.error.policyUnsupported")
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	policy := PartitionPolicy{
+		ProjectUuid: request.ProjectUUID,
+		Schema:      schema,
+		TableName:   tableName,
+		Interval:    request.Interval,
+		Premake:     request.Premake,
+		Retention:   request.Retention,
+		NextRunAt:   time.Now().Add(constants.PartitionMaintenanceRunInterval),
+	}
+
+	storedPolicy, err := s.policyRepo.Upsert(policy)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	lastError := ""
+	if err = s.maintain(clientPartitionRepo, storedPolicy); err != nil {
+		lastError = err.Error()
+	}
+
+	if err = s.policyRepo.MarkRun(storedPolicy.ID, time.Now(), lastError); err != nil {
+		return PartitionedTable{}, err
+	}
+
+	return s.getPartitionedTable(clientPartitionRepo, request.ProjectUUID, fullTableName)
+}
+
+func (s *PartitionServiceImpl) DeletePolicy(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	if err = s.policyRepo.Delete(projectUUID, schema, tableName); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// MaintainDue runs the maintenance policies that are due and returns how many were claimed.
+// Failures are kept on the policy instead of stopping the remaining tables
+func (s *PartitionServiceImpl) MaintainDue() (int, error) {
+	policies, err := s.policyRepo.ClaimDue(constants.PartitionMaintenanceBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, policy := range policies {
+		lastError := ""
+		if err := s.maintainScheduled(policy); err != nil {
+			lastError = err.Error()
+
+			log.Error().
+				Str("action", constants.ActionPartition).
+				Str("project", policy.ProjectUuid.String()).
+				Str("table", policy.FullTableName()).
+				Str("error", lastError).
+				Msg("scheduled partition maintenance failed")
+		}
+
+		if err := s.policyRepo.MarkRun(policy.ID, time.Now(), lastError); err != nil {
+			return len(policies), err
+		}
+	}
+
+	return len(policies), nil
+}
+
+func (s *PartitionServiceImpl) maintainScheduled(policy PartitionPolicy) error {
+	fetchedProject, err := s.projectRepo.GetByUUID(policy.ProjectUuid)
+	if err != nil {
+		return err
+	}
+
+	clientPartitionRepo, connection, err := s.getClientPartitionRepo(fetchedProject.DBName)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	return s.maintain(clientPartitionRepo, policy)
+}
+
+// maintain creates upcoming partitions and drops expired ones. Each statement stands on its own, so
+// a partition overlapping one created by hand doesn't hold back the rest. Changes made here are
+// routine upkeep and aren't recorded as migrations
+func (s *PartitionServiceImpl) maintain(clientPartitionRepo PartitionRepository, policy PartitionPolicy) error {
+	key, err := clientPartitionRepo.GetKey(policy.FullTableName())
+	if err != nil {
+		return err
+	}
+
+	keyType, ok := partitionMaintenanceKeyType(key)
+	if !ok {
+		return flxErrors.NewBadRequestError("partition.error.policyUnsupported")
+	}
+
+	partitions, err := clientPartitionRepo.List(policy.FullTableName())
+	if err != nil {
+		return err
+	}
+
+	create, drop := planPartitionMaintenance(policy, keyType, partitions, time.Now())
+
+	var errs []error
+	for _, partition := range create {
+		statement := buildCreatePartitionStatement(policy.FullTableName(), policy.Schema+"."+partition.Name, partition.Bounds)
+		if err = clientPartitionRepo.Execute(statement); err != nil {
+			errs = append(errs, s.toPartitionError(err))
+		}
+	}
+
+	for _, partitionName := range drop {
+		if err = clientPartitionRepo.Execute(buildDropPartitionStatement(policy.Schema + "." + partitionName)); err != nil {
+			errs = append(errs, s.toPartitionError(err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *PartitionServiceImpl) getPartitionedTable(clientPartitionRepo PartitionRepository, projectUUID uuid.UUID, fullTableName string) (PartitionedTable, error) {
+	key, err := clientPartitionRepo.GetKey(fullTableName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	partitions, err := clientPartitionRepo.List(fullTableName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	policy, err := s.policyRepo.GetByTable(projectUUID, schema, tableName)
+	if err != nil {
+		return PartitionedTable{}, err
+	}
+
+	return PartitionedTable{
+		Schema:     schema,
+		Name:       tableName,
+		Key:        key,
+		Partitions: partitions,
+		Policy:     policy,
+	}, nil
+}
+
+func (s *PartitionServiceImpl) getPartition(clientPartitionRepo PartitionRepository, fullTableName, partitionName string) (Partition, error) {
+	partitions, err := clientPartitionRepo.List(fullTableName)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	for _, partition := range partitions {
+		if partition.Name == partitionName {
+			return partition, nil
+		}
+	}
+
+	return Partition{}, flxErrors.NewNotFoundError("partition.error.notFound")
+}
+
+// toPartitionError surfaces database errors such as overlapping bounds or rows outside of them as bad requests
+func (s *PartitionServiceImpl) toPartitionError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *PartitionServiceImpl) getClientPartitionRepo(dbName string) (PartitionRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetPartitionRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(PartitionRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientPartitionRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
+
+func (s *PartitionServiceImpl) getClientTableRepo(connection *sqlx.DB) (TableRepository, error) {
+	repo, _, err := s.connectionService.GetTableRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(TableRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
@@ -0,0 +1,320 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+	"time"
+)
+
+// plannedPartition is a partition the maintenance run creates, Bounds is its FOR VALUES clause
+type plannedPartition struct {
+	Name   string
+	Bounds string
+}
+
+func buildPartitionByClause(spec PartitionSpecInput) string {
+	return fmt.Sprintf("PARTITION BY %s (%s)", strings.ToUpper(spec.Strategy), quoteIdentifiers(spec.Columns))
+}
+
+// buildPartitionKeyColumns turns primary key and unique columns into table constraints that include the
+// partition columns, postgres only enforces uniqueness on partitioned tables across the whole key
+func buildPartitionKeyColumns(columns []Column, spec PartitionSpecInput) ([]Column, []string) {
+	keyed := make([]Column, len(columns))
+	var primary, constraints []string
+
+	for i, column := range columns {
+		keyed[i] = column
+
+		if column.Primary {
+			primary = append(primary, column.Name)
+			keyed[i].Primary = false
+		}
+
+		if column.Unique {
+			constraints = append(constraints, fmt.Sprintf("UNIQUE (%s)", quoteIdentifiers(withPartitionColumns([]string{column.Name}, spec.Columns))))
+			keyed[i].Unique = false
+		}
+	}
+
+	if len(primary) > 0 {
+		primaryKey := fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifiers(withPartitionColumns(primary, spec.Columns)))
+		constraints = append([]string{primaryKey}, constraints...)
+	}
+
+	return keyed, constraints
+}
+
+func withPartitionColumns(columns, partitionColumns []string) []string {
+	for _, column := range partitionColumns {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func buildCreatePartitionedTableStatement(fullTableName string, definitions []string, partitionBy string) string {
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n) %s;", quoteTableName(fullTableName), strings.Join(definitions, ",\n"), partitionBy)
+}
+
+// buildPartitionBoundsClause renders the FOR VALUES clause for the strategy of the parent table
+func buildPartitionBoundsClause(strategy string, bounds PartitionBoundsInput) string {
+	if bounds.Default {
+		return constants.PartitionBoundsDefault
+	}
+
+	switch strategy {
+	case constants.PartitionStrategyRange:
+		return fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", quotePartitionValues(bounds.From, true), quotePartitionValues(bounds.To, true))
+	case constants.PartitionStrategyList:
+		return fmt.Sprintf("FOR VALUES IN (%s)", quotePartitionValues(bounds.In, false))
+	default:
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", bounds.Modulus, bounds.Remainder)
+	}
+}
+
+// quotePartitionValues quotes bound values as literals, postgres casts them to the key type.
+// MINVALUE and MAXVALUE stay keywords in range bounds, NULL stays a keyword in list bounds
+func quotePartitionValues(values []string, rangeBound bool) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		keyword := strings.ToUpper(strings.TrimSpace(value))
+
+		switch {
+		case rangeBound && (keyword == "MINVALUE" || keyword == "MAXVALUE"):
+			quoted[i] = keyword
+		case !rangeBound && keyword == "NULL":
+			quoted[i] = keyword
+		default:
+			quoted[i] = pq.QuoteLiteral(value)
+		}
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// validatePartitionBounds makes sure the bounds fit the strategy, postgres checks values and overlaps
+func validatePartitionBounds(strategy string, bounds PartitionBoundsInput) bool {
+	if bounds.Default {
+		return strategy != constants.PartitionStrategyHash
+	}
+
+	switch strategy {
+	case constants.PartitionStrategyRange:
+		return len(bounds.From) > 0 && len(bounds.From) == len(bounds.To)
+	case constants.PartitionStrategyList:
+		return len(bounds.In) > 0
+	default:
+		return bounds.Modulus > 0 && bounds.Remainder >= 0 && bounds.Remainder < bounds.Modulus
+	}
+}
+
+func buildCreatePartitionStatement(fullTableName, partitionName, bounds string) string {
+	return fmt.Sprintf("CREATE TABLE %s PARTITION OF %s %s;", quoteTableName(partitionName), quoteTableName(fullTableName), bounds)
+}
+
+func buildAttachPartitionStatement(fullTableName, partitionName, bounds string) string {
+	return fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s %s;", quoteTableName(fullTableName), quoteTableName(partitionName), bounds)
+}
+
+func buildDetachPartitionStatement(fullTableName, partitionName string, concurrently bool) string {
+	statement := fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", quoteTableName(fullTableName), quoteTableName(partitionName))
+	if concurrently {
+		statement += " CONCURRENTLY"
+	}
+
+	return statement + ";"
+}
+
+func buildDropPartitionStatement(partitionName string) string {
+	return fmt.Sprintf("DROP TABLE %s;", quoteTableName(partitionName))
+}
+
+func buildCreatePartitionedTableMigration(fullTableName string, definitions, foreignKeys []string, partitionBy string) RecordMigrationInput {
+	migration := buildCreateTableMigration(fullTableName, definitions, foreignKeys)
+
+	migration.UpSQL = buildCreatePartitionedTableStatement(fullTableName, definitions, partitionBy)
+	if len(foreignKeys) > 0 {
+		migration.UpSQL += "\n" + strings.Join(foreignKeys, "\n")
+	}
+
+	return migration
+}
+
+func buildCreatePartitionMigration(fullTableName, partitionName, bounds string) RecordMigrationInput {
+	_, name := pkg.ParseTableName(partitionName)
+
+	return RecordMigrationInput{
+		Name:    "create_partition_" + name,
+		UpSQL:   buildCreatePartitionStatement(fullTableName, partitionName, bounds),
+		DownSQL: buildDropPartitionStatement(partitionName),
+	}
+}
+
+func buildAttachPartitionMigration(fullTableName, partitionName, bounds string) RecordMigrationInput {
+	_, name := pkg.ParseTableName(partitionName)
+
+	return RecordMigrationInput{
+		Name:    "attach_partition_" + name,
+		UpSQL:   buildAttachPartitionStatement(fullTableName, partitionName, bounds),
+		DownSQL: buildDetachPartitionStatement(fullTableName, partitionName, false),
+	}
+}
+
+// buildDetachPartitionMigration is always recorded without CONCURRENTLY, migrations are applied in a transaction
+func buildDetachPartitionMigration(fullTableName, partitionName, bounds string) RecordMigrationInput {
+	_, name := pkg.ParseTableName(partitionName)
+
+	return RecordMigrationInput{
+		Name:    "detach_partition_" + name,
+		UpSQL:   buildDetachPartitionStatement(fullTableName, partitionName, false),
+		DownSQL: buildAttachPartitionStatement(fullTableName, partitionName, bounds),
+	}
+}
+
+// partitionMaintenanceKeyType returns the key column type of tables partitioned by range on a single
+// date or timestamp column, the only tables time based maintenance can manage
+func partitionMaintenanceKeyType(key PartitionKey) (string, bool) {
+	if key.Strategy != constants.PartitionStrategyRange || len(key.Columns) != 1 || key.Columns[0] == "" {
+		return "", false
+	}
+
+	columnType, err := ParseColumnType(key.ColumnTypes[0])
+	if err != nil || columnType.Dimensions > 0 {
+		return "", false
+	}
+
+	switch columnType.Name {
+	case constants.ColumnTypeDate, constants.ColumnTypeTimestamp, constants.ColumnTypeTimestampTZ:
+		return columnType.Name, true
+	}
+
+	return "", false
+}
+
+// partitionPeriodStart truncates t to the start of its interval in UTC, weeks start on Monday
+func partitionPeriodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case constants.PartitionIntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case constants.PartitionIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func addPartitionPeriods(start time.Time, interval string, count int) time.Time {
+	switch interval {
+	case constants.PartitionIntervalWeek:
+		return start.AddDate(0, 0, 7*count)
+	case constants.PartitionIntervalMonth:
+		return start.AddDate(0, count, 0)
+	default:
+		return start.AddDate(0, 0, count)
+	}
+}
+
+func partitionNameLayout(interval string) string {
+	if interval == constants.PartitionIntervalMonth {
+		return "200601"
+	}
+
+	return "20060102"
+}
+
+// buildPartitionPeriodName names managed partitions after the table and the start of their period,
+// e.g. events_p20250106 or events_p202501. Long table names are cut to fit the identifier limit
+func buildPartitionPeriodName(tableName, interval string, start time.Time) string {
+	suffix := "_p" + start.Format(partitionNameLayout(interval))
+	if len(tableName)+len(suffix) > constants.MaxIdentifierLength {
+		tableName = tableName[:constants.MaxIdentifierLength-len(suffix)]
+	}
+
+	return tableName + suffix
+}
+
+// parsePartitionPeriodName returns the period start of a partition named by buildPartitionPeriodName,
+// partitions created by hand are never reported as managed
+func parsePartitionPeriodName(tableName, interval, partitionName string) (time.Time, bool) {
+	layout := partitionNameLayout(interval)
+
+	suffixStart := len(partitionName) - len(layout) - len("_p")
+	if suffixStart <= 0 || partitionName[suffixStart:suffixStart+2] != "_p" {
+		return time.Time{}, false
+	}
+
+	start, err := time.Parse(layout, partitionName[suffixStart+2:])
+	if err != nil || !partitionPeriodStart(start, interval).Equal(start) {
+		return time.Time{}, false
+	}
+
+	if buildPartitionPeriodName(tableName, interval, start) != partitionName {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
+
+func formatPartitionBound(t time.Time, keyType string) string {
+	switch keyType {
+	case constants.ColumnTypeDate:
+		return t.Format("2006-01-02")
+	case constants.ColumnTypeTimestampTZ:
+		return t.Format("2006-01-02 15:04:05") + "+00"
+	default:
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
+// planPartitionMaintenance lists the partitions to create for the current and the next Premake periods,
+// and the managed partitions that ended more than Retention periods before the current one
+func planPartitionMaintenance(policy PartitionPolicy, keyType string, partitions []Partition, now time.Time) ([]plannedPartition, []string) {
+	existing := make(map[string]bool, len(partitions))
+	for _, partition := range partitions {
+		existing[partition.Name] = true
+	}
+
+	current := partitionPeriodStart(now, policy.Interval)
+
+	var create []plannedPartition
+	for i := 0; i <= policy.Premake; i++ {
+		start := addPartitionPeriods(current, policy.Interval, i)
+		name := buildPartitionPeriodName(policy.TableName, policy.Interval, start)
+		if existing[name] {
+			continue
+		}
+
+		create = append(create, plannedPartition{
+			Name: name,
+			Bounds: buildPartitionBoundsClause(constants.PartitionStrategyRange, PartitionBoundsInput{
+				From: []string{formatPartitionBound(start, keyType)},
+				To:   []string{formatPartitionBound(addPartitionPeriods(start, policy.Interval, 1), keyType)},
+			}),
+		})
+	}
+
+	if policy.Retention == 0 {
+		return create, nil
+	}
+
+	cutoff := addPartitionPeriods(current, policy.Interval, -policy.Retention)
+
+	var drop []string
+	for _, partition := range partitions {
+		start, ok := parsePartitionPeriodName(policy.TableName, policy.Interval, partition.Name)
+		if ok && start.Before(cutoff) {
+			drop = append(drop, partition.Name)
+		}
+	}
+
+	return create, drop
+}
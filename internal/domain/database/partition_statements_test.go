@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPartitionStatements_Suite(t *testing.T) {
+	t.Run("partition by clause", func(t *testing.T) {
+		spec := PartitionSpecInput{Strategy: constants.PartitionStrategyRange, Columns: []string{"created_at"}}
+
+		assert.Equal(t, `PARTITION BY RANGE ("created_at")`, buildPartitionByClause(spec))
+	})
+
+	t.Run("key columns move into table constraints", func(t *testing.T) {
+		spec := PartitionSpecInput{Strategy: constants.PartitionStrategyRange, Columns: []string{"created_at"}}
+		columns := []Column{
+			{Name: "id", Type: "serial", Primary: true},
+			{Name: "email", Type: "varchar", Unique: true},
+			{Name: "created_at", Type: "timestamptz"},
+		}
+
+		keyed, constraints := buildPartitionKeyColumns(columns, spec)
+
+		assert.False(t, keyed[0].Primary)
+		assert.False(t, keyed[1].Unique)
+		assert.True(t, columns[0].Primary)
+		assert.Equal(t, []string{
+			`PRIMARY KEY ("id", "created_at")`,
+			`UNIQUE ("email", "created_at")`,
+		}, constraints)
+	})
+
+	t.Run("bounds clauses", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`FOR VALUES FROM ('2025-01-01') TO (MAXVALUE)`,
+			buildPartitionBoundsClause(constants.PartitionStrategyRange, PartitionBoundsInput{From: []string{"2025-01-01"}, To: []string{"maxvalue"}}),
+		)
+		assert.Equal(
+			t,
+			`FOR VALUES IN ('eu', 'it''s', NULL)`,
+			buildPartitionBoundsClause(constants.PartitionStrategyList, PartitionBoundsInput{In: []string{"eu", "it's", "null"}}),
+		)
+		assert.Equal(
+			t,
+			`FOR VALUES WITH (MODULUS 4, REMAINDER 1)`,
+			buildPartitionBoundsClause(constants.PartitionStrategyHash, PartitionBoundsInput{Modulus: 4, Remainder: 1}),
+		)
+		assert.Equal(t, "DEFAULT", buildPartitionBoundsClause(constants.PartitionStrategyList, PartitionBoundsInput{Default: true}))
+	})
+
+	t.Run("bounds must fit the strategy", func(t *testing.T) {
+		assert.True(t, validatePartitionBounds(constants.PartitionStrategyRange, PartitionBoundsInput{From: []string{"1"}, To: []string{"10"}}))
+		assert.False(t, validatePartitionBounds(constants.PartitionStrategyRange, PartitionBoundsInput{In: []string{"1"}}))
+		assert.True(t, validatePartitionBounds(constants.PartitionStrategyList, PartitionBoundsInput{In: []string{"eu"}}))
+		assert.False(t, validatePartitionBounds(constants.PartitionStrategyHash, PartitionBoundsInput{Modulus: 4, Remainder: 4}))
+		assert.False(t, validatePartitionBounds(constants.PartitionStrategyHash, PartitionBoundsInput{Default: true}))
+		assert.True(t, validatePartitionBounds(constants.PartitionStrategyRange, PartitionBoundsInput{Default: true}))
+	})
+
+	t.Run("attach and detach migrations", func(t *testing.T) {
+		bounds := `FOR VALUES IN ('eu')`
+
+		attach := buildAttachPartitionMigration("public.orders", "public.orders_eu", bounds)
+		assert.Equal(t, "attach_partition_orders_eu", attach.Name)
+		assert.Equal(t, `ALTER TABLE "public"."orders" ATTACH PARTITION "public"."orders_eu" FOR VALUES IN ('eu');`, attach.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."orders" DETACH PARTITION "public"."orders_eu";`, attach.DownSQL)
+
+		detach := buildDetachPartitionMigration("public.orders", "public.orders_eu", bounds)
+		assert.Equal(t, attach.DownSQL, detach.UpSQL)
+		assert.Equal(t, attach.UpSQL, detach.DownSQL)
+
+		assert.Equal(
+			t,
+			`ALTER TABLE "public"."orders" DETACH PARTITION "public"."orders_eu" CONCURRENTLY;`,
+			buildDetachPartitionStatement("public.orders", "public.orders_eu", true),
+		)
+	})
+}
+
+func TestPartitionMaintenanceKeyType(t *testing.T) {
+	keyType, ok := partitionMaintenanceKeyType(PartitionKey{
+		Strategy:    constants.PartitionStrategyRange,
+		Columns:     []string{"created_at"},
+		ColumnTypes: []string{"timestamp with time zone"},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, constants.ColumnTypeTimestampTZ, keyType)
+
+	_, ok = partitionMaintenanceKeyType(PartitionKey{
+		Strategy:    constants.PartitionStrategyRange,
+		Columns:     []string{"id"},
+		ColumnTypes: []string{"bigint"},
+	})
+	assert.False(t, ok)
+
+	_, ok = partitionMaintenanceKeyType(PartitionKey{
+		Strategy:    constants.PartitionStrategyList,
+		Columns:     []string{"day"},
+		ColumnTypes: []string{"date"},
+	})
+	assert.False(t, ok)
+}
+
+func TestPartitionPeriodNames(t *testing.T) {
+	thursday := time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), partitionPeriodStart(thursday, constants.PartitionIntervalWeek))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), partitionPeriodStart(thursday, constants.PartitionIntervalMonth))
+
+	name := buildPartitionPeriodName("events", constants.PartitionIntervalMonth, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "events_p202501", name)
+
+	start, ok := parsePartitionPeriodName("events", constants.PartitionIntervalMonth, name)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), start)
+
+	_, ok = parsePartitionPeriodName("events", constants.PartitionIntervalMonth, "events_archive")
+	assert.False(t, ok)
+
+	_, ok = parsePartitionPeriodName("events", constants.PartitionIntervalWeek, "events_p20250107")
+	assert.False(t, ok, "a tuesday never starts a week")
+
+	long := buildPartitionPeriodName(strings.Repeat("a", 70), constants.PartitionIntervalDay, thursday)
+	assert.Len(t, long, constants.MaxIdentifierLength)
+	assert.True(t, strings.HasSuffix(long, "_p20250109"))
+}
+
+func TestPlanPartitionMaintenance(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	policy := PartitionPolicy{TableName: "events", Interval: constants.PartitionIntervalMonth, Premake: 2, Retention: 1}
+	partitions := []Partition{
+		{Schema: "public", Name: "events_p202501"},
+		{Schema: "public", Name: "events_p202502"},
+		{Schema: "public", Name: "events_p202503"},
+		{Schema: "public", Name: "events_legacy"},
+	}
+
+	create, drop := planPartitionMaintenance(policy, constants.ColumnTypeDate, partitions, now)
+
+	assert.Equal(t, []plannedPartition{
+		{Name: "events_p202504", Bounds: `FOR VALUES FROM ('2025-04-01') TO ('2025-05-01')`},
+		{Name: "events_p202505", Bounds: `FOR VALUES FROM ('2025-05-01') TO ('2025-06-01')`},
+	}, create)
+	assert.Equal(t, []string{"events_p202501"}, drop)
+
+	policy.Retention = 0
+	_, drop = planPartitionMaintenance(policy, constants.ColumnTypeDate, partitions, now)
+	assert.Empty(t, drop)
+
+	create, _ = planPartitionMaintenance(PartitionPolicy{TableName: "events", Interval: constants.PartitionIntervalDay}, constants.ColumnTypeTimestampTZ, nil, now)
+	assert.Equal(t, []plannedPartition{
+		{Name: "events_p20250315", Bounds: `FOR VALUES FROM ('2025-03-15 00:00:00+00') TO ('2025-03-16 00:00:00+00')`},
+	}, create)
+}
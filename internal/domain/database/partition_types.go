@@ -0,0 +1,44 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type PartitionSpecInput struct {
+	Strategy string   `json:"strategy"`
+	Columns  []string `json:"columns"`
+}
+
+// PartitionBoundsInput holds the bounds of one strategy: From and To for range, In for list,
+// Modulus and Remainder for hash. Default partitions take rows no other partition accepts
+type PartitionBoundsInput struct {
+	Default   bool     `json:"default"`
+	From      []string `json:"from"`
+	To        []string `json:"to"`
+	In        []string `json:"in"`
+	Modulus   int      `json:"modulus"`
+	Remainder int      `json:"remainder"`
+}
+
+type CreatePartitionInput struct {
+	ProjectUUID uuid.UUID            `json:"projectUUID,omitempty"`
+	Name        string               `json:"name"`
+	Bounds      PartitionBoundsInput `json:"bounds"`
+}
+
+type AttachPartitionInput struct {
+	ProjectUUID uuid.UUID            `json:"projectUUID,omitempty"`
+	Bounds      PartitionBoundsInput `json:"bounds"`
+}
+
+type DetachPartitionInput struct {
+	ProjectUUID  uuid.UUID `json:"projectUUID,omitempty"`
+	Concurrently bool      `json:"concurrently"`
+}
+
+type UpdatePartitionPolicyInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Interval    string    `json:"interval"`
+	Premake     int       `json:"premake"`
+	Retention   int       `json:"retention"`
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+type Policy struct {
+	Name       string         `db:"name" json:"name"`
+	Command    string         `db:"command" json:"command"`
+	Permissive bool           `db:"permissive" json:"permissive"`
+	Roles      pq.StringArray `db:"roles" json:"roles" swaggertype:"array,string"`
+	Using      string         `db:"using" json:"using"`
+	WithCheck  string         `db:"with_check" json:"withCheck"`
+}
+
+type RowLevelSecurity struct {
+	Enabled bool `db:"enabled" json:"enabled"`
+
+	// forced row level security also applies to the table owner
+	Forced bool `db:"forced" json:"forced"`
+}
+
+// PolicyTestResult holds the rows a role could see or change, the transaction is always rolled back
+type PolicyTestResult struct {
+	Role      string          `json:"role"`
+	Columns   []ResultColumn  `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	RowCount  int             `json:"rowCount"`
+	Truncated bool            `json:"truncated"`
+}
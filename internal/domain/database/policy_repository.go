@@ -0,0 +1,19 @@
+package database
+
+import (
+	"context"
+)
+
+type PolicyRepository interface {
+	List(fullTableName string) ([]Policy, error)
+	GetByName(fullTableName, policyName string) (Policy, error)
+	Has(fullTableName, policyName string) (bool, error)
+	Create(policySQL string) error
+	Replace(dropSQL, createSQL string) error
+	Drop(policySQL string) error
+	GetRowLevelSecurity(fullTableName string) (RowLevelSecurity, error)
+	SetRowLevelSecurity(statements []string) error
+	HasRole(role string) (bool, error)
+	IsTestableRole(role, marker string) (bool, error)
+	Test(ctx context.Context, statement string, options PolicyTestOptions) (PolicyTestResult, error)
+}
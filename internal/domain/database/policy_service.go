@@ -0,0 +1,402 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"strings"
+)
+
+type PolicyService interface {
+	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Policy, error)
+	GetByName(policyName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Policy, error)
+	Create(fullTableName string, request CreatePolicyInput, authUser auth.User) (Policy, error)
+	Update(policyName, fullTableName string, request UpdatePolicyInput, authUser auth.User) (Policy, error)
+	Delete(policyName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	GetRowLevelSecurity(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (RowLevelSecurity, error)
+	UpdateRowLevelSecurity(fullTableName string, request UpdateRowLevelSecurityInput, authUser auth.User) (RowLevelSecurity, error)
+	Test(ctx context.Context, fullTableName string, request TestPolicyInput, authUser auth.User) (PolicyTestResult, error)
+}
+
+type PolicyServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewPolicyService(injector *do.Injector) (PolicyService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &PolicyServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *PolicyServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Policy, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	policies, err := clientPolicyRepo.List(fullTableName)
+	if err != nil {
+		return nil, s.toPolicyError(err)
+	}
+
+	return policies, nil
+}
+
+func (s *PolicyServiceImpl) GetByName(policyName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Policy, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Policy{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return Policy{}, err
+	}
+	defer connection.Close()
+
+	policy, err := clientPolicyRepo.GetByName(fullTableName, policyName)
+	if err != nil {
+		return Policy{}, s.toPolicyError(err)
+	}
+
+	return policy, nil
+}
+
+// Create adds a policy. Policies have no effect until row level security is enabled on the table
+func (s *PolicyServiceImpl) Create(fullTableName string, request CreatePolicyInput, authUser auth.User) (Policy, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Policy{}, flxErrors.NewForbiddenError("table.error.createForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return Policy{}, err
+	}
+	defer connection.Close()
+
+	hasPolicy, err := clientPolicyRepo.Has(fullTableName, request.Name)
+	if err != nil {
+		return Policy{}, s.toPolicyError(err)
+	}
+
+	if hasPolicy {
+		return Policy{}, flxErrors.NewUnprocessableError("policy.error.alreadyExists")
+	}
+
+	if err = s.validateRoles(clientPolicyRepo, request.Roles); err != nil {
+		return Policy{}, err
+	}
+
+	if err = clientPolicyRepo.Create(buildCreatePolicyStatement(fullTableName, request)); err != nil {
+		return Policy{}, s.toPolicyError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreatePolicyMigration(fullTableName, request))
+
+	return clientPolicyRepo.GetByName(fullTableName, request.Name)
+}
+
+// Update replaces a policy in one transaction, which unlike ALTER POLICY can also change its command
+func (s *PolicyServiceImpl) Update(policyName, fullTableName string, request UpdatePolicyInput, authUser auth.User) (Policy, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Policy{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return Policy{}, err
+	}
+	defer connection.Close()
+
+	previous, err := clientPolicyRepo.GetByName(fullTableName, policyName)
+	if err != nil {
+		return Policy{}, s.toPolicyError(err)
+	}
+
+	if err = s.validateRoles(clientPolicyRepo, request.Roles); err != nil {
+		return Policy{}, err
+	}
+
+	input := CreatePolicyInput{
+		ProjectUUID: request.ProjectUUID,
+		Name:        policyName,
+		Command:     request.Command,
+		Restrictive: request.Restrictive,
+		Roles:       request.Roles,
+		Using:       request.Using,
+		WithCheck:   request.WithCheck,
+	}
+
+	err = clientPolicyRepo.Replace(buildDropPolicyStatement(fullTableName, policyName), buildCreatePolicyStatement(fullTableName, input))
+	if err != nil {
+		return Policy{}, s.toPolicyError(err)
+	}
+
+	s.migrationService.Record(connection, buildReplacePolicyMigration(fullTableName, previous, input))
+
+	return clientPolicyRepo.GetByName(fullTableName, policyName)
+}
+
+func (s *PolicyServiceImpl) Delete(policyName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	policy, err := clientPolicyRepo.GetByName(fullTableName, policyName)
+	if err != nil {
+		return false, s.toPolicyError(err)
+	}
+
+	if err = clientPolicyRepo.Drop(buildDropPolicyStatement(fullTableName, policyName)); err != nil {
+		return false, s.toPolicyError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropPolicyMigration(fullTableName, policy))
+
+	return true, nil
+}
+
+func (s *PolicyServiceImpl) GetRowLevelSecurity(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (RowLevelSecurity, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return RowLevelSecurity{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return RowLevelSecurity{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return RowLevelSecurity{}, err
+	}
+	defer connection.Close()
+
+	return clientPolicyRepo.GetRowLevelSecurity(fullTableName)
+}
+
+// UpdateRowLevelSecurity enables or forces row level security. Once enabled, roles without a
+// matching policy see no rows at all
+func (s *PolicyServiceImpl) UpdateRowLevelSecurity(fullTableName string, request UpdateRowLevelSecurityInput, authUser auth.User) (RowLevelSecurity, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return RowLevelSecurity{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return RowLevelSecurity{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientPolicyRepo, connection, err := s.getClientPolicyRepo(fetchedProject.DBName, nil)
+	if err != nil {
+		return RowLevelSecurity{}, err
+	}
+	defer connection.Close()
+
+	current, err := clientPolicyRepo.GetRowLevelSecurity(fullTableName)
+	if err != nil {
+		return RowLevelSecurity{}, err
+	}
+
+	desired := RowLevelSecurity{Enabled: request.Enabled, Forced: request.Forced}
+
+	statements := buildRowLevelSecurityStatements(fullTableName, current, desired)
+	if len(statements) == 0 {
+		return current, nil
+	}
+
+	if err = clientPolicyRepo.SetRowLevelSecurity(statements); err != nil {
+		return RowLevelSecurity{}, s.toPolicyError(err)
+	}
+
+	s.migrationService.Record(connection, buildRowLevelSecurityMigration(fullTableName, current, desired))
+
+	return desired, nil
+}
+
+// Test runs a query as the given role with the given JWT claims, the way PostgREST would for an
+// end user, and returns what that role can see. Nothing the query changes is kept. The query runs
+// on an authenticator connection, a login without superuser, so it can't reach past the roles
+// PostgREST itself switches to
+func (s *PolicyServiceImpl) Test(ctx context.Context, fullTableName string, request TestPolicyInput, authUser auth.User) (PolicyTestResult, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return PolicyTestResult{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return PolicyTestResult{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	if err = validateTestPolicyQuery(request.Query); err != nil {
+		return PolicyTestResult{}, err
+	}
+
+	connection, err := s.connectionService.ConnectAuthenticatorByDatabaseName(fetchedProject.DBName)
+	if err != nil {
+		return PolicyTestResult{}, err
+	}
+	defer connection.Close()
+
+	clientPolicyRepo, _, err := s.getClientPolicyRepo("", connection)
+	if err != nil {
+		return PolicyTestResult{}, err
+	}
+
+	testable, err := clientPolicyRepo.IsTestableRole(request.Role, constants.RoleProjectMarker+fetchedProject.DBName)
+	if err != nil {
+		return PolicyTestResult{}, err
+	}
+
+	if !testable {
+		return PolicyTestResult{}, flxErrors.NewBadRequestError("policy.error.roleNotTestable")
+	}
+
+	claims, err := s.buildClaims(request)
+	if err != nil {
+		return PolicyTestResult{}, err
+	}
+
+	maxRows := request.MaxRows
+	if maxRows <= 0 {
+		maxRows = constants.DefaultQueryMaxRows
+	}
+
+	result, err := clientPolicyRepo.Test(ctx, buildTestPolicyStatement(fullTableName, request.Query), PolicyTestOptions{
+		Role:        request.Role,
+		Claims:      claims,
+		ReadOnly:    !authUser.IsDeveloperOrMore(),
+		TimeoutInMs: constants.DefaultQueryTimeoutInMs,
+		MaxRows:     min(maxRows, constants.MaxQueryRows),
+	})
+	if err != nil {
+		return PolicyTestResult{}, s.toPolicyError(err)
+	}
+
+	return result, nil
+}
+
+// buildClaims adds the role claim PostgREST always sets, unless the claims already carry one
+func (s *PolicyServiceImpl) buildClaims(request TestPolicyInput) (string, error) {
+	claims := make(map[string]interface{}, len(request.Claims)+1)
+	for key, value := range request.Claims {
+		claims[key] = value
+	}
+
+	if _, ok := claims["role"]; !ok {
+		claims["role"] = request.Role
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return "", flxErrors.NewBadRequestError("policy.error.invalidClaims")
+	}
+
+	return string(encoded), nil
+}
+
+func (s *PolicyServiceImpl) validateRoles(clientPolicyRepo PolicyRepository, roles []string) error {
+	for _, role := range roles {
+		if strings.EqualFold(role, constants.PolicyRolePublic) {
+			continue
+		}
+
+		exists, err := clientPolicyRepo.HasRole(role)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return flxErrors.NewBadRequestError(fmt.Sprintf("role '%s' does not exist", role))
+		}
+	}
+
+	return nil
+}
+
+// toPolicyError surfaces database errors such as invalid expressions or denied access as bad requests
+func (s *PolicyServiceImpl) toPolicyError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return flxErrors.NewBadRequestError("query.error.cancelled")
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *PolicyServiceImpl) getClientPolicyRepo(dbName string, connection *sqlx.DB) (PolicyRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetPolicyRepo(dbName, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(PolicyRepository)
+	if !ok {
+		if dbName != "" {
+			connection.Close()
+		}
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientPolicyRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func buildCreatePolicyStatement(fullTableName string, input CreatePolicyInput) string {
+	kind := "PERMISSIVE"
+	if input.Restrictive {
+		kind = "RESTRICTIVE"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(
+		"CREATE POLICY %s ON %s AS %s FOR %s TO %s",
+		pq.QuoteIdentifier(input.Name),
+		quoteTableName(fullTableName),
+		kind,
+		input.Command,
+		quotePolicyRoles(input.Roles),
+	))
+
+	if input.Using != "" {
+		builder.WriteString(fmt.Sprintf(" USING (%s)", input.Using))
+	}
+
+	if input.WithCheck != "" {
+		builder.WriteString(fmt.Sprintf(" WITH CHECK (%s)", input.WithCheck))
+	}
+
+	builder.WriteString(";")
+
+	return builder.String()
+}
+
+func buildDropPolicyStatement(fullTableName, policyName string) string {
+	return fmt.Sprintf("DROP POLICY %s ON %s;", pq.QuoteIdentifier(policyName), quoteTableName(fullTableName))
+}
+
+// buildRowLevelSecurityStatements only touches the flags that change
+func buildRowLevelSecurityStatements(fullTableName string, current, desired RowLevelSecurity) []string {
+	var statements []string
+
+	if current.Enabled != desired.Enabled {
+		action := "DISABLE"
+		if desired.Enabled {
+			action = "ENABLE"
+		}
+
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s %s ROW LEVEL SECURITY;", quoteTableName(fullTableName), action))
+	}
+
+	if current.Forced != desired.Forced {
+		action := "NO FORCE"
+		if desired.Forced {
+			action = "FORCE"
+		}
+
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s %s ROW LEVEL SECURITY;", quoteTableName(fullTableName), action))
+	}
+
+	return statements
+}
+
+func buildCreatePolicyMigration(fullTableName string, input CreatePolicyInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_policy_" + input.Name,
+		UpSQL:   buildCreatePolicyStatement(fullTableName, input),
+		DownSQL: buildDropPolicyStatement(fullTableName, input.Name),
+	}
+}
+
+func buildReplacePolicyMigration(fullTableName string, previous Policy, input CreatePolicyInput) RecordMigrationInput {
+	dropSQL := buildDropPolicyStatement(fullTableName, previous.Name)
+
+	return RecordMigrationInput{
+		Name:    "replace_policy_" + previous.Name,
+		UpSQL:   dropSQL + "\n" + buildCreatePolicyStatement(fullTableName, input),
+		DownSQL: dropSQL + "\n" + buildCreatePolicyStatement(fullTableName, policyToInput(previous)),
+	}
+}
+
+func buildDropPolicyMigration(fullTableName string, policy Policy) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_policy_" + policy.Name,
+		UpSQL:   buildDropPolicyStatement(fullTableName, policy.Name),
+		DownSQL: buildCreatePolicyStatement(fullTableName, policyToInput(policy)),
+	}
+}
+
+func buildRowLevelSecurityMigration(fullTableName string, current, desired RowLevelSecurity) RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	return RecordMigrationInput{
+		Name:    "row_level_security_" + tableName,
+		UpSQL:   strings.Join(buildRowLevelSecurityStatements(fullTableName, current, desired), "\n"),
+		DownSQL: strings.Join(buildRowLevelSecurityStatements(fullTableName, desired, current), "\n"),
+	}
+}
+
+// validateTestPolicyQuery allows a single statement that keeps the role under test and the
+// transaction that is rolled back. It only guards against mistakes, the authenticator connection
+// the test runs on is what keeps the query away from other roles
+func validateTestPolicyQuery(query string) error {
+	statements := pkg.SplitStatements(query)
+	if len(statements) > 1 {
+		return flxErrors.NewBadRequestError("policy.error.multipleStatements")
+	}
+
+	if len(statements) == 1 && pkg.IsSessionStatement(statements[0]) {
+		return flxErrors.NewBadRequestError("policy.error.sessionStatement")
+	}
+
+	return nil
+}
+
+// buildTestPolicyStatement selects every row of the table when no query is given
+func buildTestPolicyStatement(fullTableName, query string) string {
+	if query != "" {
+		return query
+	}
+
+	return "SELECT * FROM " + quoteTableName(fullTableName)
+}
+
+func policyToInput(policy Policy) CreatePolicyInput {
+	return CreatePolicyInput{
+		Name:        policy.Name,
+		Command:     policy.Command,
+		Restrictive: !policy.Permissive,
+		Roles:       policy.Roles,
+		Using:       policy.Using,
+		WithCheck:   policy.WithCheck,
+	}
+}
+
+// quotePolicyRoles keeps public as the keyword that applies a policy to every role
+func quotePolicyRoles(roles []string) string {
+	if len(roles) == 0 {
+		return "PUBLIC"
+	}
+
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		if strings.EqualFold(role, constants.PolicyRolePublic) {
+			quoted[i] = "PUBLIC"
+
+			continue
+		}
+
+		quoted[i] = pq.QuoteIdentifier(role)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPolicyStatements_Suite(t *testing.T) {
+	t.Run("create permissive policy for roles", func(t *testing.T) {
+		migration := buildCreatePolicyMigration("public.orders", CreatePolicyInput{
+			Name:      "orders_owner",
+			Command:   constants.PolicyCommandUpdate,
+			Roles:     []string{"authenticated", "api_user"},
+			Using:     "owner_id = current_setting('request.jwt.claims', true)::json->>'sub'",
+			WithCheck: "total >= 0",
+		})
+
+		assert.Equal(t, "create_policy_orders_owner", migration.Name)
+		assert.Equal(
+			t,
+			`CREATE POLICY "orders_owner" ON "public"."orders" AS PERMISSIVE FOR UPDATE TO "authenticated", "api_user" `+
+				`USING (owner_id = current_setting('request.jwt.claims', true)::json->>'sub') WITH CHECK (total >= 0);`,
+			migration.UpSQL,
+		)
+		assert.Equal(t, `DROP POLICY "orders_owner" ON "public"."orders";`, migration.DownSQL)
+	})
+
+	t.Run("create restrictive policy for public", func(t *testing.T) {
+		statement := buildCreatePolicyStatement("orders", CreatePolicyInput{
+			Name:        "orders_not_archived",
+			Command:     constants.PolicyCommandAll,
+			Restrictive: true,
+			Roles:       []string{"public"},
+			Using:       "NOT archived",
+		})
+
+		assert.Equal(
+			t,
+			`CREATE POLICY "orders_not_archived" ON "public"."orders" AS RESTRICTIVE FOR ALL TO PUBLIC USING (NOT archived);`,
+			statement,
+		)
+	})
+
+	t.Run("row level security only changes what differs", func(t *testing.T) {
+		statements := buildRowLevelSecurityStatements(
+			"public.orders",
+			RowLevelSecurity{Enabled: true, Forced: false},
+			RowLevelSecurity{Enabled: true, Forced: true},
+		)
+
+		assert.Equal(t, []string{`ALTER TABLE "public"."orders" FORCE ROW LEVEL SECURITY;`}, statements)
+		assert.Empty(t, buildRowLevelSecurityStatements("public.orders", RowLevelSecurity{Enabled: true}, RowLevelSecurity{Enabled: true}))
+	})
+
+	t.Run("row level security migration reverts both flags", func(t *testing.T) {
+		migration := buildRowLevelSecurityMigration(
+			"public.orders",
+			RowLevelSecurity{},
+			RowLevelSecurity{Enabled: true, Forced: true},
+		)
+
+		assert.Equal(t, "row_level_security_orders", migration.Name)
+		assert.Equal(
+			t,
+			"ALTER TABLE \"public\".\"orders\" ENABLE ROW LEVEL SECURITY;\nALTER TABLE \"public\".\"orders\" FORCE ROW LEVEL SECURITY;",
+			migration.UpSQL,
+		)
+		assert.Equal(
+			t,
+			"ALTER TABLE \"public\".\"orders\" DISABLE ROW LEVEL SECURITY;\nALTER TABLE \"public\".\"orders\" NO FORCE ROW LEVEL SECURITY;",
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("replace restores the previous policy on down", func(t *testing.T) {
+		previous := Policy{
+			Name:       "orders_read",
+			Command:    constants.PolicyCommandSelect,
+			Permissive: true,
+			Roles:      []string{"public"},
+			Using:      "true",
+		}
+
+		migration := buildReplacePolicyMigration("public.orders", previous, CreatePolicyInput{
+			Name:    "orders_read",
+			Command: constants.PolicyCommandSelect,
+			Roles:   []string{"authenticated"},
+			Using:   "published",
+		})
+
+		assert.Equal(t, "replace_policy_orders_read", migration.Name)
+		assert.Equal(
+			t,
+			"DROP POLICY \"orders_read\" ON \"public\".\"orders\";\n"+
+				`CREATE POLICY "orders_read" ON "public"."orders" AS PERMISSIVE FOR SELECT TO "authenticated" USING (published);`,
+			migration.UpSQL,
+		)
+		assert.Equal(
+			t,
+			"DROP POLICY \"orders_read\" ON \"public\".\"orders\";\n"+
+				`CREATE POLICY "orders_read" ON "public"."orders" AS PERMISSIVE FOR SELECT TO PUBLIC USING (true);`,
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("drop recreates restrictive policy", func(t *testing.T) {
+		migration := buildDropPolicyMigration("public.orders", Policy{
+			Name:      "orders_insert",
+			Command:   constants.PolicyCommandInsert,
+			Roles:     []string{"authenticated"},
+			WithCheck: "total > 0",
+		})
+
+		assert.Equal(t, `DROP POLICY "orders_insert" ON "public"."orders";`, migration.UpSQL)
+		assert.Equal(
+			t,
+			`CREATE POLICY "orders_insert" ON "public"."orders" AS RESTRICTIVE FOR INSERT TO "authenticated" WITH CHECK (total > 0);`,
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("test statement defaults to the whole table", func(t *testing.T) {
+		assert.Equal(t, `SELECT * FROM "public"."orders"`, buildTestPolicyStatement("orders", ""))
+		assert.Equal(t, "SELECT id FROM orders", buildTestPolicyStatement("orders", "SELECT id FROM orders"))
+	})
+
+	t.Run("test query keeps the role and the transaction", func(t *testing.T) {
+		assert.NoError(t, validateTestPolicyQuery(""))
+		assert.NoError(t, validateTestPolicyQuery("SELECT id FROM orders WHERE note = 'a;b';"))
+
+		assert.Equal(t, flxErrors.NewBadRequestError("policy.error.multipleStatements"), validateTestPolicyQuery("RESET ROLE; SELECT * FROM orders"))
+		assert.Equal(t, flxErrors.NewBadRequestError("policy.error.multipleStatements"), validateTestPolicyQuery("COMMIT; DELETE FROM orders"))
+
+		for _, query := range []string{"SET ROLE postgres", "SET SESSION AUTHORIZATION postgres", "COMMIT"} {
+			assert.Equal(t, flxErrors.NewBadRequestError("policy.error.sessionStatement"), validateTestPolicyQuery(query), query)
+		}
+	})
+}
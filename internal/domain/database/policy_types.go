@@ -0,0 +1,49 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreatePolicyInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Name        string    `json:"name"`
+	Command     string    `json:"command"`
+	Restrictive bool      `json:"restrictive"`
+	Roles       []string  `json:"roles"`
+	Using       string    `json:"using"`
+	WithCheck   string    `json:"withCheck"`
+}
+
+// UpdatePolicyInput replaces every part of a policy except its name
+type UpdatePolicyInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Command     string    `json:"command"`
+	Restrictive bool      `json:"restrictive"`
+	Roles       []string  `json:"roles"`
+	Using       string    `json:"using"`
+	WithCheck   string    `json:"withCheck"`
+}
+
+type UpdateRowLevelSecurityInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Enabled     bool      `json:"enabled"`
+	Forced      bool      `json:"forced"`
+}
+
+type TestPolicyInput struct {
+	ProjectUUID uuid.UUID              `json:"projectUUID,omitempty"`
+	Role        string                 `json:"role"`
+	Claims      map[string]interface{} `json:"claims"`
+
+	// defaults to selecting every row of the table
+	Query   string `json:"query"`
+	MaxRows int    `json:"maxRows"`
+}
+
+type PolicyTestOptions struct {
+	Role        string
+	Claims      string
+	ReadOnly    bool
+	TimeoutInMs int
+	MaxRows     int
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+type Role struct {
+	Name     string         `db:"name" json:"name"`
+	Inherit  bool           `db:"inherit" json:"inherit"`
+	MemberOf pq.StringArray `db:"member_of" json:"memberOf" swaggertype:"array,string"`
+}
+
+// RoleGrant groups the privileges a role holds on one object, column grants carry the column name
+type RoleGrant struct {
+	ObjectType string         `db:"object_type" json:"objectType"`
+	Object     string         `db:"object" json:"object"`
+	Column     string         `db:"column_name" json:"column"`
+	Privileges pq.StringArray `db:"privileges" json:"privileges" swaggertype:"array,string"`
+}
+
+// RoleToken is a JWT PostgREST accepts, it switches to Role for every request made with it
+type RoleToken struct {
+	Role      string    `json:"role"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,11 @@
+package database
+
+type RoleRepository interface {
+	List(marker string) ([]Role, error)
+	GetByName(roleName, marker string) (Role, error)
+	Exists(roleName string) (bool, error)
+	Create(statements []string) error
+	Drop(statements []string) error
+	ListGrants(roleName string) ([]RoleGrant, error)
+	Grant(grantSQL string) error
+}
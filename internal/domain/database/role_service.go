@@ -0,0 +1,320 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"os"
+	"time"
+)
+
+type RoleService interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]Role, error)
+	GetByName(roleName string, projectUUID uuid.UUID, authUser auth.User) (Role, error)
+	Create(request CreateRoleInput, authUser auth.User) (Role, error)
+	Delete(roleName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	ListGrants(roleName string, projectUUID uuid.UUID, authUser auth.User) ([]RoleGrant, error)
+	Grant(roleName string, request GrantInput, authUser auth.User) ([]RoleGrant, error)
+	Revoke(roleName string, request GrantInput, authUser auth.User) ([]RoleGrant, error)
+	IssueToken(roleName string, request IssueRoleTokenInput, authUser auth.User) (RoleToken, error)
+}
+
+type RoleServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewRoleService(injector *do.Injector) (RoleService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &RoleServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *RoleServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Role, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	return clientRoleRepo.List(constants.RoleProjectMarker + fetchedProject.DBName)
+}
+
+func (s *RoleServiceImpl) GetByName(roleName string, projectUUID uuid.UUID, authUser auth.User) (Role, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Role{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Role{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return Role{}, err
+	}
+	defer connection.Close()
+
+	return clientRoleRepo.GetByName(roleName, constants.RoleProjectMarker+fetchedProject.DBName)
+}
+
+func (s *RoleServiceImpl) Create(request CreateRoleInput, authUser auth.User) (Role, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Role{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Role{}, flxErrors.NewForbiddenError("role.error.createForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return Role{}, err
+	}
+	defer connection.Close()
+
+	// roles are shared by every database of the cluster, so names are unique across projects
+	exists, err := clientRoleRepo.Exists(request.Name)
+	if err != nil {
+		return Role{}, err
+	}
+
+	if exists {
+		return Role{}, flxErrors.NewUnprocessableError("role.error.alreadyExists")
+	}
+
+	marker := constants.RoleProjectMarker + fetchedProject.DBName
+	for _, member := range request.MemberOf {
+		if err = s.validateGrantee(clientRoleRepo, member, marker); err != nil {
+			return Role{}, flxErrors.NewBadRequestError(fmt.Sprintf("role '%s' does not exist", member))
+		}
+	}
+
+	if err = clientRoleRepo.Create(buildCreateRoleStatements(fetchedProject.DBName, request)); err != nil {
+		return Role{}, s.toRoleError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateRoleMigration(fetchedProject.DBName, request))
+
+	return clientRoleRepo.GetByName(request.Name, marker)
+}
+
+func (s *RoleServiceImpl) Delete(roleName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	role, err := clientRoleRepo.GetByName(roleName, constants.RoleProjectMarker+fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+
+	if err = clientRoleRepo.Drop(buildDropRoleStatements(role.Name)); err != nil {
+		return false, s.toRoleError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropRoleMigration(fetchedProject.DBName, role))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return true, nil
+}
+
+func (s *RoleServiceImpl) ListGrants(roleName string, projectUUID uuid.UUID, authUser auth.User) ([]RoleGrant, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	if err = s.validateGrantee(clientRoleRepo, roleName, constants.RoleProjectMarker+fetchedProject.DBName); err != nil {
+		return nil, err
+	}
+
+	return clientRoleRepo.ListGrants(roleName)
+}
+
+func (s *RoleServiceImpl) Grant(roleName string, request GrantInput, authUser auth.User) ([]RoleGrant, error) {
+	return s.changeGrant(roleName, request, authUser, buildGrantStatement, buildGrantMigration)
+}
+
+func (s *RoleServiceImpl) Revoke(roleName string, request GrantInput, authUser auth.User) ([]RoleGrant, error) {
+	return s.changeGrant(roleName, request, authUser, buildRevokeStatement, buildRevokeMigration)
+}
+
+// IssueToken signs a JWT with the secret PostgREST verifies, its role claim decides which role requests run as.
+// The PostgREST audience keeps the token from being accepted by the Fluxend API, which shares the secret
+func (s *RoleServiceImpl) IssueToken(roleName string, request IssueRoleTokenInput, authUser auth.User) (RoleToken, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return RoleToken{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return RoleToken{}, flxErrors.NewForbiddenError("role.error.tokenForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return RoleToken{}, err
+	}
+	defer connection.Close()
+
+	if err = s.validateGrantee(clientRoleRepo, roleName, constants.RoleProjectMarker+fetchedProject.DBName); err != nil {
+		return RoleToken{}, err
+	}
+
+	expiresInSeconds := request.ExpiresInSeconds
+	if expiresInSeconds == 0 {
+		expiresInSeconds = constants.DefaultRoleTokenTTLInSeconds
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(expiresInSeconds) * time.Second)
+
+	claims := jwt.MapClaims{}
+	for key, value := range request.Claims {
+		claims[key] = value
+	}
+
+	claims["role"] = roleName
+	claims["iat"] = now.Unix()
+	claims["exp"] = expiresAt.Unix()
+	claims["aud"] = constants.RoleTokenAudience
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("JWT_SECRET")))
+	if err != nil {
+		return RoleToken{}, err
+	}
+
+	return RoleToken{
+		Role:      roleName,
+		Token:     signedToken,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *RoleServiceImpl) changeGrant(
+	roleName string,
+	request GrantInput,
+	authUser auth.User,
+	buildStatement func(string, GrantInput) string,
+	buildMigration func(string, GrantInput) RecordMigrationInput,
+) ([]RoleGrant, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientRoleRepo, connection, err := s.getClientRoleRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	if err = s.validateGrantee(clientRoleRepo, roleName, constants.RoleProjectMarker+fetchedProject.DBName); err != nil {
+		return nil, err
+	}
+
+	if err = clientRoleRepo.Grant(buildStatement(roleName, request)); err != nil {
+		return nil, s.toRoleError(err)
+	}
+
+	s.migrationService.Record(connection, buildMigration(roleName, request))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return clientRoleRepo.ListGrants(roleName)
+}
+
+// validateGrantee accepts the project's own roles and the anonymous role PostgREST falls back to
+func (s *RoleServiceImpl) validateGrantee(clientRoleRepo RoleRepository, roleName, marker string) error {
+	if roleName == constants.RoleWebAnonymous {
+		return nil
+	}
+
+	_, err := clientRoleRepo.GetByName(roleName, marker)
+
+	return err
+}
+
+// toRoleError surfaces database errors such as unknown objects or invalid privileges as bad requests
+func (s *RoleServiceImpl) toRoleError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *RoleServiceImpl) getClientRoleRepo(dbName string) (RoleRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetRoleRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(RoleRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientRoleRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// buildCreateRoleStatements creates a role PostgREST can switch to, the comment ties it to the project database
+func buildCreateRoleStatements(dbName string, input CreateRoleInput) []string {
+	inherit := "NOINHERIT"
+	if input.Inherit {
+		inherit = "INHERIT"
+	}
+
+	roleName := pq.QuoteIdentifier(input.Name)
+	statements := []string{
+		fmt.Sprintf("CREATE ROLE %s NOLOGIN %s;", roleName, inherit),
+		fmt.Sprintf("COMMENT ON ROLE %s IS %s;", roleName, pq.QuoteLiteral(constants.RoleProjectMarker+dbName)),
+		fmt.Sprintf("GRANT %s TO %s;", roleName, pq.QuoteIdentifier(constants.RoleAuthenticator)),
+	}
+
+	for _, member := range input.MemberOf {
+		statements = append(statements, fmt.Sprintf("GRANT %s TO %s;", pq.QuoteIdentifier(member), roleName))
+	}
+
+	return statements
+}
+
+// buildDropRoleStatements hands owned objects over to the current user before revoking everything the role holds
+func buildDropRoleStatements(roleName string) []string {
+	quotedName := pq.QuoteIdentifier(roleName)
+
+	return []string{
+		fmt.Sprintf("REASSIGN OWNED BY %s TO CURRENT_USER;", quotedName),
+		fmt.Sprintf("DROP OWNED BY %s;", quotedName),
+		fmt.Sprintf("DROP ROLE %s;", quotedName),
+	}
+}
+
+func buildCreateRoleMigration(dbName string, input CreateRoleInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_role_" + input.Name,
+		UpSQL:   strings.Join(buildCreateRoleStatements(dbName, input), "\n"),
+		DownSQL: strings.Join(buildDropRoleStatements(input.Name), "\n"),
+	}
+}
+
+// buildDropRoleMigration recreates the role and its memberships on down, revoked privileges are not restored
+func buildDropRoleMigration(dbName string, role Role) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:  "drop_role_" + role.Name,
+		UpSQL: strings.Join(buildDropRoleStatements(role.Name), "\n"),
+		DownSQL: strings.Join(buildCreateRoleStatements(dbName, CreateRoleInput{
+			Name:     role.Name,
+			Inherit:  role.Inherit,
+			MemberOf: role.MemberOf,
+		}), "\n"),
+	}
+}
+
+func buildGrantStatement(roleName string, input GrantInput) string {
+	statement := fmt.Sprintf(
+		"GRANT %s ON %s TO %s",
+		buildPrivilegeList(input),
+		buildGrantObject(input),
+		pq.QuoteIdentifier(roleName),
+	)
+
+	if input.WithGrantOption {
+		statement += " WITH GRANT OPTION"
+	}
+
+	return statement + ";"
+}
+
+func buildRevokeStatement(roleName string, input GrantInput) string {
+	return fmt.Sprintf(
+		"REVOKE %s ON %s FROM %s;",
+		buildPrivilegeList(input),
+		buildGrantObject(input),
+		pq.QuoteIdentifier(roleName),
+	)
+}
+
+func buildGrantMigration(roleName string, input GrantInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    fmt.Sprintf("grant_%s_on_%s", roleName, input.Object),
+		UpSQL:   buildGrantStatement(roleName, input),
+		DownSQL: buildRevokeStatement(roleName, input),
+	}
+}
+
+func buildRevokeMigration(roleName string, input GrantInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    fmt.Sprintf("revoke_%s_on_%s", roleName, input.Object),
+		UpSQL:   buildRevokeStatement(roleName, input),
+		DownSQL: buildGrantStatement(roleName, input),
+	}
+}
+
+// buildPrivilegeList repeats the column list after every privilege, as postgres expects for column grants
+func buildPrivilegeList(input GrantInput) string {
+	columns := ""
+	if input.ObjectType == constants.GrantObjectColumn {
+		quoted := make([]string, len(input.Columns))
+		for i, column := range input.Columns {
+			quoted[i] = pq.QuoteIdentifier(column)
+		}
+
+		columns = " (" + strings.Join(quoted, ", ") + ")"
+	}
+
+	privileges := make([]string, len(input.Privileges))
+	for i, privilege := range input.Privileges {
+		privileges[i] = privilege + columns
+	}
+
+	return strings.Join(privileges, ", ")
+}
+
+func buildGrantObject(input GrantInput) string {
+	switch input.ObjectType {
+	case constants.GrantObjectSchema:
+		return "SCHEMA " + pq.QuoteIdentifier(input.Object)
+	case constants.GrantObjectFunction:
+		return "FUNCTION " + quoteTableName(input.Object)
+	case constants.GrantObjectSequence:
+		return "SEQUENCE " + quoteTableName(input.Object)
+	default:
+		return "TABLE " + quoteTableName(input.Object)
+	}
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoleStatements_Suite(t *testing.T) {
+	t.Run("create role marks the project and lets postgrest switch to it", func(t *testing.T) {
+		migration := buildCreateRoleMigration("udb123", CreateRoleInput{
+			Name:     "authenticated",
+			Inherit:  true,
+			MemberOf: []string{"web_anon"},
+		})
+
+		assert.Equal(t, "create_role_authenticated", migration.Name)
+		assert.Equal(
+			t,
+			"CREATE ROLE \"authenticated\" NOLOGIN INHERIT;\n"+
+				"COMMENT ON ROLE \"authenticated\" IS 'fluxend project udb123';\n"+
+				"GRANT \"authenticated\" TO \"authenticator\";\n"+
+				"GRANT \"web_anon\" TO \"authenticated\";",
+			migration.UpSQL,
+		)
+		assert.Equal(
+			t,
+			"REASSIGN OWNED BY \"authenticated\" TO CURRENT_USER;\n"+
+				"DROP OWNED BY \"authenticated\";\n"+
+				"DROP ROLE \"authenticated\";",
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("drop recreates the role on down", func(t *testing.T) {
+		migration := buildDropRoleMigration("udb123", Role{Name: "service"})
+
+		assert.Equal(t, "drop_role_service", migration.Name)
+		assert.Equal(
+			t,
+			"CREATE ROLE \"service\" NOLOGIN NOINHERIT;\n"+
+				"COMMENT ON ROLE \"service\" IS 'fluxend project udb123';\n"+
+				"GRANT \"service\" TO \"authenticator\";",
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("table grant with grant option", func(t *testing.T) {
+		statement := buildGrantStatement("service", GrantInput{
+			ObjectType:      constants.GrantObjectTable,
+			Object:          "orders",
+			Privileges:      []string{"SELECT", "INSERT"},
+			WithGrantOption: true,
+		})
+
+		assert.Equal(t, `GRANT SELECT, INSERT ON TABLE "public"."orders" TO "service" WITH GRANT OPTION;`, statement)
+	})
+
+	t.Run("column grant repeats columns per privilege", func(t *testing.T) {
+		migration := buildGrantMigration("authenticated", GrantInput{
+			ObjectType: constants.GrantObjectColumn,
+			Object:     "public.profiles",
+			Columns:    []string{"name", "avatar"},
+			Privileges: []string{"SELECT", "UPDATE"},
+		})
+
+		assert.Equal(
+			t,
+			`GRANT SELECT ("name", "avatar"), UPDATE ("name", "avatar") ON TABLE "public"."profiles" TO "authenticated";`,
+			migration.UpSQL,
+		)
+		assert.Equal(
+			t,
+			`REVOKE SELECT ("name", "avatar"), UPDATE ("name", "avatar") ON TABLE "public"."profiles" FROM "authenticated";`,
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("schema, function and sequence objects", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`GRANT USAGE ON SCHEMA "api" TO "anon";`,
+			buildGrantStatement("anon", GrantInput{ObjectType: constants.GrantObjectSchema, Object: "api", Privileges: []string{"USAGE"}}),
+		)
+		assert.Equal(
+			t,
+			`REVOKE EXECUTE ON FUNCTION "api"."login" FROM "anon";`,
+			buildRevokeStatement("anon", GrantInput{ObjectType: constants.GrantObjectFunction, Object: "api.login", Privileges: []string{"EXECUTE"}}),
+		)
+		assert.Equal(
+			t,
+			`GRANT ALL ON SEQUENCE "public"."orders_id_seq" TO "service";`,
+			buildGrantStatement("service", GrantInput{ObjectType: constants.GrantObjectSequence, Object: "orders_id_seq", Privileges: []string{"ALL"}}),
+		)
+	})
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateRoleInput struct {
+	ProjectUUID uuid.UUID
+	Name        string
+	Inherit     bool
+	MemberOf    []string
+}
+
+type GrantInput struct {
+	ProjectUUID uuid.UUID
+	ObjectType  string
+	Object      string
+	Columns     []string
+	Privileges  []string
+
+	// only used by grants, a revoke always removes the grant option along with the privilege
+	WithGrantOption bool
+}
+
+type IssueRoleTokenInput struct {
+	ProjectUUID      uuid.UUID
+	ExpiresInSeconds int
+	Claims           map[string]interface{}
+}
@@ -1,5 +1,10 @@
 package database
 
+import "context"
+
 type RowRepository interface {
 	CreateMany(tableName string, columns []Column, values [][]string) error
+	Stream(ctx context.Context, schema, tableName string, options RowQueryOptions, chunkSize int, handler RowChunkHandler) error
+	Sample(quotedTableName string, columns []string, limit int) ([][]string, error)
+	InsertMany(quotedTableName string, columns []string, rows [][]interface{}) (int64, error)
 }
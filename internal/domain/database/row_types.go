@@ -0,0 +1,48 @@
+package database
+
+// RowFilterOperators maps the operators accepted in row filters to their SQL counterparts.
+// Filters follow the PostgREST convention of column.operator.value
+var RowFilterOperators = map[string]string{
+	"eq":    "=",
+	"neq":   "<>",
+	"gt":    ">",
+	"gte":   ">=",
+	"lt":    "<",
+	"lte":   "<=",
+	"like":  "LIKE",
+	"ilike": "ILIKE",
+	"is":    "IS",
+}
+
+// RowFilterIsValues maps the values accepted by the is operator to their SQL counterparts
+var RowFilterIsValues = map[string]string{
+	"null":    "NULL",
+	"notnull": "NOT NULL",
+	"true":    "TRUE",
+	"false":   "FALSE",
+}
+
+type RowFilter struct {
+	Column   string `json:"column"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type RowOrder struct {
+	Column     string `json:"column"`
+	Descending bool   `json:"descending"`
+}
+
+type RowQueryOptions struct {
+	Columns []string
+	Filters []RowFilter
+	Order   []RowOrder
+}
+
+type ResultColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// RowChunkHandler receives rows in chunks while a table is being streamed
+type RowChunkHandler func(columns []ResultColumn, rows [][]interface{}) error
@@ -0,0 +1,527 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SchemaSnapshot is the part of a project schema compared by DiffSchemas
+type SchemaSnapshot struct {
+	Schemas    []string
+	Extensions []Extension
+	Tables     []TableSnapshot
+	Functions  []FunctionSnapshot
+}
+
+type TableSnapshot struct {
+	Table       Table
+	Columns     []Column
+	Constraints []Constraint
+	Indexes     []Index
+}
+
+func (t TableSnapshot) name() string {
+	return snapshotObjectName(t.Table.Schema, t.Table.Name)
+}
+
+// FunctionSnapshot keeps the schema next to the function, Definition holds the full CREATE OR REPLACE statement
+type FunctionSnapshot struct {
+	Schema   string
+	Function Function
+}
+
+type SchemaChange struct {
+	Object string `json:"object"`
+	Action string `json:"action"`
+	Name   string `json:"name"`
+}
+
+type SchemaDiff struct {
+	Changes    []SchemaChange `json:"changes"`
+	Statements []string       `json:"statements"`
+}
+
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.Statements) == 0
+}
+
+func (d SchemaDiff) Script() string {
+	return strings.Join(d.Statements, "\n")
+}
+
+// schemaDiffBuilder collects statements per phase, the phases are concatenated in an order
+// where nothing is dropped while still referenced and nothing is created before its dependencies
+type schemaDiffBuilder struct {
+	changes          []SchemaChange
+	createSchemas    []string
+	createExtensions []string
+	dropForeignKeys  []string
+	dropConstraints  []string
+	dropIndexes      []string
+	dropTables       []string
+	dropFunctions    []string
+	dropExtensions   []string
+	createFunctions  []string
+	createTables     []string
+	alterColumns     []string
+	addConstraints   []string
+	addForeignKeys   []string
+	createIndexes    []string
+}
+
+// DiffSchemas returns the changes and DDL statements needed to make target match source.
+// Missing schemas are created, extra schemas are left in place since they may hold objects
+// that are not part of the snapshot
+func DiffSchemas(source, target SchemaSnapshot) SchemaDiff {
+	builder := &schemaDiffBuilder{}
+
+	for _, schema := range source.Schemas {
+		if !slices.Contains(target.Schemas, schema) {
+			builder.addChange(constants.SchemaObjectSchema, constants.SchemaChangeCreate, schema)
+			builder.createSchemas = append(builder.createSchemas, fmt.Sprintf("CREATE SCHEMA %s;", pq.QuoteIdentifier(schema)))
+		}
+	}
+
+	builder.diffExtensions(source.Extensions, target.Extensions)
+
+	sourceTables := mapTableSnapshots(source.Tables)
+	targetTables := mapTableSnapshots(target.Tables)
+
+	for _, name := range sortedKeys(targetTables) {
+		if _, ok := sourceTables[name]; !ok {
+			builder.dropTable(targetTables[name])
+		}
+	}
+
+	for _, name := range sortedKeys(sourceTables) {
+		targetTable, ok := targetTables[name]
+		if !ok {
+			builder.createTable(sourceTables[name])
+			continue
+		}
+
+		builder.diffTable(sourceTables[name], targetTable)
+	}
+
+	builder.diffFunctions(source.Functions, target.Functions)
+
+	return builder.build()
+}
+
+func (b *schemaDiffBuilder) build() SchemaDiff {
+	statements := make([]string, 0)
+	for _, phase := range [][]string{
+		b.createSchemas,
+		b.createExtensions,
+		b.dropForeignKeys,
+		b.dropConstraints,
+		b.dropIndexes,
+		b.dropTables,
+		b.dropFunctions,
+		b.dropExtensions,
+		b.createFunctions,
+		b.createTables,
+		b.alterColumns,
+		b.addConstraints,
+		b.addForeignKeys,
+		b.createIndexes,
+	} {
+		statements = append(statements, phase...)
+	}
+
+	changes := b.changes
+	if changes == nil {
+		changes = make([]SchemaChange, 0)
+	}
+
+	return SchemaDiff{Changes: changes, Statements: statements}
+}
+
+func (b *schemaDiffBuilder) addChange(object, action, name string) {
+	b.changes = append(b.changes, SchemaChange{Object: object, Action: action, Name: name})
+}
+
+func (b *schemaDiffBuilder) dropTable(snapshot TableSnapshot) {
+	b.addChange(constants.SchemaObjectTable, constants.SchemaChangeDrop, snapshot.name())
+	b.dropTables = append(b.dropTables, fmt.Sprintf("DROP TABLE %s;", quoteSnapshotTable(snapshot)))
+}
+
+func (b *schemaDiffBuilder) createTable(snapshot TableSnapshot) {
+	b.addChange(constants.SchemaObjectTable, constants.SchemaChangeCreate, snapshot.name())
+
+	definitions := make([]string, 0, len(snapshot.Columns))
+	for _, column := range uniqueColumns(snapshot.Columns) {
+		definitions = append(definitions, buildDiffColumnDefinition(column))
+	}
+
+	b.createTables = append(b.createTables, fmt.Sprintf(
+		"CREATE TABLE %s (\n%s\n);",
+		quoteSnapshotTable(snapshot),
+		strings.Join(definitions, ",\n"),
+	))
+
+	for _, constraint := range snapshot.Constraints {
+		b.addConstraint(snapshot, constraint)
+	}
+
+	for _, index := range snapshot.Indexes {
+		b.createIndexes = append(b.createIndexes, index.Definition+";")
+	}
+}
+
+func (b *schemaDiffBuilder) diffTable(source, target TableSnapshot) {
+	b.diffConstraints(source, target)
+	b.diffColumns(source, target)
+	b.diffIndexes(source, target)
+}
+
+func (b *schemaDiffBuilder) diffColumns(source, target TableSnapshot) {
+	tableName := quoteSnapshotTable(target)
+	sourceColumns := uniqueColumns(source.Columns)
+	targetColumns := mapColumns(uniqueColumns(target.Columns))
+
+	for _, sourceColumn := range sourceColumns {
+		name := source.name() + "." + sourceColumn.Name
+		column := pq.QuoteIdentifier(sourceColumn.Name)
+
+		targetColumn, ok := targetColumns[sourceColumn.Name]
+		if !ok {
+			b.addChange(constants.SchemaObjectColumn, constants.SchemaChangeCreate, name)
+			b.alterColumns = append(b.alterColumns, fmt.Sprintf(
+				"ALTER TABLE %s ADD COLUMN %s;", tableName, buildDiffColumnDefinition(sourceColumn),
+			))
+			continue
+		}
+
+		delete(targetColumns, sourceColumn.Name)
+
+		typeChanged := sourceColumn.Type != targetColumn.Type
+		defaultChanged := sourceColumn.Default != targetColumn.Default
+		if !typeChanged && !defaultChanged && sourceColumn.NotNull == targetColumn.NotNull {
+			continue
+		}
+
+		b.addChange(constants.SchemaObjectColumn, constants.SchemaChangeAlter, name)
+
+		// the old default may not be castable to the new type, so it is dropped first and restored afterwards
+		if (typeChanged || defaultChanged) && targetColumn.Default != "" {
+			b.alterColumns = append(b.alterColumns, fmt.Sprintf(
+				"ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", tableName, column,
+			))
+		}
+
+		if typeChanged {
+			b.alterColumns = append(b.alterColumns, buildDiffTypeChange(tableName, sourceColumn.Name, targetColumn.Type, sourceColumn.Type))
+		}
+
+		if (typeChanged || defaultChanged) && sourceColumn.Default != "" {
+			b.alterColumns = append(b.alterColumns, fmt.Sprintf(
+				"ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", tableName, column, sourceColumn.Default,
+			))
+		}
+
+		if sourceColumn.NotNull != targetColumn.NotNull {
+			action := "DROP NOT NULL"
+			if sourceColumn.NotNull {
+				action = "SET NOT NULL"
+			}
+
+			b.alterColumns = append(b.alterColumns, fmt.Sprintf(
+				"ALTER TABLE %s ALTER COLUMN %s %s;", tableName, column, action,
+			))
+		}
+	}
+
+	for _, targetColumn := range uniqueColumns(target.Columns) {
+		if _, ok := targetColumns[targetColumn.Name]; !ok {
+			continue
+		}
+
+		b.addChange(constants.SchemaObjectColumn, constants.SchemaChangeDrop, target.name()+"."+targetColumn.Name)
+		b.alterColumns = append(b.alterColumns, fmt.Sprintf(
+			"ALTER TABLE %s DROP COLUMN %s;", tableName, pq.QuoteIdentifier(targetColumn.Name),
+		))
+	}
+}
+
+func (b *schemaDiffBuilder) diffConstraints(source, target TableSnapshot) {
+	sourceConstraints := make(map[string]Constraint, len(source.Constraints))
+	for _, constraint := range source.Constraints {
+		sourceConstraints[constraint.Name] = constraint
+	}
+
+	targetConstraints := make(map[string]Constraint, len(target.Constraints))
+	for _, constraint := range target.Constraints {
+		targetConstraints[constraint.Name] = constraint
+
+		sourceConstraint, ok := sourceConstraints[constraint.Name]
+		if ok && sourceConstraint.Definition == constraint.Definition {
+			continue
+		}
+
+		if !ok {
+			b.addChange(constants.SchemaObjectConstraint, constants.SchemaChangeDrop, target.name()+"."+constraint.Name)
+		}
+
+		statement := fmt.Sprintf(
+			"ALTER TABLE %s DROP CONSTRAINT %s;", quoteSnapshotTable(target), pq.QuoteIdentifier(constraint.Name),
+		)
+		if constraint.Type == constants.ConstraintTypeForeignKey {
+			b.dropForeignKeys = append(b.dropForeignKeys, statement)
+		} else {
+			b.dropConstraints = append(b.dropConstraints, statement)
+		}
+	}
+
+	for _, constraint := range source.Constraints {
+		targetConstraint, ok := targetConstraints[constraint.Name]
+		if ok && targetConstraint.Definition == constraint.Definition {
+			continue
+		}
+
+		action := constants.SchemaChangeCreate
+		if ok {
+			action = constants.SchemaChangeAlter
+		}
+
+		b.addChange(constants.SchemaObjectConstraint, action, source.name()+"."+constraint.Name)
+		b.addConstraint(source, constraint)
+	}
+}
+
+func (b *schemaDiffBuilder) addConstraint(snapshot TableSnapshot, constraint Constraint) {
+	statement := fmt.Sprintf(
+		"ALTER TABLE %s ADD CONSTRAINT %s %s;",
+		quoteSnapshotTable(snapshot),
+		pq.QuoteIdentifier(constraint.Name),
+		constraint.Definition,
+	)
+
+	// foreign keys go last so the referenced tables and their unique constraints exist
+	if constraint.Type == constants.ConstraintTypeForeignKey {
+		b.addForeignKeys = append(b.addForeignKeys, statement)
+	} else {
+		b.addConstraints = append(b.addConstraints, statement)
+	}
+}
+
+func (b *schemaDiffBuilder) diffIndexes(source, target TableSnapshot) {
+	sourceIndexes := make(map[string]Index, len(source.Indexes))
+	for _, index := range source.Indexes {
+		sourceIndexes[index.Name] = index
+	}
+
+	targetIndexes := make(map[string]Index, len(target.Indexes))
+	for _, index := range target.Indexes {
+		targetIndexes[index.Name] = index
+
+		sourceIndex, ok := sourceIndexes[index.Name]
+		if ok && sourceIndex.Definition == index.Definition {
+			continue
+		}
+
+		if !ok {
+			b.addChange(constants.SchemaObjectIndex, constants.SchemaChangeDrop, snapshotObjectName(target.Table.Schema, index.Name))
+		}
+
+		b.dropIndexes = append(b.dropIndexes, fmt.Sprintf(
+			"DROP INDEX %s.%s;", pq.QuoteIdentifier(target.Table.Schema), pq.QuoteIdentifier(index.Name),
+		))
+	}
+
+	for _, index := range source.Indexes {
+		targetIndex, ok := targetIndexes[index.Name]
+		if ok && targetIndex.Definition == index.Definition {
+			continue
+		}
+
+		action := constants.SchemaChangeCreate
+		if ok {
+			action = constants.SchemaChangeAlter
+		}
+
+		b.addChange(constants.SchemaObjectIndex, action, snapshotObjectName(source.Table.Schema, index.Name))
+		b.createIndexes = append(b.createIndexes, index.Definition+";")
+	}
+}
+
+// diffExtensions creates and drops extensions as a whole, the objects they bring along are not
+// part of the snapshot. Versions are left alone, an extension is created at its default version
+func (b *schemaDiffBuilder) diffExtensions(source, target []Extension) {
+	for _, extension := range source {
+		if !slices.ContainsFunc(target, func(e Extension) bool { return e.Name == extension.Name }) {
+			b.addChange(constants.SchemaObjectExtension, constants.SchemaChangeCreate, extension.Name)
+			b.createExtensions = append(b.createExtensions, buildCreateExtensionStatement(extension.Name, extension.Schema))
+		}
+	}
+
+	for _, extension := range target {
+		if !slices.ContainsFunc(source, func(e Extension) bool { return e.Name == extension.Name }) {
+			b.addChange(constants.SchemaObjectExtension, constants.SchemaChangeDrop, extension.Name)
+			b.dropExtensions = append(b.dropExtensions, buildDropExtensionStatement(extension.Name, false))
+		}
+	}
+}
+
+// diffFunctions compares overloads one by one, a function is identified by its schema, name and argument types
+func (b *schemaDiffBuilder) diffFunctions(source, target []FunctionSnapshot) {
+	targetFunctions := make(map[string]FunctionSnapshot, len(target))
+	for _, snapshot := range target {
+		targetFunctions[snapshot.signature()] = snapshot
+	}
+
+	sourceFunctions := make(map[string]FunctionSnapshot, len(source))
+	for _, snapshot := range source {
+		signature := snapshot.signature()
+		sourceFunctions[signature] = snapshot
+
+		targetFunction, ok := targetFunctions[signature]
+		if ok && targetFunction.Function.Definition == snapshot.Function.Definition {
+			continue
+		}
+
+		action := constants.SchemaChangeCreate
+		if ok {
+			action = constants.SchemaChangeAlter
+		}
+
+		b.addChange(constants.SchemaObjectFunction, action, snapshot.name())
+		b.createFunctions = append(b.createFunctions, strings.TrimSpace(snapshot.Function.Definition)+";")
+	}
+
+	for _, snapshot := range target {
+		if _, ok := sourceFunctions[snapshot.signature()]; ok {
+			continue
+		}
+
+		b.addChange(constants.SchemaObjectFunction, constants.SchemaChangeDrop, snapshot.name())
+		b.dropFunctions = append(b.dropFunctions, fmt.Sprintf("DROP FUNCTION %s;", snapshot.signature()))
+	}
+}
+
+func (f FunctionSnapshot) signature() string {
+	return buildFunctionSignature(f.Schema, f.Function.Name, f.Function.argumentTypeList())
+}
+
+func (f FunctionSnapshot) name() string {
+	return snapshotObjectName(f.Schema, f.Function.Name) + "(" + f.Function.ArgumentTypes + ")"
+}
+
+// buildDiffColumnDefinition leaves out key and unique flags, constraints are diffed on their own
+func buildDiffColumnDefinition(column Column) string {
+	columnType, columnDefault := serialColumnType(column.Type, column.Default)
+
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column.Name), columnType)
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+
+	if columnDefault != "" {
+		definition += " DEFAULT " + columnDefault
+	}
+
+	return definition
+}
+
+// serialColumnType turns sequence backed integer columns into serial types. Sequences are not
+// part of diffs and dumps, serial types recreate them wherever the statements run
+func serialColumnType(columnType, columnDefault string) (string, string) {
+	if !strings.HasPrefix(columnDefault, "nextval(") {
+		return columnType, columnDefault
+	}
+
+	switch columnType {
+	case "smallint":
+		return "smallserial", ""
+	case "integer":
+		return "serial", ""
+	case "bigint":
+		return "bigserial", ""
+	default:
+		return columnType, columnDefault
+	}
+}
+
+// uniqueColumns drops the duplicate rows the column listing returns for columns with several constraints
+func uniqueColumns(columns []Column) []Column {
+	seen := make(map[string]bool, len(columns))
+	unique := make([]Column, 0, len(columns))
+	for _, column := range columns {
+		if seen[column.Name] {
+			continue
+		}
+
+		seen[column.Name] = true
+		unique = append(unique, column)
+	}
+
+	return unique
+}
+
+func mapColumns(columns []Column) map[string]Column {
+	mapped := make(map[string]Column, len(columns))
+	for _, column := range columns {
+		mapped[column.Name] = column
+	}
+
+	return mapped
+}
+
+func mapTableSnapshots(snapshots []TableSnapshot) map[string]TableSnapshot {
+	mapped := make(map[string]TableSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		mapped[snapshot.Table.FullName()] = snapshot
+	}
+
+	return mapped
+}
+
+func sortedKeys(snapshots map[string]TableSnapshot) []string {
+	keys := make([]string, 0, len(snapshots))
+	for key := range snapshots {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// snapshotObjectName qualifies names outside the default schema, so objects of other schemas can be told apart
+func snapshotObjectName(schema, name string) string {
+	if schema == pkg.DefaultSchema {
+		return name
+	}
+
+	return schema + "." + name
+}
+
+func quoteSnapshotTable(snapshot TableSnapshot) string {
+	return pq.QuoteIdentifier(snapshot.Table.Schema) + "." + pq.QuoteIdentifier(snapshot.Table.Name)
+}
+
+// buildDiffTypeChange prefers the planned conversion and falls back to a plain cast, promoting
+// is an explicit request to make the target look like the source
+func buildDiffTypeChange(tableName, columnName, fromType, toType string) string {
+	column := pq.QuoteIdentifier(columnName)
+	using := column + "::" + toType
+
+	from, fromErr := ParseColumnType(fromType)
+	to, toErr := ParseColumnType(toType)
+	if fromErr == nil && toErr == nil {
+		if planned, err := PlanTypeChange(columnName, from, to); err == nil {
+			using = planned
+		}
+	}
+
+	if using == "" {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", tableName, column, toType)
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s;", tableName, column, toType, using)
+}
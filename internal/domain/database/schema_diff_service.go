@@ -0,0 +1,346 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+	"slices"
+)
+
+type SchemaDiffService interface {
+	Diff(request SchemaDiffInput, authUser auth.User) (SchemaDiff, error)
+	Apply(request SchemaDiffInput, authUser auth.User) (SchemaDiff, error)
+}
+
+type SchemaDiffServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	projectPolicy     *project.Policy
+	postgrestService  shared.PostgrestService
+	projectRepo       project.Repository
+}
+
+type schemaDiffRepos struct {
+	schemaRepo     SchemaRepository
+	tableRepo      TableRepository
+	columnRepo     ColumnRepository
+	constraintRepo ConstraintRepository
+	indexRepo      IndexRepository
+	functionRepo   FunctionRepository
+	extensionRepo  ExtensionRepository
+}
+
+func NewSchemaDiffService(injector *do.Injector) (SchemaDiffService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &SchemaDiffServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		postgrestService:  postgrestService,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// Diff returns the changes needed to make the target project schema match the source project
+func (s *SchemaDiffServiceImpl) Diff(request SchemaDiffInput, authUser auth.User) (SchemaDiff, error) {
+	sourceProject, targetProject, err := s.getProjects(request)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(sourceProject.OrganizationUuid, authUser) ||
+		!s.projectPolicy.CanAccess(targetProject.OrganizationUuid, authUser) {
+		return SchemaDiff{}, flxErrors.NewForbiddenError("schemaDiff.error.forbidden")
+	}
+
+	sourceSnapshot, err := s.takeSnapshot(sourceProject.DBName)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	targetConnection, err := s.connectionService.ConnectByDatabaseName(targetProject.DBName)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+	defer targetConnection.Close()
+
+	targetSnapshot, err := s.takeSnapshotWithConnection(targetConnection)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	return DiffSchemas(sourceSnapshot, targetSnapshot), nil
+}
+
+// Apply recomputes the diff and runs it against the target project in a single transaction.
+// The reverse diff is stored as down SQL, so the promotion can be rolled back like any other migration
+func (s *SchemaDiffServiceImpl) Apply(request SchemaDiffInput, authUser auth.User) (SchemaDiff, error) {
+	sourceProject, targetProject, err := s.getProjects(request)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(sourceProject.OrganizationUuid, authUser) {
+		return SchemaDiff{}, flxErrors.NewForbiddenError("schemaDiff.error.forbidden")
+	}
+
+	if !s.projectPolicy.CanUpdate(targetProject.OrganizationUuid, authUser) {
+		return SchemaDiff{}, flxErrors.NewForbiddenError("schemaDiff.error.applyForbidden")
+	}
+
+	sourceSnapshot, err := s.takeSnapshot(sourceProject.DBName)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	targetConnection, err := s.connectionService.ConnectByDatabaseName(targetProject.DBName)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+	defer targetConnection.Close()
+
+	targetSnapshot, err := s.takeSnapshotWithConnection(targetConnection)
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	diff := DiffSchemas(sourceSnapshot, targetSnapshot)
+	if diff.IsEmpty() {
+		return diff, nil
+	}
+
+	_, err = s.migrationService.Execute(targetConnection, RecordMigrationInput{
+		Name:    "promote_from_" + sourceProject.DBName,
+		UpSQL:   diff.Script(),
+		DownSQL: DiffSchemas(targetSnapshot, sourceSnapshot).Script(),
+	})
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+
+	s.postgrestService.RefreshSchemaCache(targetProject.DBName)
+
+	return diff, nil
+}
+
+func (s *SchemaDiffServiceImpl) getProjects(request SchemaDiffInput) (project.Project, project.Project, error) {
+	if request.ProjectUUID == request.TargetProjectUUID {
+		return project.Project{}, project.Project{}, flxErrors.NewBadRequestError("schemaDiff.error.sameProject")
+	}
+
+	sourceProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return project.Project{}, project.Project{}, err
+	}
+
+	targetProject, err := s.projectRepo.GetByUUID(request.TargetProjectUUID)
+	if err != nil {
+		return project.Project{}, project.Project{}, err
+	}
+
+	return sourceProject, targetProject, nil
+}
+
+func (s *SchemaDiffServiceImpl) takeSnapshot(dbName string) (SchemaSnapshot, error) {
+	connection, err := s.connectionService.ConnectByDatabaseName(dbName)
+	if err != nil {
+		return SchemaSnapshot{}, err
+	}
+	defer connection.Close()
+
+	return s.takeSnapshotWithConnection(connection)
+}
+
+// takeSnapshotWithConnection reads every schema the schemas API lists, public included. Objects that
+// belong to an extension are left out, the extension itself is compared instead
+func (s *SchemaDiffServiceImpl) takeSnapshotWithConnection(connection *sqlx.DB) (SchemaSnapshot, error) {
+	repos, err := s.getClientRepos(connection)
+	if err != nil {
+		return SchemaSnapshot{}, err
+	}
+
+	schemas, err := repos.schemaRepo.List()
+	if err != nil {
+		return SchemaSnapshot{}, err
+	}
+
+	snapshot := SchemaSnapshot{
+		Schemas:    make([]string, 0, len(schemas)),
+		Extensions: make([]Extension, 0),
+		Tables:     make([]TableSnapshot, 0),
+		Functions:  make([]FunctionSnapshot, 0),
+	}
+
+	if err = s.addExtensions(&snapshot, repos); err != nil {
+		return SchemaSnapshot{}, err
+	}
+
+	for _, schema := range schemas {
+		snapshot.Schemas = append(snapshot.Schemas, schema.Name)
+
+		if err := s.addTableSnapshots(&snapshot, repos, schema.Name); err != nil {
+			return SchemaSnapshot{}, err
+		}
+
+		if err := s.addFunctionSnapshots(&snapshot, repos, schema.Name); err != nil {
+			return SchemaSnapshot{}, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// addExtensions skips protected extensions, every database has them and they are never dropped
+func (s *SchemaDiffServiceImpl) addExtensions(snapshot *SchemaSnapshot, repos schemaDiffRepos) error {
+	extensions, err := repos.extensionRepo.List()
+	if err != nil {
+		return err
+	}
+
+	for _, extension := range extensions {
+		if extension.Installed() && !slices.Contains(constants.ProtectedExtensions, extension.Name) {
+			snapshot.Extensions = append(snapshot.Extensions, extension)
+		}
+	}
+
+	return nil
+}
+
+func (s *SchemaDiffServiceImpl) addTableSnapshots(snapshot *SchemaSnapshot, repos schemaDiffRepos, schema string) error {
+	tables, err := repos.tableRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table.Extension != "" {
+			continue
+		}
+		columns, err := repos.columnRepo.List(quoteTableName(table.FullName()))
+		if err != nil {
+			return err
+		}
+
+		constraints, err := repos.constraintRepo.List(table.FullName())
+		if err != nil {
+			return err
+		}
+
+		indexes, err := repos.indexRepo.ListDefinitions(table.Schema, table.Name)
+		if err != nil {
+			return err
+		}
+
+		snapshot.Tables = append(snapshot.Tables, TableSnapshot{
+			Table:       table,
+			Columns:     columns,
+			Constraints: constraints,
+			Indexes:     indexes,
+		})
+	}
+
+	return nil
+}
+
+func (s *SchemaDiffServiceImpl) addFunctionSnapshots(snapshot *SchemaSnapshot, repos schemaDiffRepos, schema string) error {
+	functions, err := repos.functionRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	// the listing only carries the function body, the diff needs the complete statement
+	for _, function := range functions {
+		if function.Extension != "" {
+			continue
+		}
+		fetchedFunction, err := repos.functionRepo.GetBySignature(
+			buildFunctionSignature(schema, function.Name, function.argumentTypeList()),
+		)
+		if err != nil {
+			return err
+		}
+
+		snapshot.Functions = append(snapshot.Functions, FunctionSnapshot{Schema: schema, Function: fetchedFunction})
+	}
+
+	return nil
+}
+
+func (s *SchemaDiffServiceImpl) getClientRepos(connection *sqlx.DB) (schemaDiffRepos, error) {
+	schemaRepo, _, err := s.connectionService.GetSchemaRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	tableRepo, _, err := s.connectionService.GetTableRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	columnRepo, _, err := s.connectionService.GetColumnRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	constraintRepo, _, err := s.connectionService.GetConstraintRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	indexRepo, _, err := s.connectionService.GetIndexRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	functionRepo, _, err := s.connectionService.GetFunctionRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	extensionRepo, _, err := s.connectionService.GetExtensionRepo("", connection)
+	if err != nil {
+		return schemaDiffRepos{}, err
+	}
+
+	var repos schemaDiffRepos
+	var ok bool
+
+	if repos.schemaRepo, ok = schemaRepo.(SchemaRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientSchemaRepo is invalid")
+	}
+
+	if repos.tableRepo, ok = tableRepo.(TableRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	if repos.columnRepo, ok = columnRepo.(ColumnRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientColumnRepo is invalid")
+	}
+
+	if repos.constraintRepo, ok = constraintRepo.(ConstraintRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	if repos.indexRepo, ok = indexRepo.(IndexRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientIndexRepo is invalid")
+	}
+
+	if repos.functionRepo, ok = functionRepo.(FunctionRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientFunctionRepo is invalid")
+	}
+
+	if repos.extensionRepo, ok = extensionRepo.(ExtensionRepository); !ok {
+		return schemaDiffRepos{}, flxErrors.NewUnprocessableError("clientExtensionRepo is invalid")
+	}
+
+	return repos, nil
+}
@@ -0,0 +1,217 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func usersSnapshot(columns []Column, constraints []Constraint, indexes []Index) TableSnapshot {
+	return TableSnapshot{
+		Table:       Table{Name: "users", Schema: "public"},
+		Columns:     columns,
+		Constraints: constraints,
+		Indexes:     indexes,
+	}
+}
+
+func TestDiffSchemas_Suite(t *testing.T) {
+	idColumn := Column{Name: "id", Type: "integer", NotNull: true, Default: "nextval('users_id_seq'::regclass)"}
+	emailColumn := Column{Name: "email", Type: "character varying(255)", NotNull: true}
+	primaryKey := Constraint{Name: "users_pkey", Type: constants.ConstraintTypePrimaryKey, Definition: "PRIMARY KEY (id)"}
+
+	t.Run("identical schemas produce no statements", func(t *testing.T) {
+		snapshot := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn, emailColumn}, []Constraint{primaryKey}, nil),
+		}}
+
+		diff := DiffSchemas(snapshot, snapshot)
+
+		assert.True(t, diff.IsEmpty())
+		assert.Empty(t, diff.Changes)
+	})
+
+	t.Run("missing table is created with serial columns and constraints", func(t *testing.T) {
+		source := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot(
+				// duplicate rows are returned for columns with several constraints
+				[]Column{idColumn, idColumn, emailColumn},
+				[]Constraint{primaryKey},
+				[]Index{{Name: "users_email_idx", Definition: "CREATE INDEX users_email_idx ON public.users USING btree (email)"}},
+			),
+		}}
+
+		diff := DiffSchemas(source, SchemaSnapshot{})
+
+		assert.Equal(t, []string{
+			"CREATE TABLE \"public\".\"users\" (\n\"id\" serial NOT NULL,\n\"email\" character varying(255) NOT NULL\n);",
+			`ALTER TABLE "public"."users" ADD CONSTRAINT "users_pkey" PRIMARY KEY (id);`,
+			"CREATE INDEX users_email_idx ON public.users USING btree (email);",
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectTable, Action: constants.SchemaChangeCreate, Name: "users"},
+		}, diff.Changes)
+	})
+
+	t.Run("extra table is dropped", func(t *testing.T) {
+		target := SchemaSnapshot{Tables: []TableSnapshot{usersSnapshot([]Column{idColumn}, nil, nil)}}
+
+		diff := DiffSchemas(SchemaSnapshot{}, target)
+
+		assert.Equal(t, []string{`DROP TABLE "public"."users";`}, diff.Statements)
+	})
+
+	t.Run("columns are added, altered and dropped", func(t *testing.T) {
+		source := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{
+				idColumn,
+				{Name: "email", Type: "text", NotNull: false},
+				{Name: "age", Type: "integer", Default: "18"},
+			}, nil, nil),
+		}}
+		target := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn, emailColumn, {Name: "legacy", Type: "text"}}, nil, nil),
+		}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`ALTER TABLE "public"."users" ALTER COLUMN "email" TYPE text;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "email" DROP NOT NULL;`,
+			`ALTER TABLE "public"."users" ADD COLUMN "age" integer DEFAULT 18;`,
+			`ALTER TABLE "public"."users" DROP COLUMN "legacy";`,
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectColumn, Action: constants.SchemaChangeAlter, Name: "users.email"},
+			{Object: constants.SchemaObjectColumn, Action: constants.SchemaChangeCreate, Name: "users.age"},
+			{Object: constants.SchemaObjectColumn, Action: constants.SchemaChangeDrop, Name: "users.legacy"},
+		}, diff.Changes)
+	})
+
+	t.Run("default is dropped before a type change and restored after", func(t *testing.T) {
+		source := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{{Name: "score", Type: "bigint", Default: "0"}}, nil, nil),
+		}}
+		target := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{{Name: "score", Type: "integer", Default: "0"}}, nil, nil),
+		}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" DROP DEFAULT;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" TYPE bigint;`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "score" SET DEFAULT 0;`,
+		}, diff.Statements)
+	})
+
+	t.Run("changed constraints are recreated and foreign keys go last", func(t *testing.T) {
+		foreignKey := Constraint{Name: "fk_org", Type: constants.ConstraintTypeForeignKey, Definition: "FOREIGN KEY (org_id) REFERENCES orgs(id)"}
+		source := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn}, []Constraint{
+				foreignKey,
+				{Name: "users_age_check", Type: constants.ConstraintTypeCheck, Definition: "CHECK ((age > 0))"},
+			}, nil),
+		}}
+		target := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn}, []Constraint{
+				{Name: "fk_org", Type: constants.ConstraintTypeForeignKey, Definition: "FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE CASCADE"},
+				{Name: "users_email_key", Type: constants.ConstraintTypeUnique, Definition: "UNIQUE (email)"},
+			}, nil),
+		}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`ALTER TABLE "public"."users" DROP CONSTRAINT "fk_org";`,
+			`ALTER TABLE "public"."users" DROP CONSTRAINT "users_email_key";`,
+			`ALTER TABLE "public"."users" ADD CONSTRAINT "users_age_check" CHECK ((age > 0));`,
+			`ALTER TABLE "public"."users" ADD CONSTRAINT "fk_org" FOREIGN KEY (org_id) REFERENCES orgs(id);`,
+		}, diff.Statements)
+	})
+
+	t.Run("indexes are compared by definition", func(t *testing.T) {
+		source := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn}, nil, []Index{
+				{Name: "users_email_idx", Definition: "CREATE UNIQUE INDEX users_email_idx ON public.users USING btree (email)"},
+			}),
+		}}
+		target := SchemaSnapshot{Tables: []TableSnapshot{
+			usersSnapshot([]Column{idColumn}, nil, []Index{
+				{Name: "users_email_idx", Definition: "CREATE INDEX users_email_idx ON public.users USING btree (email)"},
+			}),
+		}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`DROP INDEX "public"."users_email_idx";`,
+			"CREATE UNIQUE INDEX users_email_idx ON public.users USING btree (email);",
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectIndex, Action: constants.SchemaChangeAlter, Name: "users_email_idx"},
+		}, diff.Changes)
+	})
+
+	t.Run("functions are replaced or dropped by overload", func(t *testing.T) {
+		source := SchemaSnapshot{Functions: []FunctionSnapshot{
+			{Schema: "public", Function: Function{Name: "total", Definition: "CREATE OR REPLACE FUNCTION public.total()\n RETURNS integer\n AS $$ SELECT 2 $$\n"}},
+		}}
+		target := SchemaSnapshot{Functions: []FunctionSnapshot{
+			{Schema: "public", Function: Function{Name: "total", Definition: "CREATE OR REPLACE FUNCTION public.total()\n RETURNS integer\n AS $$ SELECT 1 $$\n"}},
+			{Schema: "public", Function: Function{Name: "total", ArgumentTypes: "integer, text", Definition: "CREATE OR REPLACE FUNCTION public.total(a integer, b text)"}},
+		}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`DROP FUNCTION "public"."total"(integer, text);`,
+			"CREATE OR REPLACE FUNCTION public.total()\n RETURNS integer\n AS $$ SELECT 2 $$;",
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectFunction, Action: constants.SchemaChangeAlter, Name: "total()"},
+			{Object: constants.SchemaObjectFunction, Action: constants.SchemaChangeDrop, Name: "total(integer, text)"},
+		}, diff.Changes)
+	})
+
+	t.Run("extensions are created and dropped as a whole", func(t *testing.T) {
+		source := SchemaSnapshot{Extensions: []Extension{{Name: "pgcrypto", Schema: "public"}}}
+		target := SchemaSnapshot{Extensions: []Extension{{Name: "pg_trgm", Schema: "public"}}}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`CREATE EXTENSION "pgcrypto" WITH SCHEMA "public";`,
+			`DROP EXTENSION "pg_trgm";`,
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectExtension, Action: constants.SchemaChangeCreate, Name: "pgcrypto"},
+			{Object: constants.SchemaObjectExtension, Action: constants.SchemaChangeDrop, Name: "pg_trgm"},
+		}, diff.Changes)
+	})
+
+	t.Run("tables of other schemas are compared on their own and missing schemas are created", func(t *testing.T) {
+		billingUsers := usersSnapshot([]Column{idColumn}, nil, nil)
+		billingUsers.Table.Schema = "billing"
+
+		source := SchemaSnapshot{
+			Schemas: []string{"billing", "public"},
+			Tables:  []TableSnapshot{usersSnapshot([]Column{idColumn}, nil, nil), billingUsers},
+		}
+		target := SchemaSnapshot{
+			Schemas: []string{"public"},
+			Tables:  []TableSnapshot{usersSnapshot([]Column{idColumn}, nil, nil)},
+		}
+
+		diff := DiffSchemas(source, target)
+
+		assert.Equal(t, []string{
+			`CREATE SCHEMA "billing";`,
+			"CREATE TABLE \"billing\".\"users\" (\n\"id\" serial NOT NULL\n);",
+		}, diff.Statements)
+		assert.Equal(t, []SchemaChange{
+			{Object: constants.SchemaObjectSchema, Action: constants.SchemaChangeCreate, Name: "billing"},
+			{Object: constants.SchemaObjectTable, Action: constants.SchemaChangeCreate, Name: "billing.users"},
+		}, diff.Changes)
+	})
+}
@@ -0,0 +1,11 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+// SchemaDiffInput compares the target project against the source project
+type SchemaDiffInput struct {
+	ProjectUUID       uuid.UUID `json:"projectUUID,omitempty"`
+	TargetProjectUUID uuid.UUID `json:"targetProjectUUID,omitempty"`
+}
@@ -0,0 +1,11 @@
+package database
+
+type Schema struct {
+	Name       string `db:"name" json:"name"`
+	Owner      string `db:"owner" json:"owner"`
+	TableCount int    `db:"table_count" json:"tableCount"`
+	Comment    string `db:"comment" json:"comment"`
+
+	// Exposed is not stored in postgres, it comes from the schemas the project's PostgREST serves
+	Exposed bool `db:"-" json:"exposed"`
+}
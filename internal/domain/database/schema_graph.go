@@ -0,0 +1,269 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SchemaGraph is the entity-relationship model of a project database, Relations are the foreign keys
+// between its tables. Partitions are left out, their parent table stands for them
+type SchemaGraph struct {
+	Schemas   []string        `json:"schemas"`
+	Tables    []GraphTable    `json:"tables"`
+	Relations []GraphRelation `json:"relations"`
+	Views     []GraphView     `json:"views"`
+	Functions []GraphFunction `json:"functions"`
+}
+
+type GraphTable struct {
+	Schema      string        `json:"schema"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Columns     []GraphColumn `json:"columns"`
+	PrimaryKey  []string      `json:"primaryKey"`
+	Indexes     []GraphIndex  `json:"indexes"`
+}
+
+type GraphColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	NotNull     bool   `json:"notNull"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+	Unique      bool   `json:"unique"`
+	Foreign     bool   `json:"foreign"`
+}
+
+type GraphIndex struct {
+	Name    string        `json:"name"`
+	Columns []IndexColumn `json:"columns"`
+	Method  string        `json:"method"`
+	Unique  bool          `json:"unique"`
+	Primary bool          `json:"primary"`
+}
+
+// GraphRelation points from the referencing table to the referenced one. Optional relations have a
+// nullable referencing column, one to one relations reference through unique columns
+type GraphRelation struct {
+	Name        string   `json:"name"`
+	FromTable   string   `json:"fromTable"`
+	FromColumns []string `json:"fromColumns"`
+	ToTable     string   `json:"toTable"`
+	ToColumns   []string `json:"toColumns"`
+	OnDelete    string   `json:"onDelete"`
+	OnUpdate    string   `json:"onUpdate"`
+	Optional    bool     `json:"optional"`
+	OneToOne    bool     `json:"oneToOne"`
+}
+
+type GraphView struct {
+	Schema       string        `json:"schema"`
+	Name         string        `json:"name"`
+	Materialized bool          `json:"materialized"`
+	Columns      []GraphColumn `json:"columns"`
+}
+
+type GraphFunction struct {
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+	Arguments  string `json:"arguments"`
+	ReturnType string `json:"returnType"`
+	Language   string `json:"language"`
+}
+
+func (t GraphTable) FullName() string {
+	return t.Schema + "." + t.Name
+}
+
+func (v GraphView) FullName() string {
+	return v.Schema + "." + v.Name
+}
+
+// addTable adds a table with its keys and indexes, foreign keys become relations
+func (g *SchemaGraph) addTable(snapshot TableSnapshot, indexes []Index) {
+	table := GraphTable{
+		Schema:      snapshot.Table.Schema,
+		Name:        snapshot.Table.Name,
+		Description: snapshot.Table.Description,
+		PrimaryKey:  make([]string, 0),
+		Indexes:     make([]GraphIndex, 0, len(indexes)),
+	}
+
+	uniqueSets := make([][]string, 0)
+	foreign := make(map[string]bool)
+	for _, constraint := range snapshot.Constraints {
+		switch constraint.Type {
+		case constants.ConstraintTypePrimaryKey:
+			table.PrimaryKey = constraint.Columns
+			uniqueSets = append(uniqueSets, constraint.Columns)
+		case constants.ConstraintTypeUnique:
+			uniqueSets = append(uniqueSets, constraint.Columns)
+		case constants.ConstraintTypeForeignKey:
+			for _, column := range constraint.Columns {
+				foreign[column] = true
+			}
+		}
+	}
+
+	for _, index := range indexes {
+		table.Indexes = append(table.Indexes, GraphIndex{
+			Name:    index.Name,
+			Columns: index.Columns,
+			Method:  index.Method,
+			Unique:  index.IsUnique,
+			Primary: index.IsPrimary,
+		})
+
+		// partial and expression indexes do not make their columns unique
+		if !index.IsUnique || index.Predicate != "" {
+			continue
+		}
+
+		columns := make([]string, 0, len(index.Columns))
+		for _, column := range index.Columns {
+			if column.IsExpression {
+				columns = nil
+				break
+			}
+
+			columns = append(columns, column.Name)
+		}
+
+		if len(columns) > 0 {
+			uniqueSets = append(uniqueSets, columns)
+		}
+	}
+
+	nullable := make(map[string]bool)
+	table.Columns = toGraphColumns(snapshot.Columns)
+	for i, column := range table.Columns {
+		table.Columns[i].Primary = slices.Contains(table.PrimaryKey, column.Name)
+		table.Columns[i].Unique = !table.Columns[i].Primary && containsColumnSet(uniqueSets, []string{column.Name})
+		table.Columns[i].Foreign = foreign[column.Name]
+		nullable[column.Name] = !column.NotNull
+	}
+
+	for _, constraint := range snapshot.Constraints {
+		if constraint.Type != constants.ConstraintTypeForeignKey {
+			continue
+		}
+
+		g.Relations = append(g.Relations, GraphRelation{
+			Name:        constraint.Name,
+			FromTable:   table.FullName(),
+			FromColumns: constraint.Columns,
+			ToTable:     normalizeRegclassName(constraint.ReferenceTable),
+			ToColumns:   constraint.ReferenceColumns,
+			OnDelete:    constraint.OnDelete,
+			OnUpdate:    constraint.OnUpdate,
+			Optional:    slices.ContainsFunc(constraint.Columns, func(column string) bool { return nullable[column] }),
+			OneToOne:    containsColumnSet(uniqueSets, constraint.Columns),
+		})
+	}
+
+	g.Tables = append(g.Tables, table)
+}
+
+func (g *SchemaGraph) addView(view View, columns []Column) {
+	g.Views = append(g.Views, GraphView{
+		Schema:       view.Schema,
+		Name:         view.Name,
+		Materialized: view.Materialized,
+		Columns:      toGraphColumns(columns),
+	})
+}
+
+func (g *SchemaGraph) addFunction(schema string, function Function) {
+	g.Functions = append(g.Functions, GraphFunction{
+		Schema:     schema,
+		Name:       function.Name,
+		Arguments:  function.Arguments,
+		ReturnType: function.DataType,
+		Language:   function.Language,
+	})
+}
+
+// sort orders everything by name so renders of an unchanged schema stay byte for byte identical
+func (g *SchemaGraph) sort() {
+	sort.Strings(g.Schemas)
+	sort.SliceStable(g.Tables, func(i, j int) bool { return g.Tables[i].FullName() < g.Tables[j].FullName() })
+	sort.SliceStable(g.Views, func(i, j int) bool { return g.Views[i].FullName() < g.Views[j].FullName() })
+	sort.SliceStable(g.Functions, func(i, j int) bool {
+		if g.Functions[i].Schema != g.Functions[j].Schema {
+			return g.Functions[i].Schema < g.Functions[j].Schema
+		}
+
+		return g.Functions[i].Name < g.Functions[j].Name
+	})
+	sort.SliceStable(g.Relations, func(i, j int) bool {
+		if g.Relations[i].FromTable != g.Relations[j].FromTable {
+			return g.Relations[i].FromTable < g.Relations[j].FromTable
+		}
+
+		return g.Relations[i].Name < g.Relations[j].Name
+	})
+}
+
+// toGraphColumns drops the duplicates the column listing returns for columns in several constraints
+func toGraphColumns(columns []Column) []GraphColumn {
+	unique := uniqueColumns(columns)
+
+	graphColumns := make([]GraphColumn, len(unique))
+	for i, column := range unique {
+		graphColumns[i] = GraphColumn{
+			Name:        column.Name,
+			Type:        column.Type,
+			NotNull:     column.NotNull,
+			Default:     column.Default,
+			Description: column.Description,
+		}
+	}
+
+	return graphColumns
+}
+
+func containsColumnSet(sets [][]string, columns []string) bool {
+	for _, set := range sets {
+		if len(set) == len(columns) && !slices.ContainsFunc(columns, func(column string) bool {
+			return !slices.Contains(set, column)
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeRegclassName turns a regclass name, which leaves out schemas on the search path and
+// quotes mixed case names, into an unquoted schema qualified name
+func normalizeRegclassName(name string) string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+
+	for i := 0; i < len(name); i++ {
+		switch {
+		case name[i] == '"' && quoted && i+1 < len(name) && name[i+1] == '"':
+			current.WriteByte('"')
+			i++
+		case name[i] == '"':
+			quoted = !quoted
+		case name[i] == '.' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(name[i])
+		}
+	}
+
+	parts = append(parts, current.String())
+	if len(parts) == 1 {
+		return pkg.DefaultSchema + "." + parts[0]
+	}
+
+	return parts[0] + "." + parts[1]
+}
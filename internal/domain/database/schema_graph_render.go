@@ -0,0 +1,425 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	dbmlPlainTypePattern    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\(\d+(,\s*\d+)?\))?(\[\])?$`)
+	mermaidUnsupportedChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// RenderSchemaGraph writes the graph as DBML, Mermaid ER or Graphviz DOT. Functions have no
+// place in a diagram and are only part of the json form
+func RenderSchemaGraph(graph SchemaGraph, format string) (string, error) {
+	switch format {
+	case constants.SchemaGraphFormatDBML:
+		return renderDBML(graph), nil
+	case constants.SchemaGraphFormatMermaid:
+		return renderMermaid(graph), nil
+	case constants.SchemaGraphFormatDOT:
+		return renderDOT(graph), nil
+	default:
+		return "", fmt.Errorf("unsupported schema graph format: %s", format)
+	}
+}
+
+func SchemaGraphContentType(format string) string {
+	switch format {
+	case constants.SchemaGraphFormatJSON:
+		return "application/json"
+	case constants.SchemaGraphFormatDOT:
+		return "text/vnd.graphviz"
+	default:
+		return "text/plain"
+	}
+}
+
+func SchemaGraphFileExtension(format string) string {
+	switch format {
+	case constants.SchemaGraphFormatMermaid:
+		return "mmd"
+	case constants.SchemaGraphFormatDOT:
+		return "gv"
+	default:
+		return format
+	}
+}
+
+func renderDBML(graph SchemaGraph) string {
+	var builder strings.Builder
+
+	for _, table := range graph.Tables {
+		fmt.Fprintf(&builder, "Table %s {\n", dbmlTableName(table.Schema, table.Name))
+
+		for _, column := range table.Columns {
+			// composite primary keys go into the indexes block
+			primary := column.Primary && len(table.PrimaryKey) == 1
+			writeDBMLColumn(&builder, column, primary)
+		}
+
+		indexes := make([]string, 0, len(table.Indexes)+1)
+		if len(table.PrimaryKey) > 1 {
+			indexes = append(indexes, fmt.Sprintf("(%s) [pk]", dbmlColumnList(table.PrimaryKey)))
+		}
+
+		for _, index := range table.Indexes {
+			if index.Primary {
+				continue
+			}
+
+			columns := make([]string, len(index.Columns))
+			for i, column := range index.Columns {
+				if column.IsExpression {
+					columns[i] = "`" + column.Name + "`"
+				} else {
+					columns[i] = dbmlIdentifier(column.Name)
+				}
+			}
+
+			settings := []string{"name: " + dbmlString(index.Name)}
+			if index.Unique {
+				settings = append([]string{"unique"}, settings...)
+			}
+
+			if index.Method != "" && index.Method != "btree" {
+				settings = append(settings, "type: "+index.Method)
+			}
+
+			indexes = append(indexes, fmt.Sprintf("(%s) [%s]", strings.Join(columns, ", "), strings.Join(settings, ", ")))
+		}
+
+		if len(indexes) > 0 {
+			builder.WriteString("\n  indexes {\n")
+			for _, index := range indexes {
+				fmt.Fprintf(&builder, "    %s\n", index)
+			}
+			builder.WriteString("  }\n")
+		}
+
+		if table.Description != "" {
+			fmt.Fprintf(&builder, "\n  Note: %s\n", dbmlString(table.Description))
+		}
+
+		builder.WriteString("}\n\n")
+	}
+
+	// dbml has no views, they are drawn as tables noted as such
+	for _, view := range graph.Views {
+		fmt.Fprintf(&builder, "Table %s {\n", dbmlTableName(view.Schema, view.Name))
+
+		for _, column := range view.Columns {
+			writeDBMLColumn(&builder, column, false)
+		}
+
+		note := "view"
+		if view.Materialized {
+			note = "materialized view"
+		}
+
+		fmt.Fprintf(&builder, "\n  Note: %s\n}\n\n", dbmlString(note))
+	}
+
+	for _, relation := range graph.Relations {
+		operator := ">"
+		if relation.OneToOne {
+			operator = "-"
+		}
+
+		fmt.Fprintf(
+			&builder,
+			"Ref: %s %s %s",
+			dbmlRelationEnd(relation.FromTable, relation.FromColumns),
+			operator,
+			dbmlRelationEnd(relation.ToTable, relation.ToColumns),
+		)
+
+		var settings []string
+		if action := dbmlReferentialAction(relation.OnDelete); action != "" {
+			settings = append(settings, "delete: "+action)
+		}
+
+		if action := dbmlReferentialAction(relation.OnUpdate); action != "" {
+			settings = append(settings, "update: "+action)
+		}
+
+		if len(settings) > 0 {
+			fmt.Fprintf(&builder, " [%s]", strings.Join(settings, ", "))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimRight(builder.String(), "\n") + "\n"
+}
+
+func writeDBMLColumn(builder *strings.Builder, column GraphColumn, primary bool) {
+	columnType := column.Type
+	if !dbmlPlainTypePattern.MatchString(columnType) {
+		columnType = `"` + strings.ReplaceAll(columnType, `"`, `\"`) + `"`
+	}
+
+	var settings []string
+	if primary {
+		settings = append(settings, "pk")
+	}
+
+	if column.NotNull && !primary {
+		settings = append(settings, "not null")
+	}
+
+	if column.Unique {
+		settings = append(settings, "unique")
+	}
+
+	if column.Default != "" {
+		settings = append(settings, "default: `"+strings.ReplaceAll(column.Default, "`", "'")+"`")
+	}
+
+	if column.Description != "" {
+		settings = append(settings, "note: "+dbmlString(column.Description))
+	}
+
+	fmt.Fprintf(builder, "  %s %s", dbmlIdentifier(column.Name), columnType)
+	if len(settings) > 0 {
+		fmt.Fprintf(builder, " [%s]", strings.Join(settings, ", "))
+	}
+
+	builder.WriteString("\n")
+}
+
+func dbmlIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
+
+func dbmlTableName(schema, name string) string {
+	return dbmlIdentifier(schema) + "." + dbmlIdentifier(name)
+}
+
+func dbmlColumnList(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = dbmlIdentifier(column)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func dbmlRelationEnd(fullTableName string, columns []string) string {
+	schema, name := splitGraphTableName(fullTableName)
+	if len(columns) == 1 {
+		return dbmlTableName(schema, name) + "." + dbmlIdentifier(columns[0])
+	}
+
+	return dbmlTableName(schema, name) + ".(" + dbmlColumnList(columns) + ")"
+}
+
+func dbmlString(value string) string {
+	if strings.Contains(value, "\n") {
+		return "'''" + strings.ReplaceAll(value, "'''", `\'''`) + "'''"
+	}
+
+	value = strings.ReplaceAll(value, `\`, `\\`)
+
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+// dbmlReferentialAction leaves out the postgres default, which dbml assumes as well
+func dbmlReferentialAction(action string) string {
+	if action == "" || strings.EqualFold(action, "NO ACTION") {
+		return ""
+	}
+
+	return strings.ToLower(action)
+}
+
+func renderMermaid(graph SchemaGraph) string {
+	var builder strings.Builder
+	builder.WriteString("erDiagram\n")
+
+	for _, table := range graph.Tables {
+		writeMermaidEntity(&builder, table.FullName(), table.FullName(), table.Columns)
+	}
+
+	for _, view := range graph.Views {
+		label := view.FullName() + " (view)"
+		if view.Materialized {
+			label = view.FullName() + " (materialized view)"
+		}
+
+		writeMermaidEntity(&builder, view.FullName(), label, view.Columns)
+	}
+
+	for _, relation := range graph.Relations {
+		parent := "||"
+		if relation.Optional {
+			parent = "|o"
+		}
+
+		child := "o{"
+		if relation.OneToOne {
+			child = "o|"
+		}
+
+		fmt.Fprintf(
+			&builder,
+			"    %s %s--%s %s : %s\n",
+			mermaidIdentifier(relation.ToTable),
+			parent,
+			child,
+			mermaidIdentifier(relation.FromTable),
+			mermaidString(relation.Name),
+		)
+	}
+
+	return builder.String()
+}
+
+func writeMermaidEntity(builder *strings.Builder, name, label string, columns []GraphColumn) {
+	fmt.Fprintf(builder, "    %s[%s] {\n", mermaidIdentifier(name), mermaidString(label))
+
+	for _, column := range columns {
+		var keys []string
+		if column.Primary {
+			keys = append(keys, "PK")
+		}
+
+		if column.Foreign {
+			keys = append(keys, "FK")
+		}
+
+		if column.Unique {
+			keys = append(keys, "UK")
+		}
+
+		fmt.Fprintf(builder, "        %s %s", mermaidIdentifier(column.Type), mermaidIdentifier(column.Name))
+		if len(keys) > 0 {
+			fmt.Fprintf(builder, " %s", strings.Join(keys, ", "))
+		}
+
+		if column.Description != "" {
+			fmt.Fprintf(builder, " %s", mermaidString(column.Description))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("    }\n")
+}
+
+// mermaidIdentifier replaces everything mermaid does not accept in entity, type and attribute names
+func mermaidIdentifier(name string) string {
+	return mermaidUnsupportedChars.ReplaceAllString(name, "_")
+}
+
+func mermaidString(value string) string {
+	value = strings.NewReplacer(`"`, "'", "\r", " ", "\n", " ").Replace(value)
+
+	return `"` + value + `"`
+}
+
+func renderDOT(graph SchemaGraph) string {
+	var builder strings.Builder
+	builder.WriteString("digraph schema {\n")
+	builder.WriteString("  graph [rankdir=LR];\n")
+	builder.WriteString("  node [shape=plaintext, fontname=\"Helvetica\"];\n")
+	builder.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	ports := make(map[string]map[string]string)
+	for _, table := range graph.Tables {
+		ports[table.FullName()] = writeDOTNode(&builder, table.FullName(), table.FullName(), "#d9e7f5", table.Columns)
+	}
+
+	for _, view := range graph.Views {
+		label := view.FullName() + " (view)"
+		if view.Materialized {
+			label = view.FullName() + " (materialized view)"
+		}
+
+		writeDOTNode(&builder, view.FullName(), label, "#eeeeee", view.Columns)
+	}
+
+	for _, relation := range graph.Relations {
+		// composite keys are drawn from their first column
+		from := dotString(relation.FromTable)
+		if port, ok := ports[relation.FromTable][relation.FromColumns[0]]; ok {
+			from += ":" + port
+		}
+
+		to := dotString(relation.ToTable)
+		if port, ok := ports[relation.ToTable][relation.ToColumns[0]]; ok {
+			to += ":" + port
+		}
+
+		attributes := []string{"label=" + dotString(relation.Name)}
+		if relation.Optional {
+			attributes = append(attributes, "style=dashed")
+		}
+
+		if relation.OneToOne {
+			attributes = append(attributes, "arrowtail=tee", "dir=both")
+		}
+
+		fmt.Fprintf(&builder, "  %s -> %s [%s];\n", from, to, strings.Join(attributes, ", "))
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// writeDOTNode draws a table as an html label and returns the port of each column
+func writeDOTNode(builder *strings.Builder, name, label, color string, columns []GraphColumn) map[string]string {
+	ports := make(map[string]string, len(columns))
+
+	fmt.Fprintf(builder, "  %s [label=<\n", dotString(name))
+	builder.WriteString("    <table border=\"0\" cellborder=\"1\" cellspacing=\"0\" cellpadding=\"4\">\n")
+	fmt.Fprintf(builder, "      <tr><td bgcolor=\"%s\" colspan=\"2\"><b>%s</b></td></tr>\n", color, html.EscapeString(label))
+
+	for i, column := range columns {
+		port := fmt.Sprintf("c%d", i)
+		ports[column.Name] = port
+
+		columnName := html.EscapeString(column.Name)
+		if column.Primary {
+			columnName = "<u>" + columnName + "</u>"
+		}
+
+		if column.Foreign {
+			columnName += " (FK)"
+		}
+
+		fmt.Fprintf(
+			builder,
+			"      <tr><td port=\"%s\" align=\"left\">%s</td><td align=\"left\"><i>%s</i></td></tr>\n",
+			port,
+			columnName,
+			html.EscapeString(column.Type),
+		)
+	}
+
+	builder.WriteString("    </table>\n  >];\n")
+
+	return ports
+}
+
+func dotString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// splitGraphTableName splits the unquoted schema qualified names the graph uses
+func splitGraphTableName(fullTableName string) (string, string) {
+	schema, name, found := strings.Cut(fullTableName, ".")
+	if !found {
+		return pkg.DefaultSchema, fullTableName
+	}
+
+	return schema, name
+}
@@ -0,0 +1,230 @@
+package database
+
+import (
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+	"slices"
+)
+
+type SchemaGraphService interface {
+	Build(request SchemaGraphInput, authUser auth.User) (SchemaGraph, error)
+}
+
+type SchemaGraphServiceImpl struct {
+	connectionService ConnectionService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+type schemaGraphRepos struct {
+	schemaRepo     SchemaRepository
+	tableRepo      TableRepository
+	columnRepo     ColumnRepository
+	constraintRepo ConstraintRepository
+	indexRepo      IndexRepository
+	viewRepo       ViewRepository
+	functionRepo   FunctionRepository
+}
+
+func NewSchemaGraphService(injector *do.Injector) (SchemaGraphService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &SchemaGraphServiceImpl{
+		connectionService: connectionService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// Build reads tables, keys, indexes, views and functions of the project database into one graph
+func (s *SchemaGraphServiceImpl) Build(request SchemaGraphInput, authUser auth.User) (SchemaGraph, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return SchemaGraph{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return SchemaGraph{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	connection, err := s.connectionService.ConnectByDatabaseName(fetchedProject.DBName)
+	if err != nil {
+		return SchemaGraph{}, err
+	}
+	defer connection.Close()
+
+	repos, err := s.getClientRepos(connection)
+	if err != nil {
+		return SchemaGraph{}, err
+	}
+
+	schemas, err := repos.schemaRepo.List()
+	if err != nil {
+		return SchemaGraph{}, err
+	}
+
+	names := make([]string, len(schemas))
+	for i, schema := range schemas {
+		names[i] = schema.Name
+	}
+
+	for _, schema := range request.Schemas {
+		if !slices.Contains(names, schema) {
+			return SchemaGraph{}, flxErrors.NewNotFoundError("schema.error.notFound")
+		}
+	}
+
+	graph := SchemaGraph{
+		Schemas:   names,
+		Tables:    make([]GraphTable, 0),
+		Relations: make([]GraphRelation, 0),
+		Views:     make([]GraphView, 0),
+		Functions: make([]GraphFunction, 0),
+	}
+
+	if len(request.Schemas) > 0 {
+		graph.Schemas = request.Schemas
+	}
+
+	for _, schema := range graph.Schemas {
+		if err := s.addSchema(&graph, repos, schema); err != nil {
+			return SchemaGraph{}, err
+		}
+	}
+
+	graph.sort()
+
+	return graph, nil
+}
+
+func (s *SchemaGraphServiceImpl) addSchema(graph *SchemaGraph, repos schemaGraphRepos, schema string) error {
+	tables, err := repos.tableRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table.PartitionOf != "" {
+			continue
+		}
+
+		fullTableName := table.Schema + "." + table.Name
+
+		columns, err := repos.columnRepo.List(quoteTableName(fullTableName))
+		if err != nil {
+			return err
+		}
+
+		constraints, err := repos.constraintRepo.List(fullTableName)
+		if err != nil {
+			return err
+		}
+
+		indexes, err := repos.indexRepo.List(table.Schema, table.Name)
+		if err != nil {
+			return err
+		}
+
+		graph.addTable(TableSnapshot{Table: table, Columns: columns, Constraints: constraints}, indexes)
+	}
+
+	views, err := repos.viewRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, view := range views {
+		columns, err := repos.columnRepo.List(quoteTableName(view.FullName()))
+		if err != nil {
+			return err
+		}
+
+		graph.addView(view, columns)
+	}
+
+	functions, err := repos.functionRepo.List(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, function := range functions {
+		graph.addFunction(schema, function)
+	}
+
+	return nil
+}
+
+func (s *SchemaGraphServiceImpl) getClientRepos(connection *sqlx.DB) (schemaGraphRepos, error) {
+	schemaRepo, _, err := s.connectionService.GetSchemaRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	tableRepo, _, err := s.connectionService.GetTableRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	columnRepo, _, err := s.connectionService.GetColumnRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	constraintRepo, _, err := s.connectionService.GetConstraintRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	indexRepo, _, err := s.connectionService.GetIndexRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	viewRepo, _, err := s.connectionService.GetViewRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	functionRepo, _, err := s.connectionService.GetFunctionRepo("", connection)
+	if err != nil {
+		return schemaGraphRepos{}, err
+	}
+
+	var repos schemaGraphRepos
+	var ok bool
+
+	if repos.schemaRepo, ok = schemaRepo.(SchemaRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientSchemaRepo is invalid")
+	}
+
+	if repos.tableRepo, ok = tableRepo.(TableRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	if repos.columnRepo, ok = columnRepo.(ColumnRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientColumnRepo is invalid")
+	}
+
+	if repos.constraintRepo, ok = constraintRepo.(ConstraintRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	if repos.indexRepo, ok = indexRepo.(IndexRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientIndexRepo is invalid")
+	}
+
+	if repos.viewRepo, ok = viewRepo.(ViewRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientViewRepo is invalid")
+	}
+
+	if repos.functionRepo, ok = functionRepo.(FunctionRepository); !ok {
+		return schemaGraphRepos{}, flxErrors.NewUnprocessableError("clientFunctionRepo is invalid")
+	}
+
+	return repos, nil
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func sampleSchemaGraph() SchemaGraph {
+	var graph SchemaGraph
+
+	graph.addTable(TableSnapshot{
+		Table: Table{Schema: "public", Name: "users", Description: "People who can sign in"},
+		Columns: []Column{
+			{Name: "id", Type: "integer", NotNull: true, Default: "nextval('users_id_seq'::regclass)"},
+			{Name: "id", Type: "integer", NotNull: true, Default: "nextval('users_id_seq'::regclass)"},
+			{Name: "email", Type: "character varying(255)", NotNull: true, Description: "Login address"},
+		},
+		Constraints: []Constraint{
+			{Name: "users_pkey", Type: constants.ConstraintTypePrimaryKey, Columns: []string{"id"}},
+		},
+	}, []Index{
+		{Name: "users_pkey", Method: "btree", IsUnique: true, IsPrimary: true, Columns: []IndexColumn{{Name: "id"}}},
+		{Name: "users_email_key", Method: "btree", IsUnique: true, Columns: []IndexColumn{{Name: "email"}}},
+		{Name: "users_lower_email_idx", Method: "btree", Columns: []IndexColumn{{Name: "lower((email)::text)", IsExpression: true}}},
+	})
+
+	graph.addTable(TableSnapshot{
+		Table: Table{Schema: "public", Name: "profiles"},
+		Columns: []Column{
+			{Name: "user_id", Type: "integer", NotNull: true},
+			{Name: "bio", Type: "text"},
+		},
+		Constraints: []Constraint{
+			{Name: "profiles_pkey", Type: constants.ConstraintTypePrimaryKey, Columns: []string{"user_id"}},
+			{
+				Name:             "profiles_user_id_fkey",
+				Type:             constants.ConstraintTypeForeignKey,
+				Columns:          []string{"user_id"},
+				ReferenceTable:   "users",
+				ReferenceColumns: []string{"id"},
+				OnDelete:         "CASCADE",
+				OnUpdate:         "NO ACTION",
+			},
+		},
+	}, nil)
+
+	graph.addTable(TableSnapshot{
+		Table: Table{Schema: "billing", Name: "invoices"},
+		Columns: []Column{
+			{Name: "id", Type: "bigint", NotNull: true},
+			{Name: "user_id", Type: "integer"},
+		},
+		Constraints: []Constraint{
+			{Name: "invoices_pkey", Type: constants.ConstraintTypePrimaryKey, Columns: []string{"id"}},
+			{
+				Name:             "invoices_user_id_fkey",
+				Type:             constants.ConstraintTypeForeignKey,
+				Columns:          []string{"user_id"},
+				ReferenceTable:   "public.users",
+				ReferenceColumns: []string{"id"},
+				OnDelete:         "SET NULL",
+				OnUpdate:         "NO ACTION",
+			},
+		},
+	}, nil)
+
+	graph.addView(View{Schema: "public", Name: "active_users"}, []Column{{Name: "id", Type: "integer"}})
+	graph.addFunction("public", Function{Name: "touch", DataType: "trigger", Language: "plpgsql"})
+	graph.sort()
+
+	return graph
+}
+
+func TestSchemaGraph_Suite(t *testing.T) {
+	graph := sampleSchemaGraph()
+
+	t.Run("tables are sorted and keep one row per column", func(t *testing.T) {
+		assert.Len(t, graph.Tables, 3)
+		assert.Equal(t, "billing.invoices", graph.Tables[0].FullName())
+		assert.Equal(t, "public.users", graph.Tables[2].FullName())
+		assert.Len(t, graph.Tables[2].Columns, 2)
+	})
+
+	t.Run("columns carry key flags", func(t *testing.T) {
+		users := graph.Tables[2]
+
+		assert.Equal(t, []string{"id"}, users.PrimaryKey)
+		assert.True(t, users.Columns[0].Primary)
+		assert.False(t, users.Columns[0].Unique)
+		assert.True(t, users.Columns[1].Unique, "a unique index makes the column unique")
+		assert.True(t, graph.Tables[0].Columns[1].Foreign)
+	})
+
+	t.Run("foreign keys become relations", func(t *testing.T) {
+		assert.Equal(t, []GraphRelation{
+			{
+				Name:        "invoices_user_id_fkey",
+				FromTable:   "billing.invoices",
+				FromColumns: []string{"user_id"},
+				ToTable:     "public.users",
+				ToColumns:   []string{"id"},
+				OnDelete:    "SET NULL",
+				OnUpdate:    "NO ACTION",
+				Optional:    true,
+			},
+			{
+				Name:        "profiles_user_id_fkey",
+				FromTable:   "public.profiles",
+				FromColumns: []string{"user_id"},
+				ToTable:     "public.users",
+				ToColumns:   []string{"id"},
+				OnDelete:    "CASCADE",
+				OnUpdate:    "NO ACTION",
+				OneToOne:    true,
+			},
+		}, graph.Relations)
+	})
+
+	t.Run("regclass names are schema qualified", func(t *testing.T) {
+		assert.Equal(t, "public.users", normalizeRegclassName("users"))
+		assert.Equal(t, "billing.invoices", normalizeRegclassName("billing.invoices"))
+		assert.Equal(t, "Sales.Order.Items", normalizeRegclassName(`"Sales"."Order.Items"`))
+		assert.Equal(t, `public.say "hi"`, normalizeRegclassName(`"say ""hi"""`))
+	})
+}
+
+func TestRenderSchemaGraph_Suite(t *testing.T) {
+	graph := sampleSchemaGraph()
+
+	t.Run("dbml", func(t *testing.T) {
+		rendered, err := RenderSchemaGraph(graph, constants.SchemaGraphFormatDBML)
+
+		assert.NoError(t, err)
+		assert.Contains(t, rendered, `Table "public"."users" {`)
+		assert.Contains(t, rendered, "  \"id\" integer [pk, default: `nextval('users_id_seq'::regclass)`]\n")
+		assert.Contains(t, rendered, `  "email" "character varying(255)" [not null, unique, note: 'Login address']`)
+		assert.Contains(t, rendered, "    (`lower((email)::text)`) [name: 'users_lower_email_idx']\n")
+		assert.Contains(t, rendered, "  Note: 'People who can sign in'\n")
+		assert.Contains(t, rendered, `Ref: "billing"."invoices"."user_id" > "public"."users"."id" [delete: set null]`)
+		assert.Contains(t, rendered, `Ref: "public"."profiles"."user_id" - "public"."users"."id" [delete: cascade]`)
+		assert.NotContains(t, rendered, "users_pkey")
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		rendered, err := RenderSchemaGraph(graph, constants.SchemaGraphFormatMermaid)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(rendered, "erDiagram\n"))
+		assert.Contains(t, rendered, `    public_users["public.users"] {`)
+		assert.Contains(t, rendered, `        character_varying_255_ email UK "Login address"`)
+		assert.Contains(t, rendered, `    public_active_users["public.active_users (view)"] {`)
+		assert.Contains(t, rendered, `    public_users |o--o{ billing_invoices : "invoices_user_id_fkey"`)
+		assert.Contains(t, rendered, `    public_users ||--o| public_profiles : "profiles_user_id_fkey"`)
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		rendered, err := RenderSchemaGraph(graph, constants.SchemaGraphFormatDOT)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(rendered, "digraph schema {\n"))
+		assert.Contains(t, rendered, `<td port="c1" align="left">email</td><td align="left"><i>character varying(255)</i></td>`)
+		assert.Contains(t, rendered, `"billing.invoices":c1 -> "public.users":c0 [label="invoices_user_id_fkey", style=dashed];`)
+		assert.Contains(t, rendered, `"public.profiles":c0 -> "public.users":c0 [label="profiles_user_id_fkey", arrowtail=tee, dir=both];`)
+	})
+
+	t.Run("json is not rendered", func(t *testing.T) {
+		_, err := RenderSchemaGraph(graph, constants.SchemaGraphFormatJSON)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,11 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+// SchemaGraphInput builds the graph of the given schemas, all user schemas when empty
+type SchemaGraphInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Schemas     []string  `json:"schemas"`
+}
@@ -0,0 +1,10 @@
+package database
+
+type SchemaRepository interface {
+	List() ([]Schema, error)
+	GetByName(name string) (Schema, error)
+	Exists(name string) (bool, error)
+	Create(schemaSQL string) error
+	Rename(schemaSQL string) error
+	Drop(schemaSQL string) error
+}
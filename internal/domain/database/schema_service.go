@@ -0,0 +1,287 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"slices"
+)
+
+type SchemaService interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]Schema, error)
+	Create(request CreateSchemaInput, authUser auth.User) (Schema, error)
+	Rename(name string, request RenameSchemaInput, authUser auth.User) (Schema, error)
+	Delete(name string, request DropSchemaInput, authUser auth.User) (bool, error)
+	ListExposed(projectUUID uuid.UUID, authUser auth.User) ([]string, error)
+	UpdateExposed(request ExposeSchemasInput, authUser auth.User) ([]string, error)
+}
+
+type SchemaServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewSchemaService(injector *do.Injector) (SchemaService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &SchemaServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *SchemaServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Schema, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientSchemaRepo, connection, err := s.getClientSchemaRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	schemas, err := clientSchemaRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range schemas {
+		schemas[i].Exposed = slices.Contains(fetchedProject.ExposedSchemas, schemas[i].Name)
+	}
+
+	return schemas, nil
+}
+
+func (s *SchemaServiceImpl) Create(request CreateSchemaInput, authUser auth.User) (Schema, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Schema{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientSchemaRepo, connection, err := s.getClientSchemaRepo(fetchedProject.DBName)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer connection.Close()
+
+	exists, err := clientSchemaRepo.Exists(request.Name)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if exists {
+		return Schema{}, flxErrors.NewUnprocessableError("schema.error.alreadyExists")
+	}
+
+	if err = clientSchemaRepo.Create(buildCreateSchemaStatement(request.Name)); err != nil {
+		return Schema{}, s.toSchemaError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateSchemaMigration(request.Name))
+
+	return clientSchemaRepo.GetByName(request.Name)
+}
+
+// Rename keeps an exposed schema exposed under its new name, which restarts the project's PostgREST
+func (s *SchemaServiceImpl) Rename(name string, request RenameSchemaInput, authUser auth.User) (Schema, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Schema{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientSchemaRepo, connection, err := s.getClientSchemaRepo(fetchedProject.DBName)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer connection.Close()
+
+	if _, err = clientSchemaRepo.GetByName(name); err != nil {
+		return Schema{}, err
+	}
+
+	exists, err := clientSchemaRepo.Exists(request.Name)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if exists {
+		return Schema{}, flxErrors.NewUnprocessableError("schema.error.alreadyExists")
+	}
+
+	if err = clientSchemaRepo.Rename(buildRenameSchemaStatement(name, request.Name)); err != nil {
+		return Schema{}, s.toSchemaError(err)
+	}
+
+	s.migrationService.Record(connection, buildRenameSchemaMigration(name, request.Name))
+
+	exposed, changed := replaceExposedSchema(fetchedProject.ExposedSchemas, name, request.Name)
+	if err = s.applyExposedSchemas(fetchedProject, exposed, changed); err != nil {
+		return Schema{}, err
+	}
+
+	schema, err := clientSchemaRepo.GetByName(request.Name)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	schema.Exposed = changed
+
+	return schema, nil
+}
+
+func (s *SchemaServiceImpl) Delete(name string, request DropSchemaInput, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientSchemaRepo, connection, err := s.getClientSchemaRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	if _, err = clientSchemaRepo.GetByName(name); err != nil {
+		return false, err
+	}
+
+	if err = clientSchemaRepo.Drop(buildDropSchemaStatement(name, request.Cascade)); err != nil {
+		return false, s.toSchemaError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropSchemaMigration(name, request.Cascade))
+
+	exposed, changed := replaceExposedSchema(fetchedProject.ExposedSchemas, name, "")
+	if err = s.applyExposedSchemas(fetchedProject, exposed, changed); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *SchemaServiceImpl) ListExposed(projectUUID uuid.UUID, authUser auth.User) ([]string, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	return fetchedProject.ExposedSchemas, nil
+}
+
+// UpdateExposed replaces the schemas PostgREST serves, every one of them has to exist
+func (s *SchemaServiceImpl) UpdateExposed(request ExposeSchemasInput, authUser auth.User) ([]string, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientSchemaRepo, connection, err := s.getClientSchemaRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	for _, name := range request.Schemas {
+		exists, err := clientSchemaRepo.Exists(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, flxErrors.NewNotFoundError("schema.error.notFound")
+		}
+	}
+
+	changed := !slices.Equal(fetchedProject.ExposedSchemas, request.Schemas)
+	if err = s.applyExposedSchemas(fetchedProject, request.Schemas, changed); err != nil {
+		return nil, err
+	}
+
+	return request.Schemas, nil
+}
+
+// applyExposedSchemas stores the exposed schemas and restarts PostgREST when they changed, otherwise
+// reloading its schema cache is enough to pick up the new or removed objects
+func (s *SchemaServiceImpl) applyExposedSchemas(fetchedProject project.Project, exposed []string, changed bool) error {
+	if !changed {
+		s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+		return nil
+	}
+
+	if _, err := s.projectRepo.UpdateExposedSchemas(fetchedProject.Uuid, exposed); err != nil {
+		return err
+	}
+
+	go s.postgrestService.RestartContainer(fetchedProject.DBName)
+
+	return nil
+}
+
+// toSchemaError surfaces database errors such as dropping a schema that still has tables as bad requests
+func (s *SchemaServiceImpl) toSchemaError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *SchemaServiceImpl) getClientSchemaRepo(dbName string) (SchemaRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetSchemaRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(SchemaRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientSchemaRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
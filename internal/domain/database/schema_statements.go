@@ -0,0 +1,66 @@
+package database
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+)
+
+func buildCreateSchemaStatement(name string) string {
+	return fmt.Sprintf("CREATE SCHEMA %s;", pq.QuoteIdentifier(name))
+}
+
+func buildRenameSchemaStatement(oldName, newName string) string {
+	return fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s;", pq.QuoteIdentifier(oldName), pq.QuoteIdentifier(newName))
+}
+
+func buildDropSchemaStatement(name string, cascade bool) string {
+	statement := "DROP SCHEMA " + pq.QuoteIdentifier(name)
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return statement + ";"
+}
+
+func buildCreateSchemaMigration(name string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_schema_" + name,
+		UpSQL:   buildCreateSchemaStatement(name),
+		DownSQL: buildDropSchemaStatement(name, false),
+	}
+}
+
+func buildRenameSchemaMigration(oldName, newName string) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    fmt.Sprintf("rename_schema_%s_to_%s", oldName, newName),
+		UpSQL:   buildRenameSchemaStatement(oldName, newName),
+		DownSQL: buildRenameSchemaStatement(newName, oldName),
+	}
+}
+
+// buildDropSchemaMigration cannot bring back objects a cascading drop removed
+func buildDropSchemaMigration(name string, cascade bool) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_schema_" + name,
+		UpSQL:   buildDropSchemaStatement(name, cascade),
+		DownSQL: buildCreateSchemaStatement(name),
+	}
+}
+
+// replaceExposedSchema renames or, with an empty newName, removes a schema from the exposed list keeping its order
+func replaceExposedSchema(exposed []string, oldName, newName string) ([]string, bool) {
+	index := slices.Index(exposed, oldName)
+	if index == -1 {
+		return exposed, false
+	}
+
+	updated := slices.Clone(exposed)
+	if newName == "" {
+		return slices.Delete(updated, index, index+1), true
+	}
+
+	updated[index] = newName
+
+	return updated, true
+}
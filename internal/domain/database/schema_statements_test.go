@@ -0,0 +1,44 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSchemaStatements_Suite(t *testing.T) {
+	t.Run("create and rename migrations", func(t *testing.T) {
+		create := buildCreateSchemaMigration("api")
+		assert.Equal(t, "create_schema_api", create.Name)
+		assert.Equal(t, `CREATE SCHEMA "api";`, create.UpSQL)
+		assert.Equal(t, `DROP SCHEMA "api";`, create.DownSQL)
+
+		rename := buildRenameSchemaMigration("internal", "private")
+		assert.Equal(t, "rename_schema_internal_to_private", rename.Name)
+		assert.Equal(t, `ALTER SCHEMA "internal" RENAME TO "private";`, rename.UpSQL)
+		assert.Equal(t, `ALTER SCHEMA "private" RENAME TO "internal";`, rename.DownSQL)
+	})
+
+	t.Run("cascading drop recreates an empty schema", func(t *testing.T) {
+		migration := buildDropSchemaMigration("api", true)
+
+		assert.Equal(t, `DROP SCHEMA "api" CASCADE;`, migration.UpSQL)
+		assert.Equal(t, `CREATE SCHEMA "api";`, migration.DownSQL)
+	})
+
+	t.Run("exposed schemas follow renames and drops in order", func(t *testing.T) {
+		exposed := []string{"api", "public", "reports"}
+
+		renamed, changed := replaceExposedSchema(exposed, "public", "web")
+		assert.True(t, changed)
+		assert.Equal(t, []string{"api", "web", "reports"}, renamed)
+		assert.Equal(t, []string{"api", "public", "reports"}, exposed)
+
+		dropped, changed := replaceExposedSchema(exposed, "api", "")
+		assert.True(t, changed)
+		assert.Equal(t, []string{"public", "reports"}, dropped)
+
+		unchanged, changed := replaceExposedSchema(exposed, "private", "")
+		assert.False(t, changed)
+		assert.Equal(t, exposed, unchanged)
+	})
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateSchemaInput struct {
+	ProjectUUID uuid.UUID
+	Name        string
+}
+
+type RenameSchemaInput struct {
+	ProjectUUID uuid.UUID
+	Name        string
+}
+
+type DropSchemaInput struct {
+	ProjectUUID uuid.UUID
+
+	// Cascade also drops every table, view and function inside the schema
+	Cascade bool
+}
+
+type ExposeSchemasInput struct {
+	ProjectUUID uuid.UUID
+
+	// Schemas are served by PostgREST in this order, the first one is the default for requests without a profile
+	Schemas []string
+}
@@ -0,0 +1,443 @@
+package database
+
+import (
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jaswdr/faker"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var seedTypeModifierPattern = regexp.MustCompile(`\(([0-9]+)(?:\s*,\s*([0-9]+))?\)`)
+
+// seedTypes are the base types the generator has values for, anything else is only seeded when nullable
+var seedTypes = map[string]bool{
+	"smallint": true, "integer": true, "bigint": true,
+	"numeric": true, "real": true, "double precision": true,
+	"boolean": true, "uuid": true, "json": true, "jsonb": true, "bytea": true,
+	"date": true, "interval": true,
+	"timestamp without time zone": true, "timestamp with time zone": true,
+	"time without time zone": true, "time with time zone": true,
+	"text": true, "character varying": true, "character": true, "citext": true, "name": true,
+	"inet": true, "cidr": true, "macaddr": true,
+}
+
+// seedColumn is a column the generator writes, columns postgres fills on its own are left out
+type seedColumn struct {
+	Name      string
+	Type      string
+	NotNull   bool
+	Unique    bool
+	Array     bool
+	Length    int
+	Precision int
+	Scale     int
+
+	// only set for enum columns
+	EnumValues []string
+}
+
+// seedReference holds parent rows sampled for a foreign key, column positions refer to seedGenerator.columns
+type seedReference struct {
+	Columns []int
+	Rows    [][]string
+}
+
+// seedGenerator builds fake rows from column names and types. Foreign key columns take values of
+// sampled parent rows and rows colliding on a unique constraint are regenerated
+type seedGenerator struct {
+	faker      faker.Faker
+	columns    []seedColumn
+	references []seedReference
+	uniqueSets [][]int
+
+	seen   []map[string]bool
+	series int
+}
+
+func newSeedGenerator(fake faker.Faker, columns []seedColumn, references []seedReference, uniqueSets [][]int) *seedGenerator {
+	seen := make([]map[string]bool, len(uniqueSets))
+	for i := range seen {
+		seen[i] = make(map[string]bool)
+	}
+
+	return &seedGenerator{
+		faker:      fake,
+		columns:    columns,
+		references: references,
+		uniqueSets: uniqueSets,
+		seen:       seen,
+	}
+}
+
+// Rows generates up to count rows, rows that keep colliding on a unique constraint are dropped
+func (g *seedGenerator) Rows(count int, attempts int) [][]interface{} {
+	rows := make([][]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		for attempt := 0; attempt < attempts; attempt++ {
+			row := g.row(attempt)
+			if g.claim(row) {
+				rows = append(rows, row)
+				break
+			}
+		}
+	}
+
+	return rows
+}
+
+func (g *seedGenerator) row(attempt int) []interface{} {
+	row := make([]interface{}, len(g.columns))
+	referenced := make(map[int]bool)
+
+	for _, reference := range g.references {
+		var parent []string
+		if len(reference.Rows) > 0 {
+			parent = reference.Rows[g.faker.IntBetween(0, len(reference.Rows)-1)]
+		}
+
+		for i, position := range reference.Columns {
+			referenced[position] = true
+			if parent != nil {
+				row[position] = parent[i]
+			}
+		}
+	}
+
+	g.series++
+
+	for i, column := range g.columns {
+		if referenced[i] {
+			continue
+		}
+
+		if !column.NotNull && !column.Unique && g.faker.IntBetween(1, 100) <= constants.SeedNullChance {
+			continue
+		}
+
+		value := g.value(column)
+
+		// later attempts make unique text values distinct instead of hoping for a different fake value
+		if column.Unique && attempt > 0 && value != nil {
+			value = g.distinct(column, value.(string))
+		}
+
+		row[i] = value
+	}
+
+	return row
+}
+
+// claim records the row against every unique constraint, a constraint with a null column never collides
+func (g *seedGenerator) claim(row []interface{}) bool {
+	keys := make([]string, len(g.uniqueSets))
+	for i, set := range g.uniqueSets {
+		parts := make([]string, len(set))
+		for j, position := range set {
+			if row[position] == nil {
+				parts = nil
+				break
+			}
+
+			parts[j] = fmt.Sprint(row[position])
+		}
+
+		if parts == nil {
+			continue
+		}
+
+		keys[i] = strings.Join(parts, "\x00")
+		if g.seen[i][keys[i]] {
+			return false
+		}
+	}
+
+	for i, key := range keys {
+		if key != "" {
+			g.seen[i][key] = true
+		}
+	}
+
+	return true
+}
+
+func (g *seedGenerator) value(column seedColumn) interface{} {
+	if column.Array {
+		elements := make([]string, g.faker.IntBetween(1, 3))
+		for i := range elements {
+			elements[i] = g.scalar(column)
+		}
+
+		return seedArrayLiteral(elements)
+	}
+
+	value := g.scalar(column)
+	if column.Length > 0 && len([]rune(value)) > column.Length {
+		value = string([]rune(value)[:column.Length])
+	}
+
+	return value
+}
+
+// distinct makes a value unlikely to collide again, enums and booleans only have their own values to pick from
+func (g *seedGenerator) distinct(column seedColumn, value string) string {
+	if len(column.EnumValues) > 0 || column.Array {
+		return value
+	}
+
+	suffix := strconv.Itoa(g.series)
+
+	switch seedBaseType(column.Type) {
+	case "boolean", "date", "timestamp without time zone", "timestamp with time zone",
+		"time without time zone", "time with time zone", "interval", "json", "jsonb", "bytea",
+		"inet", "cidr", "macaddr":
+		return value
+	case "smallint", "integer", "bigint", "numeric", "real", "double precision":
+		if column.Precision > 0 && len(suffix) > column.Precision-column.Scale {
+			return value
+		}
+
+		return suffix
+	case "uuid":
+		return uuid.NewString()
+	}
+
+	if local, domain, found := strings.Cut(value, "@"); found {
+		return local + "+" + suffix + "@" + domain
+	}
+
+	if column.Length > 0 && len(value)+len(suffix)+1 > column.Length {
+		value = value[:max(0, column.Length-len(suffix)-1)]
+	}
+
+	return value + "-" + suffix
+}
+
+func (g *seedGenerator) scalar(column seedColumn) string {
+	if len(column.EnumValues) > 0 {
+		return g.faker.RandomStringElement(column.EnumValues)
+	}
+
+	name := strings.ToLower(column.Name)
+
+	switch seedBaseType(column.Type) {
+	case "smallint":
+		return strconv.Itoa(g.integer(name, math.MaxInt16))
+	case "integer":
+		return strconv.Itoa(g.integer(name, math.MaxInt32))
+	case "bigint":
+		return strconv.Itoa(g.integer(name, 1000000000))
+	case "numeric", "real", "double precision":
+		return g.decimal(name, column)
+	case "boolean":
+		return strconv.FormatBool(g.faker.Bool())
+	case "date":
+		return g.moment(name).Format("2006-01-02")
+	case "timestamp without time zone":
+		return g.moment(name).Format("2006-01-02 15:04:05")
+	case "timestamp with time zone":
+		return g.moment(name).Format(time.RFC3339)
+	case "time without time zone", "time with time zone":
+		return fmt.Sprintf("%02d:%02d:%02d", g.faker.IntBetween(0, 23), g.faker.IntBetween(0, 59), g.faker.IntBetween(0, 59))
+	case "interval":
+		return fmt.Sprintf("%d minutes", g.faker.IntBetween(1, 10000))
+	case "uuid":
+		return uuid.NewString()
+	case "json", "jsonb":
+		document, _ := json.Marshal(map[string]interface{}{
+			"label":  g.faker.Lorem().Word(),
+			"value":  g.faker.IntBetween(1, 100),
+			"active": g.faker.Bool(),
+		})
+
+		return string(document)
+	case "bytea":
+		return fmt.Sprintf("\\x%x", g.faker.Lorem().Bytes(16))
+	case "inet":
+		return g.faker.Internet().Ipv4()
+	case "cidr":
+		return fmt.Sprintf("10.%d.0.0/16", g.faker.IntBetween(0, 255))
+	case "macaddr":
+		return g.faker.Internet().MacAddress()
+	}
+
+	return g.text(name)
+}
+
+// integer picks a range that suits the column name, ids and other numbers stay positive
+func (g *seedGenerator) integer(name string, limit int) int {
+	switch {
+	case name == "age":
+		return g.faker.IntBetween(18, 90)
+	case name == "year" || strings.HasSuffix(name, "_year"):
+		return g.faker.IntBetween(1990, time.Now().Year())
+	case seedNameHas(name, "rating", "stars", "score"):
+		return g.faker.IntBetween(1, 5)
+	case seedNameHas(name, "quantity", "qty", "count", "stock"):
+		return g.faker.IntBetween(0, 100)
+	case seedNameHas(name, "price", "amount", "total", "cost"):
+		return g.faker.IntBetween(1, 1000)
+	case seedNameHas(name, "position", "rank", "sort", "order"):
+		return g.faker.IntBetween(1, 100)
+	}
+
+	return g.faker.IntBetween(1, limit)
+}
+
+func (g *seedGenerator) decimal(name string, column seedColumn) string {
+	scale := 2
+	if column.Scale > 0 || column.Precision > 0 {
+		scale = column.Scale
+	}
+
+	limit := 1000
+	if column.Precision > 0 {
+		limit = min(limit, int(math.Pow10(column.Precision-column.Scale))-1)
+	}
+
+	switch {
+	case seedNameHas(name, "latitude") || name == "lat":
+		return strconv.FormatFloat(g.faker.Address().Latitude(), 'f', min(scale, 6), 64)
+	case seedNameHas(name, "longitude") || name == "lng" || name == "lon":
+		return strconv.FormatFloat(g.faker.Address().Longitude(), 'f', min(scale, 6), 64)
+	case seedNameHas(name, "rating", "score"):
+		limit = min(limit, 5)
+	}
+
+	return strconv.FormatFloat(g.faker.Float64(scale, 0, max(limit, 0)), 'f', scale, 64)
+}
+
+// moment is in the past, except for columns that name a deadline or an expiry
+func (g *seedGenerator) moment(name string) time.Time {
+	now := time.Now().UTC()
+
+	switch {
+	case seedNameHas(name, "birth", "dob"):
+		return g.faker.Time().TimeBetween(now.AddDate(-80, 0, 0), now.AddDate(-18, 0, 0))
+	case seedNameHas(name, "expire", "due", "deadline", "ends", "until", "scheduled"):
+		return g.faker.Time().TimeBetween(now, now.AddDate(1, 0, 0))
+	}
+
+	return g.faker.Time().TimeBetween(now.AddDate(-1, 0, 0), now)
+}
+
+func (g *seedGenerator) text(name string) string {
+	switch {
+	case seedNameHas(name, "email"):
+		return g.faker.Internet().Email()
+	case seedNameHas(name, "username", "user_name", "login", "handle"):
+		return g.faker.Internet().User()
+	case seedNameHas(name, "first_name", "firstname", "given_name"):
+		return g.faker.Person().FirstName()
+	case seedNameHas(name, "last_name", "lastname", "surname", "family_name"):
+		return g.faker.Person().LastName()
+	case seedNameHas(name, "password"):
+		return g.faker.Internet().Password()
+	case seedNameHas(name, "phone", "mobile"):
+		return g.faker.Phone().Number()
+	case seedNameHas(name, "company", "organization", "employer"):
+		return g.faker.Company().Name()
+	case seedNameHas(name, "job", "occupation"):
+		return g.faker.Company().JobTitle()
+	case seedNameHas(name, "street", "address"):
+		return g.faker.Address().StreetAddress()
+	case seedNameHas(name, "city", "town"):
+		return g.faker.Address().City()
+	case seedNameHas(name, "country"):
+		return g.faker.Address().Country()
+	case seedNameHas(name, "state", "province", "region"):
+		return g.faker.Address().State()
+	case seedNameHas(name, "zip", "postal", "postcode"):
+		return g.faker.Address().PostCode()
+	case seedNameHas(name, "url", "website", "link", "avatar", "image", "photo"):
+		return g.faker.Internet().URL()
+	case seedNameHas(name, "domain", "host"):
+		return g.faker.Internet().Domain()
+	case seedNameHas(name, "ip"):
+		return g.faker.Internet().Ipv4()
+	case seedNameHas(name, "slug"):
+		return strings.Join(g.faker.Lorem().Words(3), "-")
+	case seedNameHas(name, "color", "colour"):
+		return g.faker.Color().SafeColorName()
+	case seedNameHas(name, "currency"):
+		return g.faker.Currency().Code()
+	case seedNameHas(name, "gender"):
+		return g.faker.Person().Gender()
+	case seedNameHas(name, "title", "subject", "headline"):
+		return strings.TrimSuffix(g.faker.Lorem().Sentence(4), ".")
+	case seedNameHas(name, "description", "bio", "body", "content", "note", "comment", "summary", "message"):
+		return g.faker.Lorem().Paragraph(2)
+	case seedNameHas(name, "name"):
+		return g.faker.Person().Name()
+	case seedNameHas(name, "code", "sku", "reference"):
+		return strings.ToUpper(g.faker.Lorem().Word()) + "-" + strconv.Itoa(g.faker.IntBetween(1000, 9999))
+	}
+
+	return g.faker.Lorem().Sentence(3)
+}
+
+// seedBaseType strips type modifiers and the array marker, e.g. character varying(40)[] becomes character varying
+func seedBaseType(columnType string) string {
+	columnType = strings.TrimSuffix(strings.ToLower(columnType), "[]")
+
+	return strings.TrimSpace(seedTypeModifierPattern.ReplaceAllString(columnType, ""))
+}
+
+// seedSupportsType reports whether values can be generated for the column type, enums are resolved separately
+func seedSupportsType(columnType string) bool {
+	return seedTypes[seedBaseType(columnType)]
+}
+
+// seedTypeModifiers reads the length of character types or the precision and scale of numeric types
+func seedTypeModifiers(columnType string) (int, int) {
+	match := seedTypeModifierPattern.FindStringSubmatch(columnType)
+	if match == nil {
+		return 0, 0
+	}
+
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[2])
+
+	return first, second
+}
+
+// seedNameHas matches parts against the words of a column name, so city matches home_city but not
+// capacity. Parts made of several words match anywhere in the name
+func seedNameHas(name string, parts ...string) bool {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+
+	for _, part := range parts {
+		if strings.Contains(part, "_") {
+			if strings.Contains(name, part) {
+				return true
+			}
+
+			continue
+		}
+
+		for _, word := range words {
+			if word == part || word == part+"s" || (len(part) > 3 && strings.HasPrefix(word, part)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func seedArrayLiteral(elements []string) string {
+	quoted := make([]string, len(elements))
+	for i, element := range elements {
+		element = strings.ReplaceAll(element, `\`, `\\`)
+		quoted[i] = `"` + strings.ReplaceAll(element, `"`, `\"`) + `"`
+	}
+
+	return "{" + strings.Join(quoted, ",") + "}"
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"github.com/jaswdr/faker"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSeedGenerator(columns []seedColumn, references []seedReference, uniqueSets [][]int) *seedGenerator {
+	return newSeedGenerator(faker.NewWithSeed(rand.NewSource(42)), columns, references, uniqueSets)
+}
+
+func TestSeedBaseType(t *testing.T) {
+	tests := map[string]string{
+		"integer":                        "integer",
+		"character varying(40)":          "character varying",
+		"character varying(40)[]":        "character varying",
+		"numeric(10,2)":                  "numeric",
+		"timestamp(3) with time zone":    "timestamp with time zone",
+		"TIMESTAMP WITHOUT TIME ZONE":    "timestamp without time zone",
+		"public.order_status":            "public.order_status",
+		"time(0) without time zone":      "time without time zone",
+		"double precision":               "double precision",
+		"bit varying(8)":                 "bit varying",
+		"character(1)":                   "character",
+		"numeric":                        "numeric",
+		"interval":                       "interval",
+		"jsonb":                          "jsonb",
+		"uuid[]":                         "uuid",
+		"text":                           "text",
+		"character varying":              "character varying",
+		"timestamp with time zone":       "timestamp with time zone",
+		"timestamp without time zone":    "timestamp without time zone",
+		"time with time zone":            "time with time zone",
+		"public.\"Order Status\"":        "public.\"order status\"",
+		"smallint":                       "smallint",
+		"bigint":                         "bigint",
+		"boolean":                        "boolean",
+		"numeric(5)":                     "numeric",
+		"character varying(255)":         "character varying",
+		"timestamp(6) without time zone": "timestamp without time zone",
+	}
+
+	for raw, expected := range tests {
+		assert.Equal(t, expected, seedBaseType(raw), raw)
+	}
+}
+
+func TestSeedTypeModifiers(t *testing.T) {
+	first, second := seedTypeModifiers("character varying(40)")
+	assert.Equal(t, 40, first)
+	assert.Equal(t, 0, second)
+
+	first, second = seedTypeModifiers("numeric(10, 2)")
+	assert.Equal(t, 10, first)
+	assert.Equal(t, 2, second)
+
+	first, second = seedTypeModifiers("text")
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 0, second)
+}
+
+func TestSeedNameHas(t *testing.T) {
+	assert.True(t, seedNameHas("home_city", "city"))
+	assert.True(t, seedNameHas("emails", "email"))
+	assert.True(t, seedNameHas("email_address", "email"))
+	assert.True(t, seedNameHas("user_first_name", "first_name"))
+	assert.True(t, seedNameHas("descriptions", "description"))
+	assert.False(t, seedNameHas("capacity", "city"))
+	assert.False(t, seedNameHas("description", "ip"))
+	assert.False(t, seedNameHas("shipping", "ip"))
+}
+
+func TestSeedArrayLiteral(t *testing.T) {
+	assert.Equal(t, `{"a","b c"}`, seedArrayLiteral([]string{"a", "b c"}))
+	assert.Equal(t, `{"say \"hi\"","back\\slash"}`, seedArrayLiteral([]string{`say "hi"`, `back\slash`}))
+}
+
+func TestSeedGenerator_Heuristics(t *testing.T) {
+	columns := []seedColumn{
+		{Name: "email", Type: "character varying(255)", NotNull: true, Length: 255},
+		{Name: "age", Type: "integer", NotNull: true},
+		{Name: "created_at", Type: "timestamp with time zone", NotNull: true},
+		{Name: "expires_at", Type: "timestamp with time zone", NotNull: true},
+		{Name: "price", Type: "numeric(6,2)", NotNull: true, Precision: 6, Scale: 2},
+		{Name: "code", Type: "character(4)", NotNull: true, Length: 4},
+		{Name: "status", Type: "public.order_status", NotNull: true, EnumValues: []string{"open", "closed"}},
+		{Name: "tags", Type: "text[]", NotNull: true, Array: true},
+		{Name: "active", Type: "boolean", NotNull: true},
+		{Name: "id", Type: "uuid", NotNull: true},
+	}
+
+	now := time.Now()
+	rows := newTestSeedGenerator(columns, nil, nil).Rows(50, 1)
+	assert.Len(t, rows, 50)
+
+	for _, row := range rows {
+		_, err := mail.ParseAddress(row[0].(string))
+		assert.NoError(t, err, row[0])
+
+		age, err := strconv.Atoi(row[1].(string))
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, age, 18)
+		assert.LessOrEqual(t, age, 90)
+
+		createdAt, err := time.Parse(time.RFC3339, row[2].(string))
+		assert.NoError(t, err)
+		assert.False(t, createdAt.After(now.Add(time.Second)))
+
+		expiresAt, err := time.Parse(time.RFC3339, row[3].(string))
+		assert.NoError(t, err)
+		assert.True(t, expiresAt.After(now.Add(-time.Second)))
+
+		price, err := strconv.ParseFloat(row[4].(string), 64)
+		assert.NoError(t, err)
+		assert.Less(t, price, 10000.0)
+
+		assert.LessOrEqual(t, len([]rune(row[5].(string))), 4)
+		assert.Contains(t, []string{"open", "closed"}, row[6])
+		assert.True(t, strings.HasPrefix(row[7].(string), "{") && strings.HasSuffix(row[7].(string), "}"))
+		assert.Contains(t, []string{"true", "false"}, row[8])
+		assert.Len(t, row[9].(string), 36)
+	}
+}
+
+func TestSeedGenerator_NullableColumns(t *testing.T) {
+	columns := []seedColumn{{Name: "nickname", Type: "text"}}
+
+	rows := newTestSeedGenerator(columns, nil, nil).Rows(500, 1)
+
+	nulls := 0
+	for _, row := range rows {
+		if row[0] == nil {
+			nulls++
+		}
+	}
+
+	assert.Greater(t, nulls, 0)
+	assert.Less(t, nulls, 250)
+}
+
+func TestSeedGenerator_UniqueConstraints(t *testing.T) {
+	columns := []seedColumn{
+		{Name: "status", Type: "text", NotNull: true, Unique: true, EnumValues: []string{"a", "b"}},
+		{Name: "rating", Type: "integer", NotNull: true, Unique: true},
+	}
+
+	t.Run("drops rows that keep colliding", func(t *testing.T) {
+		rows := newTestSeedGenerator(columns[:1], nil, [][]int{{0}}).Rows(10, 5)
+
+		assert.Len(t, rows, 2)
+		assert.NotEqual(t, rows[0][0], rows[1][0])
+	})
+
+	t.Run("regenerates distinct values", func(t *testing.T) {
+		rows := newTestSeedGenerator(columns[1:], nil, [][]int{{0}}).Rows(100, 20)
+
+		assert.Len(t, rows, 100)
+
+		seen := make(map[interface{}]bool)
+		for _, row := range rows {
+			assert.False(t, seen[row[0]], row[0])
+			seen[row[0]] = true
+		}
+	})
+}
+
+func TestSeedGenerator_References(t *testing.T) {
+	columns := []seedColumn{
+		{Name: "tenant_id", Type: "integer", NotNull: true},
+		{Name: "author_id", Type: "integer", NotNull: true},
+		{Name: "title", Type: "text", NotNull: true},
+	}
+	references := []seedReference{
+		{Columns: []int{0, 1}, Rows: [][]string{{"1", "10"}, {"2", "20"}}},
+	}
+
+	rows := newTestSeedGenerator(columns, references, nil).Rows(20, 1)
+
+	for _, row := range rows {
+		pair := []string{row[0].(string), row[1].(string)}
+		assert.Contains(t, references[0].Rows, pair)
+		assert.NotEmpty(t, row[2])
+	}
+
+	t.Run("nullable foreign key without parents stays null", func(t *testing.T) {
+		columns := []seedColumn{{Name: "parent_id", Type: "integer"}}
+		references := []seedReference{{Columns: []int{0}}}
+
+		rows := newTestSeedGenerator(columns, references, nil).Rows(5, 1)
+
+		for _, row := range rows {
+			assert.Nil(t, row[0])
+		}
+	})
+}
+
+func TestSeedSupportsType(t *testing.T) {
+	assert.True(t, seedSupportsType("character varying(20)"))
+	assert.True(t, seedSupportsType("timestamp(3) with time zone"))
+	assert.True(t, seedSupportsType("integer[]"))
+	assert.False(t, seedSupportsType("public.order_status"))
+	assert.False(t, seedSupportsType("geometry"))
+}
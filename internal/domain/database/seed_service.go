@@ -0,0 +1,384 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/jaswdr/faker"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+)
+
+type SeedService interface {
+	Seed(fullTableName string, input SeedTableInput, authUser auth.User) (SeedResult, error)
+}
+
+type SeedServiceImpl struct {
+	connectionService ConnectionService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewSeedService(injector *do.Injector) (SeedService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &SeedServiceImpl{
+		connectionService: connectionService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// Seed inserts fake rows into the table. Foreign keys point at randomly sampled parent rows and
+// rows that would break a unique constraint, within the batch or against existing data, are skipped
+func (s *SeedServiceImpl) Seed(fullTableName string, input SeedTableInput, authUser auth.User) (SeedResult, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return SeedResult{}, flxErrors.NewForbiddenError("seed.error.forbidden")
+	}
+
+	connection, err := s.connectionService.ConnectByDatabaseName(fetchedProject.DBName)
+	if err != nil {
+		return SeedResult{}, err
+	}
+	defer connection.Close()
+
+	clientTableRepo, err := s.getClientTableRepo(connection)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	schema, tableName := pkg.ParseTableName(fullTableName)
+	if _, err = clientTableRepo.GetByNameInSchema(schema, tableName); err != nil {
+		return SeedResult{}, err
+	}
+
+	columns, err := s.buildColumns(fullTableName, connection)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	clientConstraintRepo, err := s.getClientConstraintRepo(connection)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	constraints, err := clientConstraintRepo.List(fullTableName)
+	if err != nil {
+		return SeedResult{}, s.toSeedError(err)
+	}
+
+	clientRowRepo, err := s.getClientRowRepo(connection)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	references, err := s.buildReferences(columns, constraints, clientRowRepo)
+	if err != nil {
+		return SeedResult{}, err
+	}
+
+	if err = s.checkColumns(columns, references); err != nil {
+		return SeedResult{}, err
+	}
+
+	uniqueSets := s.buildUniqueSets(columns, constraints)
+	for _, set := range uniqueSets {
+		for _, position := range set {
+			columns[position].Unique = true
+		}
+	}
+
+	// faker's default generator is seeded per second and shared, so every request gets its own
+	fake := faker.NewWithSeed(rand.NewSource(time.Now().UnixNano()))
+	rows := newSeedGenerator(fake, columns, references, uniqueSets).Rows(input.Count, constants.SeedUniqueAttempts)
+
+	columnNames := make([]string, len(columns))
+	for i, column := range columns {
+		columnNames[i] = column.Name
+	}
+
+	inserted, err := clientRowRepo.InsertMany(quoteTableName(fullTableName), columnNames, rows)
+	if err != nil {
+		return SeedResult{}, s.toSeedError(err)
+	}
+
+	return SeedResult{
+		Requested: input.Count,
+		Inserted:  inserted,
+		Skipped:   int64(input.Count) - inserted,
+	}, nil
+}
+
+// buildColumns lists the columns to generate values for. Columns with a default, identity and generated
+// columns are left to postgres, as are nullable columns of types the generator doesn't know
+func (s *SeedServiceImpl) buildColumns(fullTableName string, connection *sqlx.DB) ([]seedColumn, error) {
+	clientColumnRepo, err := s.getClientColumnRepo(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// the column listing repeats a column for every constraint it is part of
+	listedColumns, err := clientColumnRepo.List(quoteTableName(fullTableName))
+	if err != nil {
+		return nil, s.toSeedError(err)
+	}
+
+	enumValues, err := s.listEnumValues(listedColumns, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(listedColumns))
+	columns := make([]seedColumn, 0, len(listedColumns))
+	for _, column := range listedColumns {
+		if seen[column.Name] || column.Default != "" || column.Generated {
+			continue
+		}
+		seen[column.Name] = true
+
+		seeded := seedColumn{
+			Name:       column.Name,
+			Type:       column.Type,
+			NotNull:    column.NotNull,
+			Array:      strings.HasSuffix(column.Type, "[]"),
+			EnumValues: enumValues[seedEnumName(column.Type)],
+		}
+
+		switch seedBaseType(column.Type) {
+		case "character varying", "character":
+			seeded.Length, _ = seedTypeModifiers(column.Type)
+		case "numeric":
+			seeded.Precision, seeded.Scale = seedTypeModifiers(column.Type)
+		}
+
+		if len(seeded.EnumValues) == 0 && !seedSupportsType(column.Type) && !column.NotNull && !column.Foreign {
+			continue
+		}
+
+		columns = append(columns, seeded)
+	}
+
+	return columns, nil
+}
+
+// listEnumValues maps the unquoted schema qualified name of every enum the columns use to its labels
+func (s *SeedServiceImpl) listEnumValues(columns []Column, connection *sqlx.DB) (map[string][]string, error) {
+	enumValues := make(map[string][]string)
+
+	var schemas []string
+	for _, column := range columns {
+		schema, _, found := strings.Cut(seedEnumName(column.Type), ".")
+		if found && !slices.Contains(schemas, schema) {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	if len(schemas) == 0 {
+		return enumValues, nil
+	}
+
+	clientTypeRepo, err := s.getClientTypeRepo(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schema := range schemas {
+		types, err := clientTypeRepo.List(schema)
+		if err != nil {
+			return nil, s.toSeedError(err)
+		}
+
+		for _, currentType := range types {
+			if currentType.Kind == constants.TypeKindEnum {
+				enumValues[currentType.FullName()] = currentType.Values
+			}
+		}
+	}
+
+	return enumValues, nil
+}
+
+// buildReferences samples parent rows for every foreign key whose columns are all generated
+func (s *SeedServiceImpl) buildReferences(columns []seedColumn, constraints []Constraint, clientRowRepo RowRepository) ([]seedReference, error) {
+	var references []seedReference
+	for _, constraint := range constraints {
+		if constraint.Type != constants.ConstraintTypeForeignKey {
+			continue
+		}
+
+		positions, ok := seedColumnPositions(columns, constraint.Columns)
+		if !ok {
+			continue
+		}
+
+		parents, err := clientRowRepo.Sample(constraint.ReferenceTable, constraint.ReferenceColumns, constants.SeedParentSampleSize)
+		if err != nil {
+			return nil, s.toSeedError(err)
+		}
+
+		if len(parents) == 0 {
+			for _, position := range positions {
+				if columns[position].NotNull {
+					return nil, flxErrors.NewBadRequestError("seed.error.missingParentRows")
+				}
+			}
+		}
+
+		references = append(references, seedReference{Columns: positions, Rows: parents})
+	}
+
+	return references, nil
+}
+
+// checkColumns fails when a required column has neither a supported type nor a foreign key to take values from
+func (s *SeedServiceImpl) checkColumns(columns []seedColumn, references []seedReference) error {
+	referenced := make(map[int]bool)
+	for _, reference := range references {
+		for _, position := range reference.Columns {
+			referenced[position] = true
+		}
+	}
+
+	for i, column := range columns {
+		if referenced[i] || len(column.EnumValues) > 0 || seedSupportsType(column.Type) {
+			continue
+		}
+
+		return flxErrors.NewBadRequestError("seed.error.unsupportedColumn")
+	}
+
+	return nil
+}
+
+// buildUniqueSets returns the primary key and unique constraints the generated columns take part in,
+// constraints that include a column left to postgres can't collide within the batch
+func (s *SeedServiceImpl) buildUniqueSets(columns []seedColumn, constraints []Constraint) [][]int {
+	var uniqueSets [][]int
+	for _, constraint := range constraints {
+		if constraint.Type != constants.ConstraintTypePrimaryKey && constraint.Type != constants.ConstraintTypeUnique {
+			continue
+		}
+
+		if positions, ok := seedColumnPositions(columns, constraint.Columns); ok {
+			uniqueSets = append(uniqueSets, positions)
+		}
+	}
+
+	return uniqueSets
+}
+
+func (s *SeedServiceImpl) toSeedError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *SeedServiceImpl) getClientTableRepo(connection *sqlx.DB) (TableRepository, error) {
+	repo, _, err := s.connectionService.GetTableRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(TableRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
+
+func (s *SeedServiceImpl) getClientColumnRepo(connection *sqlx.DB) (ColumnRepository, error) {
+	repo, _, err := s.connectionService.GetColumnRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(ColumnRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientColumnRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
+
+func (s *SeedServiceImpl) getClientConstraintRepo(connection *sqlx.DB) (ConstraintRepository, error) {
+	repo, _, err := s.connectionService.GetConstraintRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(ConstraintRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientConstraintRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
+
+func (s *SeedServiceImpl) getClientTypeRepo(connection *sqlx.DB) (TypeRepository, error) {
+	repo, _, err := s.connectionService.GetTypeRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(TypeRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientTypeRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
+
+func (s *SeedServiceImpl) getClientRowRepo(connection *sqlx.DB) (RowRepository, error) {
+	repo, _, err := s.connectionService.GetRowRepo("", connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(RowRepository)
+	if !ok {
+		return nil, flxErrors.NewUnprocessableError("clientRowRepo is invalid")
+	}
+
+	return clientRepo, nil
+}
+
+// seedColumnPositions maps column names onto generator positions, ok is false when one isn't generated
+func seedColumnPositions(columns []seedColumn, names []string) ([]int, bool) {
+	positions := make([]int, len(names))
+	for i, name := range names {
+		position := slices.IndexFunc(columns, func(column seedColumn) bool {
+			return column.Name == name
+		})
+		if position < 0 {
+			return nil, false
+		}
+
+		positions[i] = position
+	}
+
+	return positions, true
+}
+
+// seedEnumName strips the quoting and array marker columns report user-defined types with
+func seedEnumName(columnType string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(columnType, "[]"), `"`, "")
+}
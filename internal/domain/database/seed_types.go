@@ -0,0 +1,17 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type SeedTableInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Count       int       `json:"count"`
+}
+
+// SeedResult reports how many of the requested rows made it in, the rest collided with unique constraints
+type SeedResult struct {
+	Requested int   `json:"requested"`
+	Inserted  int64 `json:"inserted"`
+	Skipped   int64 `json:"skipped"`
+}
@@ -11,4 +11,16 @@ type Table struct {
 	Schema        string `db:"schema"`
 	EstimatedRows int    `db:"estimated_rows"`
 	TotalSize     string `db:"total_size"`
+	Partitioned   bool   `db:"partitioned"`
+	Description   string `db:"description"` // stored as the table comment
+
+	// PartitionOf names the parent table of a partition, it is empty for other tables
+	PartitionOf string `db:"partition_of"`
+
+	// Extension names the extension the table belongs to, it comes and goes with the extension
+	Extension string `db:"extension"`
+}
+
+func (t Table) FullName() string {
+	return t.Schema + "." + t.Name
 }
@@ -1,11 +1,11 @@
 package database
 
 type TableRepository interface {
-	Exists(name string) (bool, error)
-	Create(name string, columns []Column) error
+	Exists(schema, name string) (bool, error)
+	Create(fullTableName string, columns []Column) error
 	Duplicate(existingTable string, newTable string) error
-	List() ([]Table, error)
+	List(schema string) ([]Table, error)
 	GetByNameInSchema(schema, name string) (Table, error)
-	DropIfExists(name string) error
-	Rename(oldName string, newName string) error
+	Rename(fullTableName string, newName string) error
+	Comment(fullTableName, description string) error
 }
@@ -13,18 +13,19 @@ import (
 )
 
 type TableService interface {
-	List(projectUUID uuid.UUID, authUser auth.User) ([]Table, error)
+	List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Table, error)
 	GetByName(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Table, error)
 	Create(request CreateTableInput, authUser auth.User) (Table, error)
 	Upload(request UploadTableInput, authUser auth.User) (Table, error)
 	Duplicate(fullTableName string, authUser auth.User, request RenameTableInput) (*Table, error)
 	Rename(fullTableName string, authUser auth.User, request RenameTableInput) (Table, error)
-	Delete(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	UpdateDescription(fullTableName string, authUser auth.User, request UpdateTableDescriptionInput) (Table, error)
 }
 
 type TableServiceImpl struct {
 	connectionService ConnectionService
 	fileImportService FileImportService
+	migrationService  MigrationService
 	projectPolicy     *project.Policy
 	postgrestService  shared.PostgrestService
 	projectRepo       project.Repository
@@ -36,17 +37,19 @@ func NewTableService(injector *do.Injector) (TableService, error) {
 	projectRepo := do.MustInvoke[project.Repository](injector)
 	fileImportService := do.MustInvoke[FileImportService](injector)
 	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
 
 	return &TableServiceImpl{
 		connectionService: connectionService,
 		fileImportService: fileImportService,
+		migrationService:  migrationService,
 		projectPolicy:     policy,
 		projectRepo:       projectRepo,
 		postgrestService:  postgrestService,
 	}, nil
 }
 
-func (s *TableServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Table, error) {
+func (s *TableServiceImpl) List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Table, error) {
 	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
 		return []Table{}, err
@@ -62,7 +65,7 @@ func (s *TableServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]Ta
 	}
 	defer connection.Close()
 
-	tables, err := clientTableRepo.List()
+	tables, err := clientTableRepo.List(schema)
 	if err != nil {
 		return []Table{}, err
 	}
@@ -110,17 +113,36 @@ func (s *TableServiceImpl) Create(request CreateTableInput, authUser auth.User)
 	}
 	defer connection.Close()
 
-	if err = s.validateNameForDuplication(request.Name, clientTableRepo); err != nil {
+	if err = s.validateSchema(fetchedProject.DBName, connection, request.Schema); err != nil {
 		return Table{}, err
 	}
 
-	if err = clientTableRepo.Create(request.Name, request.Columns); err != nil {
+	if err = s.validateNameForDuplication(request.Schema, request.Name, clientTableRepo); err != nil {
 		return Table{}, err
 	}
 
+	clientColumnRepo, err := s.getClientColumnRepo(fetchedProject.DBName, connection)
+	if err != nil {
+		return Table{}, err
+	}
+
+	if err = validateUserDefinedTypes(clientColumnRepo, request.Columns); err != nil {
+		return Table{}, err
+	}
+
+	if request.Partition != nil {
+		if err = s.createPartitioned(connection, clientColumnRepo, request); err != nil {
+			return Table{}, err
+		}
+	} else {
+		if err = s.createTable(fetchedProject.DBName, connection, request.FullTableName(), request.Description, request.Columns); err != nil {
+			return Table{}, err
+		}
+	}
+
 	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
 
-	return clientTableRepo.GetByNameInSchema(pkg.ParseTableName(request.Name))
+	return clientTableRepo.GetByNameInSchema(request.Schema, request.Name)
 }
 
 func (s *TableServiceImpl) Upload(request UploadTableInput, authUser auth.User) (Table, error) {
@@ -139,7 +161,11 @@ func (s *TableServiceImpl) Upload(request UploadTableInput, authUser auth.User)
 	}
 	defer connection.Close()
 
-	if err = s.validateNameForDuplication(request.Name, clientTableRepo); err != nil {
+	if err = s.validateSchema(fetchedProject.DBName, connection, request.Schema); err != nil {
+		return Table{}, err
+	}
+
+	if err = s.validateNameForDuplication(request.Schema, request.Name, clientTableRepo); err != nil {
 		return Table{}, err
 	}
 
@@ -160,17 +186,17 @@ func (s *TableServiceImpl) Upload(request UploadTableInput, authUser auth.User)
 		return Table{}, err
 	}
 
-	if err = clientTableRepo.Create(request.Name, columns); err != nil {
+	if err = s.createTable(fetchedProject.DBName, connection, request.FullTableName(), "", columns); err != nil {
 		return Table{}, err
 	}
 
-	if err = clientRowRepo.CreateMany(request.Name, columns, values); err != nil {
+	if err = clientRowRepo.CreateMany(quoteTableName(request.FullTableName()), columns, values); err != nil {
 		return Table{}, err
 	}
 
 	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
 
-	return clientTableRepo.GetByNameInSchema(pkg.ParseTableName(request.Name))
+	return clientTableRepo.GetByNameInSchema(request.Schema, request.Name)
 }
 
 func (s *TableServiceImpl) Duplicate(fullTableName string, authUser auth.User, request RenameTableInput) (*Table, error) {
@@ -189,16 +215,19 @@ func (s *TableServiceImpl) Duplicate(fullTableName string, authUser auth.User, r
 	}
 	defer connection.Close()
 
-	if err = s.validateNameForDuplication(request.Name, clientTableRepo); err != nil {
+	fetchedTable, err := clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+	if err != nil {
 		return &Table{}, err
 	}
 
-	fetchedTable, err := clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
-	if err != nil {
+	// the copy lands next to the original table
+	if err = s.validateNameForDuplication(fetchedTable.Schema, request.Name, clientTableRepo); err != nil {
 		return &Table{}, err
 	}
 
-	if err = clientTableRepo.Duplicate(fetchedTable.Name, request.Name); err != nil {
+	duplicateTableName := fetchedTable.Schema + "." + request.Name
+	_, err = s.migrationService.Execute(connection, buildDuplicateTableMigration(fetchedTable.FullName(), duplicateTableName))
+	if err != nil {
 		return &Table{}, err
 	}
 
@@ -224,16 +253,17 @@ func (s *TableServiceImpl) Rename(fullTableName string, authUser auth.User, requ
 	}
 	defer connection.Close()
 
-	if err = s.validateNameForDuplication(request.Name, clientTableRepo); err != nil {
+	fetchedTable, err := clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+	if err != nil {
 		return Table{}, err
 	}
 
-	fetchedTable, err := clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
-	if err != nil {
+	if err = s.validateNameForDuplication(fetchedTable.Schema, request.Name, clientTableRepo); err != nil {
 		return Table{}, err
 	}
 
-	if err = clientTableRepo.Rename(fetchedTable.Name, request.Name); err != nil {
+	_, err = s.migrationService.Execute(connection, buildRenameTableMigration(fetchedTable.Schema, fetchedTable.Name, request.Name))
+	if err != nil {
 		return Table{}, err
 	}
 
@@ -242,27 +272,37 @@ func (s *TableServiceImpl) Rename(fullTableName string, authUser auth.User, requ
 	return fetchedTable, nil
 }
 
-func (s *TableServiceImpl) Delete(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
-	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+func (s *TableServiceImpl) UpdateDescription(fullTableName string, authUser auth.User, request UpdateTableDescriptionInput) (Table, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
 	if err != nil {
-		return false, err
+		return Table{}, err
 	}
 
 	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
-		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+		return Table{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
 	}
 
 	clientTableRepo, connection, err := s.getClientTableRepo(fetchedProject.DBName)
 	if err != nil {
-		return false, err
+		return Table{}, err
 	}
 	defer connection.Close()
 
-	if err = clientTableRepo.DropIfExists(fullTableName); err != nil {
-		return false, err
+	fetchedTable, err := clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+	if err != nil {
+		return Table{}, err
 	}
 
-	return true, nil
+	_, err = s.migrationService.Execute(connection, buildCommentTableMigration(fetchedTable.FullName(), fetchedTable.Description, request.Description))
+	if err != nil {
+		return Table{}, err
+	}
+
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	fetchedTable.Description = request.Description
+
+	return fetchedTable, nil
 }
 
 func (s *TableServiceImpl) getClientTableRepo(dbName string) (TableRepository, *sqlx.DB, error) {
@@ -281,6 +321,54 @@ func (s *TableServiceImpl) getClientTableRepo(dbName string) (TableRepository, *
 	return clientRepo, connection, nil
 }
 
+// createTable runs the CREATE TABLE together with its foreign keys and comments, and adds it to the
+// migration history in the same transaction
+func (s *TableServiceImpl) createTable(dbName string, connection *sqlx.DB, name, description string, columns []Column) error {
+	clientColumnRepo, err := s.getClientColumnRepo(dbName, connection)
+	if err != nil {
+		return err
+	}
+
+	definitions, foreignKeys := buildColumnStatements(clientColumnRepo, name, columns)
+	if description != "" {
+		foreignKeys = append(foreignKeys, buildCommentStatement("TABLE", quoteTableName(name), description))
+	}
+
+	_, err = s.migrationService.Execute(connection, buildCreateTableMigration(name, definitions, foreignKeys))
+
+	return err
+}
+
+// createPartitioned creates the parent table only, partitions are created through the partition
+// service or by a maintenance policy
+func (s *TableServiceImpl) createPartitioned(connection *sqlx.DB, clientColumnRepo ColumnRepository, request CreateTableInput) error {
+	columns, keyConstraints := buildPartitionKeyColumns(request.Columns, *request.Partition)
+	definitions, foreignKeys := buildColumnStatements(clientColumnRepo, request.FullTableName(), columns)
+	definitions = append(definitions, keyConstraints...)
+	if request.Description != "" {
+		foreignKeys = append(foreignKeys, buildCommentStatement("TABLE", quoteTableName(request.FullTableName()), request.Description))
+	}
+
+	partitionBy := buildPartitionByClause(*request.Partition)
+	_, err := s.migrationService.Execute(connection, buildCreatePartitionedTableMigration(request.FullTableName(), definitions, foreignKeys, partitionBy))
+
+	return err
+}
+
+func (s *TableServiceImpl) getClientColumnRepo(dbName string, connection *sqlx.DB) (ColumnRepository, error) {
+	repo, _, err := s.connectionService.GetColumnRepo(dbName, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientColumnRepo, ok := repo.(ColumnRepository)
+	if !ok {
+		return nil, errors.New("clientColumnRepo is not of type *repositories.ColumnRepository")
+	}
+
+	return clientColumnRepo, nil
+}
+
 func (s *TableServiceImpl) getClientRowRepo(dbName string, connection *sqlx.DB) (RowRepository, error) {
 	repo, _, err := s.connectionService.GetRowRepo(dbName, connection)
 	if err != nil {
@@ -295,8 +383,8 @@ func (s *TableServiceImpl) getClientRowRepo(dbName string, connection *sqlx.DB)
 	return clientRepo, nil
 }
 
-func (s *TableServiceImpl) validateNameForDuplication(name string, clientTableRepo TableRepository) error {
-	exists, err := clientTableRepo.Exists(name)
+func (s *TableServiceImpl) validateNameForDuplication(schema, name string, clientTableRepo TableRepository) error {
+	exists, err := clientTableRepo.Exists(schema, name)
 	if err != nil {
 		return err
 	}
@@ -307,3 +395,27 @@ func (s *TableServiceImpl) validateNameForDuplication(name string, clientTableRe
 
 	return nil
 }
+
+// validateSchema makes sure tables are only created in schemas that exist, postgres would fail with a generic error
+func (s *TableServiceImpl) validateSchema(dbName string, connection *sqlx.DB, schema string) error {
+	repo, _, err := s.connectionService.GetSchemaRepo(dbName, connection)
+	if err != nil {
+		return err
+	}
+
+	clientSchemaRepo, ok := repo.(SchemaRepository)
+	if !ok {
+		return errors.New("clientSchemaRepo is not of type *repositories.SchemaRepository")
+	}
+
+	exists, err := clientSchemaRepo.Exists(schema)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return flxErrors.NewNotFoundError("schema.error.notFound")
+	}
+
+	return nil
+}
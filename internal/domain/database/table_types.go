@@ -7,8 +7,13 @@ import (
 
 type CreateTableInput struct {
 	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Schema      string    `json:"schema"`
 	Name        string    `json:"name"`
 	Columns     []Column  `json:"columns"`
+	Description string    `json:"description"`
+
+	// only set for partitioned tables
+	Partition *PartitionSpecInput `json:"partition"`
 }
 
 type RenameTableInput struct {
@@ -16,8 +21,22 @@ type RenameTableInput struct {
 	Name        string    `json:"name"`
 }
 
+type UpdateTableDescriptionInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Description string    `json:"description"`
+}
+
 type UploadTableInput struct {
 	ProjectUUID uuid.UUID             `json:"projectUUID,omitempty"`
+	Schema      string                `json:"schema"`
 	Name        string                `json:"name"`
 	File        *multipart.FileHeader `form:"file"`
 }
+
+func (i CreateTableInput) FullTableName() string {
+	return i.Schema + "." + i.Name
+}
+
+func (i UploadTableInput) FullTableName() string {
+	return i.Schema + "." + i.Name
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"slices"
+	"time"
+)
+
+// TrashService restores and purges the tables and columns snapshotted when they were dropped
+type TrashService interface {
+	List(projectUUID uuid.UUID, authUser auth.User) ([]TrashSnapshot, error)
+	Restore(snapshotUUID, projectUUID uuid.UUID, authUser auth.User) (TrashSnapshot, error)
+	Delete(snapshotUUID, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	PurgeExpired() (int, error)
+}
+
+type TrashServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	trashRepo         TrashSnapshotRepository
+}
+
+func NewTrashService(injector *do.Injector) (TrashService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	trashRepo := do.MustInvoke[TrashSnapshotRepository](injector)
+
+	return &TrashServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		trashRepo:         trashRepo,
+	}, nil
+}
+
+func (s *TrashServiceImpl) List(projectUUID uuid.UUID, authUser auth.User) ([]TrashSnapshot, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	return s.trashRepo.ListForProject(projectUUID)
+}
+
+// Restore recreates the table or column and copies its rows back in one transaction, then empties the
+// snapshot. Objects that were dropped along with cascade are not part of the snapshot
+func (s *TrashServiceImpl) Restore(snapshotUUID, projectUUID uuid.UUID, authUser auth.User) (TrashSnapshot, error) {
+	fetchedProject, snapshot, err := s.getSnapshot(snapshotUUID, projectUUID, authUser)
+	if err != nil {
+		return TrashSnapshot{}, err
+	}
+
+	clientDropRepo, connection, err := s.getClientDropRepo(fetchedProject.DBName)
+	if err != nil {
+		return TrashSnapshot{}, err
+	}
+	defer connection.Close()
+
+	if err = clientDropRepo.Execute(slices.Concat(snapshot.Definition, snapshot.RestoreStatements)...); err != nil {
+		return TrashSnapshot{}, toDropError(err)
+	}
+
+	if err = s.trashRepo.Delete(snapshot.Uuid); err != nil {
+		return TrashSnapshot{}, err
+	}
+
+	s.migrationService.Record(connection, buildRestoreMigration(snapshot))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return snapshot, nil
+}
+
+func (s *TrashServiceImpl) Delete(snapshotUUID, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, snapshot, err := s.getSnapshot(snapshotUUID, projectUUID, authUser)
+	if err != nil {
+		return false, err
+	}
+
+	if err = s.purge(fetchedProject.DBName, snapshot); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PurgeExpired drops snapshots past their retention. Purging is idempotent, instances running
+// the scheduler side by side at worst drop the same trash table twice
+func (s *TrashServiceImpl) PurgeExpired() (int, error) {
+	now := time.Now()
+	snapshots, err := s.trashRepo.ListExpired(now, now.Add(-constants.TrashPendingTimeout), constants.TrashPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, snapshot := range snapshots {
+		fetchedProject, err := s.projectRepo.GetByUUID(snapshot.ProjectUuid)
+		if err == nil {
+			err = s.purge(fetchedProject.DBName, snapshot)
+		}
+
+		if err != nil {
+			log.Error().
+				Str("action", constants.ActionTrash).
+				Str("project", snapshot.ProjectUuid.String()).
+				Str("snapshot", snapshot.Uuid.String()).
+				Str("error", err.Error()).
+				Msg("failed to purge expired trash snapshot")
+		}
+	}
+
+	return len(snapshots), nil
+}
+
+func (s *TrashServiceImpl) purge(dbName string, snapshot TrashSnapshot) error {
+	clientDropRepo, connection, err := s.getClientDropRepo(dbName)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	if err = clientDropRepo.Execute(buildDropTrashTableStatement(snapshot.TrashTable)); err != nil {
+		return err
+	}
+
+	return s.trashRepo.Delete(snapshot.Uuid)
+}
+
+// getSnapshot checks the snapshot belongs to the project, changing the trash needs update rights
+func (s *TrashServiceImpl) getSnapshot(snapshotUUID, projectUUID uuid.UUID, authUser auth.User) (project.Project, TrashSnapshot, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return project.Project{}, TrashSnapshot{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return project.Project{}, TrashSnapshot{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	snapshot, err := s.trashRepo.GetByUUID(snapshotUUID)
+	if err != nil {
+		return project.Project{}, TrashSnapshot{}, err
+	}
+
+	if snapshot.ProjectUuid != fetchedProject.Uuid {
+		return project.Project{}, TrashSnapshot{}, flxErrors.NewNotFoundError("trash.error.notFound")
+	}
+
+	return fetchedProject, snapshot, nil
+}
+
+func (s *TrashServiceImpl) getClientDropRepo(dbName string) (DropRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetDropRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(DropRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientDropRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+type Trigger struct {
+	Name   string         `db:"name" json:"name"`
+	Timing string         `db:"timing" json:"timing"`
+	Level  string         `db:"level" json:"level"`
+	Events pq.StringArray `db:"events" json:"events" swaggertype:"array,string"`
+
+	// only set when the trigger fires on updates of specific columns
+	UpdateColumns pq.StringArray `db:"update_columns" json:"updateColumns" swaggertype:"array,string"`
+
+	Condition  string `db:"condition" json:"condition"`
+	Function   string `db:"function" json:"function"`
+	Enabled    bool   `db:"enabled" json:"enabled"`
+	Definition string `db:"definition" json:"definition"`
+}
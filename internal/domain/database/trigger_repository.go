@@ -0,0 +1,11 @@
+package database
+
+type TriggerRepository interface {
+	List(fullTableName string) ([]Trigger, error)
+	GetByName(fullTableName, triggerName string) (Trigger, error)
+	Has(fullTableName, triggerName string) (bool, error)
+	Create(triggerSQL string) error
+	Drop(triggerSQL string) error
+	SetEnabled(triggerSQL string) error
+	GetFunctionReturnType(schema, functionName string) (string, error)
+}
@@ -0,0 +1,246 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type TriggerService interface {
+	List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Trigger, error)
+	GetByName(triggerName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Trigger, error)
+	Create(fullTableName string, request CreateTriggerInput, authUser auth.User) (Trigger, error)
+	SetEnabled(triggerName, fullTableName string, enabled bool, projectUUID uuid.UUID, authUser auth.User) (Trigger, error)
+	Delete(triggerName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+}
+
+type TriggerServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewTriggerService(injector *do.Injector) (TriggerService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &TriggerServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *TriggerServiceImpl) List(fullTableName string, projectUUID uuid.UUID, authUser auth.User) ([]Trigger, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientTriggerRepo, connection, err := s.getClientTriggerRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	triggers, err := clientTriggerRepo.List(fullTableName)
+	if err != nil {
+		return nil, s.toTriggerError(err)
+	}
+
+	return triggers, nil
+}
+
+func (s *TriggerServiceImpl) GetByName(triggerName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (Trigger, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Trigger{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Trigger{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientTriggerRepo, connection, err := s.getClientTriggerRepo(fetchedProject.DBName)
+	if err != nil {
+		return Trigger{}, err
+	}
+	defer connection.Close()
+
+	trigger, err := clientTriggerRepo.GetByName(fullTableName, triggerName)
+	if err != nil {
+		return Trigger{}, s.toTriggerError(err)
+	}
+
+	return trigger, nil
+}
+
+// Create attaches a trigger function to a table, the function must already exist and return trigger
+func (s *TriggerServiceImpl) Create(fullTableName string, request CreateTriggerInput, authUser auth.User) (Trigger, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Trigger{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Trigger{}, flxErrors.NewForbiddenError("table.error.createForbidden")
+	}
+
+	clientTriggerRepo, connection, err := s.getClientTriggerRepo(fetchedProject.DBName)
+	if err != nil {
+		return Trigger{}, err
+	}
+	defer connection.Close()
+
+	hasTrigger, err := clientTriggerRepo.Has(fullTableName, request.Name)
+	if err != nil {
+		return Trigger{}, s.toTriggerError(err)
+	}
+
+	if hasTrigger {
+		return Trigger{}, flxErrors.NewUnprocessableError("trigger.error.alreadyExists")
+	}
+
+	if err = s.validateFunction(clientTriggerRepo, request.Function); err != nil {
+		return Trigger{}, err
+	}
+
+	if err = clientTriggerRepo.Create(buildCreateTriggerStatement(fullTableName, request)); err != nil {
+		return Trigger{}, s.toTriggerError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateTriggerMigration(fullTableName, request))
+
+	return clientTriggerRepo.GetByName(fullTableName, request.Name)
+}
+
+// SetEnabled enables or disables a trigger without dropping it
+func (s *TriggerServiceImpl) SetEnabled(triggerName, fullTableName string, enabled bool, projectUUID uuid.UUID, authUser auth.User) (Trigger, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Trigger{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Trigger{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientTriggerRepo, connection, err := s.getClientTriggerRepo(fetchedProject.DBName)
+	if err != nil {
+		return Trigger{}, err
+	}
+	defer connection.Close()
+
+	trigger, err := clientTriggerRepo.GetByName(fullTableName, triggerName)
+	if err != nil {
+		return Trigger{}, s.toTriggerError(err)
+	}
+
+	if trigger.Enabled == enabled {
+		return trigger, nil
+	}
+
+	if err = clientTriggerRepo.SetEnabled(buildSetTriggerEnabledStatement(fullTableName, triggerName, enabled)); err != nil {
+		return Trigger{}, s.toTriggerError(err)
+	}
+
+	s.migrationService.Record(connection, buildSetTriggerEnabledMigration(fullTableName, triggerName, enabled))
+
+	trigger.Enabled = enabled
+
+	return trigger, nil
+}
+
+func (s *TriggerServiceImpl) Delete(triggerName, fullTableName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientTriggerRepo, connection, err := s.getClientTriggerRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	trigger, err := clientTriggerRepo.GetByName(fullTableName, triggerName)
+	if err != nil {
+		return false, s.toTriggerError(err)
+	}
+
+	if err = clientTriggerRepo.Drop(buildDropTriggerStatement(fullTableName, triggerName)); err != nil {
+		return false, s.toTriggerError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropTriggerMigration(fullTableName, trigger))
+
+	return true, nil
+}
+
+// validateFunction gives a clearer error than postgres when the function is missing or is not a trigger function
+func (s *TriggerServiceImpl) validateFunction(clientTriggerRepo TriggerRepository, fullFunctionName string) error {
+	schema, functionName := pkg.ParseTableName(fullFunctionName)
+
+	returnType, err := clientTriggerRepo.GetFunctionReturnType(schema, functionName)
+	if err != nil {
+		return err
+	}
+
+	if returnType == "" {
+		return flxErrors.NewBadRequestError(fmt.Sprintf("function '%s.%s()' does not exist", schema, functionName))
+	}
+
+	if returnType != constants.TriggerFunctionReturnType {
+		return flxErrors.NewBadRequestError(
+			fmt.Sprintf("function '%s.%s()' must return trigger, it returns %s", schema, functionName, returnType),
+		)
+	}
+
+	return nil
+}
+
+// toTriggerError surfaces database errors such as invalid conditions or unknown tables as bad requests
+func (s *TriggerServiceImpl) toTriggerError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *TriggerServiceImpl) getClientTriggerRepo(dbName string) (TriggerRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetTriggerRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(TriggerRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientTriggerRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func buildCreateTriggerStatement(fullTableName string, input CreateTriggerInput) string {
+	events := make([]string, len(input.Events))
+	for i, event := range input.Events {
+		events[i] = event
+		if event == constants.TriggerEventUpdate && len(input.UpdateColumns) > 0 {
+			events[i] += " OF " + quoteIdentifiers(input.UpdateColumns)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(
+		"CREATE TRIGGER %s %s %s ON %s FOR EACH %s",
+		pq.QuoteIdentifier(input.Name),
+		input.Timing,
+		strings.Join(events, " OR "),
+		quoteTableName(fullTableName),
+		input.Level,
+	))
+
+	if input.Condition != "" {
+		builder.WriteString(fmt.Sprintf(" WHEN (%s)", input.Condition))
+	}
+
+	builder.WriteString(fmt.Sprintf(" EXECUTE FUNCTION %s();", quoteTableName(input.Function)))
+
+	return builder.String()
+}
+
+func buildDropTriggerStatement(fullTableName, triggerName string) string {
+	return fmt.Sprintf("DROP TRIGGER %s ON %s;", pq.QuoteIdentifier(triggerName), quoteTableName(fullTableName))
+}
+
+func buildSetTriggerEnabledStatement(fullTableName, triggerName string, enabled bool) string {
+	action := "DISABLE"
+	if enabled {
+		action = "ENABLE"
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s %s TRIGGER %s;", quoteTableName(fullTableName), action, pq.QuoteIdentifier(triggerName))
+}
+
+func buildCreateTriggerMigration(fullTableName string, input CreateTriggerInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_trigger_" + input.Name,
+		UpSQL:   buildCreateTriggerStatement(fullTableName, input),
+		DownSQL: buildDropTriggerStatement(fullTableName, input.Name),
+	}
+}
+
+func buildSetTriggerEnabledMigration(fullTableName, triggerName string, enabled bool) RecordMigrationInput {
+	name := "disable_trigger_" + triggerName
+	if enabled {
+		name = "enable_trigger_" + triggerName
+	}
+
+	return RecordMigrationInput{
+		Name:    name,
+		UpSQL:   buildSetTriggerEnabledStatement(fullTableName, triggerName, enabled),
+		DownSQL: buildSetTriggerEnabledStatement(fullTableName, triggerName, !enabled),
+	}
+}
+
+// buildDropTriggerMigration restores the trigger from its catalog definition and keeps it disabled if it was
+func buildDropTriggerMigration(fullTableName string, trigger Trigger) RecordMigrationInput {
+	downSQL := trigger.Definition + ";"
+	if !trigger.Enabled {
+		downSQL += "\n" + buildSetTriggerEnabledStatement(fullTableName, trigger.Name, false)
+	}
+
+	return RecordMigrationInput{
+		Name:    "drop_trigger_" + trigger.Name,
+		UpSQL:   buildDropTriggerStatement(fullTableName, trigger.Name),
+		DownSQL: downSQL,
+	}
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTriggerStatements_Suite(t *testing.T) {
+	t.Run("create row trigger with condition and update columns", func(t *testing.T) {
+		migration := buildCreateTriggerMigration("public.orders", CreateTriggerInput{
+			Name:          "orders_touch",
+			Timing:        constants.TriggerTimingBefore,
+			Level:         constants.TriggerLevelRow,
+			Events:        []string{constants.TriggerEventInsert, constants.TriggerEventUpdate},
+			UpdateColumns: []string{"status", "total"},
+			Condition:     "NEW.total > 0",
+			Function:      "touch_updated_at",
+		})
+
+		assert.Equal(
+			t,
+			`CREATE TRIGGER "orders_touch" BEFORE INSERT OR UPDATE OF "status", "total" ON "public"."orders" `+
+				`FOR EACH ROW WHEN (NEW.total > 0) EXECUTE FUNCTION "public"."touch_updated_at"();`,
+			migration.UpSQL,
+		)
+		assert.Equal(t, `DROP TRIGGER "orders_touch" ON "public"."orders";`, migration.DownSQL)
+	})
+
+	t.Run("create statement trigger", func(t *testing.T) {
+		statement := buildCreateTriggerStatement("orders", CreateTriggerInput{
+			Name:     "orders_audit",
+			Timing:   constants.TriggerTimingAfter,
+			Level:    constants.TriggerLevelStatement,
+			Events:   []string{constants.TriggerEventTruncate},
+			Function: "audit.log_truncate",
+		})
+
+		assert.Equal(
+			t,
+			`CREATE TRIGGER "orders_audit" AFTER TRUNCATE ON "public"."orders" FOR EACH STATEMENT EXECUTE FUNCTION "audit"."log_truncate"();`,
+			statement,
+		)
+	})
+
+	t.Run("disable is reverted by enabling", func(t *testing.T) {
+		migration := buildSetTriggerEnabledMigration("public.orders", "orders_touch", false)
+
+		assert.Equal(t, "disable_trigger_orders_touch", migration.Name)
+		assert.Equal(t, `ALTER TABLE "public"."orders" DISABLE TRIGGER "orders_touch";`, migration.UpSQL)
+		assert.Equal(t, `ALTER TABLE "public"."orders" ENABLE TRIGGER "orders_touch";`, migration.DownSQL)
+	})
+
+	t.Run("drop recreates a disabled trigger disabled", func(t *testing.T) {
+		migration := buildDropTriggerMigration("public.orders", Trigger{
+			Name:       "orders_touch",
+			Definition: "CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch_updated_at()",
+			Enabled:    false,
+		})
+
+		assert.Equal(t, `DROP TRIGGER "orders_touch" ON "public"."orders";`, migration.UpSQL)
+		assert.Equal(
+			t,
+			"CREATE TRIGGER orders_touch BEFORE UPDATE ON public.orders FOR EACH ROW EXECUTE FUNCTION touch_updated_at();\n"+
+				`ALTER TABLE "public"."orders" DISABLE TRIGGER "orders_touch";`,
+			migration.DownSQL,
+		)
+	})
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateTriggerInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Name        string    `json:"name"`
+	Timing      string    `json:"timing"`
+	Level       string    `json:"level"`
+	Events      []string  `json:"events"`
+
+	// only used with the UPDATE event, limits the trigger to updates of these columns
+	UpdateColumns []string `json:"updateColumns"`
+
+	Condition string `json:"condition"`
+
+	// schema qualified, the function must take no arguments and return trigger
+	Function string `json:"function"`
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+type Type struct {
+	Schema string `db:"schema" json:"schema"`
+	Name   string `db:"name" json:"name"`
+	Kind   string `db:"kind" json:"kind"`
+
+	// only set when kind is enum, in sort order
+	Values pq.StringArray `db:"values" json:"values" swaggertype:"array,string"`
+
+	// only set when kind is composite
+	Attributes []TypeAttribute `db:"-" json:"attributes"`
+
+	// Extension names the extension the type belongs to, it comes and goes with the extension
+	Extension string `db:"extension" json:"extension"`
+}
+
+type TypeAttribute struct {
+	TypeSchema string `db:"type_schema" json:"-"`
+	TypeName   string `db:"type_name" json:"-"`
+	Name       string `db:"name" json:"name"`
+	Type       string `db:"type" json:"type"`
+}
+
+// FullName is the schema qualified name columns refer to the type with
+func (t Type) FullName() string {
+	return t.Schema + "." + t.Name
+}
@@ -0,0 +1,10 @@
+package database
+
+type TypeRepository interface {
+	List(schema string) ([]Type, error)
+	GetByName(schema, typeName string) (Type, error)
+	Has(schema, typeName string) (bool, error)
+	Create(typeSQL string) error
+	Alter(typeSQL string) error
+	Drop(schema, typeName string) error
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type TypeService interface {
+	List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Type, error)
+	GetByName(fullTypeName string, projectUUID uuid.UUID, authUser auth.User) (Type, error)
+	Create(request CreateTypeInput, authUser auth.User) (Type, error)
+	Alter(fullTypeName string, request AlterTypeInput, authUser auth.User) (Type, error)
+	Delete(fullTypeName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+}
+
+type TypeServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewTypeService(injector *do.Injector) (TypeService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &TypeServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *TypeServiceImpl) List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]Type, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientTypeRepo, connection, err := s.getClientTypeRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	return clientTypeRepo.List(schema)
+}
+
+func (s *TypeServiceImpl) GetByName(fullTypeName string, projectUUID uuid.UUID, authUser auth.User) (Type, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return Type{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Type{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientTypeRepo, connection, err := s.getClientTypeRepo(fetchedProject.DBName)
+	if err != nil {
+		return Type{}, err
+	}
+	defer connection.Close()
+
+	return clientTypeRepo.GetByName(pkg.ParseTableName(fullTypeName))
+}
+
+func (s *TypeServiceImpl) Create(request CreateTypeInput, authUser auth.User) (Type, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Type{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return Type{}, flxErrors.NewForbiddenError("type.error.createForbidden")
+	}
+
+	clientTypeRepo, connection, err := s.getClientTypeRepo(fetchedProject.DBName)
+	if err != nil {
+		return Type{}, err
+	}
+	defer connection.Close()
+
+	hasType, err := clientTypeRepo.Has(request.Schema, request.Name)
+	if err != nil {
+		return Type{}, err
+	}
+
+	if hasType {
+		return Type{}, flxErrors.NewUnprocessableError("type.error.alreadyExists")
+	}
+
+	if err = clientTypeRepo.Create(buildCreateTypeStatement(request)); err != nil {
+		return Type{}, s.toTypeError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateTypeMigration(request))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return clientTypeRepo.GetByName(request.Schema, request.Name)
+}
+
+// Alter adds or renames an enum value, existing rows keep their values under the new label
+func (s *TypeServiceImpl) Alter(fullTypeName string, request AlterTypeInput, authUser auth.User) (Type, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return Type{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return Type{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientTypeRepo, connection, err := s.getClientTypeRepo(fetchedProject.DBName)
+	if err != nil {
+		return Type{}, err
+	}
+	defer connection.Close()
+
+	schema, typeName := pkg.ParseTableName(fullTypeName)
+	fetchedType, err := clientTypeRepo.GetByName(schema, typeName)
+	if err != nil {
+		return Type{}, err
+	}
+
+	if fetchedType.Kind != constants.TypeKindEnum {
+		return Type{}, flxErrors.NewBadRequestError("type.error.notEnum")
+	}
+
+	if err = clientTypeRepo.Alter(buildAlterTypeStatement(schema, typeName, request)); err != nil {
+		return Type{}, s.toTypeError(err)
+	}
+
+	s.migrationService.Record(connection, buildAlterTypeMigration(schema, typeName, request))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return clientTypeRepo.GetByName(schema, typeName)
+}
+
+func (s *TypeServiceImpl) Delete(fullTypeName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientTypeRepo, connection, err := s.getClientTypeRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	schema, typeName := pkg.ParseTableName(fullTypeName)
+	fetchedType, err := clientTypeRepo.GetByName(schema, typeName)
+	if err != nil {
+		return false, err
+	}
+
+	// types still used by columns are refused by postgres rather than dropped with cascade
+	if err = clientTypeRepo.Drop(schema, typeName); err != nil {
+		return false, s.toTypeError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropTypeMigration(fetchedType))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return true, nil
+}
+
+// toTypeError surfaces database errors such as unknown enum labels or dependent columns as bad requests
+func (s *TypeServiceImpl) toTypeError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *TypeServiceImpl) getClientTypeRepo(dbName string) (TypeRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetTypeRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(TypeRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientTypeRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func buildCreateTypeStatement(input CreateTypeInput) string {
+	var body string
+	switch input.Kind {
+	case constants.TypeKindEnum:
+		values := make([]string, len(input.Values))
+		for i, value := range input.Values {
+			values[i] = pq.QuoteLiteral(value)
+		}
+
+		body = fmt.Sprintf("ENUM (%s)", strings.Join(values, ", "))
+	case constants.TypeKindComposite:
+		attributes := make([]string, len(input.Attributes))
+		for i, attribute := range input.Attributes {
+			attributes[i] = pq.QuoteIdentifier(attribute.Name) + " " + attribute.Type
+		}
+
+		body = fmt.Sprintf("(%s)", strings.Join(attributes, ", "))
+	}
+
+	return fmt.Sprintf("CREATE TYPE %s AS %s;", quoteTypeName(input.Schema, input.Name), body)
+}
+
+func buildDropTypeStatement(schema, typeName string) string {
+	return fmt.Sprintf("DROP TYPE %s;", quoteTypeName(schema, typeName))
+}
+
+func buildAlterTypeStatement(schema, typeName string, input AlterTypeInput) string {
+	if input.Action == constants.TypeActionRenameValue {
+		return fmt.Sprintf(
+			"ALTER TYPE %s RENAME VALUE %s TO %s;",
+			quoteTypeName(schema, typeName),
+			pq.QuoteLiteral(input.Value),
+			pq.QuoteLiteral(input.NewValue),
+		)
+	}
+
+	statement := fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", quoteTypeName(schema, typeName), pq.QuoteLiteral(input.Value))
+	switch {
+	case input.Before != "":
+		statement += " BEFORE " + pq.QuoteLiteral(input.Before)
+	case input.After != "":
+		statement += " AFTER " + pq.QuoteLiteral(input.After)
+	}
+
+	return statement + ";"
+}
+
+func buildCreateTypeMigration(input CreateTypeInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_type_" + input.Name,
+		UpSQL:   buildCreateTypeStatement(input),
+		DownSQL: buildDropTypeStatement(input.Schema, input.Name),
+	}
+}
+
+// buildAlterTypeMigration reverses renames, added values stay because postgres cannot remove enum values
+func buildAlterTypeMigration(schema, typeName string, input AlterTypeInput) RecordMigrationInput {
+	migration := RecordMigrationInput{
+		Name:  fmt.Sprintf("%s_%s", input.Action, typeName),
+		UpSQL: buildAlterTypeStatement(schema, typeName, input),
+	}
+
+	if input.Action == constants.TypeActionRenameValue {
+		migration.DownSQL = buildAlterTypeStatement(schema, typeName, AlterTypeInput{
+			Action:   constants.TypeActionRenameValue,
+			Value:    input.NewValue,
+			NewValue: input.Value,
+		})
+	}
+
+	return migration
+}
+
+// buildDropTypeMigration recreates the type from its catalog definition on the way down
+func buildDropTypeMigration(dropped Type) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:  "drop_type_" + dropped.Name,
+		UpSQL: buildDropTypeStatement(dropped.Schema, dropped.Name),
+		DownSQL: buildCreateTypeStatement(CreateTypeInput{
+			Schema:     dropped.Schema,
+			Name:       dropped.Name,
+			Kind:       dropped.Kind,
+			Values:     dropped.Values,
+			Attributes: dropped.Attributes,
+		}),
+	}
+}
+
+func quoteTypeName(schema, typeName string) string {
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(typeName)
+}
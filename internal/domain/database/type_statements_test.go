@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTypeStatements_Suite(t *testing.T) {
+	t.Run("create enum", func(t *testing.T) {
+		migration := buildCreateTypeMigration(CreateTypeInput{
+			Schema: "public",
+			Name:   "order_status",
+			Kind:   constants.TypeKindEnum,
+			Values: []string{"pending", "it's shipped"},
+		})
+
+		assert.Equal(t, `CREATE TYPE "public"."order_status" AS ENUM ('pending', 'it''s shipped');`, migration.UpSQL)
+		assert.Equal(t, `DROP TYPE "public"."order_status";`, migration.DownSQL)
+	})
+
+	t.Run("create composite", func(t *testing.T) {
+		statement := buildCreateTypeStatement(CreateTypeInput{
+			Schema: "sales",
+			Name:   "address",
+			Kind:   constants.TypeKindComposite,
+			Attributes: []TypeAttribute{
+				{Name: "street", Type: "varchar(255)"},
+				{Name: "zip", Type: "char(5)"},
+			},
+		})
+
+		assert.Equal(t, `CREATE TYPE "sales"."address" AS ("street" varchar(255), "zip" char(5));`, statement)
+	})
+
+	t.Run("add value after an existing one cannot be reverted", func(t *testing.T) {
+		migration := buildAlterTypeMigration("public", "order_status", AlterTypeInput{
+			Action: constants.TypeActionAddValue,
+			Value:  "returned",
+			After:  "delivered",
+		})
+
+		assert.Equal(t, `ALTER TYPE "public"."order_status" ADD VALUE 'returned' AFTER 'delivered';`, migration.UpSQL)
+		assert.Equal(t, "", migration.DownSQL)
+	})
+
+	t.Run("rename value is reverted by renaming back", func(t *testing.T) {
+		migration := buildAlterTypeMigration("public", "order_status", AlterTypeInput{
+			Action:   constants.TypeActionRenameValue,
+			Value:    "shipped",
+			NewValue: "dispatched",
+		})
+
+		assert.Equal(t, `ALTER TYPE "public"."order_status" RENAME VALUE 'shipped' TO 'dispatched';`, migration.UpSQL)
+		assert.Equal(t, `ALTER TYPE "public"."order_status" RENAME VALUE 'dispatched' TO 'shipped';`, migration.DownSQL)
+	})
+
+	t.Run("drop recreates the type on the way down", func(t *testing.T) {
+		migration := buildDropTypeMigration(Type{
+			Schema: "public",
+			Name:   "order_status",
+			Kind:   constants.TypeKindEnum,
+			Values: []string{"pending", "shipped"},
+		})
+
+		assert.Equal(t, `DROP TYPE "public"."order_status";`, migration.UpSQL)
+		assert.Equal(t, `CREATE TYPE "public"."order_status" AS ENUM ('pending', 'shipped');`, migration.DownSQL)
+	})
+}
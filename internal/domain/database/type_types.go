@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateTypeInput struct {
+	ProjectUUID uuid.UUID
+	Schema      string
+	Name        string
+	Kind        string
+	Values      []string
+	Attributes  []TypeAttribute
+}
+
+type AlterTypeInput struct {
+	ProjectUUID uuid.UUID
+	Action      string
+	Value       string
+	NewValue    string
+	Before      string
+	After       string
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type View struct {
+	Schema       string `db:"schema" json:"schema"`
+	Name         string `db:"name" json:"name"`
+	Materialized bool   `db:"materialized" json:"materialized"`
+	Definition   string `db:"definition" json:"definition"`
+
+	// only meaningful for materialized views, a concurrent refresh needs both
+	Populated      bool `db:"populated" json:"populated"`
+	HasUniqueIndex bool `db:"has_unique_index" json:"hasUniqueIndex"`
+
+	Schedule *ViewRefreshSchedule `db:"-" json:"schedule"`
+
+	// Extension names the extension the view belongs to, it comes and goes with the extension
+	Extension string `db:"extension" json:"extension"`
+}
+
+// ViewRefreshSchedule lives in the main database so one scheduler can serve every project
+type ViewRefreshSchedule struct {
+	ID              int        `db:"id" json:"id"`
+	ProjectUuid     uuid.UUID  `db:"project_uuid" json:"projectUuid"`
+	Schema          string     `db:"schema_name" json:"schema"`
+	ViewName        string     `db:"view_name" json:"viewName"`
+	IntervalMinutes int        `db:"interval_minutes" json:"intervalMinutes"`
+	Concurrently    bool       `db:"concurrently" json:"concurrently"`
+	NextRefreshAt   time.Time  `db:"next_refresh_at" json:"nextRefreshAt"`
+	LastRefreshedAt *time.Time `db:"last_refreshed_at" json:"lastRefreshedAt"`
+	LastError       string     `db:"last_error" json:"lastError"`
+	CreatedAt       time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+func (v View) FullName() string {
+	return v.Schema + "." + v.Name
+}
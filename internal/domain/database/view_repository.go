@@ -0,0 +1,24 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ViewRepository interface {
+	List(schema string) ([]View, error)
+	GetByName(schema, viewName string) (View, error)
+	Has(schema, viewName string) (bool, error)
+	Create(viewSQL string) error
+	Replace(dropSQL, createSQL string) error
+	Drop(viewSQL string) error
+	Refresh(refreshSQL string) error
+}
+
+type ViewRefreshScheduleRepository interface {
+	GetByView(projectUUID uuid.UUID, schema, viewName string) (*ViewRefreshSchedule, error)
+	Upsert(schedule ViewRefreshSchedule) error
+	Delete(projectUUID uuid.UUID, schema, viewName string) error
+	ClaimDue(limit int) ([]ViewRefreshSchedule, error)
+	MarkRefreshed(id int, refreshedAt time.Time, lastError string) error
+}
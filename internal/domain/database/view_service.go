@@ -0,0 +1,404 @@
+package database
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/shared"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+type ViewService interface {
+	List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]View, error)
+	GetByName(fullViewName string, projectUUID uuid.UUID, authUser auth.User) (View, error)
+	Create(request CreateViewInput, authUser auth.User) (View, error)
+	Replace(fullViewName string, request ReplaceViewInput, authUser auth.User) (View, error)
+	Delete(fullViewName string, projectUUID uuid.UUID, authUser auth.User) (bool, error)
+	Refresh(fullViewName string, request RefreshViewInput, authUser auth.User) (View, error)
+	Schedule(fullViewName string, request ScheduleViewRefreshInput, authUser auth.User) (View, error)
+	RefreshDue() (int, error)
+}
+
+type ViewServiceImpl struct {
+	connectionService ConnectionService
+	migrationService  MigrationService
+	postgrestService  shared.PostgrestService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	scheduleRepo      ViewRefreshScheduleRepository
+}
+
+func NewViewService(injector *do.Injector) (ViewService, error) {
+	connectionService := do.MustInvoke[ConnectionService](injector)
+	migrationService := do.MustInvoke[MigrationService](injector)
+	postgrestService := do.MustInvoke[shared.PostgrestService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	scheduleRepo := do.MustInvoke[ViewRefreshScheduleRepository](injector)
+
+	return &ViewServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		postgrestService:  postgrestService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		scheduleRepo:      scheduleRepo,
+	}, nil
+}
+
+func (s *ViewServiceImpl) List(schema string, projectUUID uuid.UUID, authUser auth.User) ([]View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	return clientViewRepo.List(schema)
+}
+
+func (s *ViewServiceImpl) GetByName(fullViewName string, projectUUID uuid.UUID, authUser auth.User) (View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return View{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return View{}, err
+	}
+	defer connection.Close()
+
+	schema, viewName := pkg.ParseTableName(fullViewName)
+
+	return s.getWithSchedule(clientViewRepo, projectUUID, schema, viewName)
+}
+
+func (s *ViewServiceImpl) Create(request CreateViewInput, authUser auth.User) (View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !s.projectPolicy.CanCreate(fetchedProject.OrganizationUuid, authUser) {
+		return View{}, flxErrors.NewForbiddenError("view.error.createForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return View{}, err
+	}
+	defer connection.Close()
+
+	hasRelation, err := clientViewRepo.Has(request.Schema, request.Name)
+	if err != nil {
+		return View{}, err
+	}
+
+	if hasRelation {
+		return View{}, flxErrors.NewUnprocessableError("view.error.alreadyExists")
+	}
+
+	if err = clientViewRepo.Create(buildCreateViewStatement(request, false)); err != nil {
+		return View{}, s.toViewError(err)
+	}
+
+	s.migrationService.Record(connection, buildCreateViewMigration(request))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	if request.Materialized && request.RefreshIntervalMinutes > 0 {
+		err = s.scheduleRepo.Upsert(s.buildSchedule(request.ProjectUUID, request.Schema, request.Name, ScheduleViewRefreshInput{
+			IntervalMinutes: request.RefreshIntervalMinutes,
+			Concurrently:    request.RefreshConcurrently,
+		}))
+		if err != nil {
+			return View{}, err
+		}
+	}
+
+	return s.getWithSchedule(clientViewRepo, request.ProjectUUID, request.Schema, request.Name)
+}
+
+// Replace changes the query behind a view, materialized views are rebuilt and lose their indexes
+func (s *ViewServiceImpl) Replace(fullViewName string, request ReplaceViewInput, authUser auth.User) (View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return View{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return View{}, err
+	}
+	defer connection.Close()
+
+	schema, viewName := pkg.ParseTableName(fullViewName)
+	previous, err := clientViewRepo.GetByName(schema, viewName)
+	if err != nil {
+		return View{}, err
+	}
+
+	dropSQL, createSQL := buildReplaceViewStatements(previous, request.Definition)
+	if err = clientViewRepo.Replace(dropSQL, createSQL); err != nil {
+		return View{}, s.toViewError(err)
+	}
+
+	s.migrationService.Record(connection, buildReplaceViewMigration(previous, request.Definition))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	return s.getWithSchedule(clientViewRepo, request.ProjectUUID, schema, viewName)
+}
+
+func (s *ViewServiceImpl) Delete(fullViewName string, projectUUID uuid.UUID, authUser auth.User) (bool, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return false, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return false, err
+	}
+	defer connection.Close()
+
+	schema, viewName := pkg.ParseTableName(fullViewName)
+	view, err := clientViewRepo.GetByName(schema, viewName)
+	if err != nil {
+		return false, err
+	}
+
+	if err = clientViewRepo.Drop(buildDropViewStatement(schema, viewName, view.Materialized)); err != nil {
+		return false, s.toViewError(err)
+	}
+
+	s.migrationService.Record(connection, buildDropViewMigration(view))
+	s.postgrestService.RefreshSchemaCache(fetchedProject.DBName)
+
+	if err = s.scheduleRepo.Delete(projectUUID, schema, viewName); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Refresh re-runs the query of a materialized view. A concurrent refresh keeps the view
+// readable while it runs, but needs a populated view with a unique index
+func (s *ViewServiceImpl) Refresh(fullViewName string, request RefreshViewInput, authUser auth.User) (View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return View{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return View{}, err
+	}
+	defer connection.Close()
+
+	schema, viewName := pkg.ParseTableName(fullViewName)
+	view, err := clientViewRepo.GetByName(schema, viewName)
+	if err != nil {
+		return View{}, err
+	}
+
+	if err = s.validateRefresh(view, request.Concurrently); err != nil {
+		return View{}, err
+	}
+
+	if err = clientViewRepo.Refresh(buildRefreshViewStatement(schema, viewName, request.Concurrently)); err != nil {
+		return View{}, s.toViewError(err)
+	}
+
+	return s.getWithSchedule(clientViewRepo, request.ProjectUUID, schema, viewName)
+}
+
+// Schedule sets how often a materialized view is refreshed, an interval of zero removes the schedule
+func (s *ViewServiceImpl) Schedule(fullViewName string, request ScheduleViewRefreshInput, authUser auth.User) (View, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(request.ProjectUUID)
+	if err != nil {
+		return View{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return View{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return View{}, err
+	}
+	defer connection.Close()
+
+	schema, viewName := pkg.ParseTableName(fullViewName)
+	view, err := clientViewRepo.GetByName(schema, viewName)
+	if err != nil {
+		return View{}, err
+	}
+
+	if request.IntervalMinutes == 0 {
+		if err = s.scheduleRepo.Delete(request.ProjectUUID, schema, viewName); err != nil {
+			return View{}, err
+		}
+
+		return s.getWithSchedule(clientViewRepo, request.ProjectUUID, schema, viewName)
+	}
+
+	if !view.Materialized {
+		return View{}, flxErrors.NewBadRequestError("view.error.notMaterialized")
+	}
+
+	if request.Concurrently && !view.HasUniqueIndex {
+		return View{}, flxErrors.NewBadRequestError("view.error.concurrentRefreshNeedsUniqueIndex")
+	}
+
+	if err = s.scheduleRepo.Upsert(s.buildSchedule(request.ProjectUUID, schema, viewName, request)); err != nil {
+		return View{}, err
+	}
+
+	return s.getWithSchedule(clientViewRepo, request.ProjectUUID, schema, viewName)
+}
+
+// RefreshDue runs the scheduled refreshes that are due and returns how many were claimed.
+// Failures are kept on the schedule instead of stopping the remaining refreshes
+func (s *ViewServiceImpl) RefreshDue() (int, error) {
+	schedules, err := s.scheduleRepo.ClaimDue(constants.ViewRefreshBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, schedule := range schedules {
+		lastError := ""
+		if err := s.refreshScheduled(schedule); err != nil {
+			lastError = err.Error()
+
+			log.Error().
+				Str("action", constants.ActionViewRefresh).
+				Str("project", schedule.ProjectUuid.String()).
+				Str("view", schedule.Schema+"."+schedule.ViewName).
+				Str("error", lastError).
+				Msg("scheduled view refresh failed")
+		}
+
+		if err := s.scheduleRepo.MarkRefreshed(schedule.ID, time.Now(), lastError); err != nil {
+			return len(schedules), err
+		}
+	}
+
+	return len(schedules), nil
+}
+
+func (s *ViewServiceImpl) refreshScheduled(schedule ViewRefreshSchedule) error {
+	fetchedProject, err := s.projectRepo.GetByUUID(schedule.ProjectUuid)
+	if err != nil {
+		return err
+	}
+
+	clientViewRepo, connection, err := s.getClientViewRepo(fetchedProject.DBName)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	return clientViewRepo.Refresh(buildRefreshViewStatement(schedule.Schema, schedule.ViewName, schedule.Concurrently))
+}
+
+func (s *ViewServiceImpl) validateRefresh(view View, concurrently bool) error {
+	if !view.Materialized {
+		return flxErrors.NewBadRequestError("view.error.notMaterialized")
+	}
+
+	if concurrently && !view.Populated {
+		return flxErrors.NewBadRequestError("view.error.concurrentRefreshNeedsData")
+	}
+
+	if concurrently && !view.HasUniqueIndex {
+		return flxErrors.NewBadRequestError("view.error.concurrentRefreshNeedsUniqueIndex")
+	}
+
+	return nil
+}
+
+func (s *ViewServiceImpl) buildSchedule(projectUUID uuid.UUID, schema, viewName string, request ScheduleViewRefreshInput) ViewRefreshSchedule {
+	return ViewRefreshSchedule{
+		ProjectUuid:     projectUUID,
+		Schema:          schema,
+		ViewName:        viewName,
+		IntervalMinutes: request.IntervalMinutes,
+		Concurrently:    request.Concurrently,
+		NextRefreshAt:   time.Now().Add(time.Duration(request.IntervalMinutes) * time.Minute),
+	}
+}
+
+func (s *ViewServiceImpl) getWithSchedule(clientViewRepo ViewRepository, projectUUID uuid.UUID, schema, viewName string) (View, error) {
+	view, err := clientViewRepo.GetByName(schema, viewName)
+	if err != nil {
+		return View{}, err
+	}
+
+	if view.Materialized {
+		view.Schedule, err = s.scheduleRepo.GetByView(projectUUID, schema, viewName)
+		if err != nil {
+			return View{}, err
+		}
+	}
+
+	return view, nil
+}
+
+// toViewError surfaces database errors such as invalid queries or dependent objects as bad requests
+func (s *ViewServiceImpl) toViewError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *ViewServiceImpl) getClientViewRepo(dbName string) (ViewRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetViewRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(ViewRepository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientViewRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
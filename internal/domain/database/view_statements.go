@@ -0,0 +1,108 @@
+package database
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func buildCreateViewStatement(input CreateViewInput, orReplace bool) string {
+	definition := normalizeViewDefinition(input.Definition)
+	name := quoteViewName(input.Schema, input.Name)
+
+	if !input.Materialized {
+		command := "CREATE VIEW"
+		if orReplace {
+			command = "CREATE OR REPLACE VIEW"
+		}
+
+		return fmt.Sprintf("%s %s AS %s;", command, name, definition)
+	}
+
+	data := "WITH DATA"
+	if input.SkipData {
+		data = "WITH NO DATA"
+	}
+
+	return fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS %s %s;", name, definition, data)
+}
+
+func buildDropViewStatement(schema, viewName string, materialized bool) string {
+	if materialized {
+		return fmt.Sprintf("DROP MATERIALIZED VIEW %s;", quoteViewName(schema, viewName))
+	}
+
+	return fmt.Sprintf("DROP VIEW %s;", quoteViewName(schema, viewName))
+}
+
+func buildRefreshViewStatement(schema, viewName string, concurrently bool) string {
+	if concurrently {
+		return fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s;", quoteViewName(schema, viewName))
+	}
+
+	return fmt.Sprintf("REFRESH MATERIALIZED VIEW %s;", quoteViewName(schema, viewName))
+}
+
+// buildReplaceViewStatements keeps plain views in place with CREATE OR REPLACE, materialized
+// views have no such form and are dropped and created again, which also drops their indexes
+func buildReplaceViewStatements(view View, definition string) (string, string) {
+	input := CreateViewInput{
+		Schema:       view.Schema,
+		Name:         view.Name,
+		Definition:   definition,
+		Materialized: view.Materialized,
+	}
+
+	if !view.Materialized {
+		return "", buildCreateViewStatement(input, true)
+	}
+
+	return buildDropViewStatement(view.Schema, view.Name, true), buildCreateViewStatement(input, false)
+}
+
+func buildCreateViewMigration(input CreateViewInput) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "create_view_" + input.Name,
+		UpSQL:   buildCreateViewStatement(input, false),
+		DownSQL: buildDropViewStatement(input.Schema, input.Name, input.Materialized),
+	}
+}
+
+// buildReplaceViewMigration restores the previous definition by recreating the view, since
+// CREATE OR REPLACE cannot remove columns the new definition added
+func buildReplaceViewMigration(previous View, definition string) RecordMigrationInput {
+	dropSQL, createSQL := buildReplaceViewStatements(previous, definition)
+
+	return RecordMigrationInput{
+		Name:    "replace_view_" + previous.Name,
+		UpSQL:   strings.TrimSpace(dropSQL + "\n" + createSQL),
+		DownSQL: buildDropViewStatement(previous.Schema, previous.Name, previous.Materialized) + "\n" + buildRecreateViewStatement(previous),
+	}
+}
+
+func buildDropViewMigration(view View) RecordMigrationInput {
+	return RecordMigrationInput{
+		Name:    "drop_view_" + view.Name,
+		UpSQL:   buildDropViewStatement(view.Schema, view.Name, view.Materialized),
+		DownSQL: buildRecreateViewStatement(view),
+	}
+}
+
+func buildRecreateViewStatement(view View) string {
+	return buildCreateViewStatement(CreateViewInput{
+		Schema:       view.Schema,
+		Name:         view.Name,
+		Definition:   view.Definition,
+		Materialized: view.Materialized,
+		SkipData:     view.Materialized && !view.Populated,
+	}, false)
+}
+
+// normalizeViewDefinition drops trailing semicolons, pg_get_viewdef and most editors add one
+func normalizeViewDefinition(definition string) string {
+	return strings.TrimRight(strings.TrimSpace(definition), "; \n\t")
+}
+
+func quoteViewName(schema, viewName string) string {
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(viewName)
+}
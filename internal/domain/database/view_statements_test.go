@@ -0,0 +1,87 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestViewStatements_Suite(t *testing.T) {
+	t.Run("create view strips trailing semicolons", func(t *testing.T) {
+		migration := buildCreateViewMigration(CreateViewInput{
+			Schema:     "public",
+			Name:       "active_users",
+			Definition: "SELECT id, email FROM users WHERE active;\n",
+		})
+
+		assert.Equal(t, `CREATE VIEW "public"."active_users" AS SELECT id, email FROM users WHERE active;`, migration.UpSQL)
+		assert.Equal(t, `DROP VIEW "public"."active_users";`, migration.DownSQL)
+	})
+
+	t.Run("create materialized view without data", func(t *testing.T) {
+		migration := buildCreateViewMigration(CreateViewInput{
+			Schema:       "reports",
+			Name:         "daily_sales",
+			Definition:   "SELECT day, sum(total) FROM orders GROUP BY day",
+			Materialized: true,
+			SkipData:     true,
+		})
+
+		assert.Equal(
+			t,
+			`CREATE MATERIALIZED VIEW "reports"."daily_sales" AS SELECT day, sum(total) FROM orders GROUP BY day WITH NO DATA;`,
+			migration.UpSQL,
+		)
+		assert.Equal(t, `DROP MATERIALIZED VIEW "reports"."daily_sales";`, migration.DownSQL)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		assert.Equal(t, `REFRESH MATERIALIZED VIEW "public"."daily_sales";`, buildRefreshViewStatement("public", "daily_sales", false))
+		assert.Equal(
+			t,
+			`REFRESH MATERIALIZED VIEW CONCURRENTLY "public"."daily_sales";`,
+			buildRefreshViewStatement("public", "daily_sales", true),
+		)
+	})
+
+	t.Run("replace view keeps it in place", func(t *testing.T) {
+		previous := View{Schema: "public", Name: "active_users", Definition: " SELECT id\n   FROM users;"}
+
+		dropSQL, createSQL := buildReplaceViewStatements(previous, "SELECT id, email FROM users")
+		assert.Equal(t, "", dropSQL)
+		assert.Equal(t, `CREATE OR REPLACE VIEW "public"."active_users" AS SELECT id, email FROM users;`, createSQL)
+
+		migration := buildReplaceViewMigration(previous, "SELECT id, email FROM users")
+		assert.Equal(t, createSQL, migration.UpSQL)
+		assert.Equal(
+			t,
+			"DROP VIEW \"public\".\"active_users\";\nCREATE VIEW \"public\".\"active_users\" AS SELECT id\n   FROM users;",
+			migration.DownSQL,
+		)
+	})
+
+	t.Run("replace materialized view drops and recreates it", func(t *testing.T) {
+		previous := View{
+			Schema:       "public",
+			Name:         "daily_sales",
+			Definition:   "SELECT 1",
+			Materialized: true,
+			Populated:    true,
+		}
+
+		dropSQL, createSQL := buildReplaceViewStatements(previous, "SELECT 2")
+		assert.Equal(t, `DROP MATERIALIZED VIEW "public"."daily_sales";`, dropSQL)
+		assert.Equal(t, `CREATE MATERIALIZED VIEW "public"."daily_sales" AS SELECT 2 WITH DATA;`, createSQL)
+	})
+
+	t.Run("drop recreates an unpopulated materialized view without data", func(t *testing.T) {
+		migration := buildDropViewMigration(View{
+			Schema:       "public",
+			Name:         "daily_sales",
+			Definition:   "SELECT 1;",
+			Materialized: true,
+		})
+
+		assert.Equal(t, `DROP MATERIALIZED VIEW "public"."daily_sales";`, migration.UpSQL)
+		assert.Equal(t, `CREATE MATERIALIZED VIEW "public"."daily_sales" AS SELECT 1 WITH NO DATA;`, migration.DownSQL)
+	})
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"github.com/google/uuid"
+)
+
+type CreateViewInput struct {
+	ProjectUUID  uuid.UUID
+	Schema       string
+	Name         string
+	Definition   string
+	Materialized bool
+	SkipData     bool
+
+	// only for materialized views, zero leaves the view without a schedule
+	RefreshIntervalMinutes int
+	RefreshConcurrently    bool
+}
+
+type ReplaceViewInput struct {
+	ProjectUUID uuid.UUID
+	Definition  string
+}
+
+type RefreshViewInput struct {
+	ProjectUUID  uuid.UUID
+	Concurrently bool
+}
+
+type ScheduleViewRefreshInput struct {
+	ProjectUUID     uuid.UUID
+	IntervalMinutes int
+	Concurrently    bool
+}
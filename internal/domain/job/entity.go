@@ -0,0 +1,25 @@
+package job
+
+import (
+	"encoding/json"
+	"fluxend/internal/domain/shared"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Job tracks long running database work, such as concurrent index builds, that outlives the request starting it
+type Job struct {
+	shared.BaseEntity
+	Uuid        uuid.UUID       `db:"uuid" json:"uuid"`
+	ProjectUuid uuid.UUID       `db:"project_uuid" json:"projectUuid"`
+	Type        string          `db:"type" json:"type"`
+	Status      string          `db:"status" json:"status"`
+	Payload     json.RawMessage `db:"payload" json:"payload"`
+	Result      json.RawMessage `db:"result" json:"result"`
+	Error       string          `db:"error" json:"error"`
+	CreatedBy   uuid.UUID       `db:"created_by" json:"createdBy"`
+	CreatedAt   time.Time       `db:"created_at" json:"createdAt"`
+	StartedAt   *time.Time      `db:"started_at" json:"startedAt"`
+	FinishedAt  *time.Time      `db:"finished_at" json:"finishedAt"`
+	HeartbeatAt *time.Time      `db:"heartbeat_at" json:"heartbeatAt"`
+}
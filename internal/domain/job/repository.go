@@ -0,0 +1,18 @@
+package job
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	ListForProject(projectUUID uuid.UUID, jobType string) ([]Job, error)
+	GetByUUID(jobUUID uuid.UUID) (Job, error)
+	Create(job *Job) (*Job, error)
+	MarkRunning(jobUUID uuid.UUID) error
+	MarkCompleted(jobUUID uuid.UUID, result json.RawMessage) error
+	MarkFailed(jobUUID uuid.UUID, errorMessage string) error
+	Heartbeat(jobUUID uuid.UUID) error
+	FailAbandoned(errorMessage string, timeout time.Duration) (int64, error)
+}
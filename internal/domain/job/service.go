@@ -0,0 +1,165 @@
+package job
+
+import (
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"time"
+)
+
+// Work is the body of a job, its result is stored as JSON once it succeeds
+type Work func() (interface{}, error)
+
+type Service interface {
+	List(projectUUID uuid.UUID, jobType string, authUser auth.User) ([]Job, error)
+	GetByUUID(jobUUID uuid.UUID, authUser auth.User) (Job, error)
+	Dispatch(projectUUID uuid.UUID, jobType string, payload interface{}, authUser auth.User, work Work) (Job, error)
+	FailAbandoned() (int64, error)
+}
+
+type ServiceImpl struct {
+	projectPolicy *project.Policy
+	jobRepo       Repository
+	projectRepo   project.Repository
+}
+
+func NewJobService(injector *do.Injector) (Service, error) {
+	policy := do.MustInvoke[*project.Policy](injector)
+	jobRepo := do.MustInvoke[Repository](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ServiceImpl{
+		projectPolicy: policy,
+		jobRepo:       jobRepo,
+		projectRepo:   projectRepo,
+	}, nil
+}
+
+func (s *ServiceImpl) List(projectUUID uuid.UUID, jobType string, authUser auth.User) ([]Job, error) {
+	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(projectUUID)
+	if err != nil {
+		return []Job{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(organizationUUID, authUser) {
+		return []Job{}, errors.NewForbiddenError("job.error.listForbidden")
+	}
+
+	return s.jobRepo.ListForProject(projectUUID, jobType)
+}
+
+func (s *ServiceImpl) GetByUUID(jobUUID uuid.UUID, authUser auth.User) (Job, error) {
+	job, err := s.jobRepo.GetByUUID(jobUUID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	organizationUUID, err := s.projectRepo.GetOrganizationUUIDByProjectUUID(job.ProjectUuid)
+	if err != nil {
+		return Job{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(organizationUUID, authUser) {
+		return Job{}, errors.NewForbiddenError("job.error.viewForbidden")
+	}
+
+	return job, nil
+}
+
+// Dispatch records a pending job and runs the work in the background, callers check authorization beforehand
+func (s *ServiceImpl) Dispatch(projectUUID uuid.UUID, jobType string, payload interface{}, authUser auth.User, work Work) (Job, error) {
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := Job{
+		ProjectUuid: projectUUID,
+		Type:        jobType,
+		Status:      constants.JobStatusPending,
+		Payload:     encodedPayload,
+		Result:      json.RawMessage("{}"),
+		CreatedBy:   authUser.Uuid,
+	}
+
+	createdJob, err := s.jobRepo.Create(&job)
+	if err != nil {
+		return Job{}, err
+	}
+
+	go s.run(*createdJob, work)
+
+	return *createdJob, nil
+}
+
+// FailAbandoned closes jobs whose process went away, they run in memory and cannot be resumed.
+// Instances tell their jobs apart through heartbeats, jobs of other running instances are kept
+func (s *ServiceImpl) FailAbandoned() (int64, error) {
+	return s.jobRepo.FailAbandoned(constants.JobInterruptedError, constants.JobHeartbeatTimeout)
+}
+
+func (s *ServiceImpl) run(job Job, work Work) {
+	if err := s.jobRepo.MarkRunning(job.Uuid); err != nil {
+		s.logError(job, err, "failed to mark job as running")
+
+		return
+	}
+
+	stopHeartbeat := s.startHeartbeat(job)
+	result, err := work()
+	stopHeartbeat()
+
+	if err != nil {
+		if markErr := s.jobRepo.MarkFailed(job.Uuid, err.Error()); markErr != nil {
+			s.logError(job, markErr, "failed to mark job as failed")
+		}
+
+		return
+	}
+
+	encodedResult, err := json.Marshal(result)
+	if err != nil {
+		encodedResult = json.RawMessage("{}")
+	}
+
+	if err = s.jobRepo.MarkCompleted(job.Uuid, encodedResult); err != nil {
+		s.logError(job, err, "failed to mark job as completed")
+	}
+}
+
+// startHeartbeat keeps the job marked as alive until the returned function is called
+func (s *ServiceImpl) startHeartbeat(job Job) func() {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(constants.JobHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.jobRepo.Heartbeat(job.Uuid); err != nil {
+					s.logError(job, err, "failed to record job heartbeat")
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (s *ServiceImpl) logError(job Job, err error, message string) {
+	log.Error().
+		Str("action", constants.ActionJob).
+		Str("job", job.Uuid.String()).
+		Str("type", job.Type).
+		Str("error", err.Error()).
+		Msg(message)
+}
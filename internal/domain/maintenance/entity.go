@@ -0,0 +1,13 @@
+package maintenance
+
+// Result is stored on the job once the action finished, sizes include indexes, TOAST and partitions
+type Result struct {
+	Table       string `json:"table"`
+	Action      string `json:"action"`
+	Statement   string `json:"statement"`
+	SizeBefore  string `json:"sizeBefore"`
+	SizeAfter   string `json:"sizeAfter"`
+	BytesBefore int64  `json:"bytesBefore"`
+	BytesAfter  int64  `json:"bytesAfter"`
+	BytesFreed  int64  `json:"bytesFreed"`
+}
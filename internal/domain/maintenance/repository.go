@@ -0,0 +1,5 @@
+package maintenance
+
+type Repository interface {
+	Execute(statement string) error
+}
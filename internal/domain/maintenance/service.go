@@ -0,0 +1,147 @@
+package maintenance
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/job"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/stats"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+)
+
+// Service runs TRUNCATE, VACUUM and REINDEX on a table as background jobs, the job result
+// reports the size of the table before and after the action
+type Service interface {
+	Run(fullTableName string, input RunInput, authUser auth.User) (job.Job, error)
+}
+
+type ServiceImpl struct {
+	connectionService database.ConnectionService
+	jobService        job.Service
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewMaintenanceService(injector *do.Injector) (Service, error) {
+	connectionService := do.MustInvoke[database.ConnectionService](injector)
+	jobService := do.MustInvoke[job.Service](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ServiceImpl{
+		connectionService: connectionService,
+		jobService:        jobService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+func (s *ServiceImpl) Run(fullTableName string, input RunInput, authUser auth.User) (job.Job, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return job.Job{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	table, err := s.getTable(fetchedProject.DBName, fullTableName)
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	statement := buildStatement(table.FullName(), input)
+	payload := map[string]string{
+		"table":     table.FullName(),
+		"action":    input.Action,
+		"statement": statement,
+	}
+
+	return s.jobService.Dispatch(input.ProjectUUID, constants.MaintenanceJobTypes[input.Action], payload, authUser, func() (interface{}, error) {
+		return s.run(fetchedProject.DBName, table.FullName(), input.Action, statement)
+	})
+}
+
+// run executes in the background with its own connection, sizes are read on the same connection
+// right before and after the statement
+func (s *ServiceImpl) run(dbName, fullTableName, action, statement string) (Result, error) {
+	connection, err := s.connectionService.ConnectByDatabaseName(dbName)
+	if err != nil {
+		return Result{}, err
+	}
+	defer connection.Close()
+
+	clientStatsRepo, clientMaintenanceRepo, err := s.getClientRepos(connection)
+	if err != nil {
+		return Result{}, err
+	}
+
+	before, err := clientStatsRepo.GetTableSize(quoteTableName(fullTableName))
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err = clientMaintenanceRepo.Execute(statement); err != nil {
+		return Result{}, err
+	}
+
+	after, err := clientStatsRepo.GetTableSize(quoteTableName(fullTableName))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Table:       fullTableName,
+		Action:      action,
+		Statement:   statement,
+		SizeBefore:  before.TotalSize,
+		SizeAfter:   after.TotalSize,
+		BytesBefore: before.TotalBytes,
+		BytesAfter:  after.TotalBytes,
+		BytesFreed:  before.TotalBytes - after.TotalBytes,
+	}, nil
+}
+
+func (s *ServiceImpl) getTable(dbName, fullTableName string) (database.Table, error) {
+	repo, connection, err := s.connectionService.GetTableRepo(dbName, nil)
+	if err != nil {
+		return database.Table{}, err
+	}
+	defer connection.Close()
+
+	clientTableRepo, ok := repo.(database.TableRepository)
+	if !ok {
+		return database.Table{}, flxErrors.NewUnprocessableError("clientTableRepo is invalid")
+	}
+
+	return clientTableRepo.GetByNameInSchema(pkg.ParseTableName(fullTableName))
+}
+
+func (s *ServiceImpl) getClientRepos(connection *sqlx.DB) (stats.StatRepository, Repository, error) {
+	statsRepo, _, err := s.connectionService.GetDatabaseStatsRepo("", connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientStatsRepo, ok := statsRepo.(stats.StatRepository)
+	if !ok {
+		return nil, nil, flxErrors.NewUnprocessableError("clientStatsRepo is invalid")
+	}
+
+	maintenanceRepo, _, err := s.connectionService.GetMaintenanceRepo("", connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientMaintenanceRepo, ok := maintenanceRepo.(Repository)
+	if !ok {
+		return nil, nil, flxErrors.NewUnprocessableError("clientMaintenanceRepo is invalid")
+	}
+
+	return clientStatsRepo, clientMaintenanceRepo, nil
+}
@@ -0,0 +1,62 @@
+package maintenance
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+func quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
+}
+
+func buildStatement(fullTableName string, input RunInput) string {
+	switch input.Action {
+	case constants.MaintenanceActionTruncate:
+		return buildTruncateStatement(fullTableName, input.RestartIdentity, input.Cascade)
+	case constants.MaintenanceActionVacuum:
+		return buildVacuumStatement(fullTableName, input.Full, input.Analyze)
+	default:
+		return buildReindexStatement(fullTableName)
+	}
+}
+
+// buildTruncateStatement with cascade also empties every table referencing this one through a foreign key
+func buildTruncateStatement(fullTableName string, restartIdentity, cascade bool) string {
+	statement := "TRUNCATE TABLE " + quoteTableName(fullTableName)
+	if restartIdentity {
+		statement += " RESTART IDENTITY"
+	}
+
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return statement + ";"
+}
+
+// buildVacuumStatement with full rewrites the table and holds an exclusive lock until it is done
+func buildVacuumStatement(fullTableName string, full, analyze bool) string {
+	var options []string
+	if full {
+		options = append(options, "FULL")
+	}
+
+	if analyze {
+		options = append(options, "ANALYZE")
+	}
+
+	if len(options) == 0 {
+		return fmt.Sprintf("VACUUM %s;", quoteTableName(fullTableName))
+	}
+
+	return fmt.Sprintf("VACUUM (%s) %s;", strings.Join(options, ", "), quoteTableName(fullTableName))
+}
+
+func buildReindexStatement(fullTableName string) string {
+	return fmt.Sprintf("REINDEX TABLE %s;", quoteTableName(fullTableName))
+}
@@ -0,0 +1,32 @@
+package maintenance
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMaintenanceStatements_Suite(t *testing.T) {
+	t.Run("truncate options", func(t *testing.T) {
+		assert.Equal(t, `TRUNCATE TABLE "public"."orders";`, buildTruncateStatement("public.orders", false, false))
+		assert.Equal(t, `TRUNCATE TABLE "public"."orders" RESTART IDENTITY;`, buildTruncateStatement("public.orders", true, false))
+		assert.Equal(t, `TRUNCATE TABLE "public"."orders" RESTART IDENTITY CASCADE;`, buildTruncateStatement("public.orders", true, true))
+	})
+
+	t.Run("vacuum options", func(t *testing.T) {
+		assert.Equal(t, `VACUUM "public"."orders";`, buildVacuumStatement("public.orders", false, false))
+		assert.Equal(t, `VACUUM (ANALYZE) "public"."orders";`, buildVacuumStatement("public.orders", false, true))
+		assert.Equal(t, `VACUUM (FULL, ANALYZE) "public"."orders";`, buildVacuumStatement("public.orders", true, true))
+	})
+
+	t.Run("statement follows the action", func(t *testing.T) {
+		input := RunInput{Action: constants.MaintenanceActionReindex}
+		assert.Equal(t, `REINDEX TABLE "sales"."Orders";`, buildStatement("sales.Orders", input))
+
+		input = RunInput{Action: constants.MaintenanceActionTruncate, Cascade: true}
+		assert.Equal(t, `TRUNCATE TABLE "public"."orders" CASCADE;`, buildStatement("public.orders", input))
+
+		input = RunInput{Action: constants.MaintenanceActionVacuum, Full: true}
+		assert.Equal(t, `VACUUM (FULL) "public"."orders";`, buildStatement("public.orders", input))
+	})
+}
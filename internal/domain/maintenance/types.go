@@ -0,0 +1,14 @@
+package maintenance
+
+import (
+	"github.com/google/uuid"
+)
+
+type RunInput struct {
+	ProjectUUID     uuid.UUID `json:"projectUUID,omitempty"`
+	Action          string    `json:"action"`
+	RestartIdentity bool      `json:"restartIdentity"`
+	Cascade         bool      `json:"cascade"`
+	Full            bool      `json:"full"`
+	Analyze         bool      `json:"analyze"`
+}
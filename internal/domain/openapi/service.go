@@ -3,6 +3,7 @@ package openapi
 import (
 	"encoding/json"
 	"errors"
+	"fluxend/internal/config/constants"
 	"fluxend/internal/domain/auth"
 	"fluxend/internal/domain/database"
 	"fluxend/internal/domain/project"
@@ -59,13 +60,42 @@ func (s *ServiceImpl) Generate(projectUUID uuid.UUID, requestedTables string, au
 	}
 	defer connection.Close()
 
-	tables, err := clientTableRepo.List()
+	clientTypeRepo, err := s.getTypeRepository(fetchedProject.DBName, connection)
 	if err != nil {
 		return "", err
 	}
 
+	clientViewRepo, err := s.getViewRepository(fetchedProject.DBName, connection)
+	if err != nil {
+		return "", err
+	}
+
+	// the first exposed schema is the one PostgREST serves without a profile header
+	schemas := []string(fetchedProject.ExposedSchemas)
+	if len(schemas) == 0 {
+		schemas = []string{pkg.DefaultSchema}
+	}
+
+	var tables []database.Table
+	var views []database.View
+	for _, schema := range schemas {
+		schemaTables, err := clientTableRepo.List(schema)
+		if err != nil {
+			return "", err
+		}
+
+		schemaViews, err := clientViewRepo.List(schema)
+		if err != nil {
+			return "", err
+		}
+
+		tables = append(tables, schemaTables...)
+		views = append(views, schemaViews...)
+	}
+
 	tablesToProcess := s.filterTables(tables, requestedTables)
-	spec := s.generateOpenAPISpec(fetchedProject, tablesToProcess, clientColumnRepo)
+	viewsToProcess := s.filterViews(views, requestedTables)
+	spec := s.generateOpenAPISpec(fetchedProject, schemas[0], tablesToProcess, viewsToProcess, clientColumnRepo, clientTypeRepo)
 
 	jsonBytes, err := json.MarshalIndent(spec, "", "  ")
 	if err != nil {
@@ -111,6 +141,34 @@ func (s *ServiceImpl) getRepositories(dbName string) (*sqlx.DB, database.TableRe
 	return connection, clientTableRepo, clientColumnRepo, nil
 }
 
+func (s *ServiceImpl) getTypeRepository(dbName string, connection *sqlx.DB) (database.TypeRepository, error) {
+	repo, _, err := s.connectionService.GetTypeRepo(dbName, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientTypeRepo, ok := repo.(database.TypeRepository)
+	if !ok {
+		return nil, errors.New("clientTypeRepo is not of type *repositories.TypeRepository")
+	}
+
+	return clientTypeRepo, nil
+}
+
+func (s *ServiceImpl) getViewRepository(dbName string, connection *sqlx.DB) (database.ViewRepository, error) {
+	repo, _, err := s.connectionService.GetViewRepo(dbName, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	clientViewRepo, ok := repo.(database.ViewRepository)
+	if !ok {
+		return nil, errors.New("clientViewRepo is not of type *repositories.ViewRepository")
+	}
+
+	return clientViewRepo, nil
+}
+
 func (s *ServiceImpl) filterTables(tables []database.Table, requestedTables string) []database.Table {
 	requestedTables = strings.ReplaceAll(requestedTables, " ", "")
 	if requestedTables == "" {
@@ -125,7 +183,7 @@ func (s *ServiceImpl) filterTables(tables []database.Table, requestedTables stri
 
 	var tablesToProcess []database.Table
 	for _, table := range tables {
-		if requestedSet[table.Name] {
+		if requestedSet[table.Name] || requestedSet[table.FullName()] {
 			tablesToProcess = append(tablesToProcess, table)
 		}
 	}
@@ -133,7 +191,35 @@ func (s *ServiceImpl) filterTables(tables []database.Table, requestedTables stri
 	return tablesToProcess
 }
 
-func (s *ServiceImpl) generateOpenAPISpec(project *project.Project, tables []database.Table, columnRepo database.ColumnRepository) ApiSpec {
+func (s *ServiceImpl) filterViews(views []database.View, requestedTables string) []database.View {
+	requestedTables = strings.ReplaceAll(requestedTables, " ", "")
+	if requestedTables == "" {
+		return views
+	}
+
+	requestedSet := make(map[string]bool)
+	for _, name := range strings.Split(requestedTables, ",") {
+		requestedSet[name] = true
+	}
+
+	var viewsToProcess []database.View
+	for _, view := range views {
+		if requestedSet[view.Name] || requestedSet[view.FullName()] {
+			viewsToProcess = append(viewsToProcess, view)
+		}
+	}
+
+	return viewsToProcess
+}
+
+func (s *ServiceImpl) generateOpenAPISpec(
+	project *project.Project,
+	defaultSchema string,
+	tables []database.Table,
+	views []database.View,
+	columnRepo database.ColumnRepository,
+	typeRepo database.TypeRepository,
+) ApiSpec {
 	spec := ApiSpec{
 		OpenAPI: openAPIVersion,
 		Info: Info{
@@ -153,22 +239,148 @@ func (s *ServiceImpl) generateOpenAPISpec(project *project.Project, tables []dat
 		},
 	}
 
+	var allColumns []database.Column
 	for _, table := range tables {
-		columns, err := columnRepo.List(table.Name)
+		if _, taken := spec.Paths["/"+table.Name]; taken {
+			continue // PostgREST serves every schema on the same paths, the first exposed schema is described
+		}
+
+		columns, err := columnRepo.List(table.FullName())
 		if err != nil {
 			continue // Skip tables with errors
 		}
 
-		s.addTableToSpec(&spec, table.Name, columns)
+		s.addTableToSpec(&spec, table, s.componentName(table.Schema, table.Name, defaultSchema), columns)
+		s.addProfileParameters(&spec, table.Name, table.Schema, defaultSchema)
+		allColumns = append(allColumns, columns...)
+	}
+
+	for _, view := range views {
+		if _, taken := spec.Paths["/"+view.Name]; taken {
+			continue
+		}
+
+		columns, err := columnRepo.List(view.FullName())
+		if err != nil {
+			continue // Skip views with errors
+		}
+
+		s.addViewToSpec(&spec, view.Name, s.componentName(view.Schema, view.Name, defaultSchema), columns)
+		s.addProfileParameters(&spec, view.Name, view.Schema, defaultSchema)
+		allColumns = append(allColumns, columns...)
 	}
 
+	s.addUserDefinedTypesToSpec(&spec, allColumns, typeRepo)
+
 	return spec
 }
 
-func (s *ServiceImpl) addTableToSpec(spec *ApiSpec, tableName string, columns []database.Column) {
+// addUserDefinedTypesToSpec adds the enum and composite types columns refer to as shared schemas
+func (s *ServiceImpl) addUserDefinedTypesToSpec(spec *ApiSpec, columns []database.Column, typeRepo database.TypeRepository) {
+	referenced := make(map[string]bool)
+	schemas := make(map[string]bool)
+	for _, col := range columns {
+		columnType, err := database.ParseColumnType(col.Type)
+		if err != nil || !columnType.UserDefined {
+			continue
+		}
+
+		schema, _ := columnType.SchemaAndName()
+		referenced[columnType.Name] = true
+		schemas[schema] = true
+	}
+
+	for schema := range schemas {
+		types, err := typeRepo.List(schema)
+		if err != nil {
+			continue // Skip schemas with errors, the references stay unresolved
+		}
+
+		for _, userType := range types {
+			if referenced[userType.FullName()] {
+				spec.Components.Schemas[userType.FullName()] = s.userDefinedTypeToSchema(userType)
+			}
+		}
+	}
+
+	// types from extensions such as citext are neither enums nor composites, they are sent as strings
+	for name := range referenced {
+		if _, ok := spec.Components.Schemas[name]; !ok {
+			spec.Components.Schemas[name] = Schema{Type: "string"}
+		}
+	}
+}
+
+func (s *ServiceImpl) userDefinedTypeToSchema(userType database.Type) Schema {
+	if userType.Kind == constants.TypeKindEnum {
+		return Schema{Type: "string", Enum: userType.Values}
+	}
+
+	properties := make(map[string]Schema, len(userType.Attributes))
+	for _, attribute := range userType.Attributes {
+		properties[attribute.Name] = s.columnToSchema(database.Column{Name: attribute.Name, Type: attribute.Type})
+	}
+
+	return Schema{Type: "object", Properties: properties}
+}
+
+// componentName keeps plain names for the default schema, relations of other schemas are qualified
+// so equal names in two schemas do not share a component
+func (s *ServiceImpl) componentName(schema, name, defaultSchema string) string {
+	if schema == defaultSchema {
+		return name
+	}
+
+	return schema + "." + name
+}
+
+func (s *ServiceImpl) addTableToSpec(spec *ApiSpec, table database.Table, name string, columns []database.Column) {
 	schema := s.generateTableSchema(columns)
-	spec.Components.Schemas[tableName] = schema
-	s.generateTablePaths(spec, tableName, columns)
+	schema.Description = table.Description
+	spec.Components.Schemas[name] = schema
+	s.generateTablePaths(spec, "/"+table.Name, name, columns)
+}
+
+// addViewToSpec exposes a view as read-only, postgrest cannot write through views with joins or aggregates
+func (s *ServiceImpl) addViewToSpec(spec *ApiSpec, viewName, name string, columns []database.Column) {
+	spec.Components.Schemas[name] = s.generateTableSchema(columns)
+	spec.Paths["/"+viewName] = PathItem{
+		Get: s.createGetCollectionOperation(name, columns),
+	}
+}
+
+// addProfileParameters makes the operations on a relation outside the default schema send the profile
+// headers PostgREST picks the schema with, reads use Accept-Profile and writes Content-Profile
+func (s *ServiceImpl) addProfileParameters(spec *ApiSpec, relationName, schema, defaultSchema string) {
+	if schema == defaultSchema {
+		return
+	}
+
+	profile := func(header string) Parameter {
+		return Parameter{
+			Name:     header,
+			In:       "header",
+			Required: true,
+			Schema:   Schema{Type: "string", Enum: []string{schema}},
+		}
+	}
+
+	path := "/" + relationName
+	for key, item := range spec.Paths {
+		if key != path && !strings.HasPrefix(key, path+"?") {
+			continue
+		}
+
+		if item.Get != nil {
+			item.Get.Parameters = append(item.Get.Parameters, profile("Accept-Profile"))
+		}
+
+		for _, operation := range []*Operation{item.Post, item.Patch, item.Delete} {
+			if operation != nil {
+				operation.Parameters = append(operation.Parameters, profile("Content-Profile"))
+			}
+		}
+	}
 }
 
 func (s *ServiceImpl) generateTableSchema(columns []database.Column) Schema {
@@ -186,13 +398,28 @@ func (s *ServiceImpl) generateSchemaProperties(columns []database.Column) map[st
 	properties := make(map[string]Schema)
 
 	for _, col := range columns {
-		schema := s.columnToSchema(col)
-		properties[col.Name] = schema
+		properties[col.Name] = s.describeSchema(s.columnToSchema(col), col.Description)
 	}
 
 	return properties
 }
 
+// describeSchema adds a column description to its schema. OpenAPI 3.0 ignores siblings of $ref,
+// so references are wrapped in allOf to keep the description
+func (s *ServiceImpl) describeSchema(schema Schema, description string) Schema {
+	if description == "" {
+		return schema
+	}
+
+	if schema.Ref != "" {
+		return Schema{AllOf: []Schema{schema}, Description: description}
+	}
+
+	schema.Description = description
+
+	return schema
+}
+
 func (s *ServiceImpl) extractRequiredFields(columns []database.Column) []string {
 	var required []string
 
@@ -206,6 +433,18 @@ func (s *ServiceImpl) extractRequiredFields(columns []database.Column) []string
 }
 
 func (s *ServiceImpl) columnToSchema(col database.Column) Schema {
+	columnType, err := database.ParseColumnType(col.Type)
+	if err == nil && columnType.Dimensions > 0 {
+		columnType.Dimensions--
+		items := s.columnToSchema(database.Column{Name: col.Name, Type: columnType.String()})
+
+		return Schema{Type: "array", Items: &items}
+	}
+
+	if err == nil && columnType.UserDefined {
+		return Schema{Ref: "#/components/schemas/" + columnType.Name}
+	}
+
 	schema := Schema{}
 
 	switch {
@@ -296,9 +535,7 @@ func (s *ServiceImpl) isJSONType(colType string) bool {
 	return strings.Contains(colType, "json")
 }
 
-func (s *ServiceImpl) generateTablePaths(spec *ApiSpec, tableName string, columns []database.Column) {
-	path := "/" + tableName
-
+func (s *ServiceImpl) generateTablePaths(spec *ApiSpec, path, tableName string, columns []database.Column) {
 	primaryKeys := s.extractPrimaryKeys(columns)
 
 	s.addCollectionPaths(spec, path, tableName, columns)
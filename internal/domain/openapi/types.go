@@ -64,10 +64,13 @@ type Components struct {
 }
 
 type Schema struct {
-	Type       string            `json:"type,omitempty"`
-	Properties map[string]Schema `json:"properties,omitempty"`
-	Items      *Schema           `json:"items,omitempty"`
-	Ref        string            `json:"$ref,omitempty"`
-	Format     string            `json:"format,omitempty"`
-	Required   []string          `json:"required,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Properties  map[string]Schema `json:"properties,omitempty"`
+	Items       *Schema           `json:"items,omitempty"`
+	Ref         string            `json:"$ref,omitempty"`
+	AllOf       []Schema          `json:"allOf,omitempty"`
+	Format      string            `json:"format,omitempty"`
+	Required    []string          `json:"required,omitempty"`
+	Enum        []string          `json:"enum,omitempty"`
 }
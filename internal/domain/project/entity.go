@@ -3,6 +3,7 @@ package project
 import (
 	"fluxend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"time"
 )
 
@@ -17,6 +18,10 @@ type Project struct {
 	Description      string    `db:"description"`
 	DBName           string    `db:"db_name"`
 	DBPort           int       `db:"db_port"`
-	CreatedAt        time.Time `db:"created_at"`
-	UpdatedAt        time.Time `db:"updated_at"`
+
+	// ExposedSchemas are served by the project's PostgREST, the first one being its default schema
+	ExposedSchemas pq.StringArray `db:"exposed_schemas"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -16,6 +16,7 @@ type Repository interface {
 	ExistsByNameForOrganization(name string, organizationUUID uuid.UUID) (bool, error)
 	Create(project *Project) (*Project, error)
 	Update(project *Project) (*Project, error)
+	UpdateExposedSchemas(projectUUID uuid.UUID, schemas []string) (bool, error)
 	UpdateStatusByDatabaseName(databaseName, status string) (bool, error)
 	Delete(projectUUID uuid.UUID) (bool, error)
 }
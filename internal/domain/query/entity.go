@@ -0,0 +1,39 @@
+package query
+
+import (
+	"fluxend/internal/domain/database"
+)
+
+type Result struct {
+	Columns    []database.ResultColumn `json:"columns"`
+	Rows       [][]interface{}         `json:"rows"`
+	RowCount   int                     `json:"rowCount"`
+	Truncated  bool                    `json:"truncated"`
+	ReadOnly   bool                    `json:"readOnly"`
+	DurationMs int64                   `json:"durationMs"`
+}
+
+type Plan struct {
+	Root          PlanNode          `json:"root"`
+	PlanningTime  *float64          `json:"planningTime"`
+	ExecutionTime *float64          `json:"executionTime"`
+	Analyzed      bool              `json:"analyzed"`
+	SeqScans      []SeqScan         `json:"seqScans"`
+	Suggestions   []IndexSuggestion `json:"suggestions"`
+}
+
+type SeqScan struct {
+	Schema    string `json:"schema"`
+	Table     string `json:"table"`
+	TableRows int    `json:"tableRows"`
+	Filter    string `json:"filter"`
+}
+
+type IndexSuggestion struct {
+	Schema        string   `json:"schema"`
+	Table         string   `json:"table"`
+	Columns       []string `json:"columns"`
+	Statement     string   `json:"statement"`
+	Reason        string   `json:"reason"`
+	UnusedIndexes []string `json:"unusedIndexes"`
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"encoding/json"
+	"fluxend/internal/domain/stats"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// filterColumnPattern picks column names out of plan filter and condition expressions such as
+// ((status)::text = 'active'::text) or (created_at > '2024-01-01')
+var filterColumnPattern = regexp.MustCompile(`\(*([a-z_][a-z0-9_]*)\)?(?:::[a-z ]+)?\s*(?:=|<>|<=|>=|<|>|~~\*?|!~~\*?|IS)\s`)
+
+type PlanNode struct {
+	NodeType        string     `json:"Node Type"`
+	RelationName    string     `json:"Relation Name,omitempty"`
+	Schema          string     `json:"Schema,omitempty"`
+	Alias           string     `json:"Alias,omitempty"`
+	IndexName       string     `json:"Index Name,omitempty"`
+	StartupCost     float64    `json:"Startup Cost"`
+	TotalCost       float64    `json:"Total Cost"`
+	PlanRows        float64    `json:"Plan Rows"`
+	PlanWidth       int        `json:"Plan Width"`
+	ActualTotalTime *float64   `json:"Actual Total Time,omitempty"`
+	ActualRows      *float64   `json:"Actual Rows,omitempty"`
+	ActualLoops     *float64   `json:"Actual Loops,omitempty"`
+	Filter          string     `json:"Filter,omitempty"`
+	IndexCond       string     `json:"Index Cond,omitempty"`
+	Plans           []PlanNode `json:"Plans,omitempty"`
+}
+
+type rawPlan struct {
+	Plan          PlanNode `json:"Plan"`
+	PlanningTime  *float64 `json:"Planning Time"`
+	ExecutionTime *float64 `json:"Execution Time"`
+}
+
+// ParsePlan decodes the output of EXPLAIN (FORMAT JSON)
+func ParsePlan(output []byte) (Plan, error) {
+	var rawPlans []rawPlan
+	if err := json.Unmarshal(output, &rawPlans); err != nil {
+		return Plan{}, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+
+	if len(rawPlans) == 0 {
+		return Plan{}, fmt.Errorf("query plan is empty")
+	}
+
+	return Plan{
+		Root:          rawPlans[0].Plan,
+		PlanningTime:  rawPlans[0].PlanningTime,
+		ExecutionTime: rawPlans[0].ExecutionTime,
+	}, nil
+}
+
+// AnalyzePlan flags sequential scans on tables with at least rowThreshold live rows and
+// suggests indexes for the columns they filter on. Tables are matched on schema and name,
+// tables of the same name in other schemas are told apart
+func AnalyzePlan(plan *Plan, rowCounts []stats.TableRowCount, unusedIndexes []stats.UnusedIndex, rowThreshold int) {
+	tableRows := make(map[string]int, len(rowCounts))
+	for _, rowCount := range rowCounts {
+		tableRows[tableKey(rowCount.SchemaName, rowCount.TableName)] = rowCount.EstimatedRowCount
+	}
+
+	plan.SeqScans = []SeqScan{}
+	plan.Suggestions = []IndexSuggestion{}
+
+	walkPlan(plan.Root, func(node PlanNode) {
+		if node.NodeType != "Seq Scan" || node.RelationName == "" {
+			return
+		}
+
+		rows, ok := tableRows[tableKey(nodeSchema(node), node.RelationName)]
+		if !ok {
+			rows = int(node.PlanRows)
+		}
+
+		if rows < rowThreshold {
+			return
+		}
+
+		plan.SeqScans = append(plan.SeqScans, SeqScan{
+			Schema:    nodeSchema(node),
+			Table:     node.RelationName,
+			TableRows: rows,
+			Filter:    node.Filter,
+		})
+
+		columns := extractFilterColumns(node.Filter)
+		if len(columns) == 0 || hasSuggestion(plan.Suggestions, nodeSchema(node), node.RelationName, columns) {
+			return
+		}
+
+		plan.Suggestions = append(plan.Suggestions, buildSuggestion(node, columns, rows, unusedIndexes))
+	})
+}
+
+func walkPlan(node PlanNode, visit func(node PlanNode)) {
+	visit(node)
+
+	for _, child := range node.Plans {
+		walkPlan(child, visit)
+	}
+}
+
+func extractFilterColumns(filter string) []string {
+	var columns []string
+	for _, match := range filterColumnPattern.FindAllStringSubmatch(filter, -1) {
+		if !slices.Contains(columns, match[1]) {
+			columns = append(columns, match[1])
+		}
+	}
+
+	return columns
+}
+
+func hasSuggestion(suggestions []IndexSuggestion, schema, table string, columns []string) bool {
+	for _, suggestion := range suggestions {
+		if suggestion.Schema == schema && suggestion.Table == table && slices.Equal(suggestion.Columns, columns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildSuggestion(node PlanNode, columns []string, rows int, unusedIndexes []stats.UnusedIndex) IndexSuggestion {
+	schema := nodeSchema(node)
+
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = pq.QuoteIdentifier(column)
+	}
+
+	suggestion := IndexSuggestion{
+		Schema:  schema,
+		Table:   node.RelationName,
+		Columns: columns,
+		Statement: fmt.Sprintf(
+			"CREATE INDEX ON %s.%s (%s)",
+			pq.QuoteIdentifier(schema),
+			pq.QuoteIdentifier(node.RelationName),
+			strings.Join(quotedColumns, ", "),
+		),
+		Reason:        fmt.Sprintf("Sequential scan on %d rows filtering by %s", rows, strings.Join(columns, ", ")),
+		UnusedIndexes: []string{},
+	}
+
+	// rarely used indexes on one of the filtered columns may be worth replacing by the suggested one
+	for _, unusedIndex := range unusedIndexes {
+		if unusedIndex.SchemaName != schema || unusedIndex.TableName != node.RelationName {
+			continue
+		}
+
+		if slices.ContainsFunc(unusedIndex.Columns, func(column string) bool { return slices.Contains(columns, column) }) {
+			suggestion.UnusedIndexes = append(suggestion.UnusedIndexes, unusedIndex.IndexName)
+		}
+	}
+
+	return suggestion
+}
+
+func tableKey(schema, table string) string {
+	return schema + "." + table
+}
+
+// nodeSchema falls back to the default schema for plans that were not explained with VERBOSE
+func nodeSchema(node PlanNode) string {
+	if node.Schema == "" {
+		return pkg.DefaultSchema
+	}
+
+	return node.Schema
+}
@@ -0,0 +1,124 @@
+package query
+
+import (
+	"fluxend/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const samplePlan = `[
+  {
+    "Plan": {
+      "Node Type": "Hash Join",
+      "Startup Cost": 10.5,
+      "Total Cost": 2450.75,
+      "Plan Rows": 120,
+      "Plan Width": 64,
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Relation Name": "orders",
+          "Schema": "public",
+          "Alias": "o",
+          "Startup Cost": 0,
+          "Total Cost": 2100.0,
+          "Plan Rows": 120,
+          "Plan Width": 32,
+          "Filter": "(((status)::text = 'shipped'::text) AND (customer_id = 42))"
+        },
+        {
+          "Node Type": "Seq Scan",
+          "Relation Name": "customers",
+          "Schema": "public",
+          "Alias": "c",
+          "Startup Cost": 0,
+          "Total Cost": 12.0,
+          "Plan Rows": 50,
+          "Plan Width": 32
+        }
+      ]
+    },
+    "Planning Time": 0.21,
+    "Execution Time": 3.5
+  }
+]`
+
+func TestParsePlan_Suite(t *testing.T) {
+	t.Run("ParsePlan: valid plan", func(t *testing.T) {
+		plan, err := ParsePlan([]byte(samplePlan))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hash Join", plan.Root.NodeType)
+		assert.Len(t, plan.Root.Plans, 2)
+		assert.Equal(t, "orders", plan.Root.Plans[0].RelationName)
+		assert.Equal(t, 0.21, *plan.PlanningTime)
+		assert.Equal(t, 3.5, *plan.ExecutionTime)
+	})
+
+	t.Run("ParsePlan: invalid input", func(t *testing.T) {
+		_, err := ParsePlan([]byte("not json"))
+		assert.Error(t, err)
+
+		_, err = ParsePlan([]byte("[]"))
+		assert.Error(t, err)
+	})
+}
+
+func TestAnalyzePlan_Suite(t *testing.T) {
+	rowCounts := []stats.TableRowCount{
+		{SchemaName: "public", TableName: "orders", EstimatedRowCount: 250000},
+		{SchemaName: "public", TableName: "customers", EstimatedRowCount: 50},
+		{SchemaName: "archive", TableName: "customers", EstimatedRowCount: 900000},
+	}
+
+	unusedIndexes := []stats.UnusedIndex{
+		{SchemaName: "public", TableName: "orders", IndexName: "orders_created_at_idx", Columns: []string{"created_at"}},
+		{SchemaName: "public", TableName: "orders", IndexName: "orders_status_placed_at_idx", Columns: []string{"status", "placed_at"}},
+		{SchemaName: "archive", TableName: "orders", IndexName: "archived_orders_idx", Columns: []string{"status"}},
+		{SchemaName: "public", TableName: "customers", IndexName: "customers_email_idx", IndexScans: 3, Columns: []string{"email"}},
+	}
+
+	t.Run("AnalyzePlan: flags seq scans on large tables only", func(t *testing.T) {
+		plan, err := ParsePlan([]byte(samplePlan))
+		assert.NoError(t, err)
+
+		AnalyzePlan(&plan, rowCounts, unusedIndexes, 10000)
+
+		assert.Len(t, plan.SeqScans, 1)
+		assert.Equal(t, "orders", plan.SeqScans[0].Table)
+		assert.Equal(t, 250000, plan.SeqScans[0].TableRows)
+	})
+
+	t.Run("AnalyzePlan: suggests index on filtered columns", func(t *testing.T) {
+		plan, err := ParsePlan([]byte(samplePlan))
+		assert.NoError(t, err)
+
+		AnalyzePlan(&plan, rowCounts, unusedIndexes, 10000)
+
+		assert.Len(t, plan.Suggestions, 1)
+		assert.Equal(t, []string{"status", "customer_id"}, plan.Suggestions[0].Columns)
+		assert.Equal(t, `CREATE INDEX ON "public"."orders" ("status", "customer_id")`, plan.Suggestions[0].Statement)
+		assert.Equal(t, []string{"orders_status_placed_at_idx"}, plan.Suggestions[0].UnusedIndexes)
+	})
+
+	t.Run("AnalyzePlan: tables of the same name in other schemas don't count", func(t *testing.T) {
+		plan, err := ParsePlan([]byte(samplePlan))
+		assert.NoError(t, err)
+
+		AnalyzePlan(&plan, rowCounts, unusedIndexes, 10000)
+
+		for _, seqScan := range plan.SeqScans {
+			assert.NotEqual(t, "customers", seqScan.Table)
+		}
+	})
+
+	t.Run("AnalyzePlan: falls back to planner estimate for unknown tables", func(t *testing.T) {
+		plan, err := ParsePlan([]byte(samplePlan))
+		assert.NoError(t, err)
+
+		AnalyzePlan(&plan, []stats.TableRowCount{}, []stats.UnusedIndex{}, 100)
+
+		assert.Len(t, plan.SeqScans, 1)
+		assert.Equal(t, 120, plan.SeqScans[0].TableRows)
+	})
+}
@@ -0,0 +1,10 @@
+package query
+
+import (
+	"context"
+)
+
+type Repository interface {
+	Execute(ctx context.Context, query string, options ExecuteOptions) (Result, error)
+	Explain(ctx context.Context, query string, options ExplainOptions) ([]byte, error)
+}
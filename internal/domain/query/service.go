@@ -0,0 +1,247 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/project"
+	"fluxend/internal/domain/setting"
+	"fluxend/internal/domain/stats"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"strconv"
+)
+
+type Service interface {
+	Execute(ctx context.Context, input ExecuteInput, authUser auth.User) (Result, error)
+	Explain(ctx context.Context, input ExplainInput, authUser auth.User) (Plan, error)
+}
+
+type ServiceImpl struct {
+	connectionService database.ConnectionService
+	settingService    setting.Service
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+}
+
+func NewQueryService(injector *do.Injector) (Service, error) {
+	settingService, err := setting.NewSettingService(injector)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionService := do.MustInvoke[database.ConnectionService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+
+	return &ServiceImpl{
+		connectionService: connectionService,
+		settingService:    settingService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+	}, nil
+}
+
+// Execute runs raw SQL on the project database. Users below the developer role can only run
+// read-only transactions in a read-only session, and the request context cancels the statement when the client goes away
+func (s *ServiceImpl) Execute(ctx context.Context, input ExecuteInput, authUser auth.User) (Result, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Result{}, flxErrors.NewForbiddenError("query.error.forbidden")
+	}
+
+	if err = validateStatement(input.Query); err != nil {
+		return Result{}, err
+	}
+
+	options := s.buildOptions(input, authUser)
+
+	clientQueryRepo, connection, err := s.getClientQueryRepo(fetchedProject.DBName, options.ReadOnly)
+	if err != nil {
+		return Result{}, err
+	}
+	defer connection.Close()
+
+	result, err := clientQueryRepo.Execute(ctx, input.Query, options)
+	if err != nil {
+		return Result{}, s.toQueryError(err)
+	}
+
+	return result, nil
+}
+
+// Explain returns the structured plan of a statement, with sequential scans on large tables
+// flagged and index suggestions cross-referenced against rarely used indexes
+func (s *ServiceImpl) Explain(ctx context.Context, input ExplainInput, authUser auth.User) (Plan, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return Plan{}, flxErrors.NewForbiddenError("query.error.forbidden")
+	}
+
+	if err = validateStatement(input.Query); err != nil {
+		return Plan{}, err
+	}
+
+	options := ExplainOptions{
+		Analyze:     input.Analyze,
+		ReadOnly:    !authUser.IsDeveloperOrMore(),
+		TimeoutInMs: s.getTimeout(input.TimeoutInMs),
+	}
+
+	clientQueryRepo, connection, err := s.getClientQueryRepo(fetchedProject.DBName, options.ReadOnly)
+	if err != nil {
+		return Plan{}, err
+	}
+	defer connection.Close()
+
+	output, err := clientQueryRepo.Explain(ctx, input.Query, options)
+	if err != nil {
+		return Plan{}, s.toQueryError(err)
+	}
+
+	plan, err := ParsePlan(output)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan.Analyzed = input.Analyze
+
+	clientStatsRepo, _, err := s.getClientStatsRepo(connection)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	rowCounts, err := clientStatsRepo.GetRowCountPerTable()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	unusedIndexes, err := clientStatsRepo.GetUnusedIndexes()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	AnalyzePlan(&plan, rowCounts, unusedIndexes, constants.SeqScanRowThreshold)
+
+	return plan, nil
+}
+
+func (s *ServiceImpl) buildOptions(input ExecuteInput, authUser auth.User) ExecuteOptions {
+	maxRows := s.getIntSetting("sqlConsoleMaxRows", constants.MaxQueryRows)
+
+	rows := input.MaxRows
+	if rows <= 0 {
+		rows = constants.DefaultQueryMaxRows
+	}
+
+	return ExecuteOptions{
+		ReadOnly:    input.ReadOnly || !authUser.IsDeveloperOrMore(),
+		TimeoutInMs: s.getTimeout(input.TimeoutInMs),
+		MaxRows:     min(rows, maxRows),
+	}
+}
+
+func (s *ServiceImpl) getTimeout(requestedTimeout int) int {
+	maxTimeout := s.getIntSetting("sqlConsoleMaxTimeoutInMs", constants.MaxQueryTimeoutInMs)
+
+	if requestedTimeout <= 0 {
+		requestedTimeout = constants.DefaultQueryTimeoutInMs
+	}
+
+	return min(requestedTimeout, maxTimeout)
+}
+
+func (s *ServiceImpl) getIntSetting(name string, fallback int) int {
+	value, err := strconv.Atoi(s.settingService.GetValue(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+// toQueryError surfaces errors raised by postgres (syntax, permissions, timeouts) as bad requests
+func (s *ServiceImpl) toQueryError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return flxErrors.NewBadRequestError("query.error.cancelled")
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+// validateStatement allows a single statement that leaves the transaction and the session alone,
+// otherwise COMMIT or SET would escape the read-only transaction and the timeout
+func validateStatement(statement string) error {
+	statements := pkg.SplitStatements(statement)
+	if len(statements) > 1 {
+		return flxErrors.NewBadRequestError("query.error.multipleStatements")
+	}
+
+	if len(statements) == 1 && pkg.IsSessionStatement(statements[0]) {
+		return flxErrors.NewBadRequestError("query.error.sessionStatement")
+	}
+
+	return nil
+}
+
+// getClientQueryRepo opens a read-only session as the reader role for read-only queries, on top of the
+// read-only transaction. Only those sessions are kept away from superuser privileges
+func (s *ServiceImpl) getClientQueryRepo(dbName string, readOnly bool) (Repository, *sqlx.DB, error) {
+	connect := s.connectionService.ConnectByDatabaseName
+	if readOnly {
+		connect = s.connectionService.ConnectReadOnlyByDatabaseName
+	}
+
+	connection, err := connect(dbName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo, _, err := s.connectionService.GetQueryRepo("", connection)
+	if err != nil {
+		connection.Close()
+
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(Repository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientQueryRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
+
+func (s *ServiceImpl) getClientStatsRepo(connection *sqlx.DB) (stats.StatRepository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetDatabaseStatsRepo("", connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(stats.StatRepository)
+	if !ok {
+		return nil, nil, flxErrors.NewUnprocessableError("clientStatsRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	flxErrors "fluxend/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateStatement_Suite(t *testing.T) {
+	t.Run("single statement", func(t *testing.T) {
+		assert.NoError(t, validateStatement("SELECT * FROM orders WHERE note = 'a;b';"))
+	})
+
+	t.Run("multiple statements", func(t *testing.T) {
+		err := validateStatement("COMMIT; DELETE FROM orders")
+
+		assert.Equal(t, flxErrors.NewBadRequestError("query.error.multipleStatements"), err)
+	})
+
+	t.Run("session statements", func(t *testing.T) {
+		for _, statement := range []string{"COMMIT", "SET statement_timeout = 0", "RESET ROLE"} {
+			err := validateStatement(statement)
+
+			assert.Equal(t, flxErrors.NewBadRequestError("query.error.sessionStatement"), err, statement)
+		}
+	})
+}
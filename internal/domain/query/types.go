@@ -0,0 +1,32 @@
+package query
+
+import (
+	"github.com/google/uuid"
+)
+
+type ExecuteInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Query       string    `json:"query"`
+	ReadOnly    bool      `json:"readOnly"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+	MaxRows     int       `json:"maxRows"`
+}
+
+type ExecuteOptions struct {
+	ReadOnly    bool
+	TimeoutInMs int
+	MaxRows     int
+}
+
+type ExplainInput struct {
+	ProjectUUID uuid.UUID `json:"projectUUID,omitempty"`
+	Query       string    `json:"query"`
+	Analyze     bool      `json:"analyze"`
+	TimeoutInMs int       `json:"timeoutInMs"`
+}
+
+type ExplainOptions struct {
+	Analyze     bool
+	ReadOnly    bool
+	TimeoutInMs int
+}
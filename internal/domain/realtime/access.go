@@ -0,0 +1,77 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// rowReader reads a change back the way the subscription's role gets to see it
+type rowReader interface {
+	read(subscription Subscription, change Change) (Change, bool)
+}
+
+// accessReader reads changes back on an authenticator connection, one is shared by every subscriber
+// of a database that holds a project token
+type accessReader struct {
+	dbName     string
+	repo       Repository
+	connection *sqlx.DB
+}
+
+// read selects inserted and updated rows again as the role, rows that row level security hides are
+// dropped. Deleted rows can't be selected anymore, like the old row of an update they only carry
+// their primary key
+func (r *accessReader) read(subscription Subscription, change Change) (Change, bool) {
+	readBack := Change{
+		Schema:    change.Schema,
+		Table:     change.Table,
+		Event:     change.Event,
+		Timestamp: change.Timestamp,
+	}
+
+	if change.Event != constants.RealtimeEventInsert {
+		readBack.OldRecord = pickColumns(change.OldRecord, subscription.PrimaryKey)
+	}
+
+	if change.Event == constants.RealtimeEventDelete {
+		return readBack, true
+	}
+
+	record, found, err := r.repo.ReadAs(*subscription.Access, buildReadBackStatement(subscription), change.rawRecord)
+	if err != nil {
+		log.Error().
+			Str("action", constants.ActionRealtime).
+			Str("db", r.dbName).
+			Str("role", subscription.Access.Role).
+			Str("error", err.Error()).
+			Msg("failed to read realtime change back")
+
+		return Change{}, false
+	}
+
+	if !found {
+		return Change{}, false
+	}
+
+	readBack.Record = record
+
+	return readBack, true
+}
+
+func (r *accessReader) close() error {
+	return r.connection.Close()
+}
+
+func pickColumns(record map[string]interface{}, columns []string) map[string]interface{} {
+	if record == nil {
+		return nil
+	}
+
+	picked := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		picked[column] = record[column]
+	}
+
+	return picked
+}
@@ -0,0 +1,36 @@
+package realtime
+
+import "encoding/json"
+
+// TableState tells whether row changes of a table are published
+type TableState struct {
+	Schema  string `db:"schema_name" json:"schema"`
+	Table   string `db:"table_name" json:"table"`
+	Enabled bool   `db:"enabled" json:"enabled"`
+}
+
+// Change is a row change as published by the realtime trigger. Notifications are only delivered once the
+// transaction commits, changes that are rolled back are never seen
+type Change struct {
+	Schema    string                 `json:"schema"`
+	Table     string                 `json:"table"`
+	Event     string                 `json:"event"`
+	Record    map[string]interface{} `json:"record"`
+	OldRecord map[string]interface{} `json:"oldRecord"`
+	Timestamp string                 `json:"timestamp"`
+
+	// rows too large for a notification only announce the change, without the record
+	Truncated bool `json:"truncated"`
+
+	// rawRecord keeps the record as published, numbers decoded into the map may have lost precision
+	rawRecord json.RawMessage
+}
+
+// Event is what subscribers receive, resync events carry no change
+type Event struct {
+	ID     string
+	Type   string
+	Change *Change
+
+	sequence uint64
+}
@@ -0,0 +1,130 @@
+package realtime
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	FilterEq  = "eq"
+	FilterNeq = "neq"
+	FilterGt  = "gt"
+	FilterGte = "gte"
+	FilterLt  = "lt"
+	FilterLte = "lte"
+	FilterIn  = "in"
+	FilterIs  = "is"
+)
+
+var filterOperators = []string{FilterEq, FilterNeq, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterIs}
+
+// Filter narrows a subscription down to rows with a matching column, written the way PostgREST
+// filters are, e.g. status=eq.shipped, total=gte.100 or id=in.(1,2,3)
+type Filter struct {
+	Column   string
+	Operator string
+	Values   []string
+}
+
+func ParseFilter(expression string) (Filter, error) {
+	column, condition, found := strings.Cut(expression, "=")
+	column = strings.TrimSpace(column)
+	if !found || column == "" {
+		return Filter{}, fmt.Errorf("filter '%s' must look like column=operator.value", expression)
+	}
+
+	operator, value, found := strings.Cut(condition, ".")
+	if !found || !slices.Contains(filterOperators, operator) {
+		return Filter{}, fmt.Errorf(
+			"filter '%s' has an unknown operator, use one of: %s",
+			expression,
+			strings.Join(filterOperators, ", "),
+		)
+	}
+
+	filter := Filter{Column: column, Operator: operator, Values: []string{value}}
+
+	switch operator {
+	case FilterIn:
+		if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+			return Filter{}, fmt.Errorf("filter '%s' must list its values as in.(a,b)", expression)
+		}
+
+		filter.Values = strings.Split(value[1:len(value)-1], ",")
+		for i := range filter.Values {
+			filter.Values[i] = strings.TrimSpace(filter.Values[i])
+		}
+	case FilterIs:
+		if !slices.Contains([]string{"null", "true", "false"}, value) {
+			return Filter{}, fmt.Errorf("filter '%s' only supports is.null, is.true and is.false", expression)
+		}
+	}
+
+	return filter, nil
+}
+
+// Matches follows SQL semantics, a null column only matches is.null
+func (f Filter) Matches(record map[string]interface{}) bool {
+	value, ok := record[f.Column]
+	if !ok {
+		return false
+	}
+
+	if f.Operator == FilterIs {
+		switch f.Values[0] {
+		case "null":
+			return value == nil
+		default:
+			flag, isBool := value.(bool)
+			return isBool && strconv.FormatBool(flag) == f.Values[0]
+		}
+	}
+
+	if value == nil {
+		return false
+	}
+
+	switch f.Operator {
+	case FilterEq:
+		return compareFilterValue(value, f.Values[0]) == 0
+	case FilterNeq:
+		return compareFilterValue(value, f.Values[0]) != 0
+	case FilterGt:
+		return compareFilterValue(value, f.Values[0]) > 0
+	case FilterGte:
+		return compareFilterValue(value, f.Values[0]) >= 0
+	case FilterLt:
+		return compareFilterValue(value, f.Values[0]) < 0
+	case FilterLte:
+		return compareFilterValue(value, f.Values[0]) <= 0
+	case FilterIn:
+		for _, expected := range f.Values {
+			if compareFilterValue(value, expected) == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// compareFilterValue compares numbers numerically and everything else as text, which also orders
+// ISO timestamps and dates correctly
+func compareFilterValue(value interface{}, expected string) int {
+	if number, ok := value.(float64); ok {
+		if expectedNumber, err := strconv.ParseFloat(expected, 64); err == nil {
+			switch {
+			case number < expectedNumber:
+				return -1
+			case number > expectedNumber:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(value), expected)
+}
@@ -0,0 +1,139 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFilter_Suite(t *testing.T) {
+	t.Run("ParseFilter: valid filters", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   Filter
+		}{
+			{expression: "status=eq.shipped", expected: Filter{Column: "status", Operator: FilterEq, Values: []string{"shipped"}}},
+			{expression: "total=gte.100", expected: Filter{Column: "total", Operator: FilterGte, Values: []string{"100"}}},
+			{expression: "note=eq.a=b.c", expected: Filter{Column: "note", Operator: FilterEq, Values: []string{"a=b.c"}}},
+			{expression: "id=in.(1, 2,3)", expected: Filter{Column: "id", Operator: FilterIn, Values: []string{"1", "2", "3"}}},
+			{expression: "deleted_at=is.null", expected: Filter{Column: "deleted_at", Operator: FilterIs, Values: []string{"null"}}},
+		}
+
+		for _, tt := range tests {
+			filter, err := ParseFilter(tt.expression)
+			assert.NoError(t, err, tt.expression)
+			assert.Equal(t, tt.expected, filter, tt.expression)
+		}
+	})
+
+	t.Run("ParseFilter: invalid filters", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   string
+		}{
+			{expression: "status", expected: "must look like column=operator.value"},
+			{expression: "=eq.shipped", expected: "must look like column=operator.value"},
+			{expression: "status=like.ship%", expected: "unknown operator"},
+			{expression: "status=shipped", expected: "unknown operator"},
+			{expression: "id=in.1,2", expected: "must list its values as in.(a,b)"},
+			{expression: "active=is.yes", expected: "only supports is.null, is.true and is.false"},
+		}
+
+		for _, tt := range tests {
+			_, err := ParseFilter(tt.expression)
+			assert.ErrorContains(t, err, tt.expected, tt.expression)
+		}
+	})
+}
+
+func TestFilterMatches_Suite(t *testing.T) {
+	record := map[string]interface{}{
+		"id":         float64(7),
+		"status":     "shipped",
+		"total":      float64(120.5),
+		"active":     true,
+		"deleted_at": nil,
+		"created_at": "2026-10-18T10:00:00",
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: "status=eq.shipped", expected: true},
+		{expression: "status=neq.shipped", expected: false},
+		{expression: "id=eq.7.0", expected: true},
+		{expression: "total=gt.100", expected: true},
+		{expression: "total=lte.100", expected: false},
+		{expression: "total=gt.99", expected: true},
+		{expression: "created_at=gte.2026-10-01", expected: true},
+		{expression: "created_at=lt.2026-10-01", expected: false},
+		{expression: "id=in.(1,7,9)", expected: true},
+		{expression: "status=in.(pending,paid)", expected: false},
+		{expression: "deleted_at=is.null", expected: true},
+		{expression: "deleted_at=eq.null", expected: false},
+		{expression: "deleted_at=neq.x", expected: false},
+		{expression: "active=is.true", expected: true},
+		{expression: "active=is.false", expected: false},
+		{expression: "missing=eq.1", expected: false},
+	}
+
+	for _, tt := range tests {
+		filter, err := ParseFilter(tt.expression)
+		assert.NoError(t, err, tt.expression)
+		assert.Equal(t, tt.expected, filter.Matches(record), tt.expression)
+	}
+}
+
+func TestSubscriptionMatches_Suite(t *testing.T) {
+	shipped, _ := ParseFilter("status=eq.shipped")
+	subscription := Subscription{
+		Schema:  "public",
+		Table:   "orders",
+		Events:  []string{constants.RealtimeEventInsert, constants.RealtimeEventDelete},
+		Filters: []Filter{shipped},
+	}
+
+	tests := []struct {
+		name     string
+		change   Change
+		expected bool
+	}{
+		{
+			name:     "matching insert",
+			change:   Change{Schema: "public", Table: "orders", Event: constants.RealtimeEventInsert, Record: map[string]interface{}{"status": "shipped"}},
+			expected: true,
+		},
+		{
+			name:     "other table",
+			change:   Change{Schema: "public", Table: "users", Event: constants.RealtimeEventInsert, Record: map[string]interface{}{"status": "shipped"}},
+			expected: false,
+		},
+		{
+			name:     "event not subscribed",
+			change:   Change{Schema: "public", Table: "orders", Event: constants.RealtimeEventUpdate, Record: map[string]interface{}{"status": "shipped"}},
+			expected: false,
+		},
+		{
+			name:     "filter does not match",
+			change:   Change{Schema: "public", Table: "orders", Event: constants.RealtimeEventInsert, Record: map[string]interface{}{"status": "pending"}},
+			expected: false,
+		},
+		{
+			name:     "delete is matched against the old row",
+			change:   Change{Schema: "public", Table: "orders", Event: constants.RealtimeEventDelete, OldRecord: map[string]interface{}{"status": "shipped"}},
+			expected: true,
+		},
+		{
+			name:     "truncated change skips filters",
+			change:   Change{Schema: "public", Table: "orders", Event: constants.RealtimeEventInsert, Truncated: true},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, subscription.Matches(tt.change))
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/shared"
+	flxErrors "fluxend/pkg/errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"sync"
+	"time"
+)
+
+// Hub is the listener pool, it keeps one listening connection per project database that has
+// subscribers and fans its notifications out to them
+type Hub struct {
+	databaseRepo      shared.DatabaseService
+	connectionService database.ConnectionService
+
+	mu        sync.Mutex
+	listeners map[string]*listener
+}
+
+func NewHub(injector *do.Injector) (*Hub, error) {
+	databaseRepo := do.MustInvoke[shared.DatabaseService](injector)
+	connectionService := do.MustInvoke[database.ConnectionService](injector)
+
+	return &Hub{
+		databaseRepo:      databaseRepo,
+		connectionService: connectionService,
+		listeners:         make(map[string]*listener),
+	}, nil
+}
+
+// Subscribe starts listening on the database with its first subscriber. Changes after lastEventID
+// are replayed from the backlog, when they are no longer available a resync event is sent instead.
+// Subscriptions with access read every change back first, on a connection the listener opens for them
+func (h *Hub) Subscribe(dbName string, subscription Subscription, lastEventID string) (*Subscriber, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	l, ok := h.listeners[dbName]
+	if !ok {
+		l = newListener(dbName)
+
+		pqListener, err := h.databaseRepo.Listen(dbName, constants.RealtimeChannel, l.onConnectionEvent)
+		if err != nil {
+			return nil, err
+		}
+
+		l.pqListener = pqListener
+		h.listeners[dbName] = l
+
+		go l.run()
+	}
+
+	if l.subscriberCount() >= constants.RealtimeMaxSubscribersPerDatabase {
+		return nil, flxErrors.NewUnprocessableError("realtime.error.tooManySubscribers")
+	}
+
+	subscriber := newSubscriber(dbName, subscription)
+
+	if subscription.Access != nil {
+		if l.reader == nil {
+			reader, err := h.newAccessReader(dbName)
+			if err != nil {
+				h.closeIdle(l)
+
+				return nil, err
+			}
+
+			l.reader = reader
+		}
+
+		subscriber.readBackWith(l.reader)
+	}
+
+	l.add(subscriber, lastEventID)
+
+	return subscriber, nil
+}
+
+// Unsubscribe closes the subscriber, the database is no longer listened on once its last subscriber is gone
+func (h *Hub) Unsubscribe(subscriber *Subscriber) {
+	subscriber.close("")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	l, ok := h.listeners[subscriber.dbName]
+	if !ok {
+		return
+	}
+
+	l.remove(subscriber)
+	h.closeIdle(l)
+}
+
+// closeIdle stops listening once the last subscriber is gone, the caller holds the hub lock
+func (h *Hub) closeIdle(l *listener) {
+	if l.subscriberCount() == 0 {
+		delete(h.listeners, l.dbName)
+		l.close()
+	}
+}
+
+func (h *Hub) newAccessReader(dbName string) (*accessReader, error) {
+	connection, err := h.databaseRepo.ConnectAuthenticator(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, _, err := h.connectionService.GetRealtimeRepo("", connection)
+	if err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	clientRepo, ok := repo.(Repository)
+	if !ok {
+		connection.Close()
+
+		return nil, flxErrors.NewUnprocessableError("clientRealtimeRepo is invalid")
+	}
+
+	return &accessReader{dbName: dbName, repo: clientRepo, connection: connection}, nil
+}
+
+type listener struct {
+	dbName     string
+	pqListener *pq.Listener
+
+	// reader is opened with the first subscriber holding a project token
+	reader *accessReader
+
+	mu          sync.Mutex
+	subscribers map[*Subscriber]struct{}
+
+	// event ids are <epoch>-<sequence>, a new epoch starts whenever changes may have been missed,
+	// so ids from before the gap can no longer be resumed from
+	epoch    int64
+	sequence uint64
+	backlog  []Event
+}
+
+func newListener(dbName string) *listener {
+	return &listener{
+		dbName:      dbName,
+		subscribers: make(map[*Subscriber]struct{}),
+		epoch:       time.Now().UnixNano(),
+	}
+}
+
+func (l *listener) run() {
+	ticker := time.NewTicker(constants.RealtimeListenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case notification, ok := <-l.pqListener.Notify:
+			if !ok {
+				return
+			}
+
+			// a nil notification follows a reconnect, which onConnectionEvent already handles
+			if notification != nil {
+				l.dispatch(notification.Extra)
+			}
+		case <-ticker.C:
+			go l.pqListener.Ping()
+		}
+	}
+}
+
+func (l *listener) close() {
+	if err := l.pqListener.Close(); err != nil {
+		l.logError(err, "failed to close realtime listener")
+	}
+
+	if l.reader != nil {
+		if err := l.reader.close(); err != nil {
+			l.logError(err, "failed to close realtime reader")
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for subscriber := range l.subscribers {
+		subscriber.close("")
+	}
+}
+
+// onConnectionEvent is called by pq. Notifications sent while the connection was down are lost,
+// so subscribers are told to resync once it is back
+func (l *listener) onConnectionEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+		if err != nil {
+			l.logError(err, "realtime listener lost its connection")
+		}
+	case pq.ListenerEventReconnected:
+		l.resync()
+	}
+}
+
+func (l *listener) dispatch(payload string) {
+	var change Change
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		l.logError(err, "failed to decode realtime notification")
+
+		return
+	}
+
+	var raw struct {
+		Record json.RawMessage `json:"record"`
+	}
+	if err := json.Unmarshal([]byte(payload), &raw); err == nil {
+		change.rawRecord = raw.Record
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sequence++
+
+	event := Event{
+		ID:       l.eventID(),
+		Type:     constants.RealtimeStreamChange,
+		Change:   &change,
+		sequence: l.sequence,
+	}
+
+	l.backlog = append(l.backlog, event)
+	if len(l.backlog) > constants.RealtimeBacklogSize {
+		l.backlog = l.backlog[len(l.backlog)-constants.RealtimeBacklogSize:]
+	}
+
+	for subscriber := range l.subscribers {
+		if subscriber.subscription.Matches(change) {
+			subscriber.send(event)
+		}
+	}
+}
+
+func (l *listener) resync() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.epoch = time.Now().UnixNano()
+	l.sequence = 0
+	l.backlog = nil
+
+	for subscriber := range l.subscribers {
+		subscriber.send(l.resyncEvent())
+	}
+}
+
+func (l *listener) add(subscriber *Subscriber, lastEventID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.subscribers[subscriber] = struct{}{}
+
+	if lastEventID == "" {
+		return
+	}
+
+	missed, ok := l.missedSince(lastEventID, subscriber.subscription)
+	if !ok {
+		subscriber.send(l.resyncEvent())
+
+		return
+	}
+
+	for _, event := range missed {
+		subscriber.send(event)
+	}
+}
+
+func (l *listener) remove(subscriber *Subscriber) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.subscribers, subscriber)
+}
+
+func (l *listener) subscriberCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.subscribers)
+}
+
+// missedSince returns the matching changes after an event id, it fails when the id belongs to an
+// earlier epoch, changes after it have left the backlog or there are more than a subscriber can queue
+func (l *listener) missedSince(lastEventID string, subscription Subscription) ([]Event, bool) {
+	var epoch int64
+	var sequence uint64
+	if _, err := fmt.Sscanf(lastEventID, "%d-%d", &epoch, &sequence); err != nil {
+		return nil, false
+	}
+
+	if epoch != l.epoch || sequence > l.sequence {
+		return nil, false
+	}
+
+	if len(l.backlog) > 0 && sequence+1 < l.backlog[0].sequence {
+		return nil, false
+	}
+
+	var missed []Event
+	for _, event := range l.backlog {
+		if event.sequence > sequence && subscription.Matches(*event.Change) {
+			missed = append(missed, event)
+		}
+	}
+
+	if len(missed) > constants.RealtimeSubscriberBuffer {
+		return nil, false
+	}
+
+	return missed, true
+}
+
+// resyncEvent carries the current position, so a client resuming from it does not resync again
+func (l *listener) resyncEvent() Event {
+	return Event{
+		ID:       l.eventID(),
+		Type:     constants.RealtimeStreamResync,
+		sequence: l.sequence,
+	}
+}
+
+func (l *listener) eventID() string {
+	return fmt.Sprintf("%d-%d", l.epoch, l.sequence)
+}
+
+func (l *listener) logError(err error, message string) {
+	log.Error().
+		Str("action", constants.ActionRealtime).
+		Str("db", l.dbName).
+		Str("error", err.Error()).
+		Msg(message)
+}
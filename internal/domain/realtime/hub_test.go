@@ -0,0 +1,188 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func orderPayload(id int, status string) string {
+	return fmt.Sprintf(`{"schema":"public","table":"orders","event":"INSERT","record":{"id":%d,"status":"%s"}}`, id, status)
+}
+
+func subscribe(l *listener, lastEventID string, filters ...Filter) *Subscriber {
+	subscriber := newSubscriber(l.dbName, Subscription{Schema: "public", Table: "orders", Filters: filters})
+	l.add(subscriber, lastEventID)
+
+	return subscriber
+}
+
+func receive(subscriber *Subscriber) []Event {
+	var events []Event
+	for {
+		select {
+		case event := <-subscriber.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestListener_Suite(t *testing.T) {
+	t.Run("delivers matching changes in order", func(t *testing.T) {
+		l := newListener("db")
+		shipped, _ := ParseFilter("status=eq.shipped")
+		subscriber := subscribe(l, "", shipped)
+
+		l.dispatch(orderPayload(1, "shipped"))
+		l.dispatch(orderPayload(2, "pending"))
+		l.dispatch(orderPayload(3, "shipped"))
+
+		events := receive(subscriber)
+		assert.Len(t, events, 2)
+		assert.Equal(t, float64(1), events[0].Change.Record["id"])
+		assert.Equal(t, float64(3), events[1].Change.Record["id"])
+		assert.Equal(t, fmt.Sprintf("%d-3", l.epoch), events[1].ID)
+	})
+
+	t.Run("ignores undecodable notifications", func(t *testing.T) {
+		l := newListener("db")
+		subscriber := subscribe(l, "")
+
+		l.dispatch("not json")
+
+		assert.Empty(t, receive(subscriber))
+		assert.Equal(t, uint64(0), l.sequence)
+	})
+
+	t.Run("replays changes after the last event id", func(t *testing.T) {
+		l := newListener("db")
+		first := subscribe(l, "")
+
+		l.dispatch(orderPayload(1, "shipped"))
+		lastEventID := receive(first)[0].ID
+		l.dispatch(orderPayload(2, "shipped"))
+		l.dispatch(orderPayload(3, "shipped"))
+
+		events := receive(subscribe(l, lastEventID))
+		assert.Len(t, events, 2)
+		assert.Equal(t, constants.RealtimeStreamChange, events[0].Type)
+		assert.Equal(t, float64(2), events[0].Change.Record["id"])
+	})
+
+	t.Run("asks to resync when the last event id cannot be resumed", func(t *testing.T) {
+		l := newListener("db")
+		l.dispatch(orderPayload(1, "shipped"))
+
+		for _, lastEventID := range []string{"garbage", "1-1", fmt.Sprintf("%d-5", l.epoch)} {
+			events := receive(subscribe(l, lastEventID))
+
+			assert.Len(t, events, 1, lastEventID)
+			assert.Equal(t, constants.RealtimeStreamResync, events[0].Type, lastEventID)
+			assert.Equal(t, fmt.Sprintf("%d-1", l.epoch), events[0].ID, lastEventID)
+		}
+	})
+
+	t.Run("asks to resync when changes left the backlog", func(t *testing.T) {
+		l := newListener("db")
+		l.dispatch(orderPayload(1, "shipped"))
+		lastEventID := l.eventID()
+
+		for i := 0; i < constants.RealtimeBacklogSize+1; i++ {
+			l.dispatch(orderPayload(i, "pending"))
+		}
+
+		events := receive(subscribe(l, lastEventID))
+		assert.Len(t, events, 1)
+		assert.Equal(t, constants.RealtimeStreamResync, events[0].Type)
+	})
+
+	t.Run("reconnect starts a new epoch", func(t *testing.T) {
+		l := newListener("db")
+		subscriber := subscribe(l, "")
+		l.dispatch(orderPayload(1, "shipped"))
+		lastEventID := receive(subscriber)[0].ID
+
+		l.resync()
+
+		events := receive(subscriber)
+		assert.Len(t, events, 1)
+		assert.Equal(t, constants.RealtimeStreamResync, events[0].Type)
+		assert.Empty(t, l.backlog)
+
+		resumed := receive(subscribe(l, lastEventID))
+		assert.Equal(t, constants.RealtimeStreamResync, resumed[0].Type)
+	})
+
+	t.Run("drops subscribers that fall behind", func(t *testing.T) {
+		l := newListener("db")
+		slow := subscribe(l, "")
+		fast := subscribe(l, "")
+
+		for i := 0; i <= constants.RealtimeSubscriberBuffer; i++ {
+			l.dispatch(orderPayload(i, "shipped"))
+			receive(fast)
+		}
+
+		select {
+		case <-slow.Done():
+			assert.Equal(t, constants.RealtimeSlowSubscriberError, slow.Reason())
+		default:
+			t.Fatal("slow subscriber was not closed")
+		}
+
+		select {
+		case <-fast.Done():
+			t.Fatal("fast subscriber was closed")
+		default:
+		}
+	})
+
+	t.Run("reads changes back before filtering them", func(t *testing.T) {
+		l := newListener("db")
+		shipped, _ := ParseFilter("status=eq.shipped")
+		subscriber := newSubscriber(l.dbName, Subscription{
+			Schema:     "public",
+			Table:      "orders",
+			Filters:    []Filter{shipped},
+			Access:     &Access{Role: "customer", Claims: "{}"},
+			PrimaryKey: []string{"id"},
+		})
+		subscriber.readBackWith(stubReader{
+			1: {"id": float64(1), "status": "shipped"},
+			3: {"id": float64(3), "status": "pending"},
+			4: {"id": float64(4), "status": "shipped"},
+		})
+		l.add(subscriber, "")
+
+		for id := 1; id <= 4; id++ {
+			l.dispatch(orderPayload(id, "shipped"))
+		}
+
+		for _, id := range []float64{1, 4} {
+			select {
+			case event := <-subscriber.Events():
+				assert.Equal(t, id, event.Change.Record["id"])
+			case <-time.After(time.Second):
+				t.Fatal("change was not read back")
+			}
+		}
+	})
+}
+
+// stubReader lets the rows it holds through, under the record they hold
+type stubReader map[float64]map[string]interface{}
+
+func (r stubReader) read(subscription Subscription, change Change) (Change, bool) {
+	record, ok := r[change.Record["id"].(float64)]
+	if !ok {
+		return Change{}, false
+	}
+
+	change.Record = record
+
+	return change, true
+}
@@ -0,0 +1,14 @@
+package realtime
+
+// Repository manages the realtime triggers of a project database
+type Repository interface {
+	ListEnabled() ([]TableState, error)
+	GetTableState(fullTableName string) (TableState, error)
+	Apply(statements []string) error
+	GetPrimaryKey(fullTableName string) ([]string, error)
+	// IsSubscribableRole accepts the anonymous role and the roles of the project that can select the
+	// table, as long as they are no superuser, don't bypass row level security and hold no predefined role
+	IsSubscribableRole(role, marker, fullTableName string) (bool, error)
+	// ReadAs runs the statement as the role with its JWT claims set, in a read-only transaction
+	ReadAs(access Access, statement string, record []byte) (map[string]interface{}, bool, error)
+}
@@ -0,0 +1,228 @@
+package realtime
+
+import (
+	"errors"
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/auth"
+	"fluxend/internal/domain/database"
+	"fluxend/internal/domain/project"
+	"fluxend/pkg"
+	flxErrors "fluxend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/do"
+)
+
+type Service interface {
+	ListTables(projectUUID uuid.UUID, authUser auth.User) ([]TableState, error)
+	GetTable(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (TableState, error)
+	UpdateTable(fullTableName string, input UpdateTableInput, authUser auth.User) (TableState, error)
+	Subscribe(input SubscribeInput, authUser auth.User) (*Subscriber, error)
+	Unsubscribe(subscriber *Subscriber)
+}
+
+type ServiceImpl struct {
+	connectionService database.ConnectionService
+	migrationService  database.MigrationService
+	projectPolicy     *project.Policy
+	projectRepo       project.Repository
+	hub               *Hub
+}
+
+func NewRealtimeService(injector *do.Injector) (Service, error) {
+	connectionService := do.MustInvoke[database.ConnectionService](injector)
+	migrationService := do.MustInvoke[database.MigrationService](injector)
+	policy := do.MustInvoke[*project.Policy](injector)
+	projectRepo := do.MustInvoke[project.Repository](injector)
+	hub := do.MustInvoke[*Hub](injector)
+
+	return &ServiceImpl{
+		connectionService: connectionService,
+		migrationService:  migrationService,
+		projectPolicy:     policy,
+		projectRepo:       projectRepo,
+		hub:               hub,
+	}, nil
+}
+
+func (s *ServiceImpl) ListTables(projectUUID uuid.UUID, authUser auth.User) ([]TableState, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return []TableState{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return []TableState{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRealtimeRepo, connection, err := s.getClientRealtimeRepo(fetchedProject.DBName)
+	if err != nil {
+		return []TableState{}, err
+	}
+	defer connection.Close()
+
+	return clientRealtimeRepo.ListEnabled()
+}
+
+func (s *ServiceImpl) GetTable(fullTableName string, projectUUID uuid.UUID, authUser auth.User) (TableState, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return TableState{}, err
+	}
+
+	if !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return TableState{}, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRealtimeRepo, connection, err := s.getClientRealtimeRepo(fetchedProject.DBName)
+	if err != nil {
+		return TableState{}, err
+	}
+	defer connection.Close()
+
+	return clientRealtimeRepo.GetTableState(fullTableName)
+}
+
+// UpdateTable adds or drops the trigger that publishes the row changes of a table
+func (s *ServiceImpl) UpdateTable(fullTableName string, input UpdateTableInput, authUser auth.User) (TableState, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return TableState{}, err
+	}
+
+	if !s.projectPolicy.CanUpdate(fetchedProject.OrganizationUuid, authUser) {
+		return TableState{}, flxErrors.NewForbiddenError("project.error.updateForbidden")
+	}
+
+	clientRealtimeRepo, connection, err := s.getClientRealtimeRepo(fetchedProject.DBName)
+	if err != nil {
+		return TableState{}, err
+	}
+	defer connection.Close()
+
+	current, err := clientRealtimeRepo.GetTableState(fullTableName)
+	if err != nil {
+		return TableState{}, err
+	}
+
+	if current.Enabled == input.Enabled {
+		return current, nil
+	}
+
+	statements := buildDisableStatements(fullTableName)
+	if input.Enabled {
+		statements = buildEnableStatements(fullTableName)
+	}
+
+	if err = clientRealtimeRepo.Apply(statements); err != nil {
+		return TableState{}, s.toRealtimeError(err)
+	}
+
+	s.migrationService.Record(connection, buildRealtimeMigration(fullTableName, input.Enabled))
+
+	current.Enabled = input.Enabled
+
+	return current, nil
+}
+
+// Subscribe checks access and that the table publishes its changes before joining the listener pool.
+// Project tokens stand in for a Fluxend account, their role has to be able to select the table and
+// changes are read back as that role. The caller must hand the subscriber back to Unsubscribe once
+// the client is gone
+func (s *ServiceImpl) Subscribe(input SubscribeInput, authUser auth.User) (*Subscriber, error) {
+	fetchedProject, err := s.projectRepo.GetByUUID(input.ProjectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Access == nil && !s.projectPolicy.CanAccess(fetchedProject.OrganizationUuid, authUser) {
+		return nil, flxErrors.NewForbiddenError("project.error.viewForbidden")
+	}
+
+	clientRealtimeRepo, connection, err := s.getClientRealtimeRepo(fetchedProject.DBName)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	table, err := clientRealtimeRepo.GetTableState(input.FullTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !table.Enabled {
+		return nil, flxErrors.NewBadRequestError("realtime.error.notEnabled")
+	}
+
+	schema, tableName := pkg.ParseTableName(input.FullTableName)
+	subscription := Subscription{
+		Schema:  schema,
+		Table:   tableName,
+		Events:  input.Events,
+		Filters: input.Filters,
+	}
+
+	if input.Access != nil {
+		if subscription.PrimaryKey, err = s.checkAccess(clientRealtimeRepo, fetchedProject.DBName, input); err != nil {
+			return nil, err
+		}
+
+		subscription.Access = input.Access
+	}
+
+	return s.hub.Subscribe(fetchedProject.DBName, subscription, input.LastEventID)
+}
+
+// checkAccess returns the primary key changes are read back by, a table without one can't be
+// subscribed to with a project token
+func (s *ServiceImpl) checkAccess(clientRealtimeRepo Repository, dbName string, input SubscribeInput) ([]string, error) {
+	subscribable, err := clientRealtimeRepo.IsSubscribableRole(input.Access.Role, constants.RoleProjectMarker+dbName, input.FullTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscribable {
+		return nil, flxErrors.NewForbiddenError("realtime.error.roleForbidden")
+	}
+
+	primaryKey, err := clientRealtimeRepo.GetPrimaryKey(input.FullTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(primaryKey) == 0 {
+		return nil, flxErrors.NewBadRequestError("realtime.error.primaryKeyRequired")
+	}
+
+	return primaryKey, nil
+}
+
+func (s *ServiceImpl) Unsubscribe(subscriber *Subscriber) {
+	s.hub.Unsubscribe(subscriber)
+}
+
+func (s *ServiceImpl) toRealtimeError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return flxErrors.NewBadRequestError(pqErr.Message)
+	}
+
+	return err
+}
+
+func (s *ServiceImpl) getClientRealtimeRepo(dbName string) (Repository, *sqlx.DB, error) {
+	repo, connection, err := s.connectionService.GetRealtimeRepo(dbName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientRepo, ok := repo.(Repository)
+	if !ok {
+		connection.Close()
+
+		return nil, nil, flxErrors.NewUnprocessableError("clientRealtimeRepo is invalid")
+	}
+
+	return clientRepo, connection, nil
+}
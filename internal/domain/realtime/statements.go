@@ -0,0 +1,106 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"fluxend/internal/domain/database"
+	"fluxend/pkg"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// maxNotificationPayload stays below the 8000 byte limit postgres puts on notification payloads
+const maxNotificationPayload = 7900
+
+// buildNotifyFunctionStatement creates the trigger function shared by every realtime table. It is
+// replaced on each enable, so tables enabled later pick up changes to the payload
+func buildNotifyFunctionStatement() string {
+	return fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $realtime$
+DECLARE
+    payload jsonb;
+BEGIN
+    payload := jsonb_build_object(
+        'schema', TG_TABLE_SCHEMA,
+        'table', TG_TABLE_NAME,
+        'event', TG_OP,
+        'record', CASE WHEN TG_OP = 'DELETE' THEN NULL ELSE to_jsonb(NEW) END,
+        'oldRecord', CASE WHEN TG_OP = 'INSERT' THEN NULL ELSE to_jsonb(OLD) END,
+        'timestamp', clock_timestamp()
+    );
+
+    IF octet_length(payload::text) > %d THEN
+        payload := (payload - 'record' - 'oldRecord') || jsonb_build_object('truncated', true);
+    END IF;
+
+    PERFORM pg_notify(%s, payload::text);
+
+    RETURN NULL;
+END
+$realtime$;`, constants.RealtimeNotifyFunction, maxNotificationPayload, pq.QuoteLiteral(constants.RealtimeChannel))
+}
+
+func buildEnableStatements(fullTableName string) []string {
+	return []string{
+		"CREATE SCHEMA IF NOT EXISTS fluxend;",
+		buildNotifyFunctionStatement(),
+		fmt.Sprintf(
+			"CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s();",
+			pq.QuoteIdentifier(constants.RealtimeTriggerName),
+			quoteTableName(fullTableName),
+			constants.RealtimeNotifyFunction,
+		),
+	}
+}
+
+// buildDisableStatements only drops the trigger, the function is shared with other tables
+func buildDisableStatements(fullTableName string) []string {
+	return []string{
+		fmt.Sprintf(
+			"DROP TRIGGER IF EXISTS %s ON %s;",
+			pq.QuoteIdentifier(constants.RealtimeTriggerName),
+			quoteTableName(fullTableName),
+		),
+	}
+}
+
+func buildRealtimeMigration(fullTableName string, enabled bool) database.RecordMigrationInput {
+	_, tableName := pkg.ParseTableName(fullTableName)
+
+	up, down := buildEnableStatements(fullTableName), buildDisableStatements(fullTableName)
+	if !enabled {
+		up, down = down, up
+	}
+
+	return database.RecordMigrationInput{
+		Name:    "realtime_" + tableName,
+		UpSQL:   strings.Join(up, "\n"),
+		DownSQL: strings.Join(down, "\n"),
+	}
+}
+
+// buildReadBackStatement selects the row with the primary key of the published record, the record is
+// passed as $1 and turned back into a row of the table so every key keeps its column type
+func buildReadBackStatement(subscription Subscription) string {
+	tableName := pq.QuoteIdentifier(subscription.Schema) + "." + pq.QuoteIdentifier(subscription.Table)
+
+	tableColumns := make([]string, len(subscription.PrimaryKey))
+	recordColumns := make([]string, len(subscription.PrimaryKey))
+	for i, column := range subscription.PrimaryKey {
+		tableColumns[i] = "t." + pq.QuoteIdentifier(column)
+		recordColumns[i] = "r." + pq.QuoteIdentifier(column)
+	}
+
+	return fmt.Sprintf(
+		"SELECT to_jsonb(t) FROM %s t WHERE (%s) = (SELECT %s FROM jsonb_populate_record(NULL::%s, $1::jsonb) r)",
+		tableName,
+		strings.Join(tableColumns, ", "),
+		strings.Join(recordColumns, ", "),
+		tableName,
+	)
+}
+
+func quoteTableName(fullTableName string) string {
+	schema, tableName := pkg.ParseTableName(fullTableName)
+
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(tableName)
+}
@@ -0,0 +1,41 @@
+package realtime
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRealtimeStatements_Suite(t *testing.T) {
+	t.Run("enable migration", func(t *testing.T) {
+		migration := buildRealtimeMigration("sales.orders", true)
+
+		assert.Equal(t, "realtime_orders", migration.Name)
+		assert.True(t, strings.HasPrefix(migration.UpSQL, "CREATE SCHEMA IF NOT EXISTS fluxend;\nCREATE OR REPLACE FUNCTION fluxend.realtime_notify()"))
+		assert.Contains(t, migration.UpSQL, "PERFORM pg_notify('fluxend_realtime', payload::text);")
+		assert.True(t, strings.HasSuffix(
+			migration.UpSQL,
+			`CREATE TRIGGER "fluxend_realtime" AFTER INSERT OR UPDATE OR DELETE ON "sales"."orders" FOR EACH ROW EXECUTE FUNCTION fluxend.realtime_notify();`,
+		))
+		assert.Equal(t, `DROP TRIGGER IF EXISTS "fluxend_realtime" ON "sales"."orders";`, migration.DownSQL)
+	})
+
+	t.Run("disable migration reverses enable", func(t *testing.T) {
+		enable := buildRealtimeMigration("orders", true)
+		disable := buildRealtimeMigration("orders", false)
+
+		assert.Equal(t, enable.UpSQL, disable.DownSQL)
+		assert.Equal(t, enable.DownSQL, disable.UpSQL)
+		assert.Equal(t, `DROP TRIGGER IF EXISTS "fluxend_realtime" ON "public"."orders";`, disable.UpSQL)
+	})
+
+	t.Run("read back statement selects by primary key", func(t *testing.T) {
+		statement := buildReadBackStatement(Subscription{Schema: "sales", Table: "orders", PrimaryKey: []string{"tenant", "id"}})
+
+		assert.Equal(
+			t,
+			`SELECT to_jsonb(t) FROM "sales"."orders" t WHERE (t."tenant", t."id") = (SELECT r."tenant", r."id" FROM jsonb_populate_record(NULL::"sales"."orders", $1::jsonb) r)`,
+			statement,
+		)
+	})
+}
@@ -0,0 +1,140 @@
+package realtime
+
+import (
+	"fluxend/internal/config/constants"
+	"slices"
+	"sync"
+)
+
+// Subscription selects the changes of one table a subscriber receives
+type Subscription struct {
+	Schema  string
+	Table   string
+	Events  []string
+	Filters []Filter
+
+	// Access and PrimaryKey are set for project tokens, their changes are read back as the role
+	Access     *Access
+	PrimaryKey []string
+}
+
+// Matches checks deletes against the old row. Truncated changes carry no row, they are always
+// delivered so clients can reload the table. Changes read back as a role are filtered on what the
+// role gets to see, once they have been read
+func (s Subscription) Matches(change Change) bool {
+	if change.Schema != s.Schema || change.Table != s.Table {
+		return false
+	}
+
+	if len(s.Events) > 0 && !slices.Contains(s.Events, change.Event) {
+		return false
+	}
+
+	if change.Truncated || s.Access != nil {
+		return true
+	}
+
+	return s.matchesFilters(change)
+}
+
+func (s Subscription) matchesFilters(change Change) bool {
+	record := change.Record
+	if change.Event == constants.RealtimeEventDelete {
+		record = change.OldRecord
+	}
+
+	for _, filter := range s.Filters {
+		if !filter.Matches(record) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Subscriber receives the events of a subscription until it is closed. A subscriber that does not
+// keep up is closed instead of slowing down everyone else listening on the same database
+type Subscriber struct {
+	dbName       string
+	subscription Subscription
+	events       chan Event
+	done         chan struct{}
+	closeOnce    sync.Once
+	reason       string
+
+	// incoming is where the listener delivers, it is events itself unless changes are read back first
+	incoming chan Event
+}
+
+func newSubscriber(dbName string, subscription Subscription) *Subscriber {
+	events := make(chan Event, constants.RealtimeSubscriberBuffer)
+
+	return &Subscriber{
+		dbName:       dbName,
+		subscription: subscription,
+		events:       events,
+		done:         make(chan struct{}),
+		incoming:     events,
+	}
+}
+
+// readBackWith passes every change through the reader before it reaches Events, changes the
+// subscriber's role can't see are dropped. Reading happens on the subscriber's own goroutine, a
+// subscriber that reads slowly falls behind and is closed like any other
+func (s *Subscriber) readBackWith(reader rowReader) {
+	s.incoming = make(chan Event, constants.RealtimeSubscriberBuffer)
+
+	go func() {
+		for {
+			select {
+			case <-s.done:
+				return
+			case event := <-s.incoming:
+				if event.Change != nil && !event.Change.Truncated {
+					change, ok := reader.read(s.subscription, *event.Change)
+					if !ok || !s.subscription.matchesFilters(change) {
+						continue
+					}
+
+					event.Change = &change
+				}
+
+				select {
+				case <-s.done:
+					return
+				case s.events <- event:
+				}
+			}
+		}
+	}()
+}
+
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed when the server ends the subscription, Reason tells why
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscriber) Reason() string {
+	return s.reason
+}
+
+// send never blocks, the listener delivering the event serves every subscriber of the database
+func (s *Subscriber) send(event Event) {
+	select {
+	case <-s.done:
+	case s.incoming <- event:
+	default:
+		s.close(constants.RealtimeSlowSubscriberError)
+	}
+}
+
+func (s *Subscriber) close(reason string) {
+	s.closeOnce.Do(func() {
+		s.reason = reason
+		close(s.done)
+	})
+}
@@ -0,0 +1,30 @@
+package realtime
+
+import (
+	"github.com/google/uuid"
+)
+
+type UpdateTableInput struct {
+	ProjectUUID uuid.UUID
+	Enabled     bool
+}
+
+type SubscribeInput struct {
+	ProjectUUID   uuid.UUID
+	FullTableName string
+	Events        []string
+	Filters       []Filter
+
+	// LastEventID resumes a dropped stream, changes after it are replayed when still available
+	LastEventID string
+
+	// Access is set for subscribers holding a project JWT instead of a Fluxend account
+	Access *Access
+}
+
+// Access is the role and the JWT claims of a project token, changes are read back as PostgREST
+// would read them for that token, with row level security and grants applied
+type Access struct {
+	Role   string
+	Claims string
+}
@@ -5,15 +5,20 @@ import (
 	"database/sql"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type DatabaseService interface {
 	Create(name string, userUUID uuid.NullUUID) error
+	Prepare(name string) error
 	DropIfExists(name string) error
 	Recreate(name string) error
 	List() ([]string, error)
 	Exists(name string) (bool, error)
 	Connect(name string) (*sqlx.DB, error)
+	ConnectReadOnly(name string) (*sqlx.DB, error)
+	ConnectAuthenticator(name string) (*sqlx.DB, error)
+	Listen(name, channel string, onEvent pq.EventCallbackType) (*pq.Listener, error)
 }
 
 type DB interface {
@@ -69,6 +74,8 @@ type Tx interface {
 	Select(dest interface{}, query string, args ...interface{}) error
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+
 	Commit() error
 	Rollback() error
 }
@@ -7,5 +7,6 @@ type StatRepository interface {
 	GetSlowQueries() ([]SlowQuery, error)
 	GetIndexScansPerTable() ([]IndexScan, error)
 	GetSizePerTable() ([]TableSize, error)
+	GetTableSize(fullTableName string) (TableSize, error)
 	GetRowCountPerTable() ([]TableRowCount, error)
 }
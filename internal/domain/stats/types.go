@@ -1,10 +1,18 @@
 package stats
 
+import (
+	"github.com/lib/pq"
+)
+
 type UnusedIndex struct {
+	SchemaName string `db:"schema_name"`
 	TableName  string `db:"table_name"`
 	IndexName  string `db:"index_name"`
 	IndexScans int    `db:"index_scans"`
 	IndexSize  string `db:"index_size"`
+
+	// Columns are the plain key columns in index order, expression keys and included columns are left out
+	Columns pq.StringArray `db:"columns"`
 }
 
 type SlowQuery struct {
@@ -15,11 +23,13 @@ type SlowQuery struct {
 }
 
 type TableSize struct {
-	TableName string `db:"table_name"`
-	TotalSize string `db:"total_size"`
+	TableName  string `db:"table_name"`
+	TotalSize  string `db:"total_size"`
+	TotalBytes int64  `db:"total_bytes"`
 }
 
 type TableRowCount struct {
+	SchemaName        string `db:"schema_name"`
 	TableName         string `db:"table_name"`
 	EstimatedRowCount int    `db:"estimated_row_count"`
 }
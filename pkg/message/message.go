@@ -76,6 +76,28 @@ var messages = map[string]string{
 	"table.error.createForbidden": "You don't have permission to create tables",
 	"table.error.alreadyExists":   "Table already exists",
 
+	// Tables: Export
+	"export.error.forbidden":         "You don't have permission to export this table",
+	"export.error.invalidFormat":     "Invalid export format",
+	"export.error.unknownColumn":     "Export references a column that does not exist",
+	"export.error.containerMismatch": "Container does not belong to this project",
+
+	// Tables: Seed
+	"seed.error.forbidden":         "You don't have permission to seed this table",
+	"seed.error.missingParentRows": "A required foreign key references a table without rows",
+	"seed.error.unsupportedColumn": "Table has a required column of a type that can't be generated",
+
+	// Tables: Partitions
+	"partition.error.notPartitioned":    "Table is not partitioned",
+	"partition.error.notFound":          "Partition not found",
+	"partition.error.invalidBounds":     "Partition bounds don't match the partition strategy of the table",
+	"partition.error.policyUnsupported": "Partition maintenance needs a table partitioned by range on a single date or timestamp column",
+
+	// Tables: Drop and trash
+	"drop.error.dependentObjects":    "Other objects depend on this, drop with cascade or run a dry run to see them",
+	"trash.error.notFound":           "Snapshot not found",
+	"trash.error.primaryKeyRequired": "Column snapshots need a primary key that doesn't include the column",
+
 	// Tables: File Upload
 	"fileImport.error.emptyFile":    "File is empty",
 	"fileImport.error.emptyHeaders": "File has no headers",
@@ -86,10 +108,94 @@ var messages = map[string]string{
 	"column.error.someNotFound":     "Some columns not found",
 	"column.error.notFound":         "Column not found",
 
+	// SQL console
+	"query.error.forbidden":          "You don't have permission to run queries on this project",
+	"query.error.cancelled":          "Query was cancelled",
+	"query.error.multipleStatements": "Only one statement can be run at a time",
+	"query.error.sessionStatement":   "Transaction and session statements such as COMMIT, SET or RESET cannot be run",
+
+	// Migrations
+	"migration.error.notFound":          "Migration not found",
+	"migration.error.listForbidden":     "You don't have permission to view migrations",
+	"migration.error.createForbidden":   "You don't have permission to create migrations",
+	"migration.error.applyForbidden":    "You don't have permission to apply migrations",
+	"migration.error.rollbackForbidden": "You don't have permission to roll back migrations",
+	"migration.error.irreversible":      "Migration has no down statement and cannot be rolled back",
+
+	// Schema diff
+	"schemaDiff.error.forbidden":      "You don't have permission to compare these projects",
+	"schemaDiff.error.applyForbidden": "You don't have permission to change the target project",
+	"schemaDiff.error.sameProject":    "Source and target project must be different",
+
+	// Constraints
+	"constraint.error.alreadyExists": "Constraint already exists",
+	"constraint.error.notFound":      "Constraint not found",
+
+	// Triggers
+	"trigger.error.alreadyExists": "Trigger already exists",
+	"trigger.error.notFound":      "Trigger not found",
+
+	// Row level security
+	"policy.error.alreadyExists":      "Policy already exists",
+	"policy.error.notFound":           "Policy not found",
+	"policy.error.invalidClaims":      "Claims must be a JSON object",
+	"policy.error.multipleStatements": "Only one statement can be tested at a time",
+	"policy.error.sessionStatement":   "Test queries cannot end the transaction or change the role, e.g. with COMMIT, SET ROLE or RESET ROLE",
+	"policy.error.roleNotTestable":    "Only web_anon and roles of this project can be tested, and none of them may be a superuser, bypass row level security or hold predefined roles",
+
+	// Extensions
+	"extension.error.notAllowed":     "This extension is not allowed, ask an administrator to add it to the allowlist",
+	"extension.error.notAvailable":   "Extension is not available on this server",
+	"extension.error.alreadyEnabled": "Extension is already enabled",
+	"extension.error.notEnabled":     "Extension is not enabled",
+	"extension.error.protected":      "This extension is required by Fluxend and cannot be disabled",
+
+	// Roles
+	"role.error.createForbidden": "You don't have permission to create roles",
+	"role.error.alreadyExists":   "A role with this name already exists",
+	"role.error.notFound":        "Role not found",
+	"role.error.tokenForbidden":  "You don't have permission to issue role tokens",
+
+	"type.error.createForbidden": "You don't have permission to create types",
+	"type.error.alreadyExists":   "Type already exists",
+	"type.error.notFound":        "Type not found",
+	"type.error.notEnum":         "Only enum types can be altered",
+
+	"view.error.createForbidden":                   "You don't have permission to create views",
+	"view.error.alreadyExists":                     "A table or view with this name already exists",
+	"view.error.notFound":                          "View not found",
+	"view.error.notMaterialized":                   "Only materialized views can be refreshed",
+	"view.error.concurrentRefreshNeedsData":        "A materialized view must be populated before it can be refreshed concurrently",
+	"view.error.concurrentRefreshNeedsUniqueIndex": "A materialized view needs a unique index to be refreshed concurrently",
+
+	// Schemas
+	"schema.error.alreadyExists": "Schema already exists",
+	"schema.error.notFound":      "Schema not found",
+	"schema.error.reserved":      "This schema is reserved and cannot be changed",
+
 	// Indexes
 	"index.error.alreadyExists": "Index already exists",
 	"index.error.notFound":      "Index not found",
 
+	// Jobs
+	"job.error.notFound":      "Job not found",
+	"job.error.listForbidden": "You don't have permission to view jobs",
+	"job.error.viewForbidden": "You don't have permission to view this job",
+
+	// Cron jobs
+	"cron.error.notFound":        "Cron job not found",
+	"cron.error.alreadyExists":   "Cron job with this name already exists",
+	"cron.error.listForbidden":   "You don't have permission to view cron jobs",
+	"cron.error.createForbidden": "You don't have permission to create cron jobs",
+	"cron.error.updateForbidden": "You don't have permission to update this cron job",
+	"cron.error.neverRuns":       "Cron schedule never matches a date",
+
+	// Realtime
+	"realtime.error.notEnabled":         "Realtime is not enabled for this table",
+	"realtime.error.roleForbidden":      "The role of this token can't subscribe to the table, it needs select on it and may not be a superuser, bypass row level security or hold predefined roles",
+	"realtime.error.primaryKeyRequired": "Tables need a primary key to be subscribed to with a project token",
+	"realtime.error.tooManySubscribers": "Too many realtime subscribers for this project, try again later",
+
 	// Forms
 	"form.error.notFound":        "Form not found",
 	"form.error.listForbidden":   "You don't have permission to view forms",
@@ -135,6 +241,11 @@ var messages = map[string]string{
 	"setting.error.updateForbidden": "You don't have permission to update settings",
 	"setting.error.resetForbidden":  "You don't have permission to reset settings",
 
+	// Functions
+	"function.error.notFound":      "Function not found",
+	"function.error.alreadyExists": "Function with these argument types already exists",
+	"function.error.notExecutable": "Trigger functions can only be called by triggers",
+
 	// Others
 	"database_stats.error.forbidden": "You don't have permission to view database stats",
 	"function.error.listForbidden":   "You don't have permission to view functions",
@@ -0,0 +1,202 @@
+package pkg
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// sessionKeywords start statements that end the transaction a statement runs in or change the
+// session around it, such as the role, the timeout or read-only mode
+var sessionKeywords = []string{
+	"BEGIN",
+	"START",
+	"COMMIT",
+	"END",
+	"ROLLBACK",
+	"ABORT",
+	"SAVEPOINT",
+	"RELEASE",
+	"PREPARE",
+	"SET",
+	"RESET",
+	"DISCARD",
+}
+
+// SplitStatements splits SQL on semicolons outside of quotes, quoted identifiers, dollar quotes and
+// comments. Statements made of nothing but whitespace and comments are left out
+func SplitStatements(sql string) []string {
+	var statements []string
+
+	start := 0
+	for i := 0; i < len(sql); {
+		switch {
+		case sql[i] == ';':
+			statements = appendStatement(statements, sql[start:i])
+			start = i + 1
+			i++
+		case strings.HasPrefix(sql[i:], "--"):
+			i = skipLineComment(sql, i)
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipBlockComment(sql, i)
+		case sql[i] == '\'':
+			i = skipQuoted(sql, i, '\'', isEscapeString(sql, i))
+		case sql[i] == '"':
+			i = skipQuoted(sql, i, '"', false)
+		case sql[i] == '$':
+			i = skipDollarQuoted(sql, i)
+		default:
+			i++
+		}
+	}
+
+	return appendStatement(statements, sql[start:])
+}
+
+// IsSessionStatement reports whether the statement controls the transaction or the session
+func IsSessionStatement(statement string) bool {
+	return slices.Contains(sessionKeywords, FirstKeyword(statement))
+}
+
+// FirstKeyword returns the first word of a statement in upper case, leading comments and parentheses skipped
+func FirstKeyword(statement string) string {
+	statement = stripComments(statement)
+	statement = strings.TrimLeftFunc(statement, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '('
+	})
+
+	end := strings.IndexFunc(statement, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	})
+	if end == -1 {
+		end = len(statement)
+	}
+
+	return strings.ToUpper(statement[:end])
+}
+
+func appendStatement(statements []string, statement string) []string {
+	if strings.TrimSpace(stripComments(statement)) == "" {
+		return statements
+	}
+
+	return append(statements, strings.TrimSpace(statement))
+}
+
+// stripComments drops comments outside of quotes, comments inside a statement are replaced by a space
+func stripComments(sql string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(sql); {
+		switch {
+		case strings.HasPrefix(sql[i:], "--"):
+			i = skipLineComment(sql, i)
+			builder.WriteByte(' ')
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipBlockComment(sql, i)
+			builder.WriteByte(' ')
+		default:
+			next := i + 1
+			switch sql[i] {
+			case '\'':
+				next = skipQuoted(sql, i, '\'', isEscapeString(sql, i))
+			case '"':
+				next = skipQuoted(sql, i, '"', false)
+			case '$':
+				next = skipDollarQuoted(sql, i)
+			}
+
+			builder.WriteString(sql[i:next])
+			i = next
+		}
+	}
+
+	return builder.String()
+}
+
+func skipLineComment(sql string, i int) int {
+	end := strings.IndexByte(sql[i:], '\n')
+	if end == -1 {
+		return len(sql)
+	}
+
+	return i + end + 1
+}
+
+// skipBlockComment follows nested comments, postgres allows /* /* */ */
+func skipBlockComment(sql string, i int) int {
+	depth := 0
+	for i < len(sql) {
+		switch {
+		case strings.HasPrefix(sql[i:], "/*"):
+			depth++
+			i += 2
+		case strings.HasPrefix(sql[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+
+	return len(sql)
+}
+
+// skipQuoted moves past a quoted string or identifier, doubled quotes stay inside and backslashes
+// escape the next character in strings written with the E prefix
+func skipQuoted(sql string, i int, quote byte, backslashEscapes bool) int {
+	for i++; i < len(sql); i++ {
+		switch {
+		case backslashEscapes && sql[i] == '\\':
+			i++
+		case sql[i] == quote && i+1 < len(sql) && sql[i+1] == quote:
+			i++
+		case sql[i] == quote:
+			return i + 1
+		}
+	}
+
+	return len(sql)
+}
+
+// skipDollarQuoted moves past $tag$ ... $tag$, a $ that doesn't open a dollar quote, such as a
+// positional parameter, is skipped on its own
+func skipDollarQuoted(sql string, i int) int {
+	end := strings.IndexByte(sql[i+1:], '$')
+	if end == -1 {
+		return i + 1
+	}
+
+	tag := sql[i : i+end+2]
+	for _, r := range tag[1 : len(tag)-1] {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return i + 1
+		}
+	}
+
+	if len(tag) > 2 && unicode.IsDigit(rune(tag[1])) {
+		return i + 1
+	}
+
+	closing := strings.Index(sql[i+len(tag):], tag)
+	if closing == -1 {
+		return len(sql)
+	}
+
+	return i + len(tag) + closing + len(tag)
+}
+
+func isEscapeString(sql string, i int) bool {
+	if i == 0 || (sql[i-1] != 'E' && sql[i-1] != 'e') {
+		return false
+	}
+
+	return i == 1 || !isIdentifierByte(sql[i-2])
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
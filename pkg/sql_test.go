@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitStatements_Suite(t *testing.T) {
+	t.Run("single statement with trailing semicolon", func(t *testing.T) {
+		assert.Equal(t, []string{"SELECT 1"}, SplitStatements(" SELECT 1; \n-- done\n"))
+	})
+
+	t.Run("multiple statements", func(t *testing.T) {
+		assert.Equal(t, []string{"COMMIT", "DELETE FROM orders"}, SplitStatements("COMMIT; DELETE FROM orders"))
+	})
+
+	t.Run("semicolons inside quotes and comments", func(t *testing.T) {
+		statements := SplitStatements(`SELECT 'a;b', "c;d", E'it\'s;', $fn$ x; $fn$ /* ; /* ; */ */ -- ;` + "\nFROM t")
+
+		assert.Len(t, statements, 1)
+	})
+
+	t.Run("positional parameters are not dollar quotes", func(t *testing.T) {
+		assert.Len(t, SplitStatements("SELECT $1; SELECT $2"), 2)
+	})
+
+	t.Run("escape strings need a standalone E", func(t *testing.T) {
+		assert.Len(t, SplitStatements(`SELECT name' \'; SELECT 1`), 2)
+	})
+}
+
+func TestIsSessionStatement_Suite(t *testing.T) {
+	t.Run("transaction and session control", func(t *testing.T) {
+		for _, statement := range []string{
+			"commit",
+			"  /* hidden */ ROLLBACK",
+			"-- comment\nSET statement_timeout = 0",
+			"reset role",
+			"SET SESSION AUTHORIZATION postgres",
+			"start transaction",
+		} {
+			assert.True(t, IsSessionStatement(statement), statement)
+		}
+	})
+
+	t.Run("regular statements", func(t *testing.T) {
+		for _, statement := range []string{
+			"SELECT set_config('x', 'y', true)",
+			"(SELECT 1) UNION (SELECT 2)",
+			"UPDATE settings SET value = 1",
+			"settle",
+		} {
+			assert.False(t, IsSessionStatement(statement), statement)
+		}
+	})
+}
@@ -19,4 +19,7 @@ udb.stats: ## Show the database stats for given project
 	@go run cmd/main.go udb.stats
 
 udb.restart: ## Restart all project databases
-	@go run cmd/main.go udb.restart
\ No newline at end of file
+	@go run cmd/main.go udb.restart
+
+udb.prepare: ## Create the Fluxend schema and tables in all project databases
+	@go run cmd/main.go udb.prepare
\ No newline at end of file
@@ -647,6 +647,61 @@ func (_c *MockRepository_Update_Call) RunAndReturn(run func(project1 *project.Pr
 	return _c
 }
 
+// UpdateExposedSchemas provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateExposedSchemas(projectUUID uuid.UUID, schemas []string) (bool, error) {
+	ret := _mock.Called(projectUUID, schemas)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExposedSchemas")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, []string) (bool, error)); ok {
+		return returnFunc(projectUUID, schemas)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, []string) bool); ok {
+		r0 = returnFunc(projectUUID, schemas)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, []string) error); ok {
+		r1 = returnFunc(projectUUID, schemas)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_UpdateExposedSchemas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateExposedSchemas'
+type MockRepository_UpdateExposedSchemas_Call struct {
+	*mock.Call
+}
+
+// UpdateExposedSchemas is a helper method to define mock.On call
+//   - projectUUID
+//   - schemas
+func (_e *MockRepository_Expecter) UpdateExposedSchemas(projectUUID interface{}, schemas interface{}) *MockRepository_UpdateExposedSchemas_Call {
+	return &MockRepository_UpdateExposedSchemas_Call{Call: _e.mock.On("UpdateExposedSchemas", projectUUID, schemas)}
+}
+
+func (_c *MockRepository_UpdateExposedSchemas_Call) Run(run func(projectUUID uuid.UUID, schemas []string)) *MockRepository_UpdateExposedSchemas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateExposedSchemas_Call) Return(b bool, err error) *MockRepository_UpdateExposedSchemas_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_UpdateExposedSchemas_Call) RunAndReturn(run func(projectUUID uuid.UUID, schemas []string) (bool, error)) *MockRepository_UpdateExposedSchemas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateStatusByDatabaseName provides a mock function for the type MockRepository
 func (_mock *MockRepository) UpdateStatusByDatabaseName(databaseName string, status string) (bool, error) {
 	ret := _mock.Called(databaseName, status)